@@ -100,11 +100,14 @@ var renderCmd = &cobra.Command{
 		logger.InfoContext(ctx, "Printing to stdout", "format", format)
 
 		var printer print.Printer
+		printerOpts := []print.Option{
+			print.WithNodeRenderers(eng.NodeRenderers()),
+		}
 		switch format {
 		case "md":
-			printer = mdprint.New()
+			printer = mdprint.New(printerOpts...)
 		case "html":
-			printer = htmlprint.New()
+			printer = htmlprint.New(printerOpts...)
 		default:
 			diags.Add("Unsupported format", fmt.Sprintf("Format '%s' is not supported for stdout", format))
 			return
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/blackstork-io/fabric/cmd/fabctx"
 	"github.com/blackstork-io/fabric/eval"
+	"github.com/blackstork-io/fabric/internal/builtin"
 	"github.com/blackstork-io/fabric/parser"
 	"github.com/blackstork-io/fabric/parser/definitions"
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
@@ -26,6 +28,7 @@ import (
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 	"github.com/blackstork-io/fabric/plugin/resolver"
 	"github.com/blackstork-io/fabric/plugin/runner"
+	"github.com/blackstork-io/fabric/print"
 )
 
 // Engine is the main entry point for the fabric engine. It is responsible for
@@ -74,6 +77,14 @@ func (e *Engine) PluginRunner() *runner.Runner {
 	return e.runner
 }
 
+// NodeRenderers returns renderers for custom AST nodes provided by the loaded plugins.
+func (e *Engine) NodeRenderers() plugin.NodeRenderers {
+	if e.runner == nil {
+		return nil
+	}
+	return e.runner.NodeRenderers()
+}
+
 func (e *Engine) LockFile() *resolver.LockFile {
 	return e.lockFile
 }
@@ -390,6 +401,7 @@ func (e *Engine) FetchData(ctx context.Context, target string) (result plugindat
 		attribute.String("target", target),
 	))
 	e.logger.InfoContext(ctx, "Fetching the data", "target", target)
+	ctx = builtin.WithAllowedCommands(ctx, e.config.AllowedCommands)
 	defer func() {
 		if diags.HasErrors() {
 			span.RecordError(diags)
@@ -482,6 +494,8 @@ func (e *Engine) RenderContent(
 		attribute.String("target", target),
 	))
 	e.logger.InfoContext(ctx, "Rendering the content", "target", target)
+	ctx = builtin.WithAllowedCommands(ctx, e.config.AllowedCommands)
+	ctx = builtin.WithSourceDir(ctx, e.sourceDir)
 	defer func() {
 		if diags.HasErrors() {
 			span.RecordError(diags)
@@ -497,6 +511,10 @@ func (e *Engine) RenderContent(
 	if diags.Extend(diag) {
 		return
 	}
+	diag = e.renderIncludedDocuments(ctx, doc, dataCtx, []string{target})
+	if diags.Extend(diag) {
+		return nil, nil, nil, diags
+	}
 	content, data, diag = doc.RenderContent(ctx, dataCtx, requiredTags)
 	if diags.Extend(diag) {
 		return nil, nil, nil, diags
@@ -513,6 +531,64 @@ func (e *Engine) RenderContent(
 	return doc, content, data, diags
 }
 
+// renderIncludedDocuments renders the documents included into the document with the content
+// blocks referring to other documents, such as `content include`, and puts their content into
+// the data context under plugin.IncludedDocumentsKey.
+// The stack holds the names of the documents being rendered to detect the cycles.
+func (e *Engine) renderIncludedDocuments(
+	ctx context.Context,
+	doc *eval.Document,
+	dataCtx plugindata.Map,
+	stack []string,
+) (diags diagnostics.Diag) {
+	names := doc.IncludedDocuments()
+	if len(names) == 0 {
+		return
+	}
+	documents := plugindata.Map{}
+	for _, name := range names {
+		if slices.Contains(stack, name) {
+			diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Circular document inclusion",
+				Detail: fmt.Sprintf(
+					"Document '%s' includes itself: %s",
+					name, strings.Join(append(slices.Clone(stack), name), " -> "),
+				),
+				Subject: doc.Source.Block.DefRange().Ptr(),
+			})
+			return
+		}
+		e.logger.InfoContext(ctx, "Rendering the included document", "document", name)
+		included, diag := e.loadDocument(ctx, name)
+		if diags.Extend(diag) {
+			return
+		}
+		includedDataCtx, diag := e.initialDataCtx(ctx)
+		if diags.Extend(diag) {
+			return
+		}
+		diag = e.renderIncludedDocuments(ctx, included, includedDataCtx, append(slices.Clone(stack), name))
+		if diags.Extend(diag) {
+			return
+		}
+		content, _, diag := included.RenderContent(ctx, includedDataCtx, nil)
+		if diags.Extend(diag) {
+			return
+		}
+		// the content is passed to the plugins as markdown, custom nodes are rendered in advance
+		rendered, err := print.RenderCustomNodes(ctx, content, included.NodeRenderers, plugin.OutputFormatMD)
+		if diags.AppendErr(err, "Failed to render the included document") {
+			return
+		}
+		documents[name] = plugindata.Map{
+			definitions.BlockKindContent: rendered.AsData(),
+		}
+	}
+	dataCtx[plugin.IncludedDocumentsKey] = documents
+	return
+}
+
 func (e *Engine) PublishContent(
 	ctx context.Context,
 	target string,
@@ -99,6 +99,73 @@ func TestEngineFetchData(t *testing.T) {
 		},
 		[][]diagtest.Assert{},
 	)
+	fetchDataTest(
+		t, "Allowed command",
+		[]string{
+			`
+			fabric {
+				allowed_commands = ["echo"]
+			}
+			data exec "test" {
+				command = "echo"
+				args = ["{\"property_for\": \"exec\"}"]
+			}
+			`,
+		},
+		"data.exec.test",
+		plugindata.Map{
+			"property_for": plugindata.String("exec"),
+		},
+		[][]diagtest.Assert{},
+	)
+}
+
+func TestEngineAllowedCommands(t *testing.T) {
+	renderTest(
+		t, "Not allowed",
+		[]string{
+			`
+			fabric {
+				allowed_commands = ["kubectl"]
+			}
+			document "test-doc" {
+				data exec "test" {
+					command = "echo"
+					args = ["{}"]
+				}
+				content text {
+					value = "hello"
+				}
+			}
+			`,
+		},
+		nil,
+		diagtest.Asserts{{
+			diagtest.IsError,
+			diagtest.SummaryEquals("Command is not allowed"),
+		}},
+	)
+	renderTest(
+		t, "Invalid pattern",
+		[]string{
+			`
+			fabric {
+				allowed_commands = ["echo", "scripts/["]
+			}
+			document "test-doc" {
+				content text {
+					value = "hello"
+				}
+			}
+			`,
+		},
+		nil,
+		diagtest.Asserts{{
+			diagtest.IsError,
+			diagtest.SummaryContains("Failed to parse", "allowed_commands"),
+			diagtest.DetailContains(`"scripts/["`),
+		}},
+	)
 }
 
 func TestEngineLint(t *testing.T) {
@@ -832,3 +899,191 @@ func TestEngineRenderContent(t *testing.T) {
 		optDocName("test"),
 	)
 }
+
+func TestEngineIncludeDocument(t *testing.T) {
+	renderTest(
+		t, "Include document",
+		[]string{
+			`
+			document "methodology" {
+				title = "Methodology"
+				content text {
+					value = "Scanned {{ len .vars.hosts }} hosts"
+				}
+				vars {
+					hosts = ["a", "b"]
+				}
+			}
+
+			document "test-doc" {
+				title = "Report"
+				content include {
+					document = "methodology"
+				}
+				section {
+					title = "Appendix"
+					content include {
+						document = "methodology"
+					}
+				}
+			}
+			`,
+		},
+		[]string{
+			"# Report",
+			"## Methodology\n\nScanned 2 hosts\n",
+			"# Appendix",
+			"## Methodology\n\nScanned 2 hosts\n",
+		},
+	)
+	renderTest(
+		t, "Circular include",
+		[]string{
+			`
+			document "a" {
+				content include {
+					document = "test-doc"
+				}
+			}
+
+			document "test-doc" {
+				content include {
+					document = "a"
+				}
+			}
+			`,
+		},
+		[]string{},
+		diagtest.Asserts{{
+			diagtest.IsError,
+			diagtest.SummaryEquals("Circular document inclusion"),
+			diagtest.DetailContains("test-doc -> a -> test-doc"),
+		}},
+	)
+}
+
+func TestEngineReferences(t *testing.T) {
+	renderTest(
+		t, "Numbered headings",
+		[]string{
+			`
+			document "test-doc" {
+				title             = "Report"
+				numbered_headings = true
+
+				content text {
+					value = "See {{ ref \"section.findings\" }} and {{ ref \"details\" }}"
+				}
+				section "summary" {
+					title = "Summary"
+				}
+				section "findings" {
+					title = "Findings"
+					content title {
+						value  = "Details"
+						anchor = "details"
+					}
+				}
+			}
+			`,
+		},
+		[]string{
+			"# Report",
+			"See [Section 2](#2-findings) and [Section 2.1](#21-details)\n",
+			"# 1 Summary\n",
+			"# 2 Findings\n",
+			"## 2.1 Details\n",
+		},
+	)
+	renderTest(
+		t, "References without numbering",
+		[]string{
+			`
+			document "test-doc" {
+				content text {
+					value = "See {{ ref \"section.findings\" }}"
+				}
+				section "findings" {
+					title = "Findings"
+				}
+			}
+			`,
+		},
+		[]string{
+			"See [Findings](#findings)\n",
+			"# Findings",
+		},
+	)
+	renderTest(
+		t, "Duplicate titles",
+		[]string{
+			`
+			document "test-doc" {
+				content text {
+					value = "See {{ ref \"section.second\" }} and {{ ref \"notes\" }}"
+				}
+				section "first" {
+					title = "Findings"
+				}
+				section "second" {
+					title = "Findings"
+					content title {
+						value  = "Findings"
+						anchor = "notes"
+					}
+				}
+			}
+			`,
+		},
+		[]string{
+			"See [Findings](#findings-1) and [Findings](#findings-2)\n",
+			"# Findings",
+			"# Findings",
+			"## Findings\n",
+		},
+	)
+	renderTest(
+		t, "Table of contents with numbering",
+		[]string{
+			`
+			document "test-doc" {
+				numbered_headings = true
+
+				content toc {}
+				section "findings" {
+					title = "Findings"
+					content title {
+						value  = "Details"
+						anchor = "details"
+					}
+				}
+			}
+			`,
+		},
+		[]string{
+			"- [Findings](#1-findings)\n  - [Details](#11-details)\n",
+			"# 1 Findings\n",
+			"## 1.1 Details\n",
+		},
+	)
+	renderTest(
+		t, "Unresolved reference",
+		[]string{
+			`
+			document "test-doc" {
+				content text {
+					value = "See {{ ref \"section.missing\" }}"
+				}
+			}
+			`,
+		},
+		[]string{
+			"See [section.missing](#section.missing)\n",
+		},
+		diagtest.Asserts{{
+			diagtest.IsWarning,
+			diagtest.SummaryEquals("Unresolved reference"),
+			diagtest.DetailContains(`"section.missing"`),
+		}},
+	)
+}
@@ -496,4 +496,22 @@ func TestEngineVarsHandling(t *testing.T) {
 		},
 		optDocName("bar"),
 	)
+	renderTest(
+		t, "diff function in query",
+		[]string{`
+			document "test-doc" {
+				vars {
+					previous = [{id = 1, status = "open"}, {id = 2, status = "open"}]
+					current  = [{id = 2, status = "closed"}, {id = 3, status = "open"}]
+					changes  = query_jq("diff(.vars.previous; .vars.current; \"id\")")
+				}
+				content text {
+					value = "{{ .vars.changes.summary | toJson }} {{ (index .vars.changes.changed 0).changes | toJson }}"
+				}
+			}
+		`},
+		[]string{
+			`{"added":1,"changed":1,"removed":1,"unchanged":0} [{"current":"closed","field":"status","previous":"open"}]`,
+		},
+	)
 }
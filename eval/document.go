@@ -6,11 +6,13 @@ import (
 	"slices"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/parser/definitions"
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/print"
 )
 
 type Document struct {
@@ -21,6 +23,9 @@ type Document struct {
 	DataBlocks    []*PluginDataAction
 	ContentBlocks []*Content
 	PublishBlocks []*PluginPublishAction
+	NodeRenderers plugin.NodeRenderers
+	// NumberedHeadings enables hierarchical numbering of the headings
+	NumberedHeadings bool
 }
 
 func (doc *Document) FetchData(ctx context.Context) (plugindata.Data, diagnostics.Diag) {
@@ -98,26 +103,65 @@ func (doc *Document) RenderContent(ctx context.Context, docDataCtx plugindata.Ma
 	if diags.Extend(diag) {
 		return nil, nil, diags
 	}
+	// the references are resolved when all titles are rendered
+	diags.Extend(resolveReferences(result, evaluator.sectionAnchors, doc.NumberedHeadings))
 
 	return result, docDataCtx, diags
 }
 
+// IncludedDocuments returns the names of the documents included into this document
+// with the content blocks whose providers set plugin.ContentProvider.DocumentArg, such as
+// `content include`. Only the names set as static strings are returned,
+// the documents are rendered in advance and passed in the data context.
+func (doc *Document) IncludedDocuments() []string {
+	var names []string
+	var walk func(children []*Content)
+	walk = func(children []*Content) {
+		for _, child := range children {
+			switch {
+			case child.Plugin != nil:
+				if child.Plugin.Provider == nil || child.Plugin.Provider.DocumentArg == "" {
+					continue
+				}
+				name := child.Plugin.Args.GetAttrVal(child.Plugin.Provider.DocumentArg)
+				if name.IsNull() || !name.IsKnown() || !name.Type().Equals(cty.String) {
+					continue
+				}
+				if !slices.Contains(names, name.AsString()) {
+					names = append(names, name.AsString())
+				}
+			case child.Section != nil:
+				walk(child.Section.children)
+			case child.Dynamic != nil:
+				walk(child.Dynamic.children)
+			}
+		}
+	}
+	walk(doc.ContentBlocks)
+	return names
+}
+
 func (doc *Document) Publish(ctx context.Context, content plugin.Content, data plugindata.Data, documentName string) diagnostics.Diag {
 	logger := *slog.Default()
 	logger.DebugContext(ctx, "Fetching data for the document template")
-	docData := plugindata.Map{
-		definitions.BlockKindContent: content.AsData(),
-	}
-	if doc.Meta != nil {
-		docData[definitions.BlockKindMeta] = doc.Meta.AsPluginData()
-	}
-	dataCtx := plugindata.Map{
-		definitions.BlockKindData:     data,
-		definitions.BlockKindDocument: docData,
-	}
+	contentData := content.AsData()
 	var diags diagnostics.Diag
 	for _, block := range doc.PublishBlocks {
-		diag := block.Publish(ctx, dataCtx, documentName)
+		blockContentData, diag := doc.renderCustomNodes(ctx, contentData, block.Format)
+		if diags.Extend(diag) {
+			continue
+		}
+		docData := plugindata.Map{
+			definitions.BlockKindContent: blockContentData,
+		}
+		if doc.Meta != nil {
+			docData[definitions.BlockKindMeta] = doc.Meta.AsPluginData()
+		}
+		dataCtx := plugindata.Map{
+			definitions.BlockKindData:     data,
+			definitions.BlockKindDocument: docData,
+		}
+		diag = block.Publish(ctx, dataCtx, documentName)
 		if diag != nil {
 			diags.Extend(diag)
 		}
@@ -125,12 +169,35 @@ func (doc *Document) Publish(ctx context.Context, content plugin.Content, data p
 	return diags
 }
 
+// renderCustomNodes renders custom AST nodes in a copy of the content for the publisher format.
+// Publishers can't reach node renderers of other plugins, so they receive pre-rendered content.
+func (doc *Document) renderCustomNodes(ctx context.Context, contentData plugindata.Data, format plugin.OutputFormat) (plugindata.Data, diagnostics.Diag) {
+	contentMap, ok := contentData.(plugindata.Map)
+	if !ok || len(doc.NodeRenderers) == 0 || format == plugin.OutputFormatUnspecified {
+		return contentData, nil
+	}
+	var diags diagnostics.Diag
+	content, err := plugin.ParseContentData(contentMap)
+	if diags.AppendErr(err, "Failed to parse content") {
+		return nil, diags
+	}
+	// the html is kept in the custom nodes, the printers of the publishers unwrap it
+	content, err = print.PrerenderCustomNodes(ctx, content, doc.NodeRenderers, format)
+	if diags.AppendErr(err, "Failed to render custom nodes") {
+		return nil, diags
+	}
+	return content.AsData(), diags
+}
+
 func LoadDocument(ctx context.Context, plugins Plugins, node *definitions.ParsedDocument) (_ *Document, diags diagnostics.Diag) {
 	block := Document{
-		Source:       node.Source,
-		Meta:         node.Meta,
-		Vars:         node.Vars,
-		RequiredVars: node.RequiredVars,
+		Source:        node.Source,
+		Meta:          node.Meta,
+		Vars:          node.Vars,
+		RequiredVars:  node.RequiredVars,
+		NodeRenderers: plugins.NodeRenderers(),
+
+		NumberedHeadings: node.NumberedHeadings,
 	}
 	dataNames := make(map[[2]string]struct{})
 	for _, child := range node.Data {
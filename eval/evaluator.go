@@ -196,6 +196,8 @@ type asyncContentEvaluator struct {
 	invokeMap map[plugin.InvocationOrder][]*asyncContent
 	namedMap  map[string]*asyncContent
 	rootNode  *plugin.ContentSection
+	// anchors of the sections, the title is the first element of the section
+	sectionAnchors map[*plugin.ContentSection]string
 }
 
 func (ace *asyncContentEvaluator) executeGroup(
@@ -252,20 +254,22 @@ func makeAsyncContentEvaluator(
 ) (*asyncContentEvaluator, diagnostics.Diag) {
 	namedMap := make(map[string]*asyncContent)
 	invokeMap := make(map[plugin.InvocationOrder][]*asyncContent)
+	sectionAnchors := make(map[*plugin.ContentSection]string)
 	rootNode := plugin.NewSection(0)
 
 	diags := diagnostics.Diag{}
 	for _, c := range content {
-		diag := assignAsyncContent(ctx, dataCtx, c, rootNode, rootNode, namedMap, invokeMap, nil)
+		diag := assignAsyncContent(ctx, dataCtx, c, rootNode, rootNode, namedMap, invokeMap, sectionAnchors, nil)
 		if diags.Extend(diag) {
 			return nil, diag
 		}
 	}
 
 	return &asyncContentEvaluator{
-		invokeMap: invokeMap,
-		namedMap:  namedMap,
-		rootNode:  rootNode,
+		invokeMap:      invokeMap,
+		namedMap:       namedMap,
+		rootNode:       rootNode,
+		sectionAnchors: sectionAnchors,
 	}, diags
 }
 
@@ -277,6 +281,7 @@ func assignAsyncContent(
 	parent *plugin.ContentSection,
 	namedMap map[string]*asyncContent,
 	invokeMap map[plugin.InvocationOrder][]*asyncContent,
+	sectionAnchors map[*plugin.ContentSection]string,
 	section *Section,
 ) diagnostics.Diag {
 	diags := diagnostics.Diag{}
@@ -317,8 +322,11 @@ func assignAsyncContent(
 		if diags.Extend(diag) || !include {
 			return diag
 		}
+		if c.Section.anchor != "" {
+			sectionAnchors[tmp] = c.Section.anchor
+		}
 		for _, child := range children {
-			diag := assignAsyncContent(ctx, dataCtx, child, rootNode, tmp, namedMap, invokeMap, c.Section)
+			diag := assignAsyncContent(ctx, dataCtx, child, rootNode, tmp, namedMap, invokeMap, sectionAnchors, c.Section)
 			if diags.Extend(diag) {
 				return diag
 			}
@@ -14,6 +14,7 @@ import (
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/deferred"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/print"
 )
 
 type PluginContentAction struct {
@@ -66,6 +67,20 @@ func (action *PluginContentAction) RenderContent(ctx context.Context, dataCtx pl
 	if diags.Extend(diag) {
 		return
 	}
+	// only the printers may produce the trusted html, it's written to the output as is
+	stripped, err := print.StripTrustedHTML(res.Content)
+	if diags.AppendErr(err, "Failed to process the content") {
+		return
+	}
+	if stripped {
+		diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Trusted HTML removed",
+			Detail: fmt.Sprintf("Content provider '%s' returned the nodes of %q type, they are reserved for the printers. "+
+				"The nodes are removed.", action.PluginName, print.TrustedHTMLTypeURL),
+			Subject: action.Source.Block.DefRange().Ptr(),
+		})
+	}
 	if res.Location == nil {
 		res.Location = &plugin.Location{
 			Index: contentID,
@@ -16,8 +16,13 @@ type Publishers interface {
 	Publisher(name string) (*plugin.Publisher, bool)
 }
 
+type NodeRenderers interface {
+	NodeRenderers() plugin.NodeRenderers
+}
+
 type Plugins interface {
 	DataSources
 	ContentProviders
 	Publishers
+	NodeRenderers
 }
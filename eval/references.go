@@ -0,0 +1,367 @@
+package eval
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+)
+
+// headingTarget is a heading that can be referenced by its anchor.
+type headingTarget struct {
+	title  string
+	number string
+	// id of the heading, the reference links point to it
+	id string
+}
+
+// documentHeading is a heading of the rendered document.
+type documentHeading struct {
+	el   *plugin.ContentElement
+	src  *astsrc.ASTSource
+	node *ast.Heading
+	// source of the heading, the markdown of the element for the plain headings
+	source []byte
+	// set for the headings of the markdown elements printed as is
+	plain bool
+	// set for the level 1 headings outside of sections: the document title isn't numbered
+	isDocumentTitle bool
+	// set for the title of the named section, see [definitions.SectionAnchorPrefix]
+	sectionAnchor string
+}
+
+// resolveReferences numbers the headings of the rendered content (if numbered is set) and
+// replaces the links produced by `ref` template function with links to the referenced headings.
+// The links point to the ids that the printers generate from the text of the headings, computed
+// over all headings of the document. The markdown printer drops the heading attributes, so the ids
+// of the headings are removed and the other links to the anchors, the ids generated from the titles
+// before numbering or the section anchors, such as the links of the table of contents, are updated.
+// The elements are modified in place, the markdown elements are converted to AST only if they are
+// numbered or have links to update.
+func resolveReferences(content *plugin.ContentSection, sectionAnchors map[*plugin.ContentSection]string, numbered bool) (diags diagnostics.Diag) {
+	var elements, plainElements []*plugin.ContentElement
+	var headings []documentHeading
+	collectElements(content, sectionAnchors, "", true, func(el *plugin.ContentElement, root bool, sectionAnchor string) {
+		var src *astsrc.ASTSource
+		var node ast.Node
+		var source []byte
+		plain := !el.IsAst() && !hasReferenceNodes(el.AsMarkdownSrc(), numbered, root)
+		if plain {
+			// markdown elements are printed as is, only the ids of their headings are needed
+			plainElements = append(plainElements, el)
+			source = el.AsMarkdownSrc()
+			node = parsePlainMarkdown(source)
+		} else {
+			elements = append(elements, el)
+			var content *nodes.FabricContentNode
+			src, content = el.AsNode()
+			node = content
+			source = src.AsBytes()
+		}
+		_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+			if heading, ok := n.(*ast.Heading); ok && entering {
+				headings = append(headings, documentHeading{
+					el:              el,
+					src:             src,
+					node:            heading,
+					source:          source,
+					plain:           plain,
+					isDocumentTitle: root && heading.Level == 1,
+					sectionAnchor:   sectionAnchor,
+				})
+				// only the first heading of the section title is the section target
+				sectionAnchor = ""
+				return ast.WalkSkipChildren, nil
+			}
+			return ast.WalkContinue, nil
+		})
+	})
+
+	// the top level of numbering is the largest heading, the section titles are level 1
+	// headings, the content titles outside of sections can start from level 2
+	minLevel := 0
+	for _, h := range headings {
+		if !h.plain && !h.isDocumentTitle && (minLevel == 0 || h.node.Level < minLevel) {
+			minLevel = h.node.Level
+		}
+	}
+
+	targets := map[string]headingTarget{}
+	addTarget := func(anchor string, target headingTarget) {
+		if _, found := targets[anchor]; !found {
+			targets[anchor] = target
+		}
+	}
+	// ids of the printed headings and of the headings before numbering
+	ids := headingIDs{}
+	titleIDs := headingIDs{}
+	sectionIDs := headingIDs{}
+	var counters []int
+	for _, h := range headings {
+		heading := h.node
+		title := string(heading.Text(h.source)) //nolint:staticcheck // plain text of the heading
+		id, hasID := heading.AttributeString("id")
+		var number, anchor string
+		switch {
+		case h.plain && hasID:
+			anchor = anchorString(id)
+			ids.reserve(anchor)
+		case h.plain:
+			anchor = ids.generate(headingLine(heading, h.source))
+		default:
+			if numbered && !h.isDocumentTitle {
+				number, counters = nextHeadingNumber(counters, heading.Level-minLevel)
+			}
+			printed := title
+			if number != "" {
+				printed = number + " " + title
+			}
+			anchor = ids.generate(printed)
+			if hasID {
+				// the heading is referenced by the anchor, the id is generated from the text in all formats
+				removeHeadingID(heading)
+				h.el.InvalidateCache()
+			}
+		}
+		target := headingTarget{title: title, number: number, id: anchor}
+		addTarget(anchor, target)
+		if hasID {
+			addTarget(anchorString(id), target)
+		}
+		addTarget(titleIDs.generate(title), target)
+		if h.sectionAnchor != "" {
+			// the sections produced by dynamic blocks share the name
+			targets[sectionIDs.put(h.sectionAnchor)] = target
+		}
+		if number != "" {
+			heading.InsertBefore(heading, heading.FirstChild(), ast.NewTextSegment(h.src.AppendString(number+" ")))
+			h.el.InvalidateCache()
+		}
+	}
+
+	for _, el := range plainElements {
+		if hasOutdatedLinks(el.AsMarkdownSrc(), targets) {
+			elements = append(elements, el)
+		}
+	}
+	for _, el := range elements {
+		diags.Extend(resolveLinks(el, targets))
+	}
+	return diags
+}
+
+// resolveLinks replaces the links produced by `ref` template function with the links to the
+// targets and updates the links to the anchors of the targets.
+func resolveLinks(el *plugin.ContentElement, targets map[string]headingTarget) (diags diagnostics.Diag) {
+	src, node := el.AsNode()
+	modified := false
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		link, ok := n.(*ast.Link)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		anchor, internal := strings.CutPrefix(string(link.Destination), "#")
+		target, found := targets[anchor]
+		if string(link.Title) != plugin.ReferenceLinkTitle {
+			if internal && found && target.id != anchor {
+				modified = true
+				link.Destination = []byte("#" + target.id)
+			}
+			return ast.WalkContinue, nil
+		}
+		modified = true
+		link.Title = nil
+		if !found {
+			diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unresolved reference",
+				Detail:   fmt.Sprintf("Reference %q doesn't match an anchor of any title in the document", anchor),
+			})
+			return ast.WalkSkipChildren, nil
+		}
+		link.Destination = []byte("#" + target.id)
+		label := target.title
+		if target.number != "" {
+			label = "Section " + target.number
+		}
+		for link.FirstChild() != nil {
+			link.RemoveChild(link, link.FirstChild())
+		}
+		link.AppendChild(link, ast.NewTextSegment(src.AppendString(label)))
+		return ast.WalkSkipChildren, nil
+	})
+	if modified {
+		el.InvalidateCache()
+	}
+	return diags
+}
+
+// hasOutdatedLinks reports if the markdown has the links to the anchors of the targets that
+// differ from the ids of the headings.
+func hasOutdatedLinks(md []byte, targets map[string]headingTarget) bool {
+	if !bytes.Contains(md, []byte("](#")) {
+		return false
+	}
+	found := false
+	_ = ast.Walk(parsePlainMarkdown(md), func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := n.(*ast.Link); ok && entering {
+			anchor, internal := strings.CutPrefix(string(link.Destination), "#")
+			if target, ok := targets[anchor]; internal && ok && target.id != anchor {
+				found = true
+				return ast.WalkStop, nil
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// parsePlainMarkdown parses the markdown printed as is, with the heading attributes that the
+// printers of the formats supporting them recognize.
+func parsePlainMarkdown(md []byte) ast.Node {
+	return goldmark.New(
+		plugin.BaseMarkdownOptions,
+		goldmark.WithParserOptions(parser.WithAttribute()),
+	).Parser().Parse(text.NewReader(md))
+}
+
+// hasReferenceNodes reports if the markdown has the nodes modified by resolveReferences.
+func hasReferenceNodes(md []byte, numbered, root bool) bool {
+	if bytes.Contains(md, []byte(plugin.ReferenceLinkTitle)) {
+		return true
+	}
+	if !numbered {
+		return false
+	}
+	doc := goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(text.NewReader(md))
+	found := false
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && !(root && h.Level == 1) {
+			found = true
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// collectElements calls fn for the elements of the section in the document order.
+// root is set for the elements outside of nested sections, sectionAnchor is set
+// for the title of the section with an anchor.
+func collectElements(
+	section *plugin.ContentSection,
+	sectionAnchors map[*plugin.ContentSection]string,
+	sectionAnchor string,
+	root bool,
+	fn func(el *plugin.ContentElement, root bool, sectionAnchor string),
+) {
+	for i, child := range section.Children {
+		switch child := child.(type) {
+		case *plugin.ContentSection:
+			collectElements(child, sectionAnchors, sectionAnchors[child], false, fn)
+		case *plugin.ContentElement:
+			if i == 0 {
+				fn(child, root, sectionAnchor)
+			} else {
+				fn(child, root, "")
+			}
+		}
+	}
+}
+
+// nextHeadingNumber increments the counter of the heading depth and returns
+// the hierarchical number of the heading, for example "3.2".
+func nextHeadingNumber(counters []int, depth int) (string, []int) {
+	for len(counters) <= depth {
+		counters = append(counters, 0)
+	}
+	counters = counters[:depth+1]
+	counters[depth]++
+	parts := make([]string, len(counters))
+	for i, c := range counters {
+		// the levels skipped by the headings are numbered as the first ones
+		if c == 0 {
+			counters[i] = 1
+		}
+		parts[i] = strconv.Itoa(counters[i])
+	}
+	return strings.Join(parts, "."), counters
+}
+
+// headingIDs keeps the ids of the headings unique, in the same way as the printers do.
+type headingIDs map[string]bool
+
+// unique returns the id or, if it's already taken, the id with a numeric suffix.
+func (h headingIDs) unique(id string) string {
+	result := id
+	for i := 1; h[result]; i++ {
+		result = id + "-" + strconv.Itoa(i)
+	}
+	return result
+}
+
+// reserve marks the id as taken, the explicit ids of the headings are kept as is.
+func (h headingIDs) reserve(id string) {
+	h[id] = true
+}
+
+// put reserves the unique id produced from the id.
+func (h headingIDs) put(id string) string {
+	id = h.unique(id)
+	h.reserve(id)
+	return id
+}
+
+// generate reserves the unique id produced from the heading text, same as the auto heading ids.
+func (h headingIDs) generate(text string) string {
+	return h.put(headingIDBase(text))
+}
+
+// headingIDBase returns the id produced from the heading text by the auto heading ids of goldmark.
+func headingIDBase(text string) string {
+	// fresh ids don't add the suffixes
+	return string(parser.NewContext().IDs().Generate([]byte(text), ast.KindHeading))
+}
+
+// headingLine returns the text of the heading line, the printers generate the ids from it.
+func headingLine(heading *ast.Heading, source []byte) string {
+	lines := heading.Lines()
+	if lines.Len() == 0 {
+		return ""
+	}
+	line := lines.At(lines.Len() - 1)
+	return string(line.Value(source))
+}
+
+// removeHeadingID removes the id attribute of the heading, keeping the other ones.
+func removeHeadingID(heading *ast.Heading) {
+	attrs := heading.Attributes()
+	heading.RemoveAttributes()
+	for _, attr := range attrs {
+		if string(attr.Name) != "id" {
+			heading.SetAttribute(attr.Name, attr.Value)
+		}
+	}
+}
+
+// anchorString returns the value of the id attribute, decoded attributes can be strings.
+func anchorString(id any) string {
+	switch id := id.(type) {
+	case []byte:
+		return string(id)
+	case string:
+		return id
+	}
+	return fmt.Sprint(id)
+}
@@ -24,6 +24,8 @@ type Section struct {
 	source       *definitions.Section
 	requiredVars []string
 	isIncluded   *dataspec.Attr
+	// anchor of the section title, set for the named sections with titles
+	anchor string
 }
 
 func (block *Section) PrepareData(ctx context.Context, dataCtx plugindata.Map, doc, parent *plugin.ContentSection) (diags diagnostics.Diag) {
@@ -172,7 +174,9 @@ func LoadSection(ctx context.Context, providers ContentProviders, node *definiti
 			return
 		}
 		block.children = append(block.children, title)
-
+		if name := node.Source.Name(); name != "" {
+			block.anchor = definitions.SectionAnchorPrefix + name
+		}
 	}
 	for _, child := range node.Content {
 		decoded, diag := LoadContent(ctx, providers, child)
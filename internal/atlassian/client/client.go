@@ -4,13 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"strings"
 	"time"
 )
 
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
 type Client interface {
 	SearchIssues(ctx context.Context, req *SearchIssuesReq) (*SearchIssuesRes, error)
+	GetPage(ctx context.Context, id string) (*Page, error)
+	// FindPage returns nil if the space has no page with the title.
+	FindPage(ctx context.Context, spaceKey, title string) (*Page, error)
+	CreatePage(ctx context.Context, req *CreatePageReq) (*Page, error)
+	UpdatePage(ctx context.Context, id string, req *UpdatePageReq) (*Page, error)
+	// UploadAttachment creates the attachment or adds a new version of the attachment with the same name.
+	UploadAttachment(ctx context.Context, pageID string, att *Attachment) error
 }
 
 type client struct {
@@ -96,3 +110,152 @@ func (c *client) SearchIssues(ctx context.Context, req *SearchIssuesReq) (*Searc
 
 	return &data, nil
 }
+
+// do sends the request and decodes the JSON response into data, if it's not nil.
+func (c *client) do(r *http.Request, data any) error {
+	r.Header.Set("Accept", "application/json")
+	c.auth(r)
+
+	client := c.makeHTTPClient()
+	res, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return c.handleError(res)
+	}
+	if data == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(data)
+}
+
+func (c *client) sendJSON(ctx context.Context, method string, u *url.URL, req, data any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	return c.do(r, data)
+}
+
+func (c *client) GetPage(ctx context.Context, id string) (*Page, error) {
+	u, err := c.makeURL("/wiki/rest/api/content", id)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"expand": {"version,space"}}.Encode()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data Page
+	if err := c.do(r, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (c *client) FindPage(ctx context.Context, spaceKey, title string) (*Page, error) {
+	u, err := c.makeURL("/wiki/rest/api/content")
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{
+		"spaceKey": {spaceKey},
+		"title":    {title},
+		"type":     {"page"},
+		"expand":   {"version,space"},
+	}.Encode()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data FindPagesRes
+	if err := c.do(r, &data); err != nil {
+		return nil, err
+	}
+	if len(data.Results) == 0 {
+		return nil, nil
+	}
+	return &data.Results[0], nil
+}
+
+func (c *client) CreatePage(ctx context.Context, req *CreatePageReq) (*Page, error) {
+	u, err := c.makeURL("/wiki/rest/api/content")
+	if err != nil {
+		return nil, err
+	}
+
+	var data Page
+	if err := c.sendJSON(ctx, http.MethodPost, u, req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (c *client) UpdatePage(ctx context.Context, id string, req *UpdatePageReq) (*Page, error) {
+	u, err := c.makeURL("/wiki/rest/api/content", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data Page
+	if err := c.sendJSON(ctx, http.MethodPut, u, req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (c *client) UploadAttachment(ctx context.Context, pageID string, att *Attachment) error {
+	u, err := c.makeURL("/wiki/rest/api/content", pageID, "child/attachment")
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(att.Filename)))
+	if att.ContentType != "" {
+		header.Set("Content-Type", att.ContentType)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, bytes.NewReader(att.Data)); err != nil {
+		return err
+	}
+	if err := mw.WriteField("minorEdit", "true"); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	// PUT creates the attachment or updates the existing one with the same name
+	r, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), &body)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	r.Header.Set("X-Atlassian-Token", "no-check")
+	return c.do(r, nil)
+}
@@ -106,3 +106,110 @@ func (s *ClientTestSuite) TestSearchIssuesError() {
 		ErrorMessages: []string{"Test Error"},
 	}, err)
 }
+
+func (s *ClientTestSuite) TestFindPage() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/wiki/rest/api/content", r.URL.Path)
+		s.Equal(http.MethodGet, r.Method)
+		s.Equal("SEC", r.URL.Query().Get("spaceKey"))
+		s.Equal("Weekly report", r.URL.Query().Get("title"))
+		s.Equal("version,space", r.URL.Query().Get("expand"))
+		w.Write([]byte(`{
+			"results": [
+				{
+					"id": "42",
+					"type": "page",
+					"title": "Weekly report",
+					"space": {"key": "SEC"},
+					"version": {"number": 3}
+				}
+			]
+		}`))
+	}, "test-email", "test-token")
+	defer srv.Close()
+
+	result, err := client.FindPage(s.ctx, "SEC", "Weekly report")
+	s.NoError(err)
+	s.Equal(&Page{
+		ID:      "42",
+		Type:    "page",
+		Title:   "Weekly report",
+		Space:   &PageSpace{Key: "SEC"},
+		Version: &PageVersion{Number: 3},
+	}, result)
+}
+
+func (s *ClientTestSuite) TestFindPageNotFound() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": []}`))
+	}, "", "")
+	defer srv.Close()
+
+	result, err := client.FindPage(s.ctx, "SEC", "Missing")
+	s.NoError(err)
+	s.Nil(result)
+}
+
+func (s *ClientTestSuite) TestUpdatePage() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/wiki/rest/api/content/42", r.URL.Path)
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+		s.JSONEq(`{
+			"type": "page",
+			"title": "Weekly report",
+			"version": {"number": 4},
+			"body": {"storage": {"value": "<p>Hello</p>", "representation": "storage"}}
+		}`, string(body))
+		w.Write([]byte(`{"id": "42", "type": "page", "title": "Weekly report", "version": {"number": 4}}`))
+	}, "test-email", "test-token")
+	defer srv.Close()
+
+	result, err := client.UpdatePage(s.ctx, "42", &UpdatePageReq{
+		Type:    "page",
+		Title:   "Weekly report",
+		Version: PageVersion{Number: 4},
+		Body: PageBody{
+			Storage: PageStorage{Value: "<p>Hello</p>", Representation: "storage"},
+		},
+	})
+	s.NoError(err)
+	s.Equal(4, result.Version.Number)
+}
+
+func (s *ClientTestSuite) TestUploadAttachment() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/wiki/rest/api/content/42/child/attachment", r.URL.Path)
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("no-check", r.Header.Get("X-Atlassian-Token"))
+		file, header, err := r.FormFile("file")
+		s.Require().NoError(err)
+		defer file.Close()
+		s.Equal("diagram-1.png", header.Filename)
+		s.Equal("image/png", header.Header.Get("Content-Type"))
+		data, err := io.ReadAll(file)
+		s.Require().NoError(err)
+		s.Equal("png-data", string(data))
+		w.Write([]byte(`{"results": []}`))
+	}, "test-email", "test-token")
+	defer srv.Close()
+
+	err := client.UploadAttachment(s.ctx, "42", &Attachment{
+		Filename:    "diagram-1.png",
+		ContentType: "image/png",
+		Data:        []byte("png-data"),
+	})
+	s.NoError(err)
+}
+
+func (s *ClientTestSuite) TestConfluenceError() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"statusCode": 409, "message": "Version must be incremented on update."}`))
+	}, "", "")
+	defer srv.Close()
+	_, err := client.UpdatePage(s.ctx, "42", &UpdatePageReq{})
+	s.EqualError(err, "Version must be incremented on update.")
+}
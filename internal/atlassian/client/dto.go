@@ -12,12 +12,17 @@ func Int(i int) *int {
 	return &i
 }
 
+// Error is returned by both Jira (errorMessages) and Confluence (message) APIs.
 type Error struct {
 	ErrorMessages []string `json:"errorMessages"`
+	Message       string   `json:"message"`
 }
 
 func (err *Error) Error() string {
-	return strings.Join(err.ErrorMessages, " ")
+	if err.Message == "" {
+		return strings.Join(err.ErrorMessages, " ")
+	}
+	return strings.Join(append([]string{err.Message}, err.ErrorMessages...), " ")
 }
 
 type SearchIssuesReq struct {
@@ -33,3 +38,65 @@ type SearchIssuesRes struct {
 	NextPageToken *string `json:"nextPageToken,omitempty"`
 	Issues        []any   `json:"issues"`
 }
+
+type PageSpace struct {
+	Key string `json:"key"`
+}
+
+type PageVersion struct {
+	Number  int    `json:"number"`
+	Message string `json:"message,omitempty"`
+}
+
+type PageAncestor struct {
+	ID string `json:"id"`
+}
+
+type PageStorage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type PageBody struct {
+	Storage PageStorage `json:"storage"`
+}
+
+type PageLinks struct {
+	Base  string `json:"base,omitempty"`
+	WebUI string `json:"webui,omitempty"`
+}
+
+type Page struct {
+	ID      string       `json:"id"`
+	Type    string       `json:"type"`
+	Title   string       `json:"title"`
+	Space   *PageSpace   `json:"space,omitempty"`
+	Version *PageVersion `json:"version,omitempty"`
+	Links   *PageLinks   `json:"_links,omitempty"`
+}
+
+type FindPagesRes struct {
+	Results []Page `json:"results"`
+}
+
+type CreatePageReq struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Space     PageSpace      `json:"space"`
+	Ancestors []PageAncestor `json:"ancestors,omitempty"`
+	Body      PageBody       `json:"body"`
+}
+
+type UpdatePageReq struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Version   PageVersion    `json:"version"`
+	Ancestors []PageAncestor `json:"ancestors,omitempty"`
+	Body      PageBody       `json:"body"`
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
@@ -18,30 +18,7 @@ func makeJiraIssuesDataSource(loader ClientLoadFn) *plugin.DataSource {
 	return &plugin.DataSource{
 		Doc:      "Retrieve issues from Jira.",
 		DataFunc: searchJiraIssuesData(loader),
-		Config: &dataspec.RootSpec{
-			Attrs: []*dataspec.AttrSpec{
-				{
-					Name:        "domain",
-					Type:        cty.String,
-					Constraints: constraint.RequiredMeaningful,
-					Doc:         "Account Domain.",
-				},
-				{
-					Name:        "account_email",
-					Type:        cty.String,
-					Secret:      true,
-					Constraints: constraint.RequiredMeaningful,
-					Doc:         "Account Email.",
-				},
-				{
-					Name:        "api_token",
-					Type:        cty.String,
-					Secret:      true,
-					Constraints: constraint.RequiredMeaningful,
-					Doc:         "API Token.",
-				},
-			},
-		},
+		Config:   makeConfigSpec(),
 		Args: &dataspec.RootSpec{
 			Attrs: []*dataspec.AttrSpec{
 				{
@@ -3,14 +3,15 @@ package atlassian
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/internal/atlassian/client"
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
 )
 
 type ClientLoadFn func(url, accountEmail, apiToken string) client.Client
@@ -28,6 +29,37 @@ func Plugin(version string, loader ClientLoadFn) *plugin.Schema {
 		DataSources: plugin.DataSources{
 			"jira_issues": makeJiraIssuesDataSource(loader),
 		},
+		Publishers: plugin.Publishers{
+			"confluence_page": makeConfluencePagePublisher(loader),
+		},
+	}
+}
+
+// makeConfigSpec returns the config shared by the data sources and publishers of the plugin.
+func makeConfigSpec() *dataspec.RootSpec {
+	return &dataspec.RootSpec{
+		Attrs: []*dataspec.AttrSpec{
+			{
+				Name:        "domain",
+				Type:        cty.String,
+				Constraints: constraint.RequiredMeaningful,
+				Doc:         "Account Domain.",
+			},
+			{
+				Name:        "account_email",
+				Type:        cty.String,
+				Secret:      true,
+				Constraints: constraint.RequiredMeaningful,
+				Doc:         "Account Email.",
+			},
+			{
+				Name:        "api_token",
+				Type:        cty.String,
+				Secret:      true,
+				Constraints: constraint.RequiredMeaningful,
+				Doc:         "API Token.",
+			},
+		},
 	}
 }
 
@@ -48,7 +80,7 @@ func handleClientError(err error) diagnostics.Diag {
 		return diagnostics.Diag{{
 			Severity: hcl.DiagError,
 			Summary:  "Failed to call Atlassian API",
-			Detail:   strings.Join(clientErr.ErrorMessages, " "),
+			Detail:   clientErr.Error(),
 		}}
 	}
 	return diagnostics.Diag{{
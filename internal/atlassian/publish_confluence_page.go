@@ -0,0 +1,209 @@
+package atlassian
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/atlassian/client"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/print/confluenceprint"
+)
+
+func makeConfluencePagePublisher(loader ClientLoadFn) *plugin.Publisher {
+	return &plugin.Publisher{
+		Doc: "Publishes content to a Confluence page. " +
+			"The page is found by `page_id` or by `title` in the space, and is created if it doesn't exist. " +
+			"Diagrams and the local images from the working directory are uploaded as page attachments, " +
+			"other images are referenced by URL.",
+		Tags:   []string{},
+		Config: makeConfigSpec(),
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:       "space_key",
+					Type:       cty.String,
+					Doc:        "Key of the space to create the page in. Required unless `page_id` is set.",
+					ExampleVal: cty.StringVal("SEC"),
+				},
+				{
+					Name:       "title",
+					Type:       cty.String,
+					Doc:        "Title of the page. Defaults to the document name for new pages; existing pages keep their current title.",
+					ExampleVal: cty.StringVal("Weekly threat report"),
+				},
+				{
+					Name: "page_id",
+					Type: cty.String,
+					Doc:  "ID of the page to update.",
+				},
+				{
+					Name: "parent_id",
+					Type: cty.String,
+					Doc:  "ID of the parent page.",
+				},
+				{
+					Name:       "toc",
+					Type:       cty.Bool,
+					Doc:        "Add the table of contents macro at the top of the page.",
+					DefaultVal: cty.False,
+				},
+			},
+		},
+		AllowedFormats: []plugin.OutputFormat{plugin.OutputFormatHTML},
+		PublishFunc:    publishConfluencePage(loader),
+	}
+}
+
+func parseContent(data plugindata.Map) (document *plugin.ContentSection) {
+	documentMap, ok := data["document"]
+	if !ok {
+		return
+	}
+	documentData, ok := documentMap.(plugindata.Map)
+	if !ok {
+		return
+	}
+	contentMap, ok := documentData["content"].(plugindata.Map)
+	if !ok {
+		return
+	}
+	content, err := plugin.ParseContentData(contentMap)
+	if err != nil {
+		return
+	}
+	document, _ = content.(*plugin.ContentSection)
+	return
+}
+
+func stringArg(args *dataspec.Block, name string) string {
+	attr := args.GetAttrVal(name)
+	if attr.IsNull() {
+		return ""
+	}
+	return attr.AsString()
+}
+
+func publishConfluencePage(loader ClientLoadFn) plugin.PublishFunc {
+	return func(ctx context.Context, params *plugin.PublishParams) diagnostics.Diag {
+		cli, err := parseConfig(params.Config, loader)
+		if err != nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse configuration",
+				Detail:   err.Error(),
+			}}
+		}
+		document := parseContent(params.DataContext)
+		if document == nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse the document",
+				Detail:   "document is required",
+			}}
+		}
+		pageID := stringArg(params.Args, "page_id")
+		spaceKey := stringArg(params.Args, "space_key")
+		if pageID == "" && spaceKey == "" {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid arguments",
+				Detail:   "Either page_id or space_key is required",
+			}}
+		}
+		title := stringArg(params.Args, "title")
+		lookupTitle := title
+		if lookupTitle == "" {
+			lookupTitle = params.DocumentName
+		}
+
+		printer := confluenceprint.New().WithTOC(params.Args.GetAttrVal("toc").True())
+		buff := bytes.NewBuffer(nil)
+		attachments, err := printer.PrintPage(ctx, buff, document)
+		if err != nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to print the page",
+				Detail:   err.Error(),
+			}}
+		}
+		body := client.PageBody{
+			Storage: client.PageStorage{
+				Value:          buff.String(),
+				Representation: "storage",
+			},
+		}
+		var ancestors []client.PageAncestor
+		if parentID := stringArg(params.Args, "parent_id"); parentID != "" {
+			ancestors = []client.PageAncestor{{ID: parentID}}
+		}
+
+		var page *client.Page
+		if pageID != "" {
+			slog.DebugContext(ctx, "Fetching the Confluence page", "page_id", pageID)
+			page, err = cli.GetPage(ctx, pageID)
+		} else {
+			slog.DebugContext(ctx, "Looking up the Confluence page", "space_key", spaceKey, "title", lookupTitle)
+			page, err = cli.FindPage(ctx, spaceKey, lookupTitle)
+		}
+		if err != nil {
+			return handleClientError(err)
+		}
+		if page == nil {
+			slog.InfoContext(ctx, "Creating the Confluence page", "space_key", spaceKey, "title", lookupTitle)
+			page, err = cli.CreatePage(ctx, &client.CreatePageReq{
+				Type:      "page",
+				Title:     lookupTitle,
+				Space:     client.PageSpace{Key: spaceKey},
+				Ancestors: ancestors,
+				Body:      body,
+			})
+		} else {
+			if page.Version == nil {
+				return diagnostics.Diag{{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to update the Confluence page",
+					Detail:   fmt.Sprintf("page %q has no version", page.ID),
+				}}
+			}
+			// Keep the current title unless a new one is set explicitly.
+			if title == "" {
+				title = page.Title
+			}
+			version := page.Version.Number + 1
+			slog.InfoContext(ctx, "Updating the Confluence page", "page_id", page.ID, "version", version)
+			page, err = cli.UpdatePage(ctx, page.ID, &client.UpdatePageReq{
+				Type:      "page",
+				Title:     title,
+				Version:   client.PageVersion{Number: version},
+				Ancestors: ancestors,
+				Body:      body,
+			})
+		}
+		if err != nil {
+			return handleClientError(err)
+		}
+		for _, att := range attachments {
+			slog.DebugContext(ctx, "Uploading the attachment", "page_id", page.ID, "filename", att.Filename)
+			err = cli.UploadAttachment(ctx, page.ID, &client.Attachment{
+				Filename:    att.Filename,
+				ContentType: att.ContentType,
+				Data:        att.Data,
+			})
+			if err != nil {
+				return handleClientError(err)
+			}
+		}
+		if page.Links != nil && page.Links.WebUI != "" {
+			slog.InfoContext(ctx, "The Confluence page published successfully", "url", page.Links.Base+page.Links.WebUI)
+		}
+		return nil
+	}
+}
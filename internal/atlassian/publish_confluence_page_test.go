@@ -0,0 +1,236 @@
+package atlassian
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/atlassian/client"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+// confluenceStandIn is an in-memory Confluence serving the subset of the content API used by the publisher.
+type confluenceStandIn struct {
+	pages       map[string]*client.Page
+	bodies      map[string]string
+	attachments map[string][]string
+}
+
+func (c *confluenceStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content")
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		res := client.FindPagesRes{Results: []client.Page{}}
+		for _, page := range c.pages {
+			if page.Space.Key == r.URL.Query().Get("spaceKey") && page.Title == r.URL.Query().Get("title") {
+				res.Results = append(res.Results, *page)
+			}
+		}
+		json.NewEncoder(w).Encode(res)
+	case r.Method == http.MethodPost && path == "":
+		var req client.CreatePageReq
+		json.NewDecoder(r.Body).Decode(&req)
+		page := &client.Page{
+			ID:      "100",
+			Type:    req.Type,
+			Title:   req.Title,
+			Space:   &req.Space,
+			Version: &client.PageVersion{Number: 1},
+		}
+		c.pages[page.ID] = page
+		c.bodies[page.ID] = req.Body.Storage.Value
+		json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodGet:
+		page, ok := c.pages[strings.TrimPrefix(path, "/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode": 404, "message": "No content found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodPut && strings.HasSuffix(path, "/child/attachment"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/child/attachment")
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c.attachments[id] = append(c.attachments[id], header.Filename)
+		w.Write([]byte(`{"results": []}`))
+	case r.Method == http.MethodPut:
+		page := c.pages[strings.TrimPrefix(path, "/")]
+		var req client.UpdatePageReq
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Version.Number != page.Version.Number+1 {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"statusCode": 409, "message": "Version must be incremented on update."}`))
+			return
+		}
+		page.Title = req.Title
+		page.Version = &req.Version
+		c.bodies[page.ID] = req.Body.Storage.Value
+		json.NewEncoder(w).Encode(page)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type ConfluencePagePublisherTestSuite struct {
+	suite.Suite
+
+	plugin  *plugin.Schema
+	ctx     context.Context
+	standIn *confluenceStandIn
+	srv     *httptest.Server
+}
+
+func TestConfluencePagePublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfluencePagePublisherTestSuite))
+}
+
+func (s *ConfluencePagePublisherTestSuite) SetupTest() {
+	s.standIn = &confluenceStandIn{
+		pages:       map[string]*client.Page{},
+		bodies:      map[string]string{},
+		attachments: map[string][]string{},
+	}
+	s.srv = httptest.NewServer(s.standIn)
+	s.plugin = Plugin("v0.0.0", func(_, accountEmail, apiToken string) client.Client {
+		return client.New(s.srv.URL, accountEmail, apiToken)
+	})
+	s.ctx = context.Background()
+}
+
+func (s *ConfluencePagePublisherTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestSchema() {
+	schema := s.plugin.Publishers["confluence_page"]
+	s.Require().NotNil(schema)
+	s.NotNil(schema.Config)
+	s.NotNil(schema.Args)
+	s.NotNil(schema.PublishFunc)
+}
+
+func (s *ConfluencePagePublisherTestSuite) publish(args map[string]cty.Value, markdown string) error {
+	schema := s.plugin.Publishers["confluence_page"]
+	argsDecoder := plugintest.NewTestDecoder(s.T(), schema.Args)
+	for k, v := range args {
+		argsDecoder.SetAttr(k, v)
+	}
+	diags := s.plugin.Publish(s.ctx, "confluence_page", &plugin.PublishParams{
+		Config: plugintest.NewTestDecoder(s.T(), schema.Config).
+			SetAttr("domain", cty.StringVal("test_domain")).
+			SetAttr("account_email", cty.StringVal("test_account_email")).
+			SetAttr("api_token", cty.StringVal("test_api_token")).
+			Decode(),
+		Args:   argsDecoder.Decode(),
+		Format: plugin.OutputFormatHTML,
+		DataContext: plugindata.Map{
+			"document": plugindata.Map{
+				"content": plugindata.Map{
+					"type": plugindata.String("section"),
+					"children": plugindata.List{
+						plugindata.Map{
+							"type":     plugindata.String("element"),
+							"markdown": plugindata.String(markdown),
+						},
+					},
+				},
+			},
+		},
+		DocumentName: "test_doc",
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+	return nil
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestCreateAndUpdate() {
+	args := map[string]cty.Value{
+		"space_key": cty.StringVal("SEC"),
+		"toc":       cty.True,
+	}
+	s.Require().NoError(s.publish(args, "```mermaid\ngraph LR\n  A --> B\n```"))
+	s.Require().Contains(s.standIn.pages, "100")
+	s.Equal("test_doc", s.standIn.pages["100"].Title)
+	s.Equal(1, s.standIn.pages["100"].Version.Number)
+	s.Contains(s.standIn.bodies["100"], `<ac:structured-macro ac:name="toc"`)
+	s.Contains(s.standIn.bodies["100"], `<ri:attachment ri:filename="diagram-1.png" />`)
+	s.Equal([]string{"diagram-1.png"}, s.standIn.attachments["100"])
+
+	s.Require().NoError(s.publish(args, "> Updated"))
+	s.Len(s.standIn.pages, 1)
+	s.Equal(2, s.standIn.pages["100"].Version.Number)
+	s.Contains(s.standIn.bodies["100"], `<ac:structured-macro ac:name="info"`)
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestUpdateByID() {
+	s.standIn.pages["7"] = &client.Page{
+		ID:      "7",
+		Type:    "page",
+		Title:   "Old title",
+		Space:   &client.PageSpace{Key: "SEC"},
+		Version: &client.PageVersion{Number: 5},
+	}
+	err := s.publish(map[string]cty.Value{
+		"page_id": cty.StringVal("7"),
+		"title":   cty.StringVal("New title"),
+	}, "Hello")
+	s.Require().NoError(err)
+	s.Equal("New title", s.standIn.pages["7"].Title)
+	s.Equal(6, s.standIn.pages["7"].Version.Number)
+	s.Equal("<p>Hello</p>\n", s.standIn.bodies["7"])
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestUpdateByIDKeepsTitle() {
+	s.standIn.pages["7"] = &client.Page{
+		ID:      "7",
+		Type:    "page",
+		Title:   "Old title",
+		Space:   &client.PageSpace{Key: "SEC"},
+		Version: &client.PageVersion{Number: 5},
+	}
+	err := s.publish(map[string]cty.Value{
+		"page_id": cty.StringVal("7"),
+	}, "Hello")
+	s.Require().NoError(err)
+	s.Equal("Old title", s.standIn.pages["7"].Title)
+	s.Equal(6, s.standIn.pages["7"].Version.Number)
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestUpdateWithoutVersion() {
+	s.standIn.pages["7"] = &client.Page{
+		ID:    "7",
+		Type:  "page",
+		Title: "Old title",
+		Space: &client.PageSpace{Key: "SEC"},
+	}
+	err := s.publish(map[string]cty.Value{
+		"page_id": cty.StringVal("7"),
+	}, "Hello")
+	s.ErrorContains(err, `page "7" has no version`)
+	s.Empty(s.standIn.bodies["7"])
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestPageNotFound() {
+	err := s.publish(map[string]cty.Value{
+		"page_id": cty.StringVal("404"),
+	}, "Hello")
+	s.ErrorContains(err, "No content found")
+}
+
+func (s *ConfluencePagePublisherTestSuite) TestMissingSpace() {
+	err := s.publish(map[string]cty.Value{}, "Hello")
+	s.ErrorContains(err, "Either page_id or space_key is required")
+}
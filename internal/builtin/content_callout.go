@@ -0,0 +1,93 @@
+package builtin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+)
+
+var calloutTypes = []string{"note", "tip", "important", "warning", "caution"}
+
+func makeCalloutContentProvider() *plugin.ContentProvider {
+	types := make([]cty.Value, len(calloutTypes))
+	for i, typ := range calloutTypes {
+		types[i] = cty.StringVal(typ)
+	}
+	return &plugin.ContentProvider{
+		ContentFunc: genCalloutContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:       "type",
+					Type:       cty.String,
+					Doc:        `The type of the callout, defines its color and the default title`,
+					OneOf:      types,
+					DefaultVal: cty.StringVal("note"),
+				},
+				{
+					Name:       "title",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("Key finding"),
+					Doc:        `The title of the callout. Can use go template syntax. If not set, the capitalized type is used`,
+				},
+				{
+					Name:        "body",
+					Type:        cty.String,
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal:  cty.StringVal("{{ len .vars.alerts }} alerts require attention"),
+					Doc:         `The markdown content of the callout. Can use go template syntax`,
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Produces a callout: a highlighted box with a title, for example, a key finding, a warning or a recommendation.
+
+			The callout is rendered as a styled box in HTML and PDF and as a GitHub-style alert in markdown.
+		`),
+	}
+}
+
+func genCalloutContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	typ := params.Args.GetAttrVal("type").AsString()
+	var title string
+	if titleVal := params.Args.GetAttrVal("title"); !titleVal.IsNull() {
+		var err error
+		title, err = genTextContentText(titleVal.AsString(), params.DataContext)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to render title",
+				Detail:   err.Error(),
+			}}
+		}
+		title = strings.ReplaceAll(title, "\n", " ")
+	}
+	body, err := genTextContentText(params.Args.GetAttrVal("body").AsString(), params.DataContext)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render body",
+			Detail:   err.Error(),
+		}}
+	}
+	el, err := wrapMarkdown(body, nodes.NewAdmonition(typ, title))
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render callout",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: el,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type CalloutContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestCalloutContentTestSuite(t *testing.T) {
+	suite.Run(t, &CalloutContentTestSuite{})
+}
+
+func (s *CalloutContentTestSuite) SetupSuite() {
+	s.schema = makeCalloutContentProvider()
+}
+
+func (s *CalloutContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *CalloutContentTestSuite) callout(val string, dataCtx plugindata.Map, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, dataCtx, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: dataCtx,
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *CalloutContentTestSuite) TestDefault() {
+	result := s.callout(`body = "Some **text**"`, plugindata.Map{}, diagtest.Asserts{})
+	s.Equal("> [!NOTE]\n> \n> Some **text**\n", mdprint.PrintString(result.Content))
+}
+
+func (s *CalloutContentTestSuite) TestTypeAndTitle() {
+	dataCtx := plugindata.Map{
+		"vars": plugindata.Map{
+			"count": plugindata.Number(3),
+		},
+	}
+	result := s.callout(`
+	type  = "warning"
+	title = "{{ .vars.count }} findings"
+	body  = "Review the findings"
+	`, dataCtx, diagtest.Asserts{})
+	s.Equal("> [!WARNING]\n> **3 findings**\n> \n> Review the findings\n", mdprint.PrintString(result.Content))
+}
+
+func (s *CalloutContentTestSuite) TestInvalidTemplate() {
+	s.callout(`body = "{{ .vars.missing | nosuchfunc }}"`, plugindata.Map{}, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to render body"),
+	}})
+}
@@ -0,0 +1,410 @@
+package builtin
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/print/chart"
+)
+
+// chartTypeURL is the type URL of the custom AST node payload produced by content.chart.
+const chartTypeURL = "blackstork.io/builtin.Chart"
+
+const (
+	chartMarkdownImage = "image"
+	chartMarkdownTable = "table"
+	chartMarkdownBoth  = "both"
+)
+
+// chartNode is the payload of the chart custom node.
+type chartNode struct {
+	chart.Chart
+	// Markdown is the representation of the chart in markdown output.
+	Markdown string `json:"markdown"`
+}
+
+func makeChartContentProvider() *plugin.ContentProvider {
+	types := make([]cty.Value, len(chart.Types))
+	for i, t := range chart.Types {
+		types[i] = cty.StringVal(string(t))
+	}
+	return &plugin.ContentProvider{
+		ContentFunc: genChartContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name: "rows",
+					Type: cty.List(plugindata.Encapsulated.CtyType()),
+					Doc: "A list of objects with the chart data.\n" +
+						"May be set statically or as a result of one or more queries.",
+					Constraints: constraint.RequiredNonNull,
+				},
+				{
+					Name:        "type",
+					Type:        cty.String,
+					Doc:         "Type of the chart. Pie charts use only the first series.",
+					OneOf:       types,
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal:  cty.StringVal("bar"),
+				},
+				{
+					Name:        "x",
+					Type:        cty.String,
+					Doc:         "Field of the row with the category (x axis) value. Nested fields are separated by dots.",
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("date"),
+				},
+				{
+					Name:        "y",
+					Type:        cty.String,
+					Doc:         "Field of the row with the numeric value. Nested fields are separated by dots.",
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("count"),
+				},
+				{
+					Name: "series",
+					Type: cty.String,
+					Doc: "Field of the row with the series name. If set, a series is plotted for every distinct value.\n" +
+						"Values of the rows with the same category and series are summed.",
+					ExampleVal: cty.StringVal("severity"),
+				},
+				{
+					Name:       "title",
+					Type:       cty.String,
+					Doc:        "Title of the chart.",
+					ExampleVal: cty.StringVal("Alerts per day"),
+				},
+				{
+					Name: "x_label",
+					Type: cty.String,
+					Doc:  "Label of the x axis.",
+				},
+				{
+					Name: "y_label",
+					Type: cty.String,
+					Doc:  "Label of the y axis.",
+				},
+				{
+					Name:         "width",
+					Type:         cty.Number,
+					Doc:          "Width of the chart in pixels.",
+					DefaultVal:   cty.NumberIntVal(chart.DefaultWidth),
+					MinInclusive: cty.NumberIntVal(100),
+				},
+				{
+					Name:         "height",
+					Type:         cty.Number,
+					Doc:          "Height of the chart in pixels.",
+					DefaultVal:   cty.NumberIntVal(chart.DefaultHeight),
+					MinInclusive: cty.NumberIntVal(100),
+				},
+				{
+					Name: "markdown",
+					Type: cty.String,
+					Doc: "Representation of the chart in markdown output: an embedded SVG image, a table with the data, or both.\n" +
+						"Markdown viewers often don't display embedded images, the table keeps the data readable.",
+					DefaultVal: cty.StringVal(chartMarkdownBoth),
+					OneOf: []cty.Value{
+						cty.StringVal(chartMarkdownImage),
+						cty.StringVal(chartMarkdownTable),
+						cty.StringVal(chartMarkdownBoth),
+					},
+				},
+			},
+		},
+		Doc: `
+			Produces a bar, line, pie or stacked area chart.
+
+			The chart is embedded as an SVG image in HTML and as a raster image in PDF.
+			In markdown it is embedded as an SVG image, followed by a table with the data.`,
+	}
+}
+
+func genChartContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	rows, err := utils.FnMapErr(params.Args.GetAttrVal("rows").AsValueSlice(), func(v cty.Value) (plugindata.Data, error) {
+		data, err := plugindata.Encapsulated.FromCty(v)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, nil
+		}
+		return *data, nil
+	})
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   err.Error(),
+			Subject:  &params.Args.Attrs["rows"].ValueRange,
+		}}
+	}
+	node := &chartNode{
+		Chart: chart.Chart{
+			Type:   chart.Type(params.Args.GetAttrVal("type").AsString()),
+			Title:  stringAttr(params.Args, "title"),
+			XLabel: stringAttr(params.Args, "x_label"),
+			YLabel: stringAttr(params.Args, "y_label"),
+		},
+		Markdown: stringAttr(params.Args, "markdown"),
+	}
+	width, _ := params.Args.GetAttrVal("width").AsBigFloat().Int64()
+	height, _ := params.Args.GetAttrVal("height").AsBigFloat().Int64()
+	node.Width, node.Height = int(width), int(height)
+	err = collectChartData(
+		&node.Chart, rows,
+		stringAttr(params.Args, "x"),
+		stringAttr(params.Args, "y"),
+		stringAttr(params.Args, "series"),
+	)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to collect chart data",
+			Detail:   err.Error(),
+		}}
+	}
+	payload, err := json.Marshal(node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to encode chart",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: plugin.NewElement(ast.CustomBlock(&anypb.Any{
+			TypeUrl: chartTypeURL,
+			Value:   payload,
+		})),
+	}, nil
+}
+
+// collectChartData groups the rows by category and series.
+// Categories and series keep the order of their first appearance.
+func collectChartData(c *chart.Chart, rows plugindata.List, x, y, series string) error {
+	categories := map[string]int{}
+	seriesIdx := map[string]int{}
+	for i, row := range rows {
+		category, err := chartField(row, x)
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i+1, err)
+		}
+		name := ""
+		if series != "" {
+			name, err = chartField(row, series)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", i+1, err)
+			}
+		}
+		value, err := chartValue(row, y)
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i+1, err)
+		}
+		ci, ok := categories[category]
+		if !ok {
+			ci = len(c.Categories)
+			categories[category] = ci
+			c.Categories = append(c.Categories, category)
+		}
+		si, ok := seriesIdx[name]
+		if !ok {
+			si = len(c.Series)
+			seriesIdx[name] = si
+			c.Series = append(c.Series, &chart.Series{Name: name})
+		}
+		s := c.Series[si]
+		for len(s.Values) <= ci {
+			s.Values = append(s.Values, nil)
+		}
+		if value == nil {
+			continue
+		}
+		if s.Values[ci] == nil {
+			s.Values[ci] = value
+		} else {
+			*s.Values[ci] += *value
+		}
+	}
+	for _, s := range c.Series {
+		for len(s.Values) < len(c.Categories) {
+			s.Values = append(s.Values, nil)
+		}
+	}
+	if len(c.Categories) == 0 {
+		return fmt.Errorf("no rows to plot")
+	}
+	return nil
+}
+
+// lookupChartField returns the value of the dot-separated field path.
+func lookupChartField(row plugindata.Data, path string) (plugindata.Data, error) {
+	cur := row
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(plugindata.Map)
+		if !ok {
+			return nil, fmt.Errorf("field %q not found: row is not an object", path)
+		}
+		cur = m[key]
+	}
+	return cur, nil
+}
+
+func chartField(row plugindata.Data, path string) (string, error) {
+	val, err := lookupChartField(row, path)
+	if err != nil {
+		return "", err
+	}
+	switch val := val.(type) {
+	case nil:
+		return "", nil
+	case plugindata.String:
+		return string(val), nil
+	case plugindata.Number:
+		return strconv.FormatFloat(float64(val), 'f', -1, 64), nil
+	case plugindata.Bool:
+		return strconv.FormatBool(bool(val)), nil
+	case plugindata.Time:
+		return time.Time(val).Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("field %q must be a string, number, bool or time", path)
+}
+
+func chartValue(row plugindata.Data, path string) (*float64, error) {
+	val, err := lookupChartField(row, path)
+	if err != nil {
+		return nil, err
+	}
+	var res float64
+	switch val := val.(type) {
+	case nil:
+		return nil, nil
+	case plugindata.Number:
+		res = float64(val)
+	case plugindata.String:
+		res, err = strconv.ParseFloat(strings.TrimSpace(string(val)), 64)
+		if err != nil {
+			return nil, fmt.Errorf("field %q must be a number, got %q", path, val)
+		}
+	default:
+		return nil, fmt.Errorf("field %q must be a number", path)
+	}
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return nil, nil
+	}
+	return &res, nil
+}
+
+func makeChartNodeRenderer() *plugin.NodeRenderer {
+	return &plugin.NodeRenderer{
+		Doc: "Renders the charts produced by content.chart",
+		Formats: []plugin.OutputFormat{
+			plugin.OutputFormatMD,
+			plugin.OutputFormatHTML,
+			plugin.OutputFormatPDF,
+		},
+		RenderFunc: renderChartNode,
+	}
+}
+
+func renderChartNode(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+	var node chartNode
+	err := json.Unmarshal(params.Node.GetValue(), &node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to decode chart",
+			Detail:   err.Error(),
+		}}
+	}
+	var content []byte
+	switch params.Format {
+	case plugin.OutputFormatHTML:
+		var svg []byte
+		svg, err = chart.RenderSVG(&node.Chart)
+		content = append(append([]byte(`<figure class="chart">`+"\n"), svg...), "\n</figure>"...)
+	case plugin.OutputFormatPDF:
+		var img []byte
+		img, err = chart.RenderPNG(&node.Chart)
+		content = []byte(chartImage(&node.Chart, "image/png", img))
+	default:
+		content, err = renderChartMarkdown(&node)
+	}
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render chart",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.RenderNodeResult{Content: content}, nil
+}
+
+func renderChartMarkdown(node *chartNode) ([]byte, error) {
+	var parts []string
+	if node.Markdown != chartMarkdownTable {
+		svg, err := chart.RenderSVG(&node.Chart)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, chartImage(&node.Chart, "image/svg+xml", svg))
+	}
+	if node.Markdown != chartMarkdownImage {
+		parts = append(parts, chartTable(&node.Chart))
+	}
+	return []byte(strings.Join(parts, "\n\n")), nil
+}
+
+func chartImage(c *chart.Chart, mediaType string, data []byte) string {
+	alt := c.Title
+	if alt == "" {
+		alt = "Chart"
+	}
+	return fmt.Sprintf("![%s](data:%s;base64,%s)", escapeChartCell(alt), mediaType, base64.StdEncoding.EncodeToString(data))
+}
+
+func chartTable(c *chart.Chart) string {
+	header, rows := c.Table()
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteByte('|')
+		for _, cell := range cells {
+			sb.WriteString(escapeChartCell(cell))
+			sb.WriteByte('|')
+		}
+		sb.WriteByte('\n')
+	}
+	writeRow(header)
+	sb.WriteByte('|')
+	for range header {
+		sb.WriteString("---|")
+	}
+	sb.WriteByte('\n')
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+var chartCellEscaper = strings.NewReplacer("|", `\|`, "\n", " ", "[", `\[`, "]", `\]`)
+
+func escapeChartCell(s string) string {
+	return chartCellEscaper.Replace(s)
+}
@@ -0,0 +1,158 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/chart"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type ChartGeneratorTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestChartGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, &ChartGeneratorTestSuite{})
+}
+
+func (s *ChartGeneratorTestSuite) SetupSuite() {
+	s.schema = makeChartContentProvider()
+}
+
+func (s *ChartGeneratorTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *ChartGeneratorTestSuite) genChart(val string, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, plugindata.Map{}, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *ChartGeneratorTestSuite) decode(result *plugin.ContentResult) *chartNode {
+	el, ok := result.Content.(*plugin.ContentElement)
+	s.Require().True(ok)
+	_, content := el.AsNode()
+	custom, ok := content.FirstChild().(*nodes.CustomBlock)
+	s.Require().True(ok)
+	s.Equal(chartTypeURL, custom.Data.GetTypeUrl())
+	var node chartNode
+	s.Require().NoError(json.Unmarshal(custom.Data.GetValue(), &node))
+	return &node
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func (s *ChartGeneratorTestSuite) TestSeries() {
+	result := s.genChart(`
+	type   = "stacked_area"
+	title  = "Alerts"
+	x      = "day"
+	y      = "stats.count"
+	series = "severity"
+	rows = [
+		{day = "Mon", severity = "high", stats = {count = 1}},
+		{day = "Mon", severity = "low", stats = {count = 4}},
+		{day = "Tue", severity = "low", stats = {count = "2"}},
+		{day = "Tue", severity = "low", stats = {count = 3}},
+		{day = "Wed", severity = "high", stats = {count = null}},
+	]
+	`, diagtest.Asserts{})
+	node := s.decode(result)
+	s.Equal(&chartNode{
+		Chart: chart.Chart{
+			Type:       chart.TypeStackedArea,
+			Title:      "Alerts",
+			Width:      chart.DefaultWidth,
+			Height:     chart.DefaultHeight,
+			Categories: []string{"Mon", "Tue", "Wed"},
+			Series: []*chart.Series{
+				{Name: "high", Values: []*float64{ptr(1), nil, nil}},
+				{Name: "low", Values: []*float64{ptr(4), ptr(5), nil}},
+			},
+		},
+		Markdown: chartMarkdownBoth,
+	}, node)
+}
+
+func (s *ChartGeneratorTestSuite) TestInvalidValue() {
+	s.genChart(`
+	type = "bar"
+	x    = "day"
+	y    = "count"
+	rows = [
+		{day = "Mon", count = "many"},
+	]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to collect chart data"),
+		diagtest.DetailContains(`row 1: field "count" must be a number, got "many"`),
+	}})
+}
+
+func (s *ChartGeneratorTestSuite) TestRender() {
+	val := `
+	type     = "bar"
+	title    = "Alerts"
+	x        = "day"
+	y        = "count"
+	markdown = "table"
+	rows = [
+		{day = "Mon", count = 1},
+		{day = "Tue", count = 2.5},
+	]
+	`
+	renderers := plugin.NodeRenderers{chartTypeURL: makeChartNodeRenderer()}
+
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genChart(val, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Equal("|Category|Value|\n|---|---|\n|Mon|1|\n|Tue|2.5|\n", buf.String())
+
+	buf.Reset()
+	err = htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genChart(val, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Contains(buf.String(), `<figure class="chart">`)
+	s.Contains(buf.String(), `<title>Alerts</title>`)
+}
+
+func (s *ChartGeneratorTestSuite) TestRenderMarkdownImage() {
+	result := s.genChart(`
+	type = "pie"
+	x    = "day"
+	y    = "count"
+	rows = [
+		{day = "Mon", count = 1},
+	]
+	`, diagtest.Asserts{})
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(plugin.NodeRenderers{chartTypeURL: makeChartNodeRenderer()})).
+		Print(context.Background(), &buf, result.Content)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(buf.String(), "![Chart](data:image/svg+xml;base64,"))
+	s.True(strings.HasSuffix(buf.String(), "\n\n|Category|Value|\n|---|---|\n|Mon|1|\n"))
+}
@@ -0,0 +1,85 @@
+package builtin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+)
+
+func makeDetailsContentProvider() *plugin.ContentProvider {
+	return &plugin.ContentProvider{
+		ContentFunc: genDetailsContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "summary",
+					Type:        cty.String,
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("Raw evidence"),
+					Doc:         `The summary shown when the section is collapsed. Can use go template syntax`,
+				},
+				{
+					Name:        "body",
+					Type:        cty.String,
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal:  cty.StringVal("```json\n{{ toPrettyJson .vars.evidence }}\n```"),
+					Doc:         `The markdown content of the section. Can use go template syntax`,
+				},
+				{
+					Name:        "open",
+					Type:        cty.Bool,
+					Constraints: constraint.NonNull,
+					DefaultVal:  cty.False,
+					Doc:         `Expands the section by default`,
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Produces a collapsible section with a summary.
+
+			The section is collapsible in HTML and in markdown viewers supporting ` + "`<details>`" + ` tag.
+			In PDF, the summary is followed by the expanded content.
+		`),
+	}
+}
+
+func genDetailsContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	summary, err := genTextContentText(params.Args.GetAttrVal("summary").AsString(), params.DataContext)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render summary",
+			Detail:   err.Error(),
+		}}
+	}
+	summary = strings.ReplaceAll(summary, "\n", " ")
+	body, err := genTextContentText(params.Args.GetAttrVal("body").AsString(), params.DataContext)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render body",
+			Detail:   err.Error(),
+		}}
+	}
+	open := params.Args.GetAttrVal("open").True()
+	el, err := wrapMarkdown(body, nodes.NewDetails(summary, open))
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render details",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: el,
+	}, nil
+}
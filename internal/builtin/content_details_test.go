@@ -0,0 +1,66 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type DetailsContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestDetailsContentTestSuite(t *testing.T) {
+	suite.Run(t, &DetailsContentTestSuite{})
+}
+
+func (s *DetailsContentTestSuite) SetupSuite() {
+	s.schema = makeDetailsContentProvider()
+}
+
+func (s *DetailsContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *DetailsContentTestSuite) details(val string, dataCtx plugindata.Map, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, dataCtx, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: dataCtx,
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *DetailsContentTestSuite) TestClosed() {
+	dataCtx := plugindata.Map{
+		"vars": plugindata.Map{
+			"host": plugindata.String("web-1"),
+		},
+	}
+	result := s.details(`
+	summary = "Evidence for {{ .vars.host }}"
+	body    = "Raw *logs*"
+	`, dataCtx, diagtest.Asserts{})
+	s.Equal("<details>\n<summary>Evidence for web-1</summary>\n\nRaw *logs*\n\n</details>\n", mdprint.PrintString(result.Content))
+}
+
+func (s *DetailsContentTestSuite) TestOpen() {
+	result := s.details(`
+	summary = "A < B"
+	body    = "text"
+	open    = true
+	`, plugindata.Map{}, diagtest.Asserts{})
+	s.Equal("<details open>\n<summary>A &lt; B</summary>\n\ntext\n\n</details>\n", mdprint.PrintString(result.Content))
+}
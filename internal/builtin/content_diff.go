@@ -0,0 +1,219 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeDiffContentProvider() *plugin.ContentProvider {
+	return &plugin.ContentProvider{
+		ContentFunc: genDiffContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name: "previous",
+					Type: cty.List(plugindata.Encapsulated.CtyType()),
+					Doc: "A list of objects of the previous period.\n" +
+						"May be set statically or as a result of one or more queries.",
+					Constraints: constraint.RequiredNonNull,
+				},
+				{
+					Name: "current",
+					Type: cty.List(plugindata.Encapsulated.CtyType()),
+					Doc: "A list of objects of the current period.\n" +
+						"May be set statically or as a result of one or more queries.",
+					Constraints: constraint.RequiredNonNull,
+				},
+				{
+					Name:        "key",
+					Type:        cty.String,
+					Doc:         "Field identifying the objects in both lists. Nested fields are separated by dots.",
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("id"),
+				},
+				{
+					Name: "fields",
+					Type: cty.List(cty.String),
+					Doc: "Compared fields, shown in the tables. Nested fields are separated by dots.\n" +
+						"If not set, all top-level fields of the objects are compared.",
+					ExampleVal: cty.ListVal([]cty.Value{
+						cty.StringVal("severity"),
+						cty.StringVal("status"),
+					}),
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Compares two lists of objects, for example, findings of this and last week, and produces
+			a summary followed by the tables of added, removed and changed objects. The changed objects
+			are listed with the previous and current values of every changed field.
+
+			The same comparison is available in the queries as ` + "`diff(previous; current; key)`" + ` jq function
+			(or ` + "`diff(previous; current; key; fields)`" + `), so the result can be used in other content blocks:
+
+			` + "```hcl" + `
+			vars {
+			  changes = query_jq("diff(.data.json.last_week; .data.json.this_week; \"id\")")
+			}
+			` + "```" + `
+
+			The function returns an object with ` + "`added`, `removed`, `changed`, `unchanged`" + ` lists and
+			` + "`summary`" + ` object with the number of items in every list. The items of ` + "`changed`" + ` list have
+			` + "`key`, `previous`, `current`" + ` and ` + "`changes`" + ` fields, where ` + "`changes`" + ` is a list of
+			` + "`{field, previous, current}`" + ` objects.
+		`),
+	}
+}
+
+func genDiffContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	var lists [2]plugindata.List
+	for i, name := range []string{"previous", "current"} {
+		list, err := utils.FnMapErr(params.Args.GetAttrVal(name).AsValueSlice(), func(v cty.Value) (plugindata.Data, error) {
+			data, err := plugindata.Encapsulated.FromCty(v)
+			if err != nil {
+				return nil, err
+			}
+			if data == nil {
+				return nil, nil
+			}
+			return *data, nil
+		})
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse arguments",
+				Detail:   err.Error(),
+				Subject:  &params.Args.Attrs[name].ValueRange,
+			}}
+		}
+		lists[i] = list
+	}
+	opts := plugindata.DiffOptions{
+		Key: params.Args.GetAttrVal("key").AsString(),
+	}
+	if fields := params.Args.GetAttrVal("fields"); !fields.IsNull() {
+		for _, field := range fields.AsValueSlice() {
+			opts.Fields = append(opts.Fields, field.AsString())
+		}
+	}
+	res, err := plugindata.Diff(lists[0], lists[1], opts)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to compare the lists",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: plugin.NewElementFromMarkdown(renderDiff(res, opts)),
+	}, nil
+}
+
+// renderDiff renders the summary and a table for every non-empty list of the result.
+func renderDiff(res *plugindata.DiffResult, opts plugindata.DiffOptions) string {
+	parts := []string{fmt.Sprintf(
+		"**%d** added, **%d** removed, **%d** changed, **%d** unchanged",
+		len(res.Added), len(res.Removed), len(res.Changed), len(res.Unchanged),
+	)}
+	if len(res.Added) > 0 {
+		parts = append(parts, "**Added**", renderDiffRows(res.Added, opts))
+	}
+	if len(res.Removed) > 0 {
+		parts = append(parts, "**Removed**", renderDiffRows(res.Removed, opts))
+	}
+	if len(res.Changed) > 0 {
+		var sb strings.Builder
+		sb.WriteString("|Key|Field|Previous|Current|\n|---|---|---|---|")
+		for _, item := range res.Changed {
+			for i, change := range item.Changes {
+				key := ""
+				if i == 0 {
+					key = escapeChartCell(item.Key)
+				}
+				fmt.Fprintf(&sb, "\n|%s|%s|%s|%s|",
+					key,
+					escapeChartCell(change.Field),
+					diffCell(change.Previous),
+					diffCell(change.Current),
+				)
+			}
+		}
+		parts = append(parts, "**Changed**", sb.String())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderDiffRows renders a table with the key and the compared fields of the objects.
+func renderDiffRows(rows plugindata.List, opts plugindata.DiffOptions) string {
+	columns := []string{opts.Key}
+	fields := opts.Fields
+	if len(fields) == 0 {
+		for _, row := range rows {
+			if m, ok := row.(plugindata.Map); ok {
+				for k := range m {
+					if !slices.Contains(fields, k) {
+						fields = append(fields, k)
+					}
+				}
+			}
+		}
+		slices.Sort(fields)
+	}
+	for _, field := range fields {
+		if field != opts.Key {
+			columns = append(columns, field)
+		}
+	}
+	var sb strings.Builder
+	sb.WriteByte('|')
+	for _, column := range columns {
+		sb.WriteString(escapeChartCell(column))
+		sb.WriteByte('|')
+	}
+	sb.WriteString("\n|" + strings.Repeat("---|", len(columns)))
+	for _, row := range rows {
+		sb.WriteString("\n|")
+		for _, column := range columns {
+			val, _ := plugindata.LookupField(row, column)
+			sb.WriteString(diffCell(val))
+			sb.WriteByte('|')
+		}
+	}
+	return sb.String()
+}
+
+func diffCell(val plugindata.Data) string {
+	switch val := val.(type) {
+	case nil:
+		return ""
+	case plugindata.String:
+		return escapeChartCell(string(val))
+	case plugindata.Number:
+		return strconv.FormatFloat(float64(val), 'f', -1, 64)
+	case plugindata.Bool:
+		return strconv.FormatBool(bool(val))
+	case plugindata.Time:
+		return time.Time(val).Format(time.RFC3339)
+	}
+	data, err := json.Marshal(val.Any())
+	if err != nil {
+		return ""
+	}
+	// pipes are escaped in the code spans of the table cells as well
+	return "`" + strings.ReplaceAll(string(data), "|", `\|`) + "`"
+}
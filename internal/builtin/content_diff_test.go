@@ -0,0 +1,104 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type DiffContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestDiffContentTestSuite(t *testing.T) {
+	suite.Run(t, &DiffContentTestSuite{})
+}
+
+func (s *DiffContentTestSuite) SetupSuite() {
+	s.schema = makeDiffContentProvider()
+}
+
+func (s *DiffContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *DiffContentTestSuite) genDiff(val string, asserts diagtest.Asserts) string {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, plugindata.Map{}, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	if result == nil {
+		return ""
+	}
+	return result.Content.AsData().(plugindata.Map)["markdown"].(plugindata.String).Any().(string)
+}
+
+func (s *DiffContentTestSuite) TestDiff() {
+	s.Equal("**1** added, **1** removed, **1** changed, **1** unchanged\n\n"+
+		"**Added**\n\n"+
+		"|id|severity|tags|title|\n|---|---|---|---|\n|CVE-3|low|`[\"web\"]`|XSS \\| stored|\n\n"+
+		"**Removed**\n\n"+
+		"|id|severity|title|\n|---|---|---|\n|CVE-1|high|RCE|\n\n"+
+		"**Changed**\n\n"+
+		"|Key|Field|Previous|Current|\n|---|---|---|---|\n"+
+		"|CVE-2|severity|medium|high|\n"+
+		"||title|SQLi|SQL injection|",
+		s.genDiff(`
+			key = "id"
+			previous = [
+				{id = "CVE-1", title = "RCE", severity = "high"},
+				{id = "CVE-2", title = "SQLi", severity = "medium"},
+				{id = "CVE-4", title = "CSRF", severity = "low"},
+			]
+			current = [
+				{id = "CVE-2", title = "SQL injection", severity = "high"},
+				{id = "CVE-3", title = "XSS | stored", severity = "low", tags = ["web"]},
+				{id = "CVE-4", title = "CSRF", severity = "low"},
+			]
+		`, diagtest.Asserts{}))
+}
+
+func (s *DiffContentTestSuite) TestFields() {
+	s.Equal("**0** added, **0** removed, **1** changed, **0** unchanged\n\n"+
+		"**Changed**\n\n"+
+		"|Key|Field|Previous|Current|\n|---|---|---|---|\n"+
+		"|a|status|open|closed|",
+		s.genDiff(`
+			key = "host"
+			fields = ["status"]
+			previous = [{host = "a", status = "open", seen = 1}]
+			current = [{host = "a", status = "closed", seen = 2}]
+		`, diagtest.Asserts{}))
+}
+
+func (s *DiffContentTestSuite) TestNoChanges() {
+	s.Equal("**0** added, **0** removed, **0** changed, **0** unchanged", s.genDiff(`
+		key = "id"
+		previous = []
+		current = []
+	`, diagtest.Asserts{}))
+}
+
+func (s *DiffContentTestSuite) TestMissingKey() {
+	s.genDiff(`
+		key = "id"
+		previous = [{name = "a"}]
+		current = []
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to compare the lists"),
+		diagtest.DetailEquals(`previous list: item 1: key field "id" is missing`),
+	}})
+}
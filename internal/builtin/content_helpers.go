@@ -7,8 +7,13 @@ import (
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
 
 	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 )
 
@@ -118,3 +123,19 @@ func templateString(str string, datactx plugindata.Map) (string, error) {
 	}
 	return strings.TrimSpace(buf.String()), nil
 }
+
+// wrapMarkdown parses the markdown and places its blocks into the container node.
+// The result is an AST element, the container is expected to be an extended block node.
+func wrapMarkdown(source string, container ast.Node) (*plugin.ContentElement, error) {
+	src := []byte(source)
+	doc := goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(text.NewReader(src))
+	for _, c := range nodes.Children(doc) {
+		container.AppendChild(container, c)
+	}
+	doc.AppendChild(doc, container)
+	node, err := astv1.Encode(nodes.ToFabricContentNode(doc), src)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.NewElementFromMarkdownAndAST(nil, node.GetContentNode(), nil), nil
+}
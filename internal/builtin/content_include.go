@@ -0,0 +1,225 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type sourceDirKeyT struct{}
+
+var sourceDirKey = sourceDirKeyT{}
+
+// WithSourceDir returns a context with the directory of the fabric files. Relative paths
+// in `include` content blocks are resolved from the directories of the files containing the blocks.
+func WithSourceDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, sourceDirKey, dir)
+}
+
+func makeIncludeContentProvider() *plugin.ContentProvider {
+	return &plugin.ContentProvider{
+		ContentFunc: genIncludeContent,
+		DocumentArg: "document",
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:       "path",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("path/to/disclaimer.md"),
+					Doc: utils.Dedent(`
+						A path to a local markdown file to include. Relative paths are resolved from the directory
+						of the file containing the content block
+					`),
+				},
+				{
+					Name:       "document",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("methodology"),
+					Doc: utils.Dedent(`
+						A name of the document template to include. The document is rendered without publishing
+						and its content is included. The name must be a static string
+					`),
+				},
+				{
+					Name:        "absolute_size",
+					Type:        cty.Number,
+					Constraints: constraint.Integer,
+					DefaultVal:  cty.NullVal(cty.Number),
+					Doc: utils.Dedent(`
+						Sets the absolute size of the top-level headings of the included content, other headings are adjusted accordingly.
+						If ` + "`null`" + ` – the size is determined from the document structure, same as for ` + "`content.title`" + `
+					`),
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Includes the content of a local markdown file or of another document.
+
+			Either ` + "`path`" + ` or ` + "`document`" + ` argument must be set.
+
+			The heading levels of the included content are adjusted to the position of the content block
+			in the document, so the headings appear in the table of contents of the document.
+			Relative image paths in the included file are resolved relative to the directory of the file.
+		`),
+	}
+}
+
+func genIncludeContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	pathVal := params.Args.GetAttrVal("path")
+	documentVal := params.Args.GetAttrVal("document")
+	if pathVal.IsNull() == documentVal.IsNull() {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   "Either \"path\" or \"document\" must be set",
+		}}
+	}
+	var (
+		source  []byte
+		baseDir string
+	)
+	if !pathVal.IsNull() {
+		filePath := resolveIncludePath(ctx, params.Args, pathVal.AsString())
+		var err error
+		source, err = os.ReadFile(filePath)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read a file",
+				Detail:   err.Error(),
+			}}
+		}
+		baseDir = filepath.Dir(filePath)
+	} else {
+		var err error
+		source, err = includedDocumentSource(params.DataContext, documentVal.AsString())
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to include a document",
+				Detail:   err.Error(),
+			}}
+		}
+	}
+
+	absoluteSize := params.Args.GetAttrVal("absolute_size")
+	if absoluteSize.IsNull() {
+		absoluteSize = cty.NumberIntVal(findDefaultTitleSize(params.DataContext) + 1)
+	}
+	size, _ := absoluteSize.AsBigFloat().Int64()
+	if size < minAbsoluteTitleSize || size > maxAbsoluteTitleSize {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   fmt.Sprintf("absolute_size must be between %d and %d", minAbsoluteTitleSize, maxAbsoluteTitleSize),
+		}}
+	}
+
+	doc := goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(text.NewReader(source))
+	shiftHeadings(doc, int(size)+1)
+	if baseDir != "" {
+		resolveImagePaths(doc, baseDir)
+	}
+	node, err := astv1.Encode(nodes.ToFabricContentNode(doc), source)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse the included content",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		// markdown is produced from the modified AST
+		Content: plugin.NewElementFromMarkdownAndAST(nil, node.GetContentNode(), nil),
+	}, nil
+}
+
+// resolveIncludePath resolves the relative path from the directory of the file
+// containing the content block.
+func resolveIncludePath(ctx context.Context, args *dataspec.Block, filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+	attr, ok := args.Attrs["path"]
+	if !ok || attr.NameRange.Filename == "" {
+		return filePath
+	}
+	sourceDir, _ := ctx.Value(sourceDirKey).(string)
+	return filepath.Join(sourceDir, filepath.Dir(filepath.FromSlash(attr.NameRange.Filename)), filePath)
+}
+
+// includedDocumentSource returns the markdown of the document rendered in advance by the engine.
+func includedDocumentSource(dataCtx plugindata.Map, name string) ([]byte, error) {
+	documents, _ := dataCtx[plugin.IncludedDocumentsKey].(plugindata.Map)
+	document, ok := documents[name].(plugindata.Map)
+	if !ok {
+		return nil, fmt.Errorf("document %q is not rendered, the name must be a static string of an existing document", name)
+	}
+	contentMap, _ := document["content"].(plugindata.Map)
+	content, err := plugin.ParseContentData(contentMap)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, nil
+	}
+	return []byte(mdprint.PrintString(content)), nil
+}
+
+// shiftHeadings moves the headings so the top-level ones have the given level.
+func shiftHeadings(doc ast.Node, level int) {
+	top := 0
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && entering && (top == 0 || h.Level < top) {
+			top = h.Level
+		}
+		return ast.WalkContinue, nil
+	})
+	if top == 0 {
+		return
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && entering {
+			h.Level = min(max(h.Level+level-top, 1), 6)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// resolveImagePaths prefixes the relative image paths with the directory of the included file.
+func resolveImagePaths(doc ast.Node, dir string) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		img, ok := n.(*ast.Image)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		dest := string(img.Destination)
+		u, err := url.Parse(dest)
+		if err != nil || u.Scheme != "" || u.Host != "" || dest == "" ||
+			strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, "#") {
+			return ast.WalkContinue, nil
+		}
+		img.Destination = []byte(path.Join(filepath.ToSlash(dir), dest))
+		return ast.WalkContinue, nil
+	})
+}
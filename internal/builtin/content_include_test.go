@@ -0,0 +1,116 @@
+package builtin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type IncludeContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestIncludeContentTestSuite(t *testing.T) {
+	suite.Run(t, &IncludeContentTestSuite{})
+}
+
+func (s *IncludeContentTestSuite) SetupSuite() {
+	s.schema = makeIncludeContentProvider()
+}
+
+func (s *IncludeContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *IncludeContentTestSuite) include(val string, dataCtx plugindata.Map, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, dataCtx, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: dataCtx,
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *IncludeContentTestSuite) TestFile() {
+	dir := filepath.Join(s.T().TempDir(), "docs")
+	s.Require().NoError(os.Mkdir(dir, 0o755))
+	path := filepath.Join(dir, "disclaimer.md")
+	s.Require().NoError(os.WriteFile(path, []byte(
+		"# Disclaimer\n\nSee ![logo](img/logo.png) and ![remote](https://example.com/a.png).\n\n### Details\n\ntext\n",
+	), 0o600))
+
+	result := s.include(`path = "`+filepath.ToSlash(path)+`"`, plugindata.Map{}, diagtest.Asserts{})
+	s.Equal(
+		"## Disclaimer\n\nSee ![logo]("+filepath.ToSlash(dir)+"/img/logo.png) and ![remote](https://example.com/a.png).\n\n#### Details\n\ntext\n",
+		mdprint.PrintString(result.Content),
+	)
+}
+
+func (s *IncludeContentTestSuite) TestFileRelativeToBlock() {
+	sourceDir := s.T().TempDir()
+	dir := filepath.Join(sourceDir, "reports", "parts")
+	s.Require().NoError(os.MkdirAll(dir, 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "scope.md"), []byte("# Scope\n\n![map](map.png)\n"), 0o600))
+
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, `path = "parts/scope.md"`, nil, diagtest.Asserts{})
+	args.Attrs["path"].NameRange.Filename = "reports/weekly.fabric"
+	result, diags := s.schema.ContentFunc(WithSourceDir(context.Background(), sourceDir), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	s.Require().Empty(diags)
+	s.Equal(
+		"## Scope\n\n![map]("+filepath.ToSlash(dir)+"/map.png)\n",
+		mdprint.PrintString(result.Content),
+	)
+}
+
+func (s *IncludeContentTestSuite) TestDocument() {
+	dataCtx := plugindata.Map{
+		"documents": plugindata.Map{
+			"methodology": plugindata.Map{
+				"content": plugin.NewElementFromMarkdown("# Methodology\n\n## Scope\n\nAll hosts").AsData(),
+			},
+		},
+	}
+	result := s.include(`
+	document      = "methodology"
+	absolute_size = 2
+	`, dataCtx, diagtest.Asserts{})
+	s.Equal("### Methodology\n\n#### Scope\n\nAll hosts\n", mdprint.PrintString(result.Content))
+
+	s.include(`document = "other"`, dataCtx, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to include a document"),
+		diagtest.DetailContains(`document "other" is not rendered`),
+	}})
+}
+
+func (s *IncludeContentTestSuite) TestInvalidArgs() {
+	s.include(`
+	path     = "a.md"
+	document = "a"
+	`, plugindata.Map{}, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`Either "path" or "document" must be set`),
+	}})
+	s.include(`path = "testdata/missing.md"`, plugindata.Map{}, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read a file"),
+	}})
+}
@@ -0,0 +1,354 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// metricsTypeURL is the type URL of the custom AST node payload produced by content.metrics.
+const metricsTypeURL = "blackstork.io/builtin.Metrics"
+
+// metricCard is a metric with the values formatted for display.
+type metricCard struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+	// Color of the value, set if the value crosses a threshold
+	Color string `json:"color,omitempty"`
+	// Delta is the change from the previous value, empty if the previous value is not set
+	Delta      string `json:"delta,omitempty"`
+	DeltaColor string `json:"delta_color,omitempty"`
+}
+
+// metricsNode is the payload of the metrics custom node.
+type metricsNode struct {
+	Metrics []metricCard `json:"metrics"`
+}
+
+func makeMetricsContentProvider() *plugin.ContentProvider {
+	return &plugin.ContentProvider{
+		ContentFunc: genMetricsContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name: "metrics",
+					Type: cty.List(plugindata.Encapsulated.CtyType()),
+					Doc: "A list of metric objects. May be set statically or as a result of one or more queries.\n" +
+						"Metric fields:\n" +
+						"* `label` – (required) the name of the metric\n" +
+						"* `value` – (required) the current value of the metric\n" +
+						"* `previous` – the value of the metric in the previous period, used to show the change\n" +
+						"* `unit` – a suffix appended to the values as is, for example, `%` or ` ms`\n" +
+						"* `higher_is_better` – `true` (default) if the growth of the metric is good, `false` otherwise\n" +
+						"* `warning` and `critical` – thresholds coloring the value: orange if the value reaches " +
+						"`warning` and red if it reaches `critical` threshold, green otherwise",
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal: cty.TupleVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"label":            cty.StringVal("Open criticals"),
+							"value":            cty.NumberIntVal(12),
+							"previous":         cty.NumberIntVal(9),
+							"higher_is_better": cty.False,
+							"warning":          cty.NumberIntVal(5),
+							"critical":         cty.NumberIntVal(10),
+						}),
+						cty.ObjectVal(map[string]cty.Value{
+							"label":    cty.StringVal("Patch coverage"),
+							"value":    cty.NumberIntVal(93),
+							"previous": cty.NumberIntVal(95),
+							"unit":     cty.StringVal("%"),
+						}),
+					}),
+				},
+				{
+					Name:       "previous_label",
+					Type:       cty.String,
+					Doc:        "A label of the previous period, appended to the change of the value.",
+					ExampleVal: cty.StringVal("vs last week"),
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Produces headline metrics with the change from the previous period.
+
+			The metrics are rendered as a grid of cards in HTML, as a styled row in PDF and as a compact table in markdown.
+		`),
+	}
+}
+
+func genMetricsContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	previousLabel := stringAttr(params.Args, "previous_label")
+	node := &metricsNode{}
+	for i, val := range params.Args.GetAttrVal("metrics").AsValueSlice() {
+		data, err := plugindata.Encapsulated.FromCty(val)
+		if err == nil {
+			var card *metricCard
+			if data == nil {
+				err = fmt.Errorf("metric is null")
+			} else {
+				card, err = parseMetric(*data, previousLabel)
+			}
+			if err == nil {
+				node.Metrics = append(node.Metrics, *card)
+				continue
+			}
+		}
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   fmt.Sprintf("metric %d: %s", i+1, err),
+			Subject:  &params.Args.Attrs["metrics"].ValueRange,
+		}}
+	}
+	payload, err := json.Marshal(node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to encode metrics",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: plugin.NewElement(ast.CustomBlock(&anypb.Any{
+			TypeUrl: metricsTypeURL,
+			Value:   payload,
+		})),
+	}, nil
+}
+
+// parseMetric evaluates the metric definition into the card.
+func parseMetric(data plugindata.Data, previousLabel string) (*metricCard, error) {
+	if _, ok := data.(plugindata.Map); !ok {
+		return nil, fmt.Errorf("metric must be an object")
+	}
+	label, err := chartField(data, "label")
+	if err != nil {
+		return nil, err
+	}
+	if label == "" {
+		return nil, fmt.Errorf("field \"label\" is required")
+	}
+	value, err := chartValue(data, "value")
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("field \"value\" is required")
+	}
+	previous, err := chartValue(data, "previous")
+	if err != nil {
+		return nil, err
+	}
+	unit, err := chartField(data, "unit")
+	if err != nil {
+		return nil, err
+	}
+	higherIsBetter := true
+	switch v := data.(plugindata.Map)["higher_is_better"].(type) {
+	case nil:
+	case plugindata.Bool:
+		higherIsBetter = bool(v)
+	default:
+		return nil, fmt.Errorf("field \"higher_is_better\" must be a bool")
+	}
+	warning, err := chartValue(data, "warning")
+	if err != nil {
+		return nil, err
+	}
+	critical, err := chartValue(data, "critical")
+	if err != nil {
+		return nil, err
+	}
+
+	card := &metricCard{
+		Label: label,
+		Value: formatMetricNumber(*value) + unit,
+		Color: metricColor(*value, warning, critical, higherIsBetter),
+	}
+	if previous != nil {
+		card.Delta, card.DeltaColor = metricDelta(*value, *previous, unit, higherIsBetter)
+		if previousLabel != "" {
+			card.Delta += " " + previousLabel
+		}
+	}
+	return card, nil
+}
+
+// metricColor returns the color of the value reaching the thresholds, empty if no threshold is set.
+func metricColor(value float64, warning, critical *float64, higherIsBetter bool) string {
+	if warning == nil && critical == nil {
+		return ""
+	}
+	reached := func(threshold *float64) bool {
+		if threshold == nil {
+			return false
+		}
+		if higherIsBetter {
+			return value <= *threshold
+		}
+		return value >= *threshold
+	}
+	switch {
+	case reached(critical):
+		return tableColors["red"]
+	case reached(warning):
+		return tableColors["orange"]
+	default:
+		return tableColors["green"]
+	}
+}
+
+// metricDelta formats the change of the value, for example "▲3 (+33.3%)".
+func metricDelta(value, previous float64, unit string, higherIsBetter bool) (delta, color string) {
+	diff := value - previous
+	if diff == 0 {
+		return "no change", tableColors["gray"]
+	}
+	arrow := "▲"
+	if diff < 0 {
+		arrow = "▼"
+	}
+	delta = arrow + formatMetricNumber(math.Abs(diff)) + unit
+	if previous != 0 {
+		pct := math.Round(diff/math.Abs(previous)*1000) / 10
+		delta += fmt.Sprintf(" (%+g%%)", pct)
+	}
+	if (diff > 0) == higherIsBetter {
+		return delta, tableColors["green"]
+	}
+	return delta, tableColors["red"]
+}
+
+func formatMetricNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func makeMetricsNodeRenderer() *plugin.NodeRenderer {
+	return &plugin.NodeRenderer{
+		Doc: "Renders the metrics produced by content.metrics",
+		Formats: []plugin.OutputFormat{
+			plugin.OutputFormatMD,
+			plugin.OutputFormatHTML,
+			plugin.OutputFormatPDF,
+		},
+		RenderFunc: renderMetricsNode,
+	}
+}
+
+func renderMetricsNode(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+	var node metricsNode
+	err := json.Unmarshal(params.Node.GetValue(), &node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to decode metrics",
+			Detail:   err.Error(),
+		}}
+	}
+	var content string
+	switch params.Format {
+	case plugin.OutputFormatHTML:
+		content = renderMetricsHTML(&node)
+	case plugin.OutputFormatPDF:
+		content = renderMetricsPDF(&node)
+	default:
+		content = renderMetricsMarkdown(&node)
+	}
+	return &plugin.RenderNodeResult{Content: []byte(content)}, nil
+}
+
+func (n *metricsNode) hasDelta() bool {
+	for _, m := range n.Metrics {
+		if m.Delta != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// renderMetricsMarkdown renders a table with a row per metric.
+func renderMetricsMarkdown(node *metricsNode) string {
+	var sb strings.Builder
+	if node.hasDelta() {
+		sb.WriteString("|Metric|Value|Change|\n|---|--:|---|\n")
+	} else {
+		sb.WriteString("|Metric|Value|\n|---|--:|\n")
+	}
+	for _, m := range node.Metrics {
+		sb.WriteString("|" + escapeChartCell(m.Label) + "|" + escapeChartCell(m.Value) + "|")
+		if node.hasDelta() {
+			sb.WriteString(escapeChartCell(m.Delta) + "|")
+		}
+		sb.WriteByte('\n')
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// renderMetricsHTML renders a grid of cards, the grid wraps the cards on narrow screens.
+func renderMetricsHTML(node *metricsNode) string {
+	var sb strings.Builder
+	sb.WriteString(`<div class="metrics" style="display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 1em; margin: 1em 0;">` + "\n")
+	for _, m := range node.Metrics {
+		sb.WriteString(`<div class="metric" style="padding: 0.75em 1em; border: 1px solid #d0d7de; border-radius: 6px;">` + "\n")
+		fmt.Fprintf(&sb, `<div class="metric-label" style="color: #57606a; font-size: 0.875em;">%s</div>`+"\n", html.EscapeString(m.Label))
+		valueStyle := "font-size: 1.75em; font-weight: bold;"
+		if m.Color != "" {
+			valueStyle += " color: " + m.Color + ";"
+		}
+		fmt.Fprintf(&sb, `<div class="metric-value" style="%s">%s</div>`+"\n", valueStyle, html.EscapeString(m.Value))
+		if m.Delta != "" {
+			fmt.Fprintf(&sb, `<div class="metric-delta" style="font-size: 0.875em; color: %s;">%s</div>`+"\n", m.DeltaColor, html.EscapeString(m.Delta))
+		}
+		sb.WriteString("</div>\n")
+	}
+	sb.WriteString("</div>")
+	return sb.String()
+}
+
+// renderMetricsPDF renders a table with a column per metric, the pdf printer colors the cells
+// starting with a colored span.
+func renderMetricsPDF(node *metricsNode) string {
+	var sb strings.Builder
+	cell := func(text, color string) {
+		text = escapeChartCell(text)
+		if color != "" {
+			text = fmt.Sprintf(`<span style="color: %s">%s</span>`, color, text)
+		}
+		sb.WriteString(text + "|")
+	}
+	sb.WriteByte('|')
+	for _, m := range node.Metrics {
+		cell(m.Label, "")
+	}
+	sb.WriteString("\n|")
+	for range node.Metrics {
+		sb.WriteString(":-:|")
+	}
+	sb.WriteString("\n|")
+	for _, m := range node.Metrics {
+		cell(m.Value, m.Color)
+	}
+	if node.hasDelta() {
+		sb.WriteString("\n|")
+		for _, m := range node.Metrics {
+			cell(m.Delta, m.DeltaColor)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type MetricsContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestMetricsContentTestSuite(t *testing.T) {
+	suite.Run(t, &MetricsContentTestSuite{})
+}
+
+func (s *MetricsContentTestSuite) SetupSuite() {
+	s.schema = makeMetricsContentProvider()
+}
+
+func (s *MetricsContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *MetricsContentTestSuite) genMetrics(val string, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, plugindata.Map{}, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *MetricsContentTestSuite) decode(result *plugin.ContentResult) *metricsNode {
+	el, ok := result.Content.(*plugin.ContentElement)
+	s.Require().True(ok)
+	_, content := el.AsNode()
+	custom, ok := content.FirstChild().(*nodes.CustomBlock)
+	s.Require().True(ok)
+	s.Equal(metricsTypeURL, custom.Data.GetTypeUrl())
+	var node metricsNode
+	s.Require().NoError(json.Unmarshal(custom.Data.GetValue(), &node))
+	return &node
+}
+
+const metricsTestArgs = `
+previous_label = "vs last week"
+metrics = [
+	{label = "Open criticals", value = 12, previous = 9, higher_is_better = false, warning = 5, critical = 10},
+	{label = "Patch coverage", value = 93.5, previous = 95, unit = "%", warning = 95, critical = 80},
+	{label = "Assets", value = "1200"},
+]
+`
+
+func (s *MetricsContentTestSuite) TestCards() {
+	node := s.decode(s.genMetrics(metricsTestArgs, diagtest.Asserts{}))
+	s.Equal([]metricCard{
+		{
+			Label:      "Open criticals",
+			Value:      "12",
+			Color:      tableColors["red"],
+			Delta:      "▲3 (+33.3%) vs last week",
+			DeltaColor: tableColors["red"],
+		},
+		{
+			Label:      "Patch coverage",
+			Value:      "93.5%",
+			Color:      tableColors["orange"],
+			Delta:      "▼1.5% (-1.6%) vs last week",
+			DeltaColor: tableColors["red"],
+		},
+		{
+			Label: "Assets",
+			Value: "1200",
+		},
+	}, node.Metrics)
+}
+
+func (s *MetricsContentTestSuite) TestDelta() {
+	delta, color := metricDelta(5, 5, "", true)
+	s.Equal("no change", delta)
+	s.Equal(tableColors["gray"], color)
+
+	delta, color = metricDelta(2, 0, " ms", false)
+	s.Equal("▲2 ms", delta)
+	s.Equal(tableColors["red"], color)
+
+	delta, color = metricDelta(3, 4, "", false)
+	s.Equal("▼1 (-25%)", delta)
+	s.Equal(tableColors["green"], color)
+}
+
+func (s *MetricsContentTestSuite) TestRender() {
+	renderers := plugin.NodeRenderers{metricsTypeURL: makeMetricsNodeRenderer()}
+
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genMetrics(metricsTestArgs, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Equal("|Metric|Value|Change|\n"+
+		"|---|--:|---|\n"+
+		"|Open criticals|12|▲3 (+33.3%) vs last week|\n"+
+		"|Patch coverage|93.5%|▼1.5% (-1.6%) vs last week|\n"+
+		"|Assets|1200||\n", buf.String())
+
+	buf.Reset()
+	err = htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genMetrics(metricsTestArgs, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Contains(buf.String(), `<div class="metrics" style="display: grid;`)
+	s.Contains(buf.String(), `<div class="metric-value" style="font-size: 1.75em; font-weight: bold; color: #c62828;">12</div>`)
+	s.Contains(buf.String(), `<div class="metric-delta" style="font-size: 0.875em; color: #c62828;">▲3 (+33.3%) vs last week</div>`)
+
+	s.Equal("|Open criticals|Patch coverage|Assets|\n"+
+		"|:-:|:-:|:-:|\n"+
+		`|<span style="color: #c62828">12</span>|<span style="color: #ef6c00">93.5%</span>|1200|`+"\n"+
+		`|<span style="color: #c62828">▲3 (+33.3%) vs last week</span>|<span style="color: #c62828">▼1.5% (-1.6%) vs last week</span>||`,
+		renderMetricsPDF(s.decode(s.genMetrics(metricsTestArgs, diagtest.Asserts{}))))
+}
+
+func (s *MetricsContentTestSuite) TestInvalidMetric() {
+	s.genMetrics(`metrics = [{value = 1}]`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`metric 1: field "label" is required`),
+	}})
+	s.genMetrics(`metrics = [{label = "a", value = 1}, {label = "b", value = "many"}]`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`metric 2: field "value" must be a number`),
+	}})
+}
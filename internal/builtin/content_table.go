@@ -3,16 +3,27 @@ package builtin
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
 	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/pkg/utils"
 	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
@@ -20,7 +31,63 @@ import (
 
 type tableCellTmpl = *template.Template
 
+// styledTextTypeURL is the type URL of the custom AST node payload of the colored table cells.
+const styledTextTypeURL = "blackstork.io/builtin.StyledText"
+
+// tableColors are the named colors supported in the cell styles.
+var tableColors = map[string]string{
+	"red":    "#c62828",
+	"orange": "#ef6c00",
+	"yellow": "#f9a825",
+	"green":  "#2e7d32",
+	"blue":   "#1565c0",
+	"gray":   "#757575",
+}
+
+var tableAggregates = []string{"sum", "avg", "count", "min", "max"}
+
+type tableColumn struct {
+	header tableCellTmpl
+	value  tableCellTmpl
+	// optional
+	color tableCellTmpl
+	align string
+	total string
+}
+
+type tableSortKey struct {
+	key  tableCellTmpl
+	desc bool
+}
+
+type tableOptions struct {
+	sort    []tableSortKey
+	groupBy tableCellTmpl
+	limit   int
+}
+
+type tableCell struct {
+	text  string
+	color string
+}
+
+// tableResult is the rendered table: markdown source and the colors of the data cells.
+type tableResult struct {
+	markdown string
+	// cells of the data rows in the order of the table, nil if no cell is styled
+	styled [][]tableCell
+}
+
 func makeTableContentProvider() *plugin.ContentProvider {
+	aggregates := make([]string, len(tableAggregates))
+	for i, agg := range tableAggregates {
+		aggregates[i] = "`" + agg + "`"
+	}
+	colors := make([]string, 0, len(tableColors))
+	for name := range tableColors {
+		colors = append(colors, name)
+	}
+	slices.Sort(colors)
 	return &plugin.ContentProvider{
 		ContentFunc: genTableContent,
 		Args: &dataspec.RootSpec{
@@ -33,19 +100,32 @@ func makeTableContentProvider() *plugin.ContentProvider {
 				},
 				{
 					Name: "columns",
-					Type: cty.List(cty.Object(map[string]cty.Type{
+					Type: cty.List(cty.ObjectWithOptionalAttrs(map[string]cty.Type{
 						"header": cty.String,
 						"value":  cty.String,
-					})),
-					Doc: `List of header and value go templates for each column`,
-					ExampleVal: cty.ListVal([]cty.Value{
+						"align":  cty.String,
+						"total":  cty.String,
+						"color":  cty.String,
+					}, []string{"align", "total", "color"})),
+					Doc: "List of header and value go templates for each column.\n" +
+						"Optional column settings:\n" +
+						"* `align` – alignment of the column: `left`, `center` or `right`\n" +
+						"* `total` – aggregate of the column values in the total and subtotal rows: " +
+						strings.Join(aggregates, ", ") + "\n" +
+						"* `color` – go template producing the color of the cell text: " +
+						"a color name (`" + strings.Join(colors, "`, `") + "`) or a `#rrggbb` value. " +
+						"Empty result keeps the default color",
+					ExampleVal: cty.TupleVal([]cty.Value{
 						cty.ObjectVal(map[string]cty.Value{
 							"header": cty.StringVal("1st column header template"),
 							"value":  cty.StringVal("1st column values template"),
 						}),
 						cty.ObjectVal(map[string]cty.Value{
-							"header": cty.StringVal("2nd column header template"),
-							"value":  cty.StringVal("2nd column values template"),
+							"header": cty.StringVal("Severity"),
+							"value":  cty.StringVal("{{ .row.value.severity }}"),
+							"align":  cty.StringVal("center"),
+							"total":  cty.StringVal("count"),
+							"color":  cty.StringVal(`{{ if eq .row.value.severity "critical" }}red{{ end }}`),
 						}),
 						cty.ObjectVal(map[string]cty.Value{
 							"header": cty.StringVal("..."),
@@ -54,6 +134,36 @@ func makeTableContentProvider() *plugin.ContentProvider {
 					}),
 					Constraints: constraint.RequiredMeaningful,
 				},
+				{
+					Name: "sort",
+					Type: cty.List(cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+						"key":  cty.String,
+						"desc": cty.Bool,
+					}, []string{"desc"})),
+					Doc: "Sort keys of the rows: go templates producing the values to compare, in the order of precedence.\n" +
+						"Values are compared as numbers if both are numeric, as strings otherwise. " +
+						"Set `desc` to `true` to sort in descending order",
+					ExampleVal: cty.ListVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"key":  cty.StringVal("{{ .row.value.score }}"),
+							"desc": cty.True,
+						}),
+					}),
+				},
+				{
+					Name: "group_by",
+					Type: cty.String,
+					Doc: "Go template producing the group of the row. Rows are grouped in the order of the first appearance " +
+						"of a group, every group is followed by a subtotal row, if any column has a `total` set",
+					ExampleVal: cty.StringVal("{{ .row.value.severity }}"),
+				},
+				{
+					Name: "limit",
+					Type: cty.Number,
+					Doc: "Maximum number of rows to show, the number of the remaining rows is noted below the table.\n" +
+						"Totals and subtotals are calculated over all rows",
+					MinInclusive: cty.NumberIntVal(1),
+				},
 			},
 		},
 		Doc: `
@@ -66,7 +176,12 @@ func makeTableContentProvider() *plugin.ContentProvider {
 			* ` + "`.col.index` – the current column index" + `
 
 			Header templates have access to the same variables as value templates,
-			except for ` + "`.row.value` and `.row.index`",
+			except for ` + "`.row.value` and `.row.index`" + `
+
+			Sort key and group templates have access to ` + "`.row.value` and `.row.index`" + `,
+			where the index is the position of the row in ` + "`rows`" + ` list.
+
+			Cell colors are shown in HTML and PDF output, markdown output keeps the plain text.`,
 	}
 }
 
@@ -95,7 +210,7 @@ func genTableContent(ctx context.Context, params *plugin.ProvideContentParams) (
 		}
 	}
 
-	headers, values, err := parseTableContentArgs(params)
+	columns, err := parseTableContentArgs(params)
 	if err != nil {
 		return nil, diagnostics.Diag{{
 			Severity: hcl.DiagError,
@@ -103,7 +218,28 @@ func genTableContent(ctx context.Context, params *plugin.ProvideContentParams) (
 			Detail:   err.Error(),
 		}}
 	}
-	result, err := renderTableContent(headers, values, params.DataContext, rows)
+	opts, err := parseTableOptions(params)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   err.Error(),
+		}}
+	}
+	result, err := renderTableContent(columns, opts, params.DataContext, rows)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render table",
+			Detail:   err.Error(),
+		}}
+	}
+	if result.styled == nil {
+		return &plugin.ContentResult{
+			Content: plugin.NewElementFromMarkdown(result.markdown),
+		}, nil
+	}
+	el, err := styledTableElement(result)
 	if err != nil {
 		return nil, diagnostics.Diag{{
 			Severity: hcl.DiagError,
@@ -112,38 +248,114 @@ func genTableContent(ctx context.Context, params *plugin.ProvideContentParams) (
 		}}
 	}
 	return &plugin.ContentResult{
-		Content: plugin.NewElementFromMarkdown(result),
+		Content: el,
 	}, nil
 }
 
-func parseTableContentArgs(params *plugin.ProvideContentParams) (headers, values []tableCellTmpl, err error) {
+func parseTableTemplate(name, value string) (tableCellTmpl, error) {
+	tmpl, err := template.New(name).Funcs(sprig.FuncMap()).Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+func parseTableContentArgs(params *plugin.ProvideContentParams) (columns []*tableColumn, err error) {
 	arr := params.Args.GetAttrVal("columns")
 	for _, val := range arr.AsValueSlice() {
 		obj := val.AsValueMap()
 		header := obj["header"]
 		if header.IsNull() {
-			return nil, nil, fmt.Errorf("missing header in table cell")
+			return nil, fmt.Errorf("missing header in table cell")
 		}
 		value := obj["value"]
 		if value.IsNull() {
-			return nil, nil, fmt.Errorf("missing value in table cell")
+			return nil, fmt.Errorf("missing value in table cell")
 		}
-
-		headerTmpl, err := template.New("header").Funcs(sprig.FuncMap()).Parse(header.AsString())
+		col := &tableColumn{}
+		col.header, err = parseTableTemplate("header", header.AsString())
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse header template: %w", err)
+			return nil, err
 		}
-		valueTmpl, err := template.New("value").Funcs(sprig.FuncMap()).Parse(value.AsString())
+		col.value, err = parseTableTemplate("value", value.AsString())
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse value template: %w", err)
+			return nil, err
+		}
+		if color := obj["color"]; !color.IsNull() {
+			col.color, err = parseTableTemplate("color", color.AsString())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if align := obj["align"]; !align.IsNull() {
+			col.align = align.AsString()
+			if !slices.Contains([]string{"left", "center", "right"}, col.align) {
+				return nil, fmt.Errorf("unsupported column alignment %q", col.align)
+			}
 		}
-		headers = append(headers, headerTmpl)
-		values = append(values, valueTmpl)
+		if total := obj["total"]; !total.IsNull() {
+			col.total = total.AsString()
+			if !slices.Contains(tableAggregates, col.total) {
+				return nil, fmt.Errorf("unsupported column total %q", col.total)
+			}
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func parseTableOptions(params *plugin.ProvideContentParams) (opts tableOptions, err error) {
+	sortVal := params.Args.GetAttrVal("sort")
+	if !sortVal.IsNull() {
+		for _, val := range sortVal.AsValueSlice() {
+			obj := val.AsValueMap()
+			key := obj["key"]
+			if key.IsNull() {
+				return opts, fmt.Errorf("missing key in table sort")
+			}
+			sortKey := tableSortKey{
+				desc: obj["desc"].True(),
+			}
+			sortKey.key, err = parseTableTemplate("sort", key.AsString())
+			if err != nil {
+				return opts, err
+			}
+			opts.sort = append(opts.sort, sortKey)
+		}
+	}
+	if groupBy := params.Args.GetAttrVal("group_by"); !groupBy.IsNull() {
+		opts.groupBy, err = parseTableTemplate("group", groupBy.AsString())
+		if err != nil {
+			return opts, err
+		}
+	}
+	if limit := params.Args.GetAttrVal("limit"); !limit.IsNull() {
+		n, _ := limit.AsBigFloat().Int64()
+		opts.limit = int(n)
 	}
-	return
+	return opts, nil
 }
 
-func renderTableContent(headers, values []tableCellTmpl, dataCtx plugindata.Map, rowsList plugindata.List) (string, error) {
+// executeCell executes the template, the result is a single line.
+func executeCell(tmpl tableCellTmpl, data any) (string, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.ReplaceAll(bytes.TrimSpace(buf.Bytes()), []byte("\n"), []byte(" "))), nil
+}
+
+type tableRow struct {
+	value any
+	// position in the rows list, starting from 1
+	index int
+	keys  []string
+	group string
+	cells []tableCell
+}
+
+func renderTableContent(columns []*tableColumn, opts tableOptions, dataCtx plugindata.Map, rowsList plugindata.List) (*tableResult, error) {
 	var buf bytes.Buffer
 
 	data := dataCtx.Any().(map[string]any)
@@ -153,56 +365,371 @@ func renderTableContent(headers, values []tableCellTmpl, dataCtx plugindata.Map,
 	col := map[string]any{}
 	data["col"] = col
 	buf.WriteByte('|')
-	var cellBuf bytes.Buffer
-	for colIdx, header := range headers {
-		cellBuf.Reset()
+	for colIdx, column := range columns {
 		col["index"] = colIdx + 1
-		err := header.Execute(&cellBuf, data)
+		header, err := executeCell(column.header, data)
 		if err != nil {
-			return "", fmt.Errorf("failed to render header: %w", err)
+			return nil, fmt.Errorf("failed to render header: %w", err)
 		}
-
-		buf.Write(
-			bytes.ReplaceAll(
-				bytes.TrimSpace(cellBuf.Bytes()),
-				[]byte("\n"),
-				[]byte(" "),
-			),
-		)
+		buf.WriteString(header)
 		buf.WriteByte('|')
 	}
 	buf.WriteByte('\n')
 	buf.WriteByte('|')
-	for range headers {
-		buf.WriteString("---|")
+	for _, column := range columns {
+		switch column.align {
+		case "left":
+			buf.WriteString(":---|")
+		case "center":
+			buf.WriteString(":---:|")
+		case "right":
+			buf.WriteString("---:|")
+		default:
+			buf.WriteString("---|")
+		}
 	}
 	buf.WriteString("\n")
 
 	dataRow := map[string]any{}
 	data["row"] = dataRow
+	delete(col, "index")
 
+	tableRows := make([]*tableRow, len(rows))
 	for rowIdx, row := range rows {
-		buf.WriteByte('|')
-		dataRow["index"] = rowIdx + 1
+		tr := &tableRow{
+			value: row,
+			index: rowIdx + 1,
+		}
+		dataRow["index"] = tr.index
 		dataRow["value"] = row
-		for colIdx, value := range values {
-			cellBuf.Reset()
+		for _, key := range opts.sort {
+			val, err := executeCell(key.key, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render sort key: %w", err)
+			}
+			tr.keys = append(tr.keys, val)
+		}
+		if opts.groupBy != nil {
+			val, err := executeCell(opts.groupBy, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render group: %w", err)
+			}
+			tr.group = val
+		}
+		tableRows[rowIdx] = tr
+	}
+	if len(opts.sort) > 0 {
+		slices.SortStableFunc(tableRows, func(a, b *tableRow) int {
+			for i, key := range opts.sort {
+				c := compareTableValues(a.keys[i], b.keys[i])
+				if key.desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+	var groups []string
+	if opts.groupBy != nil {
+		byGroup := map[string][]*tableRow{}
+		for _, tr := range tableRows {
+			if _, ok := byGroup[tr.group]; !ok {
+				groups = append(groups, tr.group)
+			}
+			byGroup[tr.group] = append(byGroup[tr.group], tr)
+		}
+		tableRows = tableRows[:0]
+		for _, group := range groups {
+			tableRows = append(tableRows, byGroup[group]...)
+		}
+	}
+
+	styled := false
+	for rowIdx, tr := range tableRows {
+		dataRow["index"] = rowIdx + 1
+		dataRow["value"] = tr.value
+		for colIdx, column := range columns {
 			col["index"] = colIdx + 1
-			err := value.Execute(&cellBuf, data)
+			val, err := executeCell(column.value, data)
 			if err != nil {
-				return "", fmt.Errorf("failed to render value: %w", err)
-			}
-			buf.Write(
-				bytes.ReplaceAll(
-					bytes.TrimSpace(cellBuf.Bytes()),
-					[]byte("\n"),
-					[]byte(" "),
-				),
-			)
+				return nil, fmt.Errorf("failed to render value: %w", err)
+			}
+			cell := tableCell{text: val}
+			if column.color != nil {
+				color, err := executeCell(column.color, data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to render color: %w", err)
+				}
+				cell.color, err = parseTableColor(color)
+				if err != nil {
+					return nil, err
+				}
+				styled = styled || cell.color != ""
+			}
+			tr.cells = append(tr.cells, cell)
+		}
+	}
+
+	hasTotals := slices.ContainsFunc(columns, func(c *tableColumn) bool {
+		return c.total != ""
+	})
+	shown := len(tableRows)
+	if opts.limit > 0 && opts.limit < shown {
+		shown = opts.limit
+	}
+	res := &tableResult{}
+	writeRow := func(cells []string) {
+		buf.WriteByte('|')
+		for _, cell := range cells {
+			buf.WriteString(cell)
 			buf.WriteByte('|')
 		}
 		buf.WriteByte('\n')
 	}
+	for rowIdx, tr := range tableRows[:shown] {
+		cells := make([]string, len(tr.cells))
+		for i, cell := range tr.cells {
+			cells[i] = cell.text
+		}
+		writeRow(cells)
+		if styled {
+			res.styled = append(res.styled, tr.cells)
+		}
+		lastInGroup := rowIdx == len(tableRows)-1 || tableRows[rowIdx+1].group != tr.group
+		if opts.groupBy != nil && hasTotals && (lastInGroup || rowIdx == shown-1) {
+			var groupRows []*tableRow
+			for _, other := range tableRows {
+				if other.group == tr.group {
+					groupRows = append(groupRows, other)
+				}
+			}
+			label := "Subtotal"
+			if tr.group != "" {
+				label += ": " + tr.group
+			}
+			writeRow(totalRow(columns, groupRows, label))
+			if styled {
+				res.styled = append(res.styled, nil)
+			}
+		}
+	}
+	if hasTotals {
+		writeRow(totalRow(columns, tableRows, "Total"))
+	}
+	if more := len(tableRows) - shown; more > 0 {
+		noun := "rows"
+		if more == 1 {
+			noun = "row"
+		}
+		fmt.Fprintf(&buf, "\n*%d more %s*\n", more, noun)
+	}
+	res.markdown = buf.String()
+	return res, nil
+}
+
+// compareTableValues compares the values as numbers if both are numeric, as strings otherwise.
+func compareTableValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// totalRow returns the cells of the total row. The label is put in the first column without a total.
+func totalRow(columns []*tableColumn, rows []*tableRow, label string) []string {
+	cells := make([]string, len(columns))
+	for colIdx, column := range columns {
+		if column.total == "" {
+			if label != "" {
+				cells[colIdx] = "**" + label + "**"
+				label = ""
+			}
+			continue
+		}
+		values := make([]string, len(rows))
+		for i, tr := range rows {
+			values[i] = tr.cells[colIdx].text
+		}
+		if total := aggregateTableValues(column.total, values); total != "" {
+			cells[colIdx] = "**" + total + "**"
+		}
+	}
+	return cells
+}
+
+func aggregateTableValues(agg string, values []string) string {
+	if agg == "count" {
+		count := 0
+		for _, v := range values {
+			if v != "" {
+				count++
+			}
+		}
+		return strconv.Itoa(count)
+	}
+	var nums []float64
+	for _, v := range values {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			nums = append(nums, f)
+		}
+	}
+	if len(nums) == 0 {
+		return ""
+	}
+	var res float64
+	switch agg {
+	case "sum", "avg":
+		for _, f := range nums {
+			res += f
+		}
+		if agg == "avg" {
+			res = math.Round(res/float64(len(nums))*100) / 100
+		}
+	case "min":
+		res = slices.Min(nums)
+	case "max":
+		res = slices.Max(nums)
+	}
+	return strconv.FormatFloat(res, 'f', -1, 64)
+}
+
+func parseTableColor(color string) (string, error) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color == "" {
+		return "", nil
+	}
+	if hex, ok := tableColors[color]; ok {
+		return hex, nil
+	}
+	if len(color) == 7 && color[0] == '#' {
+		if _, err := strconv.ParseUint(color[1:], 16, 32); err == nil {
+			return color, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported cell color %q", color)
+}
+
+// styledText is the payload of the colored table cell.
+type styledText struct {
+	// Text is the markdown content of the cell.
+	Text  string `json:"text"`
+	Color string `json:"color"`
+}
+
+// styledTableElement builds the table element with the colored cells replaced by the custom nodes,
+// rendered by the plugin node renderer. The markdown source of the element keeps the plain text.
+func styledTableElement(res *tableResult) (*plugin.ContentElement, error) {
+	source := []byte(res.markdown)
+	doc := goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(text.NewReader(source))
+	table, ok := doc.FirstChild().(*east.Table)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse the table")
+	}
+	rowIdx := 0
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		if _, ok := row.(*east.TableRow); !ok {
+			continue
+		}
+		if rowIdx >= len(res.styled) {
+			break
+		}
+		cells := res.styled[rowIdx]
+		rowIdx++
+		colIdx := 0
+		for cell := row.FirstChild(); cell != nil && colIdx < len(cells); cell = cell.NextSibling() {
+			styled := cells[colIdx]
+			colIdx++
+			if styled.color == "" || styled.text == "" {
+				continue
+			}
+			payload, err := json.Marshal(styledText{
+				Text:  styled.text,
+				Color: styled.color,
+			})
+			if err != nil {
+				return nil, err
+			}
+			cell.RemoveChildren(cell)
+			cell.AppendChild(cell, &nodes.CustomInline{
+				Data: &anypb.Any{
+					TypeUrl: styledTextTypeURL,
+					Value:   payload,
+				},
+			})
+		}
+	}
+	node, err := astv1.Encode(nodes.ToFabricContentNode(doc), source)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.NewElementFromMarkdownAndAST(source, node.GetContentNode(), nil), nil
+}
+
+func makeStyledTextNodeRenderer() *plugin.NodeRenderer {
+	return &plugin.NodeRenderer{
+		Doc: "Renders the colored cells of content.table",
+		Formats: []plugin.OutputFormat{
+			plugin.OutputFormatMD,
+			plugin.OutputFormatHTML,
+			plugin.OutputFormatPDF,
+		},
+		RenderFunc: renderStyledTextNode,
+	}
+}
 
-	return buf.String(), nil
+func renderStyledTextNode(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+	var node styledText
+	err := json.Unmarshal(params.Node.GetValue(), &node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to decode styled text",
+			Detail:   err.Error(),
+		}}
+	}
+	// the node may come from another plugin, the color is validated before it's put into the html
+	color, err := parseTableColor(node.Color)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid styled text color",
+			Detail:   err.Error(),
+		}}
+	}
+	if color == "" || params.Format == plugin.OutputFormatMD {
+		return &plugin.RenderNodeResult{Content: []byte(node.Text)}, nil
+	}
+	if params.Format == plugin.OutputFormatHTML {
+		// the html is inserted as is, the raw html of the cell text is omitted by the renderer
+		var buf bytes.Buffer
+		err = goldmark.New(plugin.BaseMarkdownOptions).Convert([]byte(node.Text), &buf)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to render styled text",
+				Detail:   err.Error(),
+			}}
+		}
+		// the cell text is a single paragraph, the paragraph tags are dropped
+		cell := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(buf.String()), "<p>"), "</p>")
+		return &plugin.RenderNodeResult{
+			Content: fmt.Appendf(nil, `<span style="color: %s">%s</span>`, color, cell),
+		}, nil
+	}
+	// the pdf printer parses the content as markdown and uses the color of the span
+	// for the table cells
+	return &plugin.RenderNodeResult{
+		Content: fmt.Appendf(nil, `<span style="color: %s">%s</span>`, color, node.Text),
+	}, nil
 }
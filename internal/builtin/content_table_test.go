@@ -2,17 +2,22 @@ package builtin
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/suite"
 	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/htmlprint"
 	"github.com/blackstork-io/fabric/print/mdprint"
 )
 
@@ -311,3 +316,157 @@ func (s *TableGeneratorTestSuite) TestInvalidValueTemplate() {
 		Detail:   "failed to parse value template: template: value:1: bad character U+007D '}'",
 	}}, diags)
 }
+
+func (s *TableGeneratorTestSuite) genTable(val string, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, plugindata.Map{}, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+const tableTestRows = `
+	rows = [
+		{name = "a", severity = "low", score = 2},
+		{name = "b", severity = "critical", score = 10},
+		{name = "c", severity = "low", score = 3},
+		{name = "d", severity = "critical", score = 9},
+		{name = "e", severity = "high", score = "n/a"},
+	]
+`
+
+func (s *TableGeneratorTestSuite) TestSortAndAlign() {
+	result := s.genTable(tableTestRows+`
+	columns = [
+		{header = "#", value = "{{.row.index}}", align = "right"},
+		{header = "Name", value = "{{.row.value.name}}", align = "center"},
+		{header = "Score", value = "{{.row.value.score}}", align = "left"},
+	]
+	sort = [
+		{key = "{{.row.value.score}}", desc = true},
+	]
+	`, diagtest.Asserts{})
+	s.Equal("|#|Name|Score|\n|---:|:---:|:---|\n|1|e|n/a|\n|2|b|10|\n|3|d|9|\n|4|c|3|\n|5|a|2|\n",
+		mdprint.PrintString(result.Content))
+}
+
+func (s *TableGeneratorTestSuite) TestGroupTotalsAndLimit() {
+	result := s.genTable(tableTestRows+`
+	columns = [
+		{header = "Severity", value = "{{.row.value.severity}}"},
+		{header = "Name", value = "{{.row.value.name}}", total = "count"},
+		{header = "Score", value = "{{.row.value.score}}", total = "sum"},
+		{header = "Avg", value = "{{.row.value.score}}", total = "avg"},
+	]
+	sort = [
+		{key = "{{.row.value.name}}"},
+	]
+	group_by = "{{.row.value.severity}}"
+	limit    = 3
+	`, diagtest.Asserts{})
+	s.Equal("|Severity|Name|Score|Avg|\n|---|---|---|---|\n"+
+		"|low|a|2|2|\n"+
+		"|low|c|3|3|\n"+
+		"|**Subtotal: low**|**2**|**5**|**2.5**|\n"+
+		"|critical|b|10|10|\n"+
+		"|**Subtotal: critical**|**2**|**19**|**9.5**|\n"+
+		"|**Total**|**5**|**24**|**6**|\n"+
+		"\n*2 more rows*\n",
+		mdprint.PrintString(result.Content))
+}
+
+func (s *TableGeneratorTestSuite) TestColor() {
+	val := tableTestRows + `
+	columns = [
+		{header = "Name", value = "{{.row.value.name}}"},
+		{
+			header = "Severity"
+			value  = "{{.row.value.severity}}"
+			color  = "{{ if eq .row.value.severity \"critical\" }}red{{ else if eq .row.value.severity \"high\" }}#AABBCC{{ end }}"
+		},
+	]
+	limit = 4
+	`
+	renderers := plugin.NodeRenderers{styledTextTypeURL: makeStyledTextNodeRenderer()}
+	// markdown keeps the plain text
+	expected := "| Name | Severity |\n| ---- | -------- |\n| a    | low      |\n| b    | critical |\n" +
+		"| c    | low      |\n| d    | critical |\n\n*1 more row*\n"
+
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTable(val, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Equal(expected, buf.String())
+
+	buf.Reset()
+	err = htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTable(val, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Equal(2, strings.Count(buf.String(), `<td><span style="color: #c62828">critical</span></td>`))
+	s.Contains(buf.String(), `<td>low</td>`)
+	s.Contains(buf.String(), `<p><em>1 more row</em></p>`)
+}
+
+func (s *TableGeneratorTestSuite) TestColorRawHTML() {
+	val := `
+	rows = [
+		{name = "**a** <script>alert(1)</script>"},
+	]
+	columns = [
+		{header = "Name", value = "{{.row.value.name}}", color = "red"},
+	]
+	`
+	renderers := plugin.NodeRenderers{styledTextTypeURL: makeStyledTextNodeRenderer()}
+	var buf strings.Builder
+	err := htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTable(val, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.NotContains(buf.String(), "<script>")
+	s.Contains(buf.String(), `<td><span style="color: #c62828"><strong>a</strong> <!-- raw HTML omitted -->alert(1)<!-- raw HTML omitted --></span></td>`)
+}
+
+func (s *TableGeneratorTestSuite) TestStyledTextInvalidColor() {
+	value, err := json.Marshal(styledText{Text: "a", Color: `red"><script>alert(1)</script>`})
+	s.Require().NoError(err)
+	res, diags := renderStyledTextNode(context.Background(), &plugin.RenderNodeParams{
+		Node:   &anypb.Any{TypeUrl: styledTextTypeURL, Value: value},
+		Inline: true,
+		Format: plugin.OutputFormatHTML,
+	})
+	s.Nil(res)
+	s.Require().Len(diags, 1)
+	s.Equal("Invalid styled text color", diags[0].Summary)
+	s.Contains(diags[0].Detail, "unsupported cell color")
+}
+
+func (s *TableGeneratorTestSuite) TestInvalidOptions() {
+	s.genTable(tableTestRows+`
+	columns = [
+		{header = "Name", value = "{{.row.value.name}}", total = "median"},
+	]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`unsupported column total "median"`),
+	}})
+	s.genTable(tableTestRows+`
+	columns = [
+		{header = "Name", value = "{{.row.value.name}}", align = "justify"},
+	]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`unsupported column alignment "justify"`),
+	}})
+	s.genTable(tableTestRows+`
+	columns = [
+		{header = "Name", value = "{{.row.value.name}}", color = "{{.row.value.name}}"},
+	]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to render table"),
+		diagtest.DetailContains(`unsupported cell color "a"`),
+	}})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
@@ -32,7 +33,14 @@ func makeTextContentProvider() *plugin.ContentProvider {
 				},
 			},
 		},
-		Doc: `Renders text`,
+		Doc: utils.Dedent(`
+			Renders text
+
+			Besides the usual template functions, the text can reference the titles with anchors:
+			` + "`{{ ref \"section.findings\" }}`" + ` produces a link to the title, resolved after the document is rendered.
+			The link text is the number of the section if the document has ` + "`numbered_headings`" + ` enabled,
+			otherwise – the title itself.
+		`),
 	}
 }
 
@@ -59,8 +67,18 @@ func genTextContent(ctx context.Context, params *plugin.ProvideContentParams) (*
 	}, nil
 }
 
+// refTemplateFunc produces a link to the title with the given anchor.
+func refTemplateFunc(anchor string) (string, error) {
+	if !anchorRe.MatchString(anchor) {
+		return "", fmt.Errorf("invalid reference %q", anchor)
+	}
+	return fmt.Sprintf("[%s](#%s %q)", anchor, anchor, plugin.ReferenceLinkTitle), nil
+}
+
 func genTextContentText(text string, datactx plugindata.Map) (string, error) {
-	tmpl, err := template.New("text").Funcs(sprig.FuncMap()).Parse(text)
+	funcs := sprig.FuncMap()
+	funcs["ref"] = refTemplateFunc
+	tmpl, err := template.New("text").Funcs(funcs).Parse(text)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse text template: %w", err)
 	}
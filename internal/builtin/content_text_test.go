@@ -116,3 +116,36 @@ func (s *TextTestSuite) TestSprigTemplate() {
 	s.Empty(diags)
 	s.Equal("Hello WORLD!", mdprint.PrintString(result.Content))
 }
+
+func (s *TextTestSuite) TestRef() {
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args: &dataspec.Block{
+			Attrs: dataspec.Attributes{
+				"value": &dataspec.Attr{
+					Name:  "value",
+					Value: cty.StringVal(`See {{ ref "section.findings" }}`),
+				},
+			},
+		},
+		DataContext: plugindata.Map{},
+	})
+	s.Empty(diags)
+	s.Equal(`See [section.findings](#section.findings "fabric:ref")`, mdprint.PrintString(result.Content))
+
+	_, diags = s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args: &dataspec.Block{
+			Attrs: dataspec.Attributes{
+				"value": &dataspec.Attr{
+					Name:  "value",
+					Value: cty.StringVal(`See {{ ref "a b" }}`),
+				},
+			},
+		},
+		DataContext: plugindata.Map{},
+	})
+	diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to render text"),
+		diagtest.DetailContains(`invalid reference "a b"`),
+	}}.AssertMatch(s.T(), diags, nil)
+}
@@ -0,0 +1,393 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// timelineTypeURL is the type URL of the custom AST node payload produced by content.timeline.
+const timelineTypeURL = "blackstork.io/builtin.Timeline"
+
+const (
+	defaultTimelineTimeFormat = "15:04"
+	defaultTimelineDateFormat = "2006-01-02"
+)
+
+// timelineTimeLayouts are the layouts of the string timestamps, tried in order.
+var timelineTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02",
+}
+
+// timelineEvent is an event with the texts formatted for display.
+type timelineEvent struct {
+	Time        string `json:"time"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type timelineDay struct {
+	Date   string          `json:"date"`
+	Events []timelineEvent `json:"events"`
+}
+
+// timelineNode is the payload of the timeline custom node.
+type timelineNode struct {
+	Days []timelineDay `json:"days"`
+}
+
+func makeTimelineContentProvider() *plugin.ContentProvider {
+	return &plugin.ContentProvider{
+		ContentFunc: genTimelineContent,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name: "rows",
+					Type: cty.List(plugindata.Encapsulated.CtyType()),
+					Doc: "A list of objects representing the events.\n" +
+						"May be set statically or as a result of one or more queries.",
+					Constraints: constraint.RequiredNonNull,
+				},
+				{
+					Name: "time_field",
+					Type: cty.String,
+					Doc: "Field of the row with the timestamp of the event. Nested fields are separated by dots.\n" +
+						"The timestamp can be a string in RFC 3339 or `YYYY-MM-DD[ hh:mm[:ss]]` format, " +
+						"or a number of seconds since the Unix epoch. Timestamps without a timezone are in `timezone`.",
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("created_at"),
+				},
+				{
+					Name:        "title",
+					Type:        cty.String,
+					Doc:         "Go template of the event title. The row is available as `.row.value`, its position as `.row.index`.",
+					Constraints: constraint.RequiredMeaningful,
+					ExampleVal:  cty.StringVal("{{ .row.value.title }}"),
+				},
+				{
+					Name:       "description",
+					Type:       cty.String,
+					Doc:        "Go template of the event description in markdown.",
+					ExampleVal: cty.StringVal("Severity: **{{ .row.value.severity }}**"),
+				},
+				{
+					Name:       "timezone",
+					Type:       cty.String,
+					Doc:        "Timezone of the displayed times, an IANA timezone name, for example `Europe/Berlin`, or `Local`.",
+					DefaultVal: cty.StringVal("UTC"),
+				},
+				{
+					Name:       "time_format",
+					Type:       cty.String,
+					Doc:        "Format of the event time, a Go time layout.",
+					DefaultVal: cty.StringVal(defaultTimelineTimeFormat),
+				},
+				{
+					Name:       "date_format",
+					Type:       cty.String,
+					Doc:        "Format of the day headers, a Go time layout.",
+					DefaultVal: cty.StringVal(defaultTimelineDateFormat),
+				},
+				{
+					Name:       "order",
+					Type:       cty.String,
+					Doc:        "Order of the events.",
+					DefaultVal: cty.StringVal("asc"),
+					OneOf: []cty.Value{
+						cty.StringVal("asc"),
+						cty.StringVal("desc"),
+					},
+				},
+			},
+		},
+		Doc: utils.Dedent(`
+			Produces a chronological timeline of events, grouped by day.
+
+			The timeline is rendered as a vertical timeline in HTML and as a list of events under
+			the day headers in markdown and PDF.
+
+			The time layouts use Go reference time ` + "`Mon Jan 2 15:04:05 MST 2006`" + `, for example,
+			` + "`15:04:05`" + ` or ` + "`Jan 2, 2006`" + `.
+		`),
+	}
+}
+
+type timelineRow struct {
+	time        time.Time
+	title       string
+	description string
+}
+
+func genTimelineContent(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
+	loc, err := time.LoadLocation(params.Args.GetAttrVal("timezone").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   fmt.Sprintf("invalid timezone: %s", err),
+			Subject:  &params.Args.Attrs["timezone"].ValueRange,
+		}}
+	}
+	rows, err := utils.FnMapErr(params.Args.GetAttrVal("rows").AsValueSlice(), func(v cty.Value) (plugindata.Data, error) {
+		data, err := plugindata.Encapsulated.FromCty(v)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, nil
+		}
+		return *data, nil
+	})
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   err.Error(),
+			Subject:  &params.Args.Attrs["rows"].ValueRange,
+		}}
+	}
+	events, err := collectTimelineRows(params, rows, loc)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render timeline",
+			Detail:   err.Error(),
+		}}
+	}
+	slices.SortStableFunc(events, func(a, b timelineRow) int {
+		return a.time.Compare(b.time)
+	})
+	if params.Args.GetAttrVal("order").AsString() == "desc" {
+		slices.Reverse(events)
+	}
+
+	timeFormat := params.Args.GetAttrVal("time_format").AsString()
+	dateFormat := params.Args.GetAttrVal("date_format").AsString()
+	node := &timelineNode{}
+	for _, ev := range events {
+		date := ev.time.Format(dateFormat)
+		if len(node.Days) == 0 || node.Days[len(node.Days)-1].Date != date {
+			node.Days = append(node.Days, timelineDay{Date: date})
+		}
+		day := &node.Days[len(node.Days)-1]
+		day.Events = append(day.Events, timelineEvent{
+			Time:        ev.time.Format(timeFormat),
+			Title:       ev.title,
+			Description: ev.description,
+		})
+	}
+	payload, err := json.Marshal(node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to encode timeline",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.ContentResult{
+		Content: plugin.NewElement(ast.CustomBlock(&anypb.Any{
+			TypeUrl: timelineTypeURL,
+			Value:   payload,
+		})),
+	}, nil
+}
+
+// collectTimelineRows parses the timestamps of the rows and renders the templates.
+func collectTimelineRows(params *plugin.ProvideContentParams, rows plugindata.List, loc *time.Location) ([]timelineRow, error) {
+	titleTmpl, err := parseTableTemplate("title", params.Args.GetAttrVal("title").AsString())
+	if err != nil {
+		return nil, err
+	}
+	var descriptionTmpl tableCellTmpl
+	if description := params.Args.GetAttrVal("description"); !description.IsNull() {
+		descriptionTmpl, err = parseTableTemplate("description", description.AsString())
+		if err != nil {
+			return nil, err
+		}
+	}
+	timeField := params.Args.GetAttrVal("time_field").AsString()
+
+	data := params.DataContext.Any().(map[string]any)
+	dataRow := map[string]any{}
+	data["row"] = dataRow
+	events := make([]timelineRow, 0, len(rows))
+	for i, row := range rows {
+		ts, err := lookupChartField(row, timeField)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		t, err := parseTimelineTime(ts, loc)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: field %q: %w", i+1, timeField, err)
+		}
+		dataRow["index"] = i + 1
+		dataRow["value"] = row.Any()
+		ev := timelineRow{time: t.In(loc)}
+		ev.title, err = executeCell(titleTmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render title: %w", err)
+		}
+		if descriptionTmpl != nil {
+			var buf bytes.Buffer
+			err = descriptionTmpl.Execute(&buf, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render description: %w", err)
+			}
+			ev.description = strings.TrimSpace(buf.String())
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+func parseTimelineTime(val plugindata.Data, loc *time.Location) (time.Time, error) {
+	switch val := val.(type) {
+	case plugindata.Time:
+		return time.Time(val), nil
+	case plugindata.Number:
+		sec, frac := math.Modf(float64(val))
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	case plugindata.String:
+		s := strings.TrimSpace(string(val))
+		for _, layout := range timelineTimeLayouts {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unsupported timestamp format %q", s)
+	case nil:
+		return time.Time{}, fmt.Errorf("timestamp is missing")
+	}
+	return time.Time{}, fmt.Errorf("timestamp must be a string or a number")
+}
+
+func makeTimelineNodeRenderer() *plugin.NodeRenderer {
+	return &plugin.NodeRenderer{
+		Doc: "Renders the timelines produced by content.timeline",
+		Formats: []plugin.OutputFormat{
+			plugin.OutputFormatMD,
+			plugin.OutputFormatHTML,
+			plugin.OutputFormatPDF,
+		},
+		RenderFunc: renderTimelineNode,
+	}
+}
+
+func renderTimelineNode(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+	var node timelineNode
+	err := json.Unmarshal(params.Node.GetValue(), &node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to decode timeline",
+			Detail:   err.Error(),
+		}}
+	}
+	var content string
+	if params.Format == plugin.OutputFormatHTML {
+		content, err = renderTimelineHTML(&node)
+	} else {
+		// pdf renderer produces markdown as well
+		content = renderTimelineMarkdown(&node)
+	}
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render timeline",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.RenderNodeResult{Content: []byte(content)}, nil
+}
+
+// renderTimelineMarkdown renders a list of events under every day header.
+func renderTimelineMarkdown(node *timelineNode) string {
+	var parts []string
+	for _, day := range node.Days {
+		parts = append(parts, "**"+day.Date+"**")
+		items := make([]string, len(day.Events))
+		for i, ev := range day.Events {
+			item := "- **" + ev.Time + "** " + ev.Title
+			if ev.Description != "" {
+				item += "  \n  " + strings.ReplaceAll(ev.Description, "\n", "\n  ")
+			}
+			items[i] = item
+		}
+		parts = append(parts, strings.Join(items, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderTimelineHTML renders a vertical line with a marker for every event.
+func renderTimelineHTML(node *timelineNode) (string, error) {
+	md := goldmark.New(
+		plugin.BaseMarkdownOptions,
+		goldmark.WithRendererOptions(
+			goldmarkhtml.WithHardWraps(),
+			goldmarkhtml.WithXHTML(),
+		),
+	)
+	toHTML := func(source string) (string, error) {
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(source), &buf); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(buf.String()), nil
+	}
+	var sb strings.Builder
+	sb.WriteString(`<div class="timeline">` + "\n")
+	for _, day := range node.Days {
+		fmt.Fprintf(&sb, `<div class="timeline-day" style="margin: 1em 0 0.5em; font-weight: bold;">%s</div>`+"\n", html.EscapeString(day.Date))
+		sb.WriteString(`<ol class="timeline-events" style="list-style: none; margin: 0 0 0 6px; padding: 0 0 0 1.25em; border-left: 2px solid #d0d7de;">` + "\n")
+		for _, ev := range day.Events {
+			title, err := toHTML(ev.Title)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(`<li class="timeline-event" style="position: relative; padding-bottom: 1em;">` + "\n")
+			sb.WriteString(`<span style="position: absolute; left: calc(-1.25em - 7px); top: 0.4em; width: 12px; height: 12px; border-radius: 50%; background: #0969da;"></span>` + "\n")
+			fmt.Fprintf(&sb, `<div class="timeline-time" style="color: #57606a; font-size: 0.875em;">%s</div>`+"\n", html.EscapeString(ev.Time))
+			// the title is a single paragraph, the paragraph tags are dropped
+			title = strings.TrimSuffix(strings.TrimPrefix(title, "<p>"), "</p>")
+			fmt.Fprintf(&sb, `<div class="timeline-title" style="font-weight: bold;">%s</div>`+"\n", title)
+			if ev.Description != "" {
+				description, err := toHTML(ev.Description)
+				if err != nil {
+					return "", err
+				}
+				fmt.Fprintf(&sb, `<div class="timeline-description">%s</div>`+"\n", description)
+			}
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ol>\n")
+	}
+	sb.WriteString("</div>")
+	return sb.String(), nil
+}
@@ -0,0 +1,204 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+type TimelineContentTestSuite struct {
+	suite.Suite
+	schema *plugin.ContentProvider
+}
+
+func TestTimelineContentTestSuite(t *testing.T) {
+	suite.Run(t, &TimelineContentTestSuite{})
+}
+
+func (s *TimelineContentTestSuite) SetupSuite() {
+	s.schema = makeTimelineContentProvider()
+}
+
+func (s *TimelineContentTestSuite) TestSchema() {
+	s.NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.ContentFunc)
+}
+
+func (s *TimelineContentTestSuite) genTimeline(val string, asserts diagtest.Asserts) *plugin.ContentResult {
+	args := plugintest.DecodeAndAssert(s.T(), s.schema.Args, val, plugindata.Map{}, diagtest.Asserts{})
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return result
+}
+
+func (s *TimelineContentTestSuite) decode(result *plugin.ContentResult) *timelineNode {
+	el, ok := result.Content.(*plugin.ContentElement)
+	s.Require().True(ok)
+	_, content := el.AsNode()
+	custom, ok := content.FirstChild().(*nodes.CustomBlock)
+	s.Require().True(ok)
+	s.Equal(timelineTypeURL, custom.Data.GetTypeUrl())
+	var node timelineNode
+	s.Require().NoError(json.Unmarshal(custom.Data.GetValue(), &node))
+	return &node
+}
+
+const timelineTestRows = `
+rows = [
+	{event = {at = "2024-03-02T09:15:00Z"}, title = "Contained", severity = "low"},
+	{event = {at = "2024-03-01T23:30:00Z"}, title = "Detected", severity = "high"},
+	{event = {at = 1709337600}, title = "Escalated", severity = "high"},
+]
+time_field = "event.at"
+title = "{{ .row.value.title }}"
+`
+
+func (s *TimelineContentTestSuite) TestGroupByDay() {
+	node := s.decode(s.genTimeline(timelineTestRows+`
+		description = "Severity: **{{ .row.value.severity }}**"
+	`, diagtest.Asserts{}))
+	s.Equal([]timelineDay{
+		{
+			Date: "2024-03-01",
+			Events: []timelineEvent{
+				{Time: "23:30", Title: "Detected", Description: "Severity: **high**"},
+			},
+		},
+		{
+			Date: "2024-03-02",
+			Events: []timelineEvent{
+				{Time: "00:00", Title: "Escalated", Description: "Severity: **high**"},
+				{Time: "09:15", Title: "Contained", Description: "Severity: **low**"},
+			},
+		},
+	}, node.Days)
+}
+
+func (s *TimelineContentTestSuite) TestTimezoneAndOrder() {
+	node := s.decode(s.genTimeline(timelineTestRows+`
+		timezone = "America/New_York"
+		time_format = "3:04 PM"
+		date_format = "Jan 2"
+		order = "desc"
+	`, diagtest.Asserts{}))
+	s.Equal([]timelineDay{
+		{
+			Date: "Mar 2",
+			Events: []timelineEvent{
+				{Time: "4:15 AM", Title: "Contained"},
+			},
+		},
+		{
+			Date: "Mar 1",
+			Events: []timelineEvent{
+				{Time: "7:00 PM", Title: "Escalated"},
+				{Time: "6:30 PM", Title: "Detected"},
+			},
+		},
+	}, node.Days)
+}
+
+func (s *TimelineContentTestSuite) TestLocalTime() {
+	node := s.decode(s.genTimeline(`
+		rows = [{at = "2024-03-01 10:00"}]
+		time_field = "at"
+		title = "Event {{ .row.index }}"
+		timezone = "Europe/Berlin"
+	`, diagtest.Asserts{}))
+	s.Equal([]timelineDay{{
+		Date:   "2024-03-01",
+		Events: []timelineEvent{{Time: "10:00", Title: "Event 1"}},
+	}}, node.Days)
+}
+
+func (s *TimelineContentTestSuite) TestRender() {
+	renderers := plugin.NodeRenderers{timelineTypeURL: makeTimelineNodeRenderer()}
+	args := timelineTestRows + `
+		description = "Severity: **{{ .row.value.severity }}**"
+	`
+
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTimeline(args, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Equal("**2024-03-01**\n\n"+
+		"- **23:30** Detected  \n  Severity: **high**\n\n"+
+		"**2024-03-02**\n\n"+
+		"- **00:00** Escalated  \n  Severity: **high**\n"+
+		"- **09:15** Contained  \n  Severity: **low**\n", buf.String())
+
+	buf.Reset()
+	err = htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTimeline(args, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.Contains(buf.String(), `<div class="timeline">`)
+	s.Contains(buf.String(), `<div class="timeline-day" style="margin: 1em 0 0.5em; font-weight: bold;">2024-03-02</div>`)
+	s.Contains(buf.String(), `<div class="timeline-title" style="font-weight: bold;">Contained</div>`)
+	s.Contains(buf.String(), `<div class="timeline-description"><p>Severity: <strong>low</strong></p></div>`)
+}
+
+func (s *TimelineContentTestSuite) TestRenderRawHTML() {
+	renderers := plugin.NodeRenderers{timelineTypeURL: makeTimelineNodeRenderer()}
+	var buf strings.Builder
+	err := htmlprint.New(print.WithNodeRenderers(renderers)).
+		Print(context.Background(), &buf, s.genTimeline(`
+			rows = [{at = "2024-03-01 10:00"}]
+			time_field = "at"
+			title = "<img src=x onerror=alert(1)>Event"
+			description = "<script>alert(1)</script>"
+		`, diagtest.Asserts{}).Content)
+	s.Require().NoError(err)
+	s.NotContains(buf.String(), "<script>")
+	s.NotContains(buf.String(), "<img")
+	s.Contains(buf.String(), `<div class="timeline-title" style="font-weight: bold;"><!-- raw HTML omitted -->Event</div>`)
+}
+
+func (s *TimelineContentTestSuite) TestInvalidTimestamp() {
+	s.genTimeline(`
+		rows = [{at = "2024-03-01"}, {at = "yesterday"}]
+		time_field = "at"
+		title = "event"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to render timeline"),
+		diagtest.DetailContains(`row 2: field "at": unsupported timestamp format "yesterday"`),
+	}})
+	s.genTimeline(`
+		rows = [{}]
+		time_field = "at"
+		title = "event"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains(`row 1`),
+	}})
+}
+
+func (s *TimelineContentTestSuite) TestInvalidTimezone() {
+	s.genTimeline(`
+		rows = []
+		time_field = "at"
+		title = "event"
+		timezone = "Mars/Olympus"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains("invalid timezone"),
+	}})
+}
@@ -3,14 +3,20 @@ package builtin
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	goldmarktext "github.com/yuin/goldmark/text"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/pkg/utils"
 	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
@@ -54,6 +60,17 @@ func makeTitleContentProvider() *plugin.ContentProvider {
 						The value (which may be negative) is added to the ` + "`absolute_size`" + ` to produce the final title size
 					`),
 				},
+				{
+					Name:       "anchor",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("findings"),
+					Doc: utils.Dedent(`
+						Sets the anchor of the title, the title can be referenced in text templates with
+						` + "`{{ ref \"<anchor>\" }}`" + `. The titles of named sections have ` + "`section.<name>`" + ` anchors.
+						The links point to the id generated from the title text, the anchor isn't kept in the output.
+						Letters, digits, ` + "`_`, `-`, `:` and `.`" + ` are allowed
+					`),
+				},
 			},
 		},
 		Doc: utils.Dedent(`
@@ -105,11 +122,53 @@ func genTitleContent(ctx context.Context, params *plugin.ProvideContentParams) (
 	// remove all newlines
 	text = strings.ReplaceAll(text, "\n", " ")
 	text = strings.Repeat("#", int(titleSize)+1) + " " + text
+	anchor := params.Args.GetAttrVal("anchor")
+	if anchor.IsNull() {
+		return &plugin.ContentResult{
+			Content: plugin.NewElementFromMarkdown(text),
+		}, nil
+	}
+	if !anchorRe.MatchString(anchor.AsString()) {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse arguments",
+			Detail:   fmt.Sprintf("anchor %q must be non-empty and contain only letters, digits, '_', '-', ':' and '.'", anchor.AsString()),
+		}}
+	}
+	el, err := anchoredTitle(text, anchor.AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render value",
+			Detail:   err.Error(),
+		}}
+	}
 	return &plugin.ContentResult{
-		Content: plugin.NewElementFromMarkdown(text),
+		Content: el,
 	}, nil
 }
 
+// anchorRe matches the anchors kept intact by the markdown heading attribute syntax.
+var anchorRe = regexp.MustCompile(`^[\p{L}\p{N}_\-:.]+$`)
+
+// anchoredTitle produces the title with the anchor set as the heading id, the references to the
+// anchor are resolved to the id generated from the title text.
+func anchoredTitle(text, anchor string) (*plugin.ContentElement, error) {
+	source := []byte(text)
+	doc := goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(goldmarktext.NewReader(source))
+	heading, ok := doc.FirstChild().(*ast.Heading)
+	if !ok {
+		return nil, fmt.Errorf("title is not a heading")
+	}
+	heading.SetAttributeString("id", []byte(anchor))
+	node, err := astv1.Encode(nodes.ToFabricContentNode(doc), source)
+	if err != nil {
+		return nil, err
+	}
+	// markdown source is kept without the anchor, for the table of contents
+	return plugin.NewElementFromMarkdownAndAST(source, node.GetContentNode(), nil), nil
+}
+
 func findDefaultTitleSize(datactx plugindata.Map) int64 {
 	document, section := parseScope(datactx)
 	if section == nil {
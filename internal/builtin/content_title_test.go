@@ -2,6 +2,7 @@ package builtin
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
@@ -13,6 +14,7 @@ import (
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print/htmlprint"
 	"github.com/blackstork-io/fabric/print/mdprint"
 )
 
@@ -116,3 +118,39 @@ func (s *TitleTestSuite) TestWithSizeTooBig() {
 	}}, diags)
 	s.Nil(result)
 }
+
+func (s *TitleTestSuite) TestAnchor() {
+	val := cty.ObjectVal(map[string]cty.Value{
+		"value":  cty.StringVal("Key *findings*"),
+		"anchor": cty.StringVal("section.findings"),
+	})
+	args := plugintest.ReencodeCTY(s.T(), s.schema.Args, val, nil)
+	result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	s.Empty(diags)
+	// markdown viewers don't support the heading attributes
+	s.Equal("## Key *findings*\n", mdprint.PrintString(result.Content))
+
+	var buf strings.Builder
+	s.Require().NoError(htmlprint.New().Print(context.Background(), &buf, result.Content))
+	s.Contains(buf.String(), `<h2 id="section.findings">Key <em>findings</em></h2>`)
+}
+
+func (s *TitleTestSuite) TestInvalidAnchor() {
+	val := cty.ObjectVal(map[string]cty.Value{
+		"value":  cty.StringVal("Findings"),
+		"anchor": cty.StringVal("key findings"),
+	})
+	args := plugintest.ReencodeCTY(s.T(), s.schema.Args, val, nil)
+	_, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args:        args,
+		DataContext: plugindata.Map{},
+	})
+	diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains(`anchor "key findings"`),
+	}}.AssertMatch(s.T(), diags, nil)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/yuin/goldmark/ast"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
@@ -119,8 +120,10 @@ func genTOC(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.C
 }
 
 type tocNode struct {
-	level    int
-	title    string
+	level int
+	title string
+	// anchor of the title, if not set, the anchor is produced from the title
+	anchor   string
 	children tocNodeList
 }
 
@@ -130,8 +133,12 @@ func (n tocNode) render(pos, depth int, ordered bool) string {
 		format = "%s" + strconv.Itoa(pos+1) + ". [%s](#%s)\n"
 	}
 	const indentStep = "  "
+	anchor := n.anchor
+	if anchor == "" {
+		anchor = anchorize(n.title)
+	}
 	dst := []string{
-		fmt.Sprintf(format, strings.Repeat(indentStep, depth), n.title, anchorize(n.title)),
+		fmt.Sprintf(format, strings.Repeat(indentStep, depth), n.title, anchor),
 		n.children.render(depth+1, ordered),
 	}
 	return strings.Join(dst, "")
@@ -165,18 +172,34 @@ func anchorize(s string) string {
 	return strings.ToLower(strings.ReplaceAll(s, " ", "-"))
 }
 
-func extractTitles(section *plugin.ContentSection) []string {
-	var titles []string
+// tocTitle is a title in the markdown form with the optional anchor.
+type tocTitle struct {
+	markdown string
+	anchor   string
+}
+
+func extractTitles(section *plugin.ContentSection) []tocTitle {
+	var titles []tocTitle
 	for _, content := range section.Children {
 		switch content := content.(type) {
 		case *plugin.ContentSection:
 			titles = append(titles, extractTitles(content)...)
 		case *plugin.ContentElement:
 			meta := content.Meta()
-			if meta == nil || meta.Plugin != Name || meta.Provider != "title" {
+			if meta == nil || meta.Plugin != Name {
 				continue
 			}
-			titles = append(titles, string(content.AsMarkdownSrc()))
+			switch meta.Provider {
+			case "title":
+				if content.IsAst() {
+					// titles with anchors
+					titles = append(titles, extractHeadings(content)...)
+				} else {
+					titles = append(titles, tocTitle{markdown: string(content.AsMarkdownSrc())})
+				}
+			case "include":
+				titles = append(titles, extractHeadings(content)...)
+			}
 		}
 	}
 	return titles
@@ -184,7 +207,7 @@ func extractTitles(section *plugin.ContentSection) []string {
 
 func parseContentTitles(data plugindata.Map, startLvl, endLvl int, scope string) (tocNodeList, error) {
 	document, section := parseScope(data)
-	var list []string
+	var list []tocTitle
 	if scope == "auto" {
 		if section != nil {
 			scope = "section"
@@ -201,7 +224,7 @@ func parseContentTitles(data plugindata.Map, startLvl, endLvl int, scope string)
 	}
 	var result tocNodeList
 	for _, item := range list {
-		line := strings.TrimSpace(item)
+		line := strings.TrimSpace(item.markdown)
 		if strings.HasPrefix(line, "#") {
 			level := -1
 			for i := 0; i < len(line); i++ {
@@ -214,9 +237,32 @@ func parseContentTitles(data plugindata.Map, startLvl, endLvl int, scope string)
 				continue
 			}
 			title := strings.TrimSpace(line[level+1:])
-			result = result.add(tocNode{level: level, title: title})
+			result = result.add(tocNode{level: level, title: title, anchor: item.anchor})
 		}
 	}
 
 	return result, nil
 }
+
+// extractHeadings returns the headings of the element in the same form as the titles.
+func extractHeadings(content *plugin.ContentElement) []tocTitle {
+	src, node := content.AsNode()
+	var headings []tocTitle
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && entering {
+			title := tocTitle{
+				markdown: strings.Repeat("#", h.Level) + " " + string(h.Text(src.AsBytes())),
+			}
+			switch id, _ := h.AttributeString("id"); id := id.(type) {
+			case []byte:
+				title.anchor = string(id)
+			case string:
+				title.anchor = id
+			}
+			headings = append(headings, title)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return headings
+}
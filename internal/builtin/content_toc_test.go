@@ -169,3 +169,68 @@ func (s *TOCContentTestSuite) TestAdvanced() {
 		"3. [Header 5](#header-5)",
 	}, "\n")+"\n", mdprint.PrintString(res.Content))
 }
+
+func (s *TOCContentTestSuite) TestIncludedHeadings() {
+	val := cty.ObjectVal(map[string]cty.Value{})
+	args := plugintest.ReencodeCTY(s.T(), s.schema.Args, val, nil)
+	ctx := context.Background()
+	res, diags := s.schema.ContentFunc(ctx, &plugin.ProvideContentParams{
+		Args: args,
+		DataContext: plugindata.Map{
+			"document": plugindata.Map{
+				"content": plugindata.Map{
+					"type": plugindata.String("section"),
+					"children": plugindata.List{
+						plugindata.Map{
+							"type":     plugindata.String("element"),
+							"markdown": plugindata.String("# Header 1"),
+							"meta": plugindata.Map{
+								"provider": plugindata.String("title"),
+								"plugin":   plugindata.String("blackstork/builtin"),
+							},
+						},
+						plugindata.Map{
+							"type":     plugindata.String("element"),
+							"markdown": plugindata.String("## Included\n\n```sh\n# not a heading\n```\n\n### Included *details*"),
+							"meta": plugindata.Map{
+								"provider": plugindata.String("include"),
+								"plugin":   plugindata.String("blackstork/builtin"),
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	s.Len(diags, 0, "no errors")
+	s.Equal(strings.Join([]string{
+		"- [Header 1](#header-1)",
+		"  - [Included](#included)",
+		"    - [Included details](#included-details)",
+	}, "\n")+"\n", mdprint.PrintString(res.Content))
+}
+
+func (s *TOCContentTestSuite) TestAnchoredTitles() {
+	title, err := anchoredTitle("# Findings", "section.findings")
+	s.Require().NoError(err)
+	titleData := title.AsData().(plugindata.Map)
+	titleData["meta"] = plugindata.Map{
+		"provider": plugindata.String("title"),
+		"plugin":   plugindata.String("blackstork/builtin"),
+	}
+	val := cty.ObjectVal(map[string]cty.Value{})
+	args := plugintest.ReencodeCTY(s.T(), s.schema.Args, val, nil)
+	res, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+		Args: args,
+		DataContext: plugindata.Map{
+			"document": plugindata.Map{
+				"content": plugindata.Map{
+					"type":     plugindata.String("section"),
+					"children": plugindata.List{titleData},
+				},
+			},
+		},
+	})
+	s.Len(diags, 0, "no errors")
+	s.Equal("- [Findings](#section.findings)\n", mdprint.PrintString(res.Content))
+}
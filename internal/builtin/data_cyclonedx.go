@@ -0,0 +1,384 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// cdxSeverityRanks orders CycloneDX severities to pick the worst rating of a vulnerability.
+var cdxSeverityRanks = map[string]int{
+	"unknown":  0,
+	"none":     1,
+	"info":     2,
+	"low":      3,
+	"medium":   4,
+	"high":     5,
+	"critical": 6,
+}
+
+type cdxBOM struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	SerialNumber    string             `json:"serialNumber"`
+	Version         int                `json:"version"`
+	Metadata        *cdxMetadata       `json:"metadata"`
+	Components      []cdxComponent     `json:"components"`
+	Dependencies    []cdxDependency    `json:"dependencies"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp"`
+	Component *cdxComponent `json:"component"`
+	// an array of tools before CycloneDX 1.5 and an object with components and services after
+	Tools json.RawMessage `json:"tools"`
+}
+
+type cdxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cdxComponent struct {
+	BOMRef      string `json:"bom-ref"`
+	Type        string `json:"type"`
+	Group       string `json:"group"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Scope       string `json:"scope"`
+	PURL        string `json:"purl"`
+	CPE         string `json:"cpe"`
+	Supplier    *struct {
+		Name string `json:"name"`
+	} `json:"supplier"`
+	Licenses []cdxLicenseChoice `json:"licenses"`
+	Hashes   []struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	} `json:"hashes"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxLicenseChoice struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cdxVulnerability struct {
+	BOMRef string `json:"bom-ref"`
+	ID     string `json:"id"`
+	Source *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"source"`
+	Ratings []struct {
+		Score    *float64 `json:"score"`
+		Severity string   `json:"severity"`
+		Method   string   `json:"method"`
+		Vector   string   `json:"vector"`
+	} `json:"ratings"`
+	CWEs           []int        `json:"cwes"`
+	Description    string       `json:"description"`
+	Recommendation string       `json:"recommendation"`
+	Published      string       `json:"published"`
+	Analysis       *cdxAnalysis `json:"analysis"`
+	Affects        []struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+}
+
+type cdxAnalysis struct {
+	State         string   `json:"state"`
+	Justification string   `json:"justification"`
+	Response      []string `json:"response"`
+	Detail        string   `json:"detail"`
+}
+
+// statement converts CycloneDX analysis to a VEX statement in OpenVEX vocabulary.
+func (a *cdxAnalysis) statement() vexStatement {
+	status := a.State
+	switch a.State {
+	case "resolved", "resolved_with_pedigree":
+		status = "fixed"
+	case "exploitable":
+		status = "affected"
+	case "in_triage":
+		status = "under_investigation"
+	case "false_positive":
+		status = "not_affected"
+	}
+	return vexStatement{
+		status:        status,
+		justification: a.Justification,
+		detail:        a.Detail,
+		response:      a.Response,
+	}
+}
+
+func makeCycloneDXDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchCycloneDXData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("CycloneDX", "path/to/*.cdx.json", "path/to/bom.cdx.json"),
+				vexAttr,
+			),
+		},
+		Doc: u.Dedent(`
+			Loads CycloneDX SBOMs in JSON format with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + ` value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The document is normalized to a dict with the following fields:
+			- ` + "`spec_version`" + `, ` + "`serial_number`" + `, ` + "`timestamp`" + ` and ` + "`tools`" + ` of the document
+			- ` + "`component`" + ` – the component the SBOM describes
+			- ` + "`components`" + ` – a flat list of all components, including the nested ones, with ` + "`bom_ref`" + `, ` + "`type`" + `,
+			  ` + "`group`" + `, ` + "`name`" + `, ` + "`version`" + `, ` + "`purl`" + `, ` + "`cpe`" + `, ` + "`scope`" + `, ` + "`supplier`" + `, ` + "`licenses`" + ` and ` + "`hashes`" + ` fields
+			- ` + "`dependencies`" + ` – a list of dicts with ` + "`ref`" + ` and ` + "`depends_on`" + ` fields
+			- ` + "`vulnerabilities`" + ` – a list of the vulnerabilities with ` + "`id`" + `, ` + "`source`" + `, ` + "`severity`" + ` and ` + "`score`" + `
+			  of the worst rating, ` + "`cwes`" + `, ` + "`description`" + `, ` + "`affects`" + ` with the affected components and ` + "`vex`" + ` status
+
+			The ` + "`vex`" + ` field has ` + "`status`" + ` (` + "`not_affected`" + `, ` + "`affected`" + `, ` + "`fixed`" + ` or ` + "`under_investigation`" + `),
+			` + "`justification`" + `, ` + "`detail`" + `, ` + "`action`" + ` and ` + "`response`" + ` fields. It's taken from the analysis in
+			the SBOM or, if ` + "`vex`" + ` argument is set, from the VEX document. The vulnerabilities are matched by their IDs
+			and aliases.
+		`),
+	}
+}
+
+func fetchCycloneDXData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	vex, diags := readVEXAttr(params.Args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return fetchFiles(ctx, params.Args, "CycloneDX", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readCycloneDXFile(path, vex)
+	})
+}
+
+func readCycloneDXFile(path string, vex vexIndex) (plugindata.Data, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var bom cdxBOM
+	if err := json.Unmarshal(raw, &bom); err != nil {
+		return nil, err
+	}
+	if bom.BOMFormat != "CycloneDX" {
+		return nil, errors.New("not a CycloneDX document")
+	}
+	return bom.data(vex), nil
+}
+
+func (bom *cdxBOM) data(vex vexIndex) plugindata.Map {
+	components := plugindata.List{}
+	byRef := map[string]*cdxComponent{}
+	var walk func(list []cdxComponent)
+	walk = func(list []cdxComponent) {
+		for i := range list {
+			c := &list[i]
+			components = append(components, c.data())
+			if c.BOMRef != "" {
+				byRef[c.BOMRef] = c
+			}
+			walk(c.Components)
+		}
+	}
+	walk(bom.Components)
+
+	var (
+		component plugindata.Data
+		timestamp plugindata.Data
+		tools     = plugindata.List{}
+	)
+	if bom.Metadata != nil {
+		if bom.Metadata.Component != nil {
+			component = bom.Metadata.Component.data()
+			if ref := bom.Metadata.Component.BOMRef; ref != "" {
+				byRef[ref] = bom.Metadata.Component
+			}
+		}
+		timestamp = parseOptionalTime(bom.Metadata.Timestamp)
+		for _, tool := range parseCycloneDXTools(bom.Metadata.Tools) {
+			tools = append(tools, plugindata.Map{
+				"vendor":  optionalString(tool.Vendor),
+				"name":    optionalString(tool.Name),
+				"version": optionalString(tool.Version),
+			})
+		}
+	}
+
+	dependencies := make(plugindata.List, 0, len(bom.Dependencies))
+	for _, dep := range bom.Dependencies {
+		dependsOn := make(plugindata.List, 0, len(dep.DependsOn))
+		for _, ref := range dep.DependsOn {
+			dependsOn = append(dependsOn, plugindata.String(ref))
+		}
+		dependencies = append(dependencies, plugindata.Map{
+			"ref":        plugindata.String(dep.Ref),
+			"depends_on": dependsOn,
+		})
+	}
+
+	vulnerabilities := make(plugindata.List, 0, len(bom.Vulnerabilities))
+	for _, vuln := range bom.Vulnerabilities {
+		vulnerabilities = append(vulnerabilities, vuln.data(byRef, vex))
+	}
+
+	return plugindata.Map{
+		"spec_version":    plugindata.String(bom.SpecVersion),
+		"serial_number":   optionalString(bom.SerialNumber),
+		"version":         plugindata.Number(bom.Version),
+		"timestamp":       timestamp,
+		"tools":           tools,
+		"component":       component,
+		"components":      components,
+		"dependencies":    dependencies,
+		"vulnerabilities": vulnerabilities,
+	}
+}
+
+func parseCycloneDXTools(raw json.RawMessage) []cdxTool {
+	if len(raw) == 0 {
+		return nil
+	}
+	var tools []cdxTool
+	if err := json.Unmarshal(raw, &tools); err == nil {
+		return tools
+	}
+	var obj struct {
+		Components []cdxTool `json:"components"`
+		Services   []cdxTool `json:"services"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	return append(obj.Components, obj.Services...)
+}
+
+func (c *cdxComponent) data() plugindata.Map {
+	licenses := make(plugindata.List, 0, len(c.Licenses))
+	for _, l := range c.Licenses {
+		switch {
+		case l.Expression != "":
+			licenses = append(licenses, plugindata.String(l.Expression))
+		case l.License != nil && l.License.ID != "":
+			licenses = append(licenses, plugindata.String(l.License.ID))
+		case l.License != nil && l.License.Name != "":
+			licenses = append(licenses, plugindata.String(l.License.Name))
+		}
+	}
+	hashes := plugindata.Map{}
+	for _, h := range c.Hashes {
+		hashes[h.Alg] = plugindata.String(h.Content)
+	}
+	var supplier plugindata.Data
+	if c.Supplier != nil {
+		supplier = optionalString(c.Supplier.Name)
+	}
+	return plugindata.Map{
+		"bom_ref":     optionalString(c.BOMRef),
+		"type":        optionalString(c.Type),
+		"group":       optionalString(c.Group),
+		"name":        plugindata.String(c.Name),
+		"version":     optionalString(c.Version),
+		"description": optionalString(c.Description),
+		"scope":       optionalString(c.Scope),
+		"purl":        optionalString(c.PURL),
+		"cpe":         optionalString(c.CPE),
+		"supplier":    supplier,
+		"licenses":    licenses,
+		"hashes":      hashes,
+	}
+}
+
+func (v *cdxVulnerability) data(components map[string]*cdxComponent, vex vexIndex) plugindata.Map {
+	var (
+		severity = "unknown"
+		score    plugindata.Data
+		maxScore float64
+	)
+	for _, r := range v.Ratings {
+		if cdxSeverityRanks[r.Severity] > cdxSeverityRanks[severity] {
+			severity = r.Severity
+		}
+		if r.Score != nil && (score == nil || *r.Score > maxScore) {
+			maxScore = *r.Score
+			score = plugindata.Number(maxScore)
+		}
+	}
+	cwes := make(plugindata.List, 0, len(v.CWEs))
+	for _, cwe := range v.CWEs {
+		cwes = append(cwes, plugindata.Number(cwe))
+	}
+	affects := make(plugindata.List, 0, len(v.Affects))
+	for _, a := range v.Affects {
+		affected := plugindata.Map{
+			"ref":     plugindata.String(a.Ref),
+			"name":    nil,
+			"version": nil,
+			"purl":    nil,
+		}
+		if c, ok := components[a.Ref]; ok {
+			affected["name"] = plugindata.String(c.Name)
+			affected["version"] = optionalString(c.Version)
+			affected["purl"] = optionalString(c.PURL)
+		}
+		affects = append(affects, affected)
+	}
+	var source plugindata.Data
+	if v.Source != nil {
+		source = optionalString(v.Source.Name)
+	}
+	var status plugindata.Data
+	if stmt, ok := vex.lookup(v.ID); ok {
+		status = stmt.data()
+	} else if v.Analysis != nil {
+		status = v.Analysis.statement().data()
+	}
+	return plugindata.Map{
+		"id":             plugindata.String(v.ID),
+		"bom_ref":        optionalString(v.BOMRef),
+		"source":         source,
+		"severity":       plugindata.String(severity),
+		"score":          score,
+		"cwes":           cwes,
+		"description":    optionalString(v.Description),
+		"recommendation": optionalString(v.Recommendation),
+		"published":      parseOptionalTime(v.Published),
+		"affects":        affects,
+		"vex":            status,
+	}
+}
+
+// parseOptionalTime parses RFC 3339 timestamps and keeps the other values as strings.
+func parseOptionalTime(s string) plugindata.Data {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return plugindata.String(s)
+	}
+	return plugindata.Time(t)
+}
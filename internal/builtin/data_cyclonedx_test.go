@@ -0,0 +1,198 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type CycloneDXDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestCycloneDXDataSourceSuite(t *testing.T) {
+	suite.Run(t, &CycloneDXDataSourceTestSuite{})
+}
+
+func (s *CycloneDXDataSourceTestSuite) SetupSuite() {
+	s.schema = makeCycloneDXDataSource()
+}
+
+func (s *CycloneDXDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *CycloneDXDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *CycloneDXDataSourceTestSuite) TestPath() {
+	data := s.fetch(`path = "testdata/cyclonedx/bom.cdx.json"`, diagtest.Asserts{})
+	bom, ok := data.(plugindata.Map)
+	s.Require().True(ok)
+	s.Equal(plugindata.String("1.5"), bom["spec_version"])
+	s.Equal(plugindata.String("urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"), bom["serial_number"])
+	s.Equal(plugindata.Time(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)), bom["timestamp"])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"vendor":  nil,
+			"name":    plugindata.String("syft"),
+			"version": plugindata.String("1.4.0"),
+		},
+	}, bom["tools"])
+	s.Equal(plugindata.String("webapp"), bom["component"].(plugindata.Map)["name"])
+
+	components := bom["components"].(plugindata.List)
+	s.Require().Len(components, 3)
+	s.Equal(plugindata.Map{
+		"bom_ref":     plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+		"type":        plugindata.String("library"),
+		"group":       plugindata.String("golang.org/x"),
+		"name":        plugindata.String("net"),
+		"version":     plugindata.String("v0.15.0"),
+		"description": nil,
+		"scope":       nil,
+		"purl":        plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+		"cpe":         nil,
+		"supplier":    nil,
+		"licenses":    plugindata.List{plugindata.String("BSD-3-Clause")},
+		"hashes":      plugindata.Map{"SHA-256": plugindata.String("deadbeef")},
+	}, components[0])
+	s.Equal(plugindata.String("http2"), components[1].(plugindata.Map)["name"])
+	s.Equal(plugindata.List{plugindata.String("MIT OR Apache-2.0")}, components[1].(plugindata.Map)["licenses"])
+	s.Equal(plugindata.String("OpenSSL Foundation"), components[2].(plugindata.Map)["supplier"])
+	s.Equal(plugindata.List{plugindata.String("OpenSSL License")}, components[2].(plugindata.Map)["licenses"])
+
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"ref": plugindata.String("app"),
+			"depends_on": plugindata.List{
+				plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+				plugindata.String("openssl"),
+			},
+		},
+		plugindata.Map{
+			"ref":        plugindata.String("openssl"),
+			"depends_on": plugindata.List{},
+		},
+	}, bom["dependencies"])
+
+	vulns := bom["vulnerabilities"].(plugindata.List)
+	s.Require().Len(vulns, 3)
+	s.Equal(plugindata.Map{
+		"id":             plugindata.String("CVE-2023-44487"),
+		"bom_ref":        nil,
+		"source":         plugindata.String("NVD"),
+		"severity":       plugindata.String("high"),
+		"score":          plugindata.Number(7.5),
+		"cwes":           plugindata.List{plugindata.Number(400)},
+		"description":    plugindata.String("HTTP/2 rapid reset"),
+		"recommendation": nil,
+		"published":      plugindata.Time(time.Date(2023, time.October, 10, 14, 15, 10, 0, time.UTC)),
+		"affects": plugindata.List{
+			plugindata.Map{
+				"ref":     plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+				"name":    plugindata.String("net"),
+				"version": plugindata.String("v0.15.0"),
+				"purl":    plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+			},
+		},
+		"vex": plugindata.Map{
+			"status":        plugindata.String("under_investigation"),
+			"justification": nil,
+			"detail":        nil,
+			"action":        nil,
+			"response":      plugindata.List{},
+		},
+	}, vulns[0])
+
+	second := vulns[1].(plugindata.Map)
+	s.Equal(plugindata.String("critical"), second["severity"])
+	s.Nil(second["score"])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"ref":     plugindata.String("openssl"),
+			"name":    plugindata.String("openssl"),
+			"version": plugindata.String("3.0.7"),
+			"purl":    nil,
+		},
+		plugindata.Map{
+			"ref":     plugindata.String("missing"),
+			"name":    nil,
+			"version": nil,
+			"purl":    nil,
+		},
+	}, second["affects"])
+	s.Equal(plugindata.Map{
+		"status":        plugindata.String("affected"),
+		"justification": nil,
+		"detail":        plugindata.String("Reachable from the TLS client"),
+		"action":        nil,
+		"response":      plugindata.List{plugindata.String("update")},
+	}, second["vex"])
+
+	third := vulns[2].(plugindata.Map)
+	s.Equal(plugindata.String("unknown"), third["severity"])
+	s.Equal(plugindata.String("http2"), third["affects"].(plugindata.List)[0].(plugindata.Map)["name"])
+	s.Nil(third["vex"])
+}
+
+func (s *CycloneDXDataSourceTestSuite) TestVEX() {
+	data := s.fetch(`
+		glob = "testdata/cyclonedx/bom.*"
+		vex = "testdata/cyclonedx/vex.cdx.json"
+	`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Require().Len(list, 1)
+	vulns := list[0].(plugindata.Map)["content"].(plugindata.Map)["vulnerabilities"].(plugindata.List)
+	s.Equal(plugindata.Map{
+		"status":        plugindata.String("fixed"),
+		"justification": nil,
+		"detail":        plugindata.String("Upgraded to v0.17.0"),
+		"action":        nil,
+		"response":      plugindata.List{plugindata.String("update")},
+	}, vulns[0].(plugindata.Map)["vex"])
+	// the statuses from the SBOM are kept for the vulnerabilities missing in the VEX document
+	s.Equal(plugindata.String("affected"), vulns[1].(plugindata.Map)["vex"].(plugindata.Map)["status"])
+
+	data = s.fetch(`
+		path = "testdata/cyclonedx/bom.cdx.json"
+		vex = "testdata/vex/openvex.json"
+	`, diagtest.Asserts{})
+	vulns = data.(plugindata.Map)["vulnerabilities"].(plugindata.List)
+	s.Equal(plugindata.String("not_affected"), vulns[0].(plugindata.Map)["vex"].(plugindata.Map)["status"])
+}
+
+func (s *CycloneDXDataSourceTestSuite) TestErrors() {
+	s.fetch(`path = "testdata/spdx/sbom.spdx.json"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("not a CycloneDX document"),
+	}})
+	s.fetch(`path = "testdata/csv/comma.csv"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+	s.fetch(`
+		path = "testdata/cyclonedx/bom.cdx.json"
+		vex = "testdata/vex/missing.json"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the VEX document"),
+	}})
+}
@@ -0,0 +1,293 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gobwas/glob"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/builtin/utils"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// maxExecStderrLen is the maximum length of the command stderr included in the diagnostics.
+const maxExecStderrLen = 4096
+
+type allowedCommandsKeyT struct{}
+
+var allowedCommandsKey = allowedCommandsKeyT{}
+
+// WithAllowedCommands returns a context with the glob patterns of the commands
+// `exec` data source is allowed to run.
+func WithAllowedCommands(ctx context.Context, patterns []string) context.Context {
+	return context.WithValue(ctx, allowedCommandsKey, patterns)
+}
+
+// resolveCommand returns the absolute, cleaned path of the command if it's a path,
+// resolving relative paths from the working directory. Names of executables in
+// `PATH` are returned as is.
+func resolveCommand(command, workingDir string) (string, error) {
+	if !strings.ContainsRune(command, '/') && !strings.ContainsRune(command, filepath.Separator) {
+		return command, nil
+	}
+	if !filepath.IsAbs(command) {
+		command = filepath.Join(workingDir, command)
+	}
+	return filepath.Abs(command)
+}
+
+// commandAllowed matches the resolved command against the allowed patterns.
+// Relative path patterns are resolved from the current directory.
+func commandAllowed(ctx context.Context, command string) (bool, error) {
+	patterns, _ := ctx.Value(allowedCommandsKey).([]string)
+	var cwd string
+	for _, pattern := range patterns {
+		if strings.ContainsRune(pattern, '/') && !filepath.IsAbs(pattern) {
+			if cwd == "" {
+				dir, err := os.Getwd()
+				if err != nil {
+					return false, fmt.Errorf("failed to get the current directory: %w", err)
+				}
+				cwd = glob.QuoteMeta(dir)
+			}
+			pattern = filepath.Join(cwd, pattern)
+		}
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if g.Match(command) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func makeExecDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchExecData,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "command",
+					Type:        cty.String,
+					ExampleVal:  cty.StringVal("kubectl"),
+					Constraints: constraint.RequiredMeaningful,
+					Doc: u.Dedent(`
+						The command to run: a name of an executable in ` + "`PATH`" + ` or a path to it.
+						Relative paths are resolved from ` + "`working_dir`" + `.
+					`),
+				},
+				{
+					Name: "args",
+					Type: cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{
+						cty.StringVal("get"),
+						cty.StringVal("pods"),
+						cty.StringVal("-o"),
+						cty.StringVal("json"),
+					}),
+					Doc: `Arguments of the command`,
+				},
+				{
+					Name: "env",
+					Type: cty.Map(cty.String),
+					ExampleVal: cty.MapVal(map[string]cty.Value{
+						"KUBECONFIG": cty.StringVal("/path/to/kubeconfig"),
+					}),
+					Doc: `Environment variables set for the command in addition to the environment of Fabric`,
+				},
+				{
+					Name:       "working_dir",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("path/to/dir"),
+					Doc:        `Working directory of the command. Defaults to the current directory`,
+				},
+				{
+					Name:       "timeout",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal("1m"),
+					Doc:        `The duration after which the command is killed`,
+				},
+				{
+					Name:       "format",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal("json"),
+					OneOf: []cty.Value{
+						cty.StringVal("json"),
+						cty.StringVal("yaml"),
+						cty.StringVal("csv"),
+						cty.StringVal("lines"),
+					},
+					Doc: u.Dedent(`
+						Format of the command output. With ` + "`lines`" + `, the output is returned as a list
+						of strings, one per line
+					`),
+				},
+			},
+		},
+		Doc: u.Dedent(`
+			Runs a local command and parses its output.
+
+			The data source is disabled by default. The commands it's allowed to run must be listed in
+			` + "`allowed_commands`" + ` argument of the global ` + "`fabric`" + ` configuration block.
+			The values of ` + "`command`" + ` argument are matched against the glob patterns from the list. Commands
+			given as paths are resolved from ` + "`working_dir`" + ` and relative patterns from the current directory,
+			so both are compared as absolute paths. For example:
+
+			` + "```hcl" + `
+			fabric {
+			  allowed_commands = ["kubectl", "./scripts/*"]
+			}
+			` + "```" + `
+
+			The standard output of the command is parsed according to ` + "`format`" + ` argument. CSV output must
+			have a header row. If the command exits with a non-zero code, the data source fails with its
+			standard error output in the diagnostics.
+		`),
+	}
+}
+
+func fetchExecData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	command := params.Args.GetAttrVal("command").AsString()
+	workingDir := stringAttr(params.Args, "working_dir")
+	resolved, err := resolveCommand(command, workingDir)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   fmt.Sprintf("Invalid command: %s", err),
+		}}
+	}
+	allowed, err := commandAllowed(ctx, resolved)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid allowed commands",
+			Detail:   err.Error(),
+		}}
+	}
+	if !allowed {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Command is not allowed",
+			Detail: fmt.Sprintf(
+				"Command %q doesn't match any pattern in \"allowed_commands\" argument of the global \"fabric\" configuration block",
+				resolved,
+			),
+		}}
+	}
+	timeout, err := time.ParseDuration(params.Args.GetAttrVal("timeout").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   fmt.Sprintf("Invalid timeout: %s", err),
+		}}
+	}
+	var args []string
+	if val := params.Args.GetAttrVal("args"); !val.IsNull() {
+		for _, arg := range val.AsValueSlice() {
+			args = append(args, arg.AsString())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, resolved, args...)
+	cmd.Dir = workingDir
+	// the children of the killed command may keep the output pipes open
+	cmd.WaitDelay = time.Second
+	if val := params.Args.GetAttrVal("env"); !val.IsNull() && val.LengthInt() > 0 {
+		cmd.Env = os.Environ()
+		env := val.AsValueMap()
+		names := make([]string, 0, len(env))
+		for name := range env {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			cmd.Env = append(cmd.Env, name+"="+env[name].AsString())
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	slog.DebugContext(ctx, "Running a command", "command", command, "args", args)
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Command timed out",
+			Detail:   fmt.Sprintf("Command %q didn't finish in %s", command, timeout),
+		}}
+	}
+	if err != nil {
+		detail := err.Error()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			if len(msg) > maxExecStderrLen {
+				start := len(msg) - maxExecStderrLen
+				for start < len(msg) && !utf8.RuneStart(msg[start]) {
+					start++
+				}
+				msg = "..." + msg[start:]
+			}
+			detail += ":\n" + msg
+		}
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Command failed",
+			Detail:   fmt.Sprintf("Command %q failed with %s", command, detail),
+		}}
+	}
+
+	data, err := parseExecOutput(ctx, stdout.Bytes(), params.Args.GetAttrVal("format").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse the command output",
+			Detail:   err.Error(),
+		}}
+	}
+	return data, nil
+}
+
+func parseExecOutput(ctx context.Context, output []byte, format string) (plugindata.Data, error) {
+	switch format {
+	case "yaml":
+		return plugindata.UnmarshalYAML(output)
+	case "csv":
+		return utils.ParseCSVContent(ctx, csv.NewReader(bytes.NewReader(output)))
+	case "lines":
+		result := plugindata.List{}
+		text := strings.TrimSuffix(strings.ReplaceAll(string(output), "\r\n", "\n"), "\n")
+		if text == "" {
+			return result, nil
+		}
+		for _, line := range strings.Split(text, "\n") {
+			result = append(result, plugindata.String(line))
+		}
+		return result, nil
+	default:
+		return plugindata.UnmarshalJSON(output)
+	}
+}
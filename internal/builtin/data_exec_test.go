@@ -0,0 +1,227 @@
+package builtin
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type ExecDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestExecDataSourceSuite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the tests run POSIX shell commands")
+	}
+	suite.Run(t, &ExecDataSourceTestSuite{})
+}
+
+func (s *ExecDataSourceTestSuite) SetupSuite() {
+	s.schema = makeExecDataSource()
+}
+
+func (s *ExecDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *ExecDataSourceTestSuite) fetch(allowed []string, args string, asserts diagtest.Asserts) plugindata.Data {
+	ctx := WithAllowedCommands(context.Background(), allowed)
+	data, diags := s.schema.DataFunc(ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *ExecDataSourceTestSuite) TestFormats() {
+	data := s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "echo '{\"hosts\": [\"a\", \"b\"], \"total\": 2}'"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"hosts": plugindata.List{plugindata.String("a"), plugindata.String("b")},
+		"total": plugindata.Number(2),
+	}, data)
+
+	data = s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "printf 'name: fabric\nports:\n  - 80\n'"]
+		format = "yaml"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"name":  plugindata.String("fabric"),
+		"ports": plugindata.List{plugindata.Number(80)},
+	}, data)
+
+	data = s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "printf 'host,open\n10.0.0.1,true\n10.0.0.2,false\n'"]
+		format = "csv"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"host": plugindata.String("10.0.0.1"), "open": plugindata.Bool(true)},
+		plugindata.Map{"host": plugindata.String("10.0.0.2"), "open": plugindata.Bool(false)},
+	}, data)
+
+	data = s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "printf 'first\r\n\nthird\n'"]
+		format = "lines"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.String("first"),
+		plugindata.String(""),
+		plugindata.String("third"),
+	}, data)
+
+	data = s.fetch([]string{"true"}, `
+		command = "true"
+		format = "lines"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{}, data)
+}
+
+func (s *ExecDataSourceTestSuite) TestEnvAndWorkingDir() {
+	dir, err := filepath.Abs("testdata")
+	s.Require().NoError(err)
+	data := s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "pwd; echo $FABRIC_EXEC_TEST; test -n \"$PATH\" && echo has-path"]
+		env = {
+			FABRIC_EXEC_TEST = "value"
+		}
+		working_dir = "testdata"
+		format = "lines"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.String(dir),
+		plugindata.String("value"),
+		plugindata.String("has-path"),
+	}, data)
+}
+
+func (s *ExecDataSourceTestSuite) TestAllowedCommands() {
+	s.fetch(nil, `
+		command = "sh"
+		args = ["-c", "echo 1"]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command is not allowed"),
+		diagtest.DetailContains(`"sh"`, "allowed_commands"),
+	}})
+	s.fetch([]string{"./scripts/*"}, `
+		command = "./scripts/nested/export.sh"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command is not allowed"),
+	}})
+	s.fetch([]string{"["}, `
+		command = "sh"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Invalid allowed commands"),
+		diagtest.DetailContains(`invalid pattern "["`),
+	}})
+	data := s.fetch([]string{"kubectl", "s?"}, `
+		command = "sh"
+		args = ["-c", "echo 1"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.Number(1), data)
+}
+
+func (s *ExecDataSourceTestSuite) TestAllowedPaths() {
+	data := s.fetch([]string{"./testdata/exec/*"}, `
+		command = "./testdata/exec/run.sh"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{plugindata.String("builtin")}, data)
+	data = s.fetch([]string{"./testdata/exec/*"}, `
+		command = "./exec/run.sh"
+		working_dir = "testdata"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{plugindata.String("testdata")}, data)
+	s.fetch([]string{"./testdata/exec/*"}, `
+		command = "./testdata/exec/run.sh"
+		working_dir = "testdata/exec"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command is not allowed"),
+		diagtest.DetailContains("testdata/exec/testdata/exec/run.sh"),
+	}})
+	s.fetch([]string{"./testdata/exec/*"}, `
+		command = "./testdata/exec/../../data_exec.go"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command is not allowed"),
+	}})
+}
+
+func (s *ExecDataSourceTestSuite) TestStderrTruncated() {
+	ctx := WithAllowedCommands(context.Background(), []string{"sh"})
+	_, diags := s.schema.DataFunc(ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `
+			command = "sh"
+			args = ["-c", "yes é | head -n 3000 | tr -d '\\n' >&2; printf x >&2; exit 1"]
+		`, nil, diagtest.Asserts{}),
+	})
+	s.Require().Len(diags, 1)
+	s.True(utf8.ValidString(diags[0].Detail))
+	s.Contains(diags[0].Detail, ":\n...é")
+	s.True(strings.HasSuffix(diags[0].Detail, "éx"))
+}
+
+func (s *ExecDataSourceTestSuite) TestErrors() {
+	s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "echo partial; echo 'permission denied' >&2; exit 3"]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command failed"),
+		diagtest.DetailContains("exit status 3", "permission denied"),
+	}})
+	s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "sleep 5"]
+		timeout = "100ms"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command timed out"),
+		diagtest.DetailContains("100ms"),
+	}})
+	s.fetch([]string{"sh"}, `
+		command = "sh"
+		args = ["-c", "echo not json"]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse the command output"),
+	}})
+	s.fetch([]string{"*"}, `
+		command = "fabric-missing-command"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Command failed"),
+		diagtest.DetailContains("not found"),
+	}})
+	s.fetch([]string{"sh"}, `
+		command = "sh"
+		timeout = "soon"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains("Invalid timeout"),
+	}})
+}
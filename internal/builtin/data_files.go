@@ -0,0 +1,132 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// fileReader reads and decodes a single file.
+type fileReader func(ctx context.Context, path string) (plugindata.Data, error)
+
+// fileAttrs returns "glob" and "path" arguments of the data sources that read the files
+// of the given kind.
+func fileAttrs(kind, globExample, pathExample string) []*dataspec.AttrSpec {
+	return []*dataspec.AttrSpec{
+		{
+			Name:       "glob",
+			Type:       cty.String,
+			ExampleVal: cty.StringVal(globExample),
+			Doc:        fmt.Sprintf(`A glob pattern to select %s files to read`, kind),
+		},
+		{
+			Name:       "path",
+			Type:       cty.String,
+			ExampleVal: cty.StringVal(pathExample),
+			Doc:        fmt.Sprintf(`A file path to a %s file to read`, kind),
+		},
+	}
+}
+
+// selectFiles returns the file from "path" argument or the files that match "glob" argument.
+// The glob pattern is returned if the files were selected with it.
+func selectFiles(ctx context.Context, args *dataspec.Block) (paths []string, glob string, diags diagnostics.Diag) {
+	glob = stringAttr(args, "glob")
+	path := stringAttr(args, "path")
+
+	switch {
+	case path != "" && glob != "":
+		return nil, "", diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Either \"glob\" or \"path\" must be provided, not both",
+		}}
+	case path != "":
+		slog.DebugContext(ctx, "Reading a file from a path", "path", path)
+		return []string{path}, "", nil
+	case glob != "":
+		slog.DebugContext(ctx, "Reading the files that match the glob pattern", "glob", glob)
+		paths, err := filepath.Glob(glob)
+		if err != nil {
+			slog.ErrorContext(ctx, "Invalid glob pattern", slog.String("glob", glob), slog.Any("error", err))
+			return nil, "", diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the files",
+				Detail:   err.Error(),
+			}}
+		}
+		return paths, glob, nil
+	}
+	return nil, "", diagnostics.Diag{{
+		Severity: hcl.DiagError,
+		Summary:  "Failed to parse provided arguments",
+		Detail:   "Either \"glob\" value or \"path\" value must be provided",
+	}}
+}
+
+// fetchFiles reads a single file from "path" argument or the files that match "glob" argument.
+func fetchFiles(ctx context.Context, args *dataspec.Block, kind string, read fileReader) (plugindata.Data, diagnostics.Diag) {
+	paths, glob, diags := selectFiles(ctx, args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if glob == "" {
+		data, err := read(ctx, paths[0])
+		if err != nil {
+			slog.ErrorContext(
+				ctx, "Error while reading a "+kind+" file",
+				slog.String("path", paths[0]),
+				slog.Any("error", err),
+			)
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the file",
+				Detail:   err.Error(),
+			}}
+		}
+		return data, nil
+	}
+	data, err := readFiles(ctx, paths, read)
+	if err != nil {
+		slog.ErrorContext(
+			ctx, "Error while reading the "+kind+" files",
+			slog.String("glob", glob),
+			slog.Any("error", err),
+		)
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read the files",
+			Detail:   err.Error(),
+		}}
+	}
+	return data, nil
+}
+
+func readFiles(ctx context.Context, paths []string, read fileReader) (plugindata.List, error) {
+	result := make(plugindata.List, 0, len(paths))
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			content, err := read(ctx, path)
+			if err != nil {
+				return result, fmt.Errorf("%s: %w", path, err)
+			}
+			result = append(result, plugindata.Map{
+				"file_path": plugindata.String(path),
+				"file_name": plugindata.String(filepath.Base(path)),
+				"content":   content,
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,552 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeGitDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchGitData,
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "path",
+					Type:        cty.String,
+					ExampleVal:  cty.StringVal("path/to/repo"),
+					Constraints: constraint.RequiredMeaningful,
+					Doc:         `A path to the local repository or a directory inside it`,
+				},
+				{
+					Name:       "from",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("v0.4.0"),
+					Doc: u.Dedent(`
+						A revision the range of commits starts after: a branch, a tag or a commit hash.
+						The commits reachable from it are excluded. If not set, the range starts with the first commit
+					`),
+				},
+				{
+					Name:       "to",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal("HEAD"),
+					Doc:        `A revision the range of commits ends with: a branch, a tag or a commit hash`,
+				},
+				{
+					Name:       "since",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("2024-01-01T00:00:00Z"),
+					Doc:        `Include only the commits made at or after this time, in RFC3339 format`,
+				},
+				{
+					Name:       "until",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("2024-02-01T00:00:00Z"),
+					Doc:        `Include only the commits made at or before this time, in RFC3339 format`,
+				},
+				{
+					Name:       "paths",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("internal/"), cty.StringVal("go.mod")}),
+					Doc: u.Dedent(`
+						Include only the commits that change the files in these paths: files or directories relative
+						to the root of the repository. The files and the churn of the commits are limited to the paths too
+					`),
+				},
+				{
+					Name:       "authors",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("jane@example.com")}),
+					Doc:        `Include only the commits by these authors, matched by email or name, case-insensitively`,
+				},
+				{
+					Name:         "max_commits",
+					Type:         cty.Number,
+					ExampleVal:   cty.NumberIntVal(100),
+					MinInclusive: cty.NumberIntVal(1),
+					Doc:          `The maximum number of the latest commits to return`,
+				},
+			},
+		},
+		Doc: u.Dedent(`
+			Reads the history and the metadata of a local git repository. No network access is needed.
+
+			The data source returns a dict with the following keys:
+
+			- ` + "`head`" + `: the current branch and commit
+			- ` + "`commits`" + `: the commits in the range, from the latest to the earliest, with the files they change.
+			  The files of merge commits are not listed
+			- ` + "`tags`" + ` and ` + "`branches`" + `: all tags and local branches with the commits they point to
+			- ` + "`churn`" + `: the number of commits, added and deleted lines per file in the commits
+			- ` + "`authors`" + `: the number of commits, added and deleted lines per author in the commits
+
+			For example:
+
+			` + "```json" + `
+			{
+			  "head": {"branch": "main", "hash": "5d3f..."},
+			  "commits": [
+			    {
+			      "hash": "5d3f...",
+			      "short_hash": "5d3f2a1",
+			      "subject": "Fix parser",
+			      "message": "Fix parser\n\nDetails",
+			      "author": {"name": "Jane", "email": "jane@example.com", "time": "2024-01-02T10:00:00Z"},
+			      "committer": {"name": "Jane", "email": "jane@example.com", "time": "2024-01-02T10:00:00Z"},
+			      "parents": ["9a1c..."],
+			      "merge": false,
+			      "files": [{"path": "parser.go", "additions": 3, "deletions": 1}],
+			      "additions": 3,
+			      "deletions": 1
+			    }
+			  ],
+			  "tags": [{"name": "v0.1.0", "hash": "5d3f...", "annotated": true, "message": "Release", "time": "2024-01-02T10:00:00Z"}],
+			  "branches": [{"name": "main", "hash": "5d3f...", "head": true}],
+			  "churn": [{"path": "parser.go", "commits": 1, "additions": 3, "deletions": 1}],
+			  "authors": [{"name": "Jane", "email": "jane@example.com", "commits": 1, "additions": 3, "deletions": 1}]
+			}
+			` + "```",
+		),
+	}
+}
+
+type gitOptions struct {
+	from, to     string
+	since, until *time.Time
+	paths        []string
+	authors      []string
+	maxCommits   int
+}
+
+func parseGitArgs(args *dataspec.Block) (opts gitOptions, err error) {
+	opts.from = stringAttr(args, "from")
+	opts.to = stringAttr(args, "to")
+	for _, name := range []string{"since", "until"} {
+		val := stringAttr(args, name)
+		if val == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %q: %w", name, err)
+		}
+		if name == "since" {
+			opts.since = &t
+		} else {
+			opts.until = &t
+		}
+	}
+	if val := args.GetAttrVal("paths"); !val.IsNull() {
+		for _, p := range val.AsValueSlice() {
+			opts.paths = append(opts.paths, strings.Trim(path.Clean(p.AsString()), "/"))
+		}
+	}
+	if val := args.GetAttrVal("authors"); !val.IsNull() {
+		for _, a := range val.AsValueSlice() {
+			opts.authors = append(opts.authors, strings.ToLower(a.AsString()))
+		}
+	}
+	if val := args.GetAttrVal("max_commits"); !val.IsNull() {
+		n, _ := val.AsBigFloat().Int64()
+		opts.maxCommits = int(n)
+	}
+	return opts, nil
+}
+
+func fetchGitData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	opts, err := parseGitArgs(params.Args)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   err.Error(),
+		}}
+	}
+	repoPath := params.Args.GetAttrVal("path").AsString()
+	repo, err := git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to open the repository",
+			Detail:   fmt.Sprintf("%s: %s", repoPath, err),
+		}}
+	}
+	slog.DebugContext(ctx, "Reading git repository", "path", repoPath)
+	data, err := readGitRepository(ctx, repo, opts)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read the repository",
+			Detail:   err.Error(),
+		}}
+	}
+	return data, nil
+}
+
+func readGitRepository(ctx context.Context, repo *git.Repository, opts gitOptions) (plugindata.Map, error) {
+	head := plugindata.Map{"branch": nil, "hash": nil}
+	headRef, err := repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// the repository has no commits yet
+		return plugindata.Map{
+			"head":     head,
+			"commits":  plugindata.List{},
+			"tags":     plugindata.List{},
+			"branches": plugindata.List{},
+			"churn":    plugindata.List{},
+			"authors":  plugindata.List{},
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+	head["hash"] = plugindata.String(headRef.Hash().String())
+	if headRef.Name().IsBranch() {
+		head["branch"] = plugindata.String(headRef.Name().Short())
+	}
+
+	commits, churn, authors, err := readGitCommits(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := readGitTags(repo)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := readGitBranches(repo, headRef)
+	if err != nil {
+		return nil, err
+	}
+	return plugindata.Map{
+		"head":     head,
+		"commits":  commits,
+		"tags":     tags,
+		"branches": branches,
+		"churn":    churn,
+		"authors":  authors,
+	}, nil
+}
+
+func resolveGitRevision(repo *git.Repository, rev string) (plumbing.Hash, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve revision %q: %w", rev, err)
+	}
+	return *hash, nil
+}
+
+type gitCounter struct {
+	name, email          string
+	commits              int
+	additions, deletions int
+}
+
+func (c *gitCounter) add(additions, deletions int) {
+	c.commits++
+	c.additions += additions
+	c.deletions += deletions
+}
+
+func readGitCommits(ctx context.Context, repo *git.Repository, opts gitOptions) (commits, churn, authors plugindata.List, err error) {
+	to, err := resolveGitRevision(repo, opts.to)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// the commits reachable from "from" are excluded from the range
+	excluded := map[plumbing.Hash]bool{}
+	if opts.from != "" {
+		from, err := resolveGitRevision(repo, opts.from)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		iter, err := repo.Log(&git.LogOptions{From: from})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return ctx.Err()
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	iter, err := repo.Log(&git.LogOptions{
+		From:  to,
+		Order: git.LogOrderCommitterTime,
+		Since: opts.since,
+		Until: opts.until,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer iter.Close()
+
+	commits = plugindata.List{}
+	files := map[string]*gitCounter{}
+	people := map[string]*gitCounter{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if excluded[c.Hash] || !gitAuthorMatches(c.Author, opts.authors) {
+			return nil
+		}
+		commit, stats, err := gitCommitData(ctx, c, opts.paths)
+		if err != nil {
+			return err
+		}
+		if stats == nil && len(opts.paths) > 0 {
+			return nil
+		}
+		var additions, deletions int
+		for _, stat := range stats {
+			additions += stat.Addition
+			deletions += stat.Deletion
+			file := files[stat.Name]
+			if file == nil {
+				file = &gitCounter{name: stat.Name}
+				files[stat.Name] = file
+			}
+			file.add(stat.Addition, stat.Deletion)
+		}
+		key := strings.ToLower(c.Author.Email)
+		person := people[key]
+		if person == nil {
+			person = &gitCounter{name: c.Author.Name, email: c.Author.Email}
+			people[key] = person
+		}
+		person.add(additions, deletions)
+
+		commits = append(commits, commit)
+		if opts.maxCommits > 0 && len(commits) == opts.maxCommits {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return commits, gitChurnData(files), gitAuthorsData(people), nil
+}
+
+func gitAuthorMatches(author object.Signature, authors []string) bool {
+	if len(authors) == 0 {
+		return true
+	}
+	for _, a := range authors {
+		if a == strings.ToLower(author.Email) || a == strings.ToLower(author.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func gitPathMatches(name string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	for _, p := range paths {
+		if p == "." || name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// gitCommitData returns the commit data and the stats of the files changed by the commit in the paths.
+// The stats are nil for merge commits and the commits that don't change the paths.
+func gitCommitData(ctx context.Context, c *object.Commit, paths []string) (plugindata.Map, object.FileStats, error) {
+	var stats object.FileStats
+	if c.NumParents() < 2 {
+		all, err := c.StatsContext(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the changes of commit %s: %w", c.Hash, err)
+		}
+		for _, stat := range all {
+			if gitPathMatches(stat.Name, paths) {
+				stats = append(stats, stat)
+			}
+		}
+	}
+	parents := make(plugindata.List, len(c.ParentHashes))
+	for i, hash := range c.ParentHashes {
+		parents[i] = plugindata.String(hash.String())
+	}
+	files := make(plugindata.List, len(stats))
+	var additions, deletions int
+	for i, stat := range stats {
+		additions += stat.Addition
+		deletions += stat.Deletion
+		files[i] = plugindata.Map{
+			"path":      plugindata.String(stat.Name),
+			"additions": plugindata.Number(stat.Addition),
+			"deletions": plugindata.Number(stat.Deletion),
+		}
+	}
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	hash := c.Hash.String()
+	return plugindata.Map{
+		"hash":       plugindata.String(hash),
+		"short_hash": plugindata.String(hash[:7]),
+		"subject":    plugindata.String(strings.TrimSpace(subject)),
+		"message":    plugindata.String(strings.TrimSpace(c.Message)),
+		"author":     gitSignatureData(c.Author),
+		"committer":  gitSignatureData(c.Committer),
+		"parents":    parents,
+		"merge":      plugindata.Bool(c.NumParents() > 1),
+		"files":      files,
+		"additions":  plugindata.Number(additions),
+		"deletions":  plugindata.Number(deletions),
+	}, stats, nil
+}
+
+func gitSignatureData(sig object.Signature) plugindata.Map {
+	return plugindata.Map{
+		"name":  plugindata.String(sig.Name),
+		"email": plugindata.String(sig.Email),
+		"time":  plugindata.Time(sig.When.UTC()),
+	}
+}
+
+func gitChurnData(files map[string]*gitCounter) plugindata.List {
+	counters := make([]*gitCounter, 0, len(files))
+	for _, file := range files {
+		counters = append(counters, file)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		a, b := counters[i], counters[j]
+		if a.additions+a.deletions != b.additions+b.deletions {
+			return a.additions+a.deletions > b.additions+b.deletions
+		}
+		return a.name < b.name
+	})
+	result := make(plugindata.List, len(counters))
+	for i, file := range counters {
+		result[i] = plugindata.Map{
+			"path":      plugindata.String(file.name),
+			"commits":   plugindata.Number(file.commits),
+			"additions": plugindata.Number(file.additions),
+			"deletions": plugindata.Number(file.deletions),
+		}
+	}
+	return result
+}
+
+func gitAuthorsData(people map[string]*gitCounter) plugindata.List {
+	counters := make([]*gitCounter, 0, len(people))
+	for _, person := range people {
+		counters = append(counters, person)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		a, b := counters[i], counters[j]
+		if a.commits != b.commits {
+			return a.commits > b.commits
+		}
+		return strings.ToLower(a.email) < strings.ToLower(b.email)
+	})
+	result := make(plugindata.List, len(counters))
+	for i, person := range counters {
+		result[i] = plugindata.Map{
+			"name":      plugindata.String(person.name),
+			"email":     plugindata.String(person.email),
+			"commits":   plugindata.Number(person.commits),
+			"additions": plugindata.Number(person.additions),
+			"deletions": plugindata.Number(person.deletions),
+		}
+	}
+	return result
+}
+
+func readGitTags(repo *git.Repository) (plugindata.List, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	result := plugindata.List{}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		tag := plugindata.Map{
+			"name":      plugindata.String(ref.Name().Short()),
+			"annotated": plugindata.Bool(false),
+			"message":   nil,
+			"tagger":    nil,
+		}
+		var commit *object.Commit
+		obj, err := repo.TagObject(ref.Hash())
+		switch {
+		case err == nil:
+			tag["annotated"] = plugindata.Bool(true)
+			tag["message"] = plugindata.String(strings.TrimSpace(obj.Message))
+			tag["tagger"] = gitSignatureData(obj.Tagger)
+			tag["time"] = plugindata.Time(obj.Tagger.When.UTC())
+			commit, err = obj.Commit()
+			if errors.Is(err, object.ErrUnsupportedObject) {
+				// tags of trees and blobs
+				tag["hash"] = plugindata.String(obj.Target.String())
+				result = append(result, tag)
+				return nil
+			}
+		case errors.Is(err, plumbing.ErrObjectNotFound):
+			commit, err = repo.CommitObject(ref.Hash())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tag %q: %w", ref.Name().Short(), err)
+		}
+		tag["hash"] = plugindata.String(commit.Hash.String())
+		if _, ok := tag["time"]; !ok {
+			tag["time"] = plugindata.Time(commit.Committer.When.UTC())
+		}
+		result = append(result, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].(plugindata.Map)["name"].(plugindata.String) < result[j].(plugindata.Map)["name"].(plugindata.String)
+	})
+	return result, nil
+}
+
+func readGitBranches(repo *git.Repository, head *plumbing.Reference) (plugindata.List, error) {
+	iter, err := repo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	result := plugindata.List{}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		result = append(result, plugindata.Map{
+			"name": plugindata.String(ref.Name().Short()),
+			"hash": plugindata.String(ref.Hash().String()),
+			"head": plugindata.Bool(ref.Name() == head.Name()),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].(plugindata.Map)["name"].(plugindata.String) < result[j].(plugindata.Map)["name"].(plugindata.String)
+	})
+	return result, nil
+}
@@ -0,0 +1,321 @@
+package builtin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type GitDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+	dir    string
+	hashes map[string]string
+}
+
+func TestGitDataSourceSuite(t *testing.T) {
+	suite.Run(t, &GitDataSourceTestSuite{})
+}
+
+var gitTestStart = time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+// SetupSuite creates a repository with the following history:
+//
+//	c1 (alice): add README.md and src/main.go
+//	c2 (bob): change src/main.go, tag v0.1.0 (annotated)
+//	c3 (alice, on feature branch): add docs/guide.md
+//	c4 (alice): change README.md, tag v0.2.0 (lightweight)
+//	c5: merge feature into master
+func (s *GitDataSourceTestSuite) SetupSuite() {
+	s.schema = makeGitDataSource()
+	s.dir = s.T().TempDir()
+	s.hashes = map[string]string{}
+
+	repo, err := git.PlainInit(s.dir, false)
+	s.Require().NoError(err)
+	wt, err := repo.Worktree()
+	s.Require().NoError(err)
+
+	alice := object.Signature{Name: "Alice", Email: "alice@example.com"}
+	bob := object.Signature{Name: "Bob", Email: "Bob@Example.com"}
+	commit := func(name string, author object.Signature, hour int, files map[string]string, parents ...plumbing.Hash) plumbing.Hash {
+		for path, content := range files {
+			full := filepath.Join(s.dir, path)
+			s.Require().NoError(os.MkdirAll(filepath.Dir(full), 0o755))
+			s.Require().NoError(os.WriteFile(full, []byte(content), 0o644))
+			_, err := wt.Add(path)
+			s.Require().NoError(err)
+		}
+		author.When = gitTestStart.Add(time.Duration(hour) * time.Hour)
+		hash, err := wt.Commit(name+" subject\n\n"+name+" body\n", &git.CommitOptions{
+			Author:    &author,
+			Committer: &author,
+			Parents:   parents,
+		})
+		s.Require().NoError(err)
+		s.hashes[name] = hash.String()
+		return hash
+	}
+
+	commit("c1", alice, 0, map[string]string{
+		"README.md":   "# Project\n",
+		"src/main.go": "package main\n\nfunc main() {}\n",
+	})
+	c2 := commit("c2", bob, 1, map[string]string{
+		"src/main.go": "package main\n\nfunc main() {\n\tprintln(1)\n}\n",
+	})
+	_, err = repo.CreateTag("v0.1.0", c2, &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Bob", Email: "Bob@Example.com", When: gitTestStart.Add(90 * time.Minute)},
+		Message: "First release",
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	c3 := commit("c3", alice, 2, map[string]string{
+		"docs/guide.md": "Guide\nLine 2\n",
+	})
+	s.Require().NoError(wt.Checkout(&git.CheckoutOptions{Branch: plumbing.Master}))
+	c4 := commit("c4", alice, 3, map[string]string{
+		"README.md": "# Project\n\nAbout\n",
+	})
+	s.Require().NoError(repo.Storer.SetReference(plumbing.NewHashReference("refs/tags/v0.2.0", c4)))
+	commit("c5", alice, 4, map[string]string{
+		"docs/guide.md": "Guide\nLine 2\n",
+	}, c4, c3)
+}
+
+func (s *GitDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *GitDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Map {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `path = "`+s.dir+`"`+"\n"+args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	if data == nil {
+		return nil
+	}
+	return data.(plugindata.Map)
+}
+
+func (s *GitDataSourceTestSuite) commitHashes(data plugindata.Map) []string {
+	var hashes []string
+	for _, commit := range data["commits"].(plugindata.List) {
+		hash := string(commit.(plugindata.Map)["hash"].(plugindata.String))
+		for name, h := range s.hashes {
+			if h == hash {
+				hashes = append(hashes, name)
+			}
+		}
+	}
+	return hashes
+}
+
+func (s *GitDataSourceTestSuite) TestAll() {
+	data := s.fetch(``, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"branch": plugindata.String("master"),
+		"hash":   plugindata.String(s.hashes["c5"]),
+	}, data["head"])
+	s.Equal([]string{"c5", "c4", "c3", "c2", "c1"}, s.commitHashes(data))
+
+	commits := data["commits"].(plugindata.List)
+	s.Equal(plugindata.Map{
+		"hash":       plugindata.String(s.hashes["c2"]),
+		"short_hash": plugindata.String(s.hashes["c2"][:7]),
+		"subject":    plugindata.String("c2 subject"),
+		"message":    plugindata.String("c2 subject\n\nc2 body"),
+		"author": plugindata.Map{
+			"name":  plugindata.String("Bob"),
+			"email": plugindata.String("Bob@Example.com"),
+			"time":  plugindata.Time(gitTestStart.Add(time.Hour)),
+		},
+		"committer": plugindata.Map{
+			"name":  plugindata.String("Bob"),
+			"email": plugindata.String("Bob@Example.com"),
+			"time":  plugindata.Time(gitTestStart.Add(time.Hour)),
+		},
+		"parents": plugindata.List{plugindata.String(s.hashes["c1"])},
+		"merge":   plugindata.Bool(false),
+		"files": plugindata.List{
+			plugindata.Map{
+				"path":      plugindata.String("src/main.go"),
+				"additions": plugindata.Number(3),
+				"deletions": plugindata.Number(1),
+			},
+		},
+		"additions": plugindata.Number(3),
+		"deletions": plugindata.Number(1),
+	}, commits[3])
+	s.Equal(plugindata.Bool(true), commits[0].(plugindata.Map)["merge"])
+	s.Equal(plugindata.List{}, commits[0].(plugindata.Map)["files"])
+
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"name":      plugindata.String("v0.1.0"),
+			"hash":      plugindata.String(s.hashes["c2"]),
+			"annotated": plugindata.Bool(true),
+			"message":   plugindata.String("First release"),
+			"tagger": plugindata.Map{
+				"name":  plugindata.String("Bob"),
+				"email": plugindata.String("Bob@Example.com"),
+				"time":  plugindata.Time(gitTestStart.Add(90 * time.Minute)),
+			},
+			"time": plugindata.Time(gitTestStart.Add(90 * time.Minute)),
+		},
+		plugindata.Map{
+			"name":      plugindata.String("v0.2.0"),
+			"hash":      plugindata.String(s.hashes["c4"]),
+			"annotated": plugindata.Bool(false),
+			"message":   nil,
+			"tagger":    nil,
+			"time":      plugindata.Time(gitTestStart.Add(3 * time.Hour)),
+		},
+	}, data["tags"])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"name": plugindata.String("feature"),
+			"hash": plugindata.String(s.hashes["c3"]),
+			"head": plugindata.Bool(false),
+		},
+		plugindata.Map{
+			"name": plugindata.String("master"),
+			"hash": plugindata.String(s.hashes["c5"]),
+			"head": plugindata.Bool(true),
+		},
+	}, data["branches"])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"path":      plugindata.String("src/main.go"),
+			"commits":   plugindata.Number(2),
+			"additions": plugindata.Number(6),
+			"deletions": plugindata.Number(1),
+		},
+		plugindata.Map{
+			"path":      plugindata.String("README.md"),
+			"commits":   plugindata.Number(2),
+			"additions": plugindata.Number(3),
+			"deletions": plugindata.Number(0),
+		},
+		plugindata.Map{
+			"path":      plugindata.String("docs/guide.md"),
+			"commits":   plugindata.Number(1),
+			"additions": plugindata.Number(2),
+			"deletions": plugindata.Number(0),
+		},
+	}, data["churn"])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"name":      plugindata.String("Alice"),
+			"email":     plugindata.String("alice@example.com"),
+			"commits":   plugindata.Number(4),
+			"additions": plugindata.Number(8),
+			"deletions": plugindata.Number(0),
+		},
+		plugindata.Map{
+			"name":      plugindata.String("Bob"),
+			"email":     plugindata.String("Bob@Example.com"),
+			"commits":   plugindata.Number(1),
+			"additions": plugindata.Number(3),
+			"deletions": plugindata.Number(1),
+		},
+	}, data["authors"])
+}
+
+func (s *GitDataSourceTestSuite) TestRange() {
+	data := s.fetch(`
+		from = "v0.1.0"
+		to = "v0.2.0"
+	`, diagtest.Asserts{})
+	s.Equal([]string{"c4"}, s.commitHashes(data))
+
+	data = s.fetch(`
+		from = "v0.1.0"
+		to = "feature"
+	`, diagtest.Asserts{})
+	s.Equal([]string{"c3"}, s.commitHashes(data))
+
+	data = s.fetch(`
+		since = "2024-01-01T11:00:00Z"
+		until = "2024-01-01T13:00:00Z"
+	`, diagtest.Asserts{})
+	s.Equal([]string{"c4", "c3", "c2"}, s.commitHashes(data))
+
+	data = s.fetch(`max_commits = 2`, diagtest.Asserts{})
+	s.Equal([]string{"c5", "c4"}, s.commitHashes(data))
+}
+
+func (s *GitDataSourceTestSuite) TestFilters() {
+	data := s.fetch(`paths = ["src", "docs/guide.md"]`, diagtest.Asserts{})
+	s.Equal([]string{"c3", "c2", "c1"}, s.commitHashes(data))
+	first := data["commits"].(plugindata.List)[2].(plugindata.Map)
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"path":      plugindata.String("src/main.go"),
+			"additions": plugindata.Number(3),
+			"deletions": plugindata.Number(0),
+		},
+	}, first["files"])
+	s.Len(data["churn"], 2)
+
+	data = s.fetch(`authors = ["bob@example.com"]`, diagtest.Asserts{})
+	s.Equal([]string{"c2"}, s.commitHashes(data))
+	data = s.fetch(`authors = ["alice", "nobody"]`, diagtest.Asserts{})
+	s.Equal([]string{"c5", "c4", "c3", "c1"}, s.commitHashes(data))
+}
+
+func (s *GitDataSourceTestSuite) TestSubdirectory() {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `path = "`+filepath.Join(s.dir, "src")+`"`, nil, diagtest.Asserts{}),
+	})
+	s.Empty(diags)
+	s.Len(data.(plugindata.Map)["commits"], 5)
+}
+
+func (s *GitDataSourceTestSuite) TestEmptyRepository() {
+	dir := s.T().TempDir()
+	_, err := git.PlainInit(dir, false)
+	s.Require().NoError(err)
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `path = "`+dir+`"`, nil, diagtest.Asserts{}),
+	})
+	s.Empty(diags)
+	s.Equal(plugindata.List{}, data.(plugindata.Map)["commits"])
+}
+
+func (s *GitDataSourceTestSuite) TestErrors() {
+	s.fetch(`from = "v9.9.9"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the repository"),
+		diagtest.DetailContains(`failed to resolve revision "v9.9.9"`),
+	}})
+	s.fetch(`since = "yesterday"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains(`invalid "since"`),
+	}})
+	_, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `path = "`+s.T().TempDir()+`"`, nil, diagtest.Asserts{}),
+	})
+	diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to open the repository"),
+		diagtest.DetailContains("repository does not exist"),
+	}}.AssertMatch(s.T(), diags, nil)
+}
@@ -0,0 +1,354 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/net/html/charset"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// maxHTMLCellSpan limits colspan and rowspan of the cells, browsers limit them similarly.
+const maxHTMLCellSpan = 1000
+
+func makeHTMLTableDataSource(version string) *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchHTMLTableDataWrapper(version),
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:       "path",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("path/to/report.html"),
+					Doc:        `A file path to a HTML file to read`,
+				},
+				{
+					Name:       "url",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("https://example.localhost/report.html"),
+					Doc:        "URL to fetch the HTML page from with `GET` request",
+				},
+				{
+					Name:        "selector",
+					Type:        cty.String,
+					DefaultVal:  cty.StringVal("table"),
+					Constraints: constraint.Meaningful,
+					Doc:         "CSS selector of the tables to extract, for example, `table#findings` or `table:nth-of-type(2)`",
+				},
+				{
+					Name:       "headers",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("host"), cty.StringVal("severity")}),
+					Doc:        "Names of the columns. If not set, the names are taken from the header rows of the tables",
+				},
+				{
+					Name:       "timeout",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal("30s"),
+					Doc:        "The duration of a timeout for a request, if `url` is set",
+				},
+			},
+		},
+		Doc: u.Dedent(`
+			Extracts the rows of HTML tables from a local file or a web page.
+
+			Either ` + "`path`" + ` or ` + "`url`" + ` argument must be set.
+
+			The data source returns a list of dicts, one per row of the tables matching ` + "`selector`" + `.
+			The keys of the dicts are the names of the columns: the text of the cells in ` + "`<thead>`" + ` or,
+			if there is no ` + "`<thead>`" + `, in the first row consisting of ` + "`<th>`" + ` cells. The names of the
+			columns without header are ` + "`column_<n>`" + `. The values are the text of the cells, with
+			` + "`colspan`" + ` and ` + "`rowspan`" + ` cells repeated in all the columns and rows they span.
+
+			The rows of the matched tables are merged into a single list, so the pages of a report split into
+			several tables with the same columns can be read at once.
+		`),
+	}
+}
+
+func fetchHTMLTableDataWrapper(version string) plugin.RetrieveDataFunc {
+	return func(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+		return fetchHTMLTableData(ctx, params, version)
+	}
+}
+
+func fetchHTMLTableData(ctx context.Context, params *plugin.RetrieveDataParams, version string) (plugindata.Data, diagnostics.Diag) {
+	path := stringAttr(params.Args, "path")
+	url := stringAttr(params.Args, "url")
+	var headers []string
+	if val := params.Args.GetAttrVal("headers"); !val.IsNull() {
+		for _, header := range val.AsValueSlice() {
+			headers = append(headers, header.AsString())
+		}
+	}
+
+	var content io.Reader
+	switch {
+	case path != "" && url != "":
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Either \"path\" or \"url\" must be provided, not both",
+		}}
+	case path != "":
+		slog.Debug("Reading a file from a path", "path", path)
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the file",
+				Detail:   err.Error(),
+			}}
+		}
+		defer f.Close()
+		// the encoding is detected by the meta tags of the document
+		content, err = charset.NewReader(f, "")
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the file",
+				Detail:   err.Error(),
+			}}
+		}
+	case url != "":
+		timeout, err := time.ParseDuration(params.Args.GetAttrVal("timeout").AsString())
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse a timeout duration value",
+				Detail:   err.Error(),
+			}}
+		}
+		res, err := SendRequest(ctx, &Request{
+			Url:     url,
+			Method:  "GET",
+			Timeout: timeout,
+			Headers: map[string]string{
+				"User-Agent": fmt.Sprintf("fabric-data-html_table/%s", version),
+			},
+		})
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to fetch the page",
+				Detail:   err.Error(),
+			}}
+		}
+		content = bytes.NewReader(res.Body)
+	default:
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Either \"path\" value or \"url\" value must be provided",
+		}}
+	}
+
+	rows, err := parseHTMLTables(content, params.Args.GetAttrVal("selector").AsString(), headers)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse HTML tables",
+			Detail:   err.Error(),
+		}}
+	}
+	return rows, nil
+}
+
+// parseHTMLTables returns the rows of the tables matching the selector.
+func parseHTMLTables(content io.Reader, selector string, headers []string) (plugindata.List, error) {
+	// goquery matches nothing with invalid selectors, so the selector is compiled beforehand
+	matcher, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+	}
+	doc, err := goquery.NewDocumentFromReader(content)
+	if err != nil {
+		return nil, err
+	}
+	result := plugindata.List{}
+	doc.FindMatcher(matcher).EachWithBreak(func(i int, table *goquery.Selection) bool {
+		if !table.Is("table") {
+			err = fmt.Errorf("selector %q matches a <%s> element, not a table", selector, goquery.NodeName(table))
+			return false
+		}
+		result = append(result, parseHTMLTable(table, headers)...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type htmlTableRow struct {
+	cells []string
+	// header is set for the rows in <thead> and the rows of <th> cells
+	header bool
+	inHead bool
+}
+
+func parseHTMLTable(table *goquery.Selection, headers []string) plugindata.List {
+	rows := htmlTableGrid(table)
+	hasHead := false
+	for _, row := range rows {
+		hasHead = hasHead || row.inHead
+	}
+	// the header rows are the rows of <thead>, or the first row of <th> cells
+	var headerRows [][]string
+	var dataRows [][]string
+	for i, row := range rows {
+		if row.inHead || (!hasHead && i == 0 && row.header) {
+			headerRows = append(headerRows, row.cells)
+			continue
+		}
+		dataRows = append(dataRows, row.cells)
+	}
+	if headers == nil {
+		headers = htmlTableHeaders(headerRows)
+	}
+	result := make(plugindata.List, 0, len(dataRows))
+	for _, cells := range dataRows {
+		empty := true
+		for _, cell := range cells {
+			empty = empty && cell == ""
+		}
+		if empty {
+			continue
+		}
+		row := make(plugindata.Map, max(len(headers), len(cells)))
+		for j := range max(len(headers), len(cells)) {
+			name := fmt.Sprintf("column_%d", j+1)
+			if j < len(headers) && headers[j] != "" {
+				name = headers[j]
+			}
+			if j < len(cells) {
+				row[name] = plugindata.String(cells[j])
+			} else {
+				row[name] = nil
+			}
+		}
+		result = append(result, row)
+	}
+	return result
+}
+
+// htmlTableGrid returns the text of the cells of the rows, with the spanned cells repeated.
+func htmlTableGrid(table *goquery.Selection) []htmlTableRow {
+	type span struct {
+		text string
+		rows int
+	}
+	var rows []htmlTableRow
+	spans := map[int]*span{}
+	addRow := func(tr *goquery.Selection, inHead bool) {
+		var row htmlTableRow
+		row.inHead = inHead
+		col := 0
+		// fill the columns taken by the cells spanning from the rows above
+		fillSpans := func() {
+			for {
+				s := spans[col]
+				if s == nil {
+					return
+				}
+				row.cells = append(row.cells, s.text)
+				if s.rows--; s.rows == 0 {
+					delete(spans, col)
+				}
+				col++
+			}
+		}
+		cells := tr.ChildrenFiltered("th, td")
+		row.header = cells.Length() > 0 && cells.Length() == tr.ChildrenFiltered("th").Length()
+		cells.Each(func(_ int, cell *goquery.Selection) {
+			fillSpans()
+			text := strings.Join(strings.Fields(cell.Text()), " ")
+			colspan := htmlCellSpan(cell, "colspan")
+			rowspan := htmlCellSpan(cell, "rowspan")
+			for range colspan {
+				row.cells = append(row.cells, text)
+				if rowspan > 1 {
+					spans[col] = &span{text: text, rows: rowspan - 1}
+				}
+				col++
+			}
+		})
+		fillSpans()
+		rows = append(rows, row)
+	}
+	table.Children().Each(func(_ int, child *goquery.Selection) {
+		switch goquery.NodeName(child) {
+		case "tr":
+			addRow(child, false)
+		case "thead", "tbody", "tfoot":
+			inHead := goquery.NodeName(child) == "thead"
+			child.ChildrenFiltered("tr").Each(func(_ int, tr *goquery.Selection) {
+				addRow(tr, inHead)
+			})
+		}
+	})
+	return rows
+}
+
+func htmlCellSpan(cell *goquery.Selection, attr string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(cell.AttrOr(attr, "1")))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return min(n, maxHTMLCellSpan)
+}
+
+// htmlTableHeaders combines the header rows into the names of the columns, for example,
+// a group header spanning two columns over "min" and "max" gives "score min" and "score max".
+func htmlTableHeaders(headerRows [][]string) []string {
+	var headers []string
+	for _, cells := range headerRows {
+		for j, cell := range cells {
+			if j >= len(headers) {
+				headers = append(headers, make([]string, j-len(headers)+1)...)
+			}
+			// the cells spanning several header rows are not repeated
+			if cell == "" || cell == headers[j] || strings.HasSuffix(headers[j], " "+cell) {
+				continue
+			}
+			if headers[j] != "" {
+				headers[j] += " "
+			}
+			headers[j] += cell
+		}
+	}
+	return numberDuplicateNames(headers)
+}
+
+// numberDuplicateNames numbers the repeated names of the columns, so no column is lost:
+// "name", "name" become "name", "name_2".
+func numberDuplicateNames(names []string) []string {
+	seen := map[string]int{}
+	for j, name := range names {
+		if name == "" {
+			continue
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			names[j] = fmt.Sprintf("%s_%d", name, seen[name])
+		}
+	}
+	return names
+}
@@ -0,0 +1,152 @@
+package builtin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type HTMLTableDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestHTMLTableDataSourceSuite(t *testing.T) {
+	suite.Run(t, &HTMLTableDataSourceTestSuite{})
+}
+
+func (s *HTMLTableDataSourceTestSuite) SetupSuite() {
+	s.schema = makeHTMLTableDataSource("1.2.3")
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *HTMLTableDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestHeaderRow() {
+	data := s.fetch(`
+		path = "testdata/html/report.html"
+		selector = "table#summary"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"Severity": plugindata.String("High"), "Count": plugindata.String("2")},
+		plugindata.Map{"Severity": plugindata.String("Low"), "Count": plugindata.String("5")},
+	}, data)
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestSpansAndMerge() {
+	data := s.fetch(`
+		path = "testdata/html/report.html"
+		selector = "table.findings"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"Host":          plugindata.String("10.0.0.1"),
+			"Port Number":   plugindata.String("22"),
+			"Port Protocol": plugindata.String("tcp"),
+			"Finding":       plugindata.String("Weak ciphers"),
+		},
+		plugindata.Map{
+			"Host":          plugindata.String("10.0.0.1"),
+			"Port Number":   plugindata.String("443"),
+			"Port Protocol": plugindata.String("tcp"),
+			"Finding":       plugindata.String("Expired certificate"),
+		},
+		plugindata.Map{
+			"Host":          plugindata.String("10.0.0.2"),
+			"Port Number":   plugindata.String("53"),
+			"Port Protocol": plugindata.String("udp"),
+			"Finding":       nil,
+		},
+	}, data)
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestHeaders() {
+	data := s.fetch(`
+		path = "testdata/html/report.html"
+		selector = "table#summary"
+		headers = ["severity"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"severity": plugindata.String("High"), "column_2": plugindata.String("2")},
+		plugindata.Map{"severity": plugindata.String("Low"), "column_2": plugindata.String("5")},
+	}, data)
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestURL() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("fabric-data-html_table/1.2.3", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<table><tr><td>a</td><td>b</td></tr></table>`))
+	}))
+	defer srv.Close()
+
+	data := s.fetch(`url = "`+srv.URL+`"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"column_1": plugindata.String("a"), "column_2": plugindata.String("b")},
+	}, data)
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestNotATable() {
+	s.fetch(`
+		path = "testdata/html/report.html"
+		selector = "#notes"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse HTML tables"),
+		diagtest.DetailContains("<div>", "not a table"),
+	}})
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestInvalidSelector() {
+	s.fetch(`
+		path = "testdata/html/report.html"
+		selector = "table[["
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse HTML tables"),
+		diagtest.DetailContains("invalid selector"),
+	}})
+}
+
+func (s *HTMLTableDataSourceTestSuite) TestArgs() {
+	s.fetch(`
+		path = "testdata/html/report.html"
+		url = "http://example.localhost"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains("not both"),
+	}})
+	s.fetch(``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+	}})
+}
+
+func TestHTMLTableHeaders(t *testing.T) {
+	assert.Equal(t, []string{"score min", "score max", "name", "name_2"}, htmlTableHeaders([][]string{
+		{"score", "score", "name", "name"},
+		{"min", "max", "name", "name"},
+	}))
+}
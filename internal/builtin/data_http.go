@@ -4,19 +4,25 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"mime"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/blackstork-io/fabric/internal/builtin/utils"
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
@@ -57,6 +63,93 @@ func makeHTTPDataSource(version string) *plugin.DataSource {
 						},
 					},
 				},
+				{
+					Header: dataspec.HeadersSpec{
+						dataspec.ExactMatcher{"bearer_auth"},
+					},
+					Doc: u.Dedent(`
+						Bearer token to be sent in ` + "`Authorization`" + ` header of HTTP request.
+					`),
+					Attrs: []*dataspec.AttrSpec{
+						{
+							Name:        "token",
+							Type:        cty.String,
+							ExampleVal:  cty.StringVal("<token>"),
+							Constraints: constraint.RequiredMeaningful,
+							Secret:      true,
+						},
+					},
+				},
+				{
+					Header: dataspec.HeadersSpec{
+						dataspec.ExactMatcher{"oauth2"},
+					},
+					Doc: u.Dedent(`
+						OAuth2 client credentials flow. The access token is requested from the token URL
+						and sent with every request.
+					`),
+					Attrs: []*dataspec.AttrSpec{
+						{
+							Name:        "token_url",
+							Type:        cty.String,
+							ExampleVal:  cty.StringVal("https://auth.example.localhost/oauth2/token"),
+							Constraints: constraint.RequiredMeaningful,
+						},
+						{
+							Name:        "client_id",
+							Type:        cty.String,
+							ExampleVal:  cty.StringVal("fabric"),
+							Constraints: constraint.RequiredMeaningful,
+						},
+						{
+							Name:        "client_secret",
+							Type:        cty.String,
+							ExampleVal:  cty.StringVal("<secret>"),
+							Constraints: constraint.RequiredNonNull,
+							Secret:      true,
+						},
+						{
+							Name:       "scopes",
+							Type:       cty.List(cty.String),
+							ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("read")}),
+						},
+						{
+							Name: "params",
+							Type: cty.Map(cty.String),
+							Doc:  "Additional parameters of the token request, for example, `audience`.",
+						},
+					},
+				},
+				{
+					Header: dataspec.HeadersSpec{
+						dataspec.ExactMatcher{"tls"},
+					},
+					Doc: u.Dedent(`
+						TLS settings: a custom certificate authority and a client certificate.
+					`),
+					Attrs: []*dataspec.AttrSpec{
+						{
+							Name:       "ca_cert_file",
+							Type:       cty.String,
+							ExampleVal: cty.StringVal("path/to/ca.pem"),
+							Doc:        "Path to PEM-encoded certificates of the certificate authorities trusted in addition to the system ones.",
+						},
+						{
+							Name:       "cert_file",
+							Type:       cty.String,
+							ExampleVal: cty.StringVal("path/to/client.pem"),
+							Doc:        "Path to PEM-encoded client certificate. Requires `key_file`.",
+						},
+						{
+							Name:       "key_file",
+							Type:       cty.String,
+							ExampleVal: cty.StringVal("path/to/client-key.pem"),
+							Doc:        "Path to PEM-encoded private key of the client certificate.",
+						},
+					},
+				},
+				makeHTTPRetryBlockSpec(),
+				makeHTTPPaginationBlockSpec(),
 			},
 			Attrs: []*dataspec.AttrSpec{
 				{
@@ -106,16 +199,136 @@ func makeHTTPDataSource(version string) *plugin.DataSource {
 		Doc: u.Dedent(`
 			Loads data from a URL.
 
-			At the moment, the data source accepts only responses with UTF-8 charset and parses only responses
-			with MIME types ` + "`text/csv`" + ` or ` + "`application/json`" + `.
+			At the moment, the data source accepts only responses with UTF-8 charset.
+
+			The response content is parsed according to its MIME type:
+			- ` + "`text/csv`" + ` is parsed as CSV, similar to the behaviour of CSV data source
+			- ` + "`application/json`" + ` and ` + "`+json`" + ` types are parsed as JSON
+			- ` + "`application/x-ndjson`" + ` and ` + "`application/jsonl`" + ` are parsed as a list of JSON values, one per line
+			- ` + "`application/yaml`" + ` and ` + "`text/yaml`" + ` are parsed as YAML
+			- ` + "`application/xml`" + `, ` + "`text/xml`" + ` and ` + "`+xml`" + ` types are parsed as XML: the elements become
+			  objects with attributes prefixed with ` + "`@`" + ` and the text stored in ` + "`#text`" + `, and the elements
+			  without attributes and children become strings
+			- otherwise, the response content is returned as text
 
-			If MIME type of the response is ` + "`text/csv`" + ` or ` + "`application/json`" + `, the response
-			content will be parsed and returned as a JSON structure (similar to the behaviour of CSV and JSON data
-			sources). Otherwise, the response content will be returned as text
+			With ` + "`pagination`" + ` block set, the pages are fetched one by one and their items are merged into a single list.
 		`),
 	}
 }
 
+// makeHTTPRetryBlockSpec returns the spec of the retry options, shared by the data sources making HTTP requests.
+func makeHTTPRetryBlockSpec() *dataspec.BlockSpec {
+	return &dataspec.BlockSpec{
+		Header: dataspec.HeadersSpec{
+			dataspec.ExactMatcher{"retry"},
+		},
+		Doc: u.Dedent(`
+			Retries of the requests that failed with status code 429 or 5xx. The delay between
+			the attempts doubles, starting with ` + "`initial_backoff`" + `, unless the server sets
+			` + "`Retry-After`" + ` header. POST requests are not idempotent, they are retried only if
+			` + "`non_idempotent`" + ` is set.
+		`),
+		Attrs: []*dataspec.AttrSpec{
+			{
+				Name:         "max_retries",
+				Type:         cty.Number,
+				DefaultVal:   cty.NumberIntVal(3),
+				Constraints:  constraint.NonNull | constraint.Integer,
+				MinInclusive: cty.NumberIntVal(0),
+			},
+			{
+				Name:       "initial_backoff",
+				Type:       cty.String,
+				DefaultVal: cty.StringVal("1s"),
+			},
+			{
+				Name:       "max_backoff",
+				Type:       cty.String,
+				DefaultVal: cty.StringVal("30s"),
+				Doc:        "The maximum delay between the attempts, `Retry-After` header is capped at it too.",
+			},
+			{
+				Name:       "non_idempotent",
+				Type:       cty.Bool,
+				DefaultVal: cty.False,
+				Doc:        "Retry the non-idempotent requests, such as POST.",
+			},
+		},
+	}
+}
+
+// makeHTTPPaginationBlockSpec returns the spec of the pagination options, shared by the data sources making HTTP requests.
+func makeHTTPPaginationBlockSpec() *dataspec.BlockSpec {
+	return &dataspec.BlockSpec{
+		Header: dataspec.HeadersSpec{
+			dataspec.ExactMatcher{"pagination"},
+		},
+		Doc: u.Dedent(`
+			Fetches the pages of the response and merges their items into a single list.
+		`),
+		Attrs: []*dataspec.AttrSpec{
+			{
+				Name:        "type",
+				Type:        cty.String,
+				Constraints: constraint.RequiredNonNull,
+				OneOf: []cty.Value{
+					cty.StringVal("link"),
+					cty.StringVal("cursor"),
+					cty.StringVal("offset"),
+				},
+				Doc: u.Dedent(`
+					Pagination strategy:
+					- ` + "`link`" + ` follows the URL with ` + "`rel=\"next\"`" + ` in ` + "`Link`" + ` header of the response, the link must have the same scheme and host as the request
+					- ` + "`cursor`" + ` passes the value of ` + "`cursor_field`" + ` of the response in ` + "`cursor_param`" + ` query parameter of the next request
+					- ` + "`offset`" + ` sets ` + "`offset_param`" + ` and ` + "`limit_param`" + ` query parameters, until a page has less than ` + "`page_size`" + ` items
+				`),
+			},
+			{
+				Name:       "items_field",
+				Type:       cty.String,
+				ExampleVal: cty.StringVal("data.items"),
+				Doc:        "Path to the list of items in the response, with nested fields separated by dots. If not set, the response must be a list.",
+			},
+			{
+				Name:         "max_pages",
+				Type:         cty.Number,
+				DefaultVal:   cty.NumberIntVal(10),
+				Constraints:  constraint.NonNull | constraint.Integer,
+				MinInclusive: cty.NumberIntVal(1),
+				Doc:          "The maximum number of pages to fetch.",
+			},
+			{
+				Name:       "cursor_field",
+				Type:       cty.String,
+				ExampleVal: cty.StringVal("meta.next_cursor"),
+				Doc:        "Path to the cursor of the next page in the response. The pagination stops when the cursor is missing or empty. Required for `cursor` pagination.",
+			},
+			{
+				Name:       "cursor_param",
+				Type:       cty.String,
+				DefaultVal: cty.StringVal("cursor"),
+			},
+			{
+				Name:       "offset_param",
+				Type:       cty.String,
+				DefaultVal: cty.StringVal("offset"),
+			},
+			{
+				Name:       "limit_param",
+				Type:       cty.String,
+				DefaultVal: cty.StringVal("limit"),
+			},
+			{
+				Name:         "page_size",
+				Type:         cty.Number,
+				DefaultVal:   cty.NumberIntVal(100),
+				Constraints:  constraint.NonNull | constraint.Integer,
+				MinInclusive: cty.NumberIntVal(1),
+			},
+		},
+	}
+}
+
 func StringPtr(s string) *string {
 	return &s
 }
@@ -129,22 +342,129 @@ type Request struct {
 	Body              *string
 	BasicAuthUsername *string
 	BasicAuthPassword *string
+	BearerToken       *string
+	// OAuth2 requests the access token with client credentials flow
+	OAuth2 *clientcredentials.Config
+	// CACertFile, CertFile and KeyFile are paths to PEM-encoded certificates
+	CACertFile string
+	CertFile   string
+	KeyFile    string
+	Retry      RetryOptions
+}
+
+// RetryOptions configure the retries of the requests failed with status code 429 or 5xx.
+type RetryOptions struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	NonIdempotent  bool
 }
 
 type Response struct {
 	Body     []byte
 	MimeType string
+	Header   http.Header
 }
 
+// SendRequest sends the request, retrying it according to the retry options.
 func SendRequest(ctx context.Context, r *Request) (*Response, error) {
-	var u *url.URL
-	var err error
+	client, err := newHTTPClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return sendRequest(ctx, client, r)
+}
 
-	u, err = url.Parse(r.Url)
+// newHTTPClient creates the client with the TLS settings and authentication of the request.
+func newHTTPClient(ctx context.Context, r *Request) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: r.SkipVerify, //nolint:gosec,G402
+	}
+	if r.CACertFile != "" {
+		pem, err := os.ReadFile(r.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", r.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if r.CertFile != "" || r.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport, Timeout: r.Timeout}
+	if r.OAuth2 != nil {
+		// the token is requested with the same TLS settings
+		client = r.OAuth2.Client(context.WithValue(ctx, oauth2.HTTPClient, client))
+		client.Timeout = r.Timeout
+	}
+	return client, nil
+}
+
+func sendRequest(ctx context.Context, client *http.Client, r *Request) (*Response, error) {
+	u, err := url.Parse(r.Url)
 	if err != nil {
 		return nil, err
 	}
 
+	backoff := r.Retry.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		slog.Debug(
+			"Sending a HTTP request",
+			"url", r.Url,
+			"method", r.Method,
+			"insecure", r.SkipVerify,
+			"timeout", r.Timeout,
+			"attempt", attempt+1,
+		)
+		res, err := doRequest(ctx, client, u, r)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode == http.StatusOK {
+			return readResponse(res)
+		}
+		res.Body.Close()
+		err = fmt.Errorf("the server responded with status code %d", res.StatusCode)
+		if attempt >= r.Retry.MaxRetries || !isRetryableStatus(res.StatusCode) ||
+			!(r.Retry.NonIdempotent || isIdempotentMethod(r.Method)) {
+			return nil, err
+		}
+		delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			delay = backoff
+			backoff *= 2
+		}
+		if r.Retry.MaxBackoff > 0 {
+			delay = min(delay, r.Retry.MaxBackoff)
+			backoff = min(backoff, r.Retry.MaxBackoff)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, fmt.Errorf("%w, the retry delay %s exceeds the deadline", err, delay)
+		}
+		slog.Debug("Retrying a HTTP request", "url", r.Url, "status_code", res.StatusCode, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func doRequest(ctx context.Context, client *http.Client, u *url.URL, r *Request) (*http.Response, error) {
 	var reqBody io.Reader
 	if r.Body != nil {
 		reqBody = strings.NewReader(*r.Body)
@@ -158,37 +478,24 @@ func SendRequest(ctx context.Context, r *Request) (*Response, error) {
 	if r.BasicAuthUsername != nil && r.BasicAuthPassword != nil {
 		request.SetBasicAuth(*r.BasicAuthUsername, *r.BasicAuthPassword)
 	}
+	if r.BearerToken != nil {
+		request.Header.Set("Authorization", "Bearer "+*r.BearerToken)
+	}
 
 	if r.Headers != nil {
 		for k, v := range r.Headers {
 			request.Header.Set(k, v)
 		}
 	}
+	return client.Do(request)
+}
 
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: r.SkipVerify, //nolint:gosec,G402
-	}
-	client := &http.Client{Transport: transport, Timeout: r.Timeout}
-
-	slog.Debug(
-		"Sending a HTTP request",
-		"url", r.Url,
-		"method", r.Method,
-		"insecure", r.SkipVerify,
-		"timeout", r.Timeout,
-	)
-
-	res, err := client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("the server responded with status code %d", res.StatusCode)
-	}
+func readResponse(res *http.Response) (*Response, error) {
+	defer res.Body.Close()
 
 	contentType := res.Header.Get("Content-Type")
 	var mimeType string
+	var err error
 	if contentType == "" {
 		mimeType = "text/plain" // assume `text/plain` if no content type set
 	} else {
@@ -198,17 +505,44 @@ func SendRequest(ctx context.Context, r *Request) (*Response, error) {
 		}
 	}
 
-	defer res.Body.Close()
-
 	bytes, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error while reading response body: %s", err)
 	}
 
 	if !utf8.Valid(bytes) {
-		return nil, fmt.Errorf("response body is not recognized as UTF-8: %s", err)
+		return nil, fmt.Errorf("response body is not recognized as UTF-8")
+	}
+	return &Response{Body: bytes, MimeType: mimeType, Header: res.Header}, nil
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// isIdempotentMethod reports whether the request with the method can be safely repeated.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodConnect:
+		return false
+	}
+	return true
+}
+
+// parseRetryAfter parses the value of `Retry-After` header: a number of seconds or a date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
 	}
-	return &Response{Body: bytes, MimeType: mimeType}, nil
+	return max(date.Sub(now), 0), true
 }
 
 func fetchHTTPDataWrapper(version string) plugin.RetrieveDataFunc {
@@ -218,96 +552,193 @@ func fetchHTTPDataWrapper(version string) plugin.RetrieveDataFunc {
 }
 
 func fetchHTTPData(ctx context.Context, params *plugin.RetrieveDataParams, version string) (plugindata.Data, diagnostics.Diag) {
-	url := params.Args.GetAttrVal("url").AsString()
-	method := params.Args.GetAttrVal("method").AsString()
-	insecure := params.Args.GetAttrVal("insecure").True()
-
-	timeout, err := time.ParseDuration(params.Args.GetAttrVal("timeout").AsString())
+	req, err := parseHTTPRequest(params.Args)
 	if err != nil {
 		return nil, diagnostics.Diag{
 			{
 				Severity: hcl.DiagError,
-				Summary:  "Failed to parse a timeout duraction value",
+				Summary:  "Failed to parse arguments",
 				Detail:   err.Error(),
 			},
 		}
 	}
-
-	req := Request{
-		Url:        url,
-		Method:     method,
-		Timeout:    timeout,
-		SkipVerify: insecure,
-		Headers:    make(map[string]string),
-		Body:       nil,
-	}
-
-	basicAuth := params.Args.Blocks.GetFirstMatching("basic_auth")
-	if basicAuth != nil {
-		req.BasicAuthUsername = StringPtr(basicAuth.GetAttrVal("username").AsString())
-		req.BasicAuthPassword = StringPtr(basicAuth.GetAttrVal("password").AsString())
-	}
-
-	headers := params.Args.GetAttrVal("headers")
-	if !headers.IsNull() {
-		for k, v := range headers.AsValueMap() {
-			req.Headers[k] = v.AsString()
-		}
-	}
-
-	body := params.Args.GetAttrVal("body")
-	if !body.IsNull() && body.AsString() != "" {
-		req.Body = StringPtr(body.AsString())
-	}
-
 	req.Headers["User-Agent"] = fmt.Sprintf("fabric-data-http/%s", version)
 
-	response, err := SendRequest(ctx, &req)
+	client, err := newHTTPClient(ctx, req)
 	if err != nil {
 		return nil, diagnostics.Diag{
 			{
 				Severity: hcl.DiagError,
-				Summary:  "Failed to fetch data with HTTP request",
+				Summary:  "Failed to create HTTP client",
 				Detail:   err.Error(),
 			},
 		}
 	}
-	slog.Debug("Response received", "mime_type", response.MimeType, "body_bytes_count", len(response.Body))
 
-	var result plugindata.Data
-
-	if response.MimeType == "text/csv" {
-		reader := csv.NewReader(bytes.NewBuffer(response.Body))
-		reader.Comma = ',' // Use `,` as a delimiter by default
-
-		slog.Debug("Parsing fetched data as CSV", "mime-type", response.MimeType)
-		result, err = utils.ParseCSVContent(ctx, reader)
+	if pagination := params.Args.Blocks.GetFirstMatching("pagination"); pagination != nil {
+		opts, err := parsePaginationOptions(pagination)
 		if err != nil {
 			return nil, diagnostics.Diag{
 				{
 					Severity: hcl.DiagError,
-					Summary:  "Failed to parse CSV content",
+					Summary:  "Failed to parse pagination options",
 					Detail:   err.Error(),
 				},
 			}
 		}
-	} else if response.MimeType == "application/json" {
-
-		slog.Debug("Parsing fetched data as JSON", "mime-type", response.MimeType)
-
-		result, err = plugindata.UnmarshalJSON(response.Body)
+		result, err := fetchPages(ctx, client, req, opts)
 		if err != nil {
 			return nil, diagnostics.Diag{
 				{
 					Severity: hcl.DiagError,
-					Summary:  "Failed to parse JSON content",
+					Summary:  "Failed to fetch data with HTTP request",
 					Detail:   err.Error(),
 				},
 			}
 		}
-	} else {
-		slog.Debug("Returning fetched data as text", "mime-type", response.MimeType)
-		result = plugindata.String(response.Body)
+		return result, nil
+	}
+
+	response, err := sendRequest(ctx, client, req)
+	if err != nil {
+		return nil, diagnostics.Diag{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to fetch data with HTTP request",
+				Detail:   err.Error(),
+			},
+		}
+	}
+	slog.Debug("Response received", "mime_type", response.MimeType, "body_bytes_count", len(response.Body))
+
+	result, err := decodeResponse(ctx, response)
+	if err != nil {
+		return nil, diagnostics.Diag{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse response content",
+				Detail:   err.Error(),
+			},
+		}
 	}
 	return result, nil
 }
+
+func parseHTTPRequest(args *dataspec.Block) (*Request, error) {
+	timeout, err := time.ParseDuration(args.GetAttrVal("timeout").AsString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse a timeout duration value: %w", err)
+	}
+
+	req := &Request{
+		Url:        args.GetAttrVal("url").AsString(),
+		Method:     args.GetAttrVal("method").AsString(),
+		Timeout:    timeout,
+		SkipVerify: args.GetAttrVal("insecure").True(),
+		Headers:    make(map[string]string),
+		Body:       nil,
+	}
+
+	basicAuth := args.Blocks.GetFirstMatching("basic_auth")
+	if basicAuth != nil {
+		req.BasicAuthUsername = StringPtr(basicAuth.GetAttrVal("username").AsString())
+		req.BasicAuthPassword = StringPtr(basicAuth.GetAttrVal("password").AsString())
+	}
+
+	if bearerAuth := args.Blocks.GetFirstMatching("bearer_auth"); bearerAuth != nil {
+		req.BearerToken = StringPtr(bearerAuth.GetAttrVal("token").AsString())
+	}
+
+	if oauth := args.Blocks.GetFirstMatching("oauth2"); oauth != nil {
+		req.OAuth2 = &clientcredentials.Config{
+			ClientID:     oauth.GetAttrVal("client_id").AsString(),
+			ClientSecret: oauth.GetAttrVal("client_secret").AsString(),
+			TokenURL:     oauth.GetAttrVal("token_url").AsString(),
+		}
+		if scopes := oauth.GetAttrVal("scopes"); !scopes.IsNull() {
+			for _, scope := range scopes.AsValueSlice() {
+				req.OAuth2.Scopes = append(req.OAuth2.Scopes, scope.AsString())
+			}
+		}
+		if extra := oauth.GetAttrVal("params"); !extra.IsNull() {
+			req.OAuth2.EndpointParams = url.Values{}
+			for k, v := range extra.AsValueMap() {
+				req.OAuth2.EndpointParams.Set(k, v.AsString())
+			}
+		}
+	}
+
+	if tlsBlock := args.Blocks.GetFirstMatching("tls"); tlsBlock != nil {
+		req.CACertFile = stringAttr(tlsBlock, "ca_cert_file")
+		req.CertFile = stringAttr(tlsBlock, "cert_file")
+		req.KeyFile = stringAttr(tlsBlock, "key_file")
+		if (req.CertFile == "") != (req.KeyFile == "") {
+			return nil, errors.New("both cert_file and key_file must be set for a client certificate")
+		}
+	}
+
+	if retry := args.Blocks.GetFirstMatching("retry"); retry != nil {
+		req.Retry, err = parseRetryOptions(retry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	headers := args.GetAttrVal("headers")
+	if !headers.IsNull() {
+		for k, v := range headers.AsValueMap() {
+			req.Headers[k] = v.AsString()
+		}
+	}
+
+	body := args.GetAttrVal("body")
+	if !body.IsNull() && body.AsString() != "" {
+		req.Body = StringPtr(body.AsString())
+	}
+	return req, nil
+}
+
+func parseRetryOptions(block *dataspec.Block) (opts RetryOptions, err error) {
+	maxRetries, _ := block.GetAttrVal("max_retries").AsBigFloat().Int64()
+	opts.MaxRetries = int(maxRetries)
+	opts.InitialBackoff, err = time.ParseDuration(block.GetAttrVal("initial_backoff").AsString())
+	if err != nil {
+		return opts, fmt.Errorf("failed to parse initial_backoff: %w", err)
+	}
+	opts.MaxBackoff, err = time.ParseDuration(block.GetAttrVal("max_backoff").AsString())
+	if err != nil {
+		return opts, fmt.Errorf("failed to parse max_backoff: %w", err)
+	}
+	opts.NonIdempotent = block.GetAttrVal("non_idempotent").True()
+	return opts, nil
+}
+
+// decodeResponse parses the response content according to its MIME type.
+func decodeResponse(ctx context.Context, response *Response) (plugindata.Data, error) {
+	mimeType := response.MimeType
+	switch {
+	case mimeType == "text/csv":
+		reader := csv.NewReader(bytes.NewBuffer(response.Body))
+		reader.Comma = ',' // Use `,` as a delimiter by default
+
+		slog.Debug("Parsing fetched data as CSV", "mime-type", mimeType)
+		return utils.ParseCSVContent(ctx, reader)
+	case mimeType == "application/json" || strings.HasSuffix(mimeType, "+json"):
+		slog.Debug("Parsing fetched data as JSON", "mime-type", mimeType)
+		return plugindata.UnmarshalJSON(response.Body)
+	case mimeType == "application/x-ndjson" || mimeType == "application/ndjson" ||
+		mimeType == "application/jsonl" || mimeType == "application/x-jsonlines":
+		slog.Debug("Parsing fetched data as NDJSON", "mime-type", mimeType)
+		return utils.ParseNDJSONContent(ctx, bytes.NewReader(response.Body))
+	case mimeType == "application/yaml" || mimeType == "application/x-yaml" ||
+		mimeType == "text/yaml" || mimeType == "text/x-yaml":
+		slog.Debug("Parsing fetched data as YAML", "mime-type", mimeType)
+		return plugindata.UnmarshalYAML(response.Body)
+	case mimeType == "application/xml" || mimeType == "text/xml" || strings.HasSuffix(mimeType, "+xml"):
+		slog.Debug("Parsing fetched data as XML", "mime-type", mimeType)
+		return utils.ParseXMLContent(ctx, bytes.NewReader(response.Body))
+	default:
+		slog.Debug("Returning fetched data as text", "mime-type", mimeType)
+		return plugindata.String(response.Body), nil
+	}
+}
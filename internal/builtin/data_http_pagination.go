@@ -0,0 +1,197 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+const (
+	paginationLink   = "link"
+	paginationCursor = "cursor"
+	paginationOffset = "offset"
+)
+
+type paginationOptions struct {
+	Type        string
+	ItemsField  string
+	MaxPages    int
+	CursorField string
+	CursorParam string
+	OffsetParam string
+	LimitParam  string
+	PageSize    int
+}
+
+func parsePaginationOptions(block *dataspec.Block) (*paginationOptions, error) {
+	maxPages, _ := block.GetAttrVal("max_pages").AsBigFloat().Int64()
+	pageSize, _ := block.GetAttrVal("page_size").AsBigFloat().Int64()
+	opts := &paginationOptions{
+		Type:        block.GetAttrVal("type").AsString(),
+		ItemsField:  stringAttr(block, "items_field"),
+		MaxPages:    int(maxPages),
+		CursorField: stringAttr(block, "cursor_field"),
+		CursorParam: stringAttr(block, "cursor_param"),
+		OffsetParam: stringAttr(block, "offset_param"),
+		LimitParam:  stringAttr(block, "limit_param"),
+		PageSize:    int(pageSize),
+	}
+	if opts.Type == paginationCursor && opts.CursorField == "" {
+		return nil, errors.New("cursor_field is required for cursor pagination")
+	}
+	return opts, nil
+}
+
+// fetchPages fetches the pages of the response and merges their items.
+func fetchPages(ctx context.Context, client *http.Client, req *Request, opts *paginationOptions) (plugindata.List, error) {
+	pageURL, err := url.Parse(req.Url)
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	if opts.Type == paginationOffset {
+		pageURL = withOffset(pageURL, opts, offset)
+	}
+	result := plugindata.List{}
+	for page := 1; ; page++ {
+		pageReq := *req
+		pageReq.Url = pageURL.String()
+		response, err := sendRequest(ctx, client, &pageReq)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		data, err := decodeResponse(ctx, response)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: failed to parse response content: %w", page, err)
+		}
+		items, err := pageItems(data, opts.ItemsField)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		result = append(result, items...)
+
+		var next *url.URL
+		switch opts.Type {
+		case paginationLink:
+			if link := nextLink(response.Header.Values("Link")); link != "" {
+				next, err = pageURL.Parse(link)
+				if err != nil {
+					return nil, fmt.Errorf("page %d: invalid next page link: %w", page, err)
+				}
+				// the credentials and headers of the request must not be sent to another server
+				if next.Scheme != pageURL.Scheme || next.Host != pageURL.Host {
+					return nil, fmt.Errorf("page %d: next page link %q points to another origin", page, next.Redacted())
+				}
+			}
+		case paginationCursor:
+			if cursor := pageCursor(data, opts.CursorField); cursor != "" {
+				next = withQueryParam(pageURL, opts.CursorParam, cursor)
+			}
+		case paginationOffset:
+			if len(items) >= opts.PageSize {
+				offset += len(items)
+				next = withOffset(pageURL, opts, offset)
+			}
+		}
+		if next == nil {
+			return result, nil
+		}
+		if page >= opts.MaxPages {
+			slog.Warn("The limit of pages is reached, the remaining pages are not fetched", "url", req.Url, "max_pages", opts.MaxPages)
+			return result, nil
+		}
+		pageURL = next
+	}
+}
+
+// pageItems returns the list of items of the page.
+func pageItems(data plugindata.Data, field string) (plugindata.List, error) {
+	if field != "" {
+		val, found := plugindata.LookupField(data, field)
+		if !found {
+			return nil, fmt.Errorf("items field %q not found in the response", field)
+		}
+		data = val
+	}
+	switch data := data.(type) {
+	case nil:
+		return nil, nil
+	case plugindata.List:
+		return data, nil
+	default:
+		if field == "" {
+			return nil, errors.New("the response is not a list, set items_field to the path of the list of items")
+		}
+		return nil, fmt.Errorf("items field %q is not a list", field)
+	}
+}
+
+// pageCursor returns the cursor of the next page, or an empty string if there is none.
+func pageCursor(data plugindata.Data, field string) string {
+	val, _ := plugindata.LookupField(data, field)
+	switch val := val.(type) {
+	case plugindata.String:
+		return string(val)
+	case plugindata.Number:
+		return strconv.FormatFloat(float64(val), 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func withOffset(u *url.URL, opts *paginationOptions, offset int) *url.URL {
+	u = withQueryParam(u, opts.OffsetParam, strconv.Itoa(offset))
+	return withQueryParam(u, opts.LimitParam, strconv.Itoa(opts.PageSize))
+}
+
+func withQueryParam(u *url.URL, name, value string) *url.URL {
+	res := *u
+	query := res.Query()
+	query.Set(name, value)
+	res.RawQuery = query.Encode()
+	return &res
+}
+
+// nextLink returns the target of the link with `rel="next"` in the values of `Link` header (RFC 8288).
+func nextLink(values []string) string {
+	for _, value := range values {
+		for value != "" {
+			start := strings.IndexByte(value, '<')
+			if start < 0 {
+				break
+			}
+			end := strings.IndexByte(value[start:], '>')
+			if end < 0 {
+				break
+			}
+			target := value[start+1 : start+end]
+			value = value[start+end+1:]
+			params := value
+			if next := strings.IndexByte(value, '<'); next >= 0 {
+				params, value = value[:next], value[next:]
+			} else {
+				value = ""
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, val, ok := strings.Cut(param, "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(val, "\", \t")) {
+					if strings.EqualFold(rel, "next") {
+						return target
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -2,13 +2,18 @@ package builtin
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -16,9 +21,11 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
 )
 
 // HTTPClientTestSuite is a test suite for contract testing http data source
@@ -312,3 +319,491 @@ func (s *HTTPClientTestSuite) TestFetchHTTPDataUnknownType() {
 	s.Nil(diags, "Error while fetching data: %s", diags)
 	s.Equal(plugindata.String(unknownData), data)
 }
+
+func (s *HTTPClientTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	schema := makeHTTPDataSource("9.9.9")
+	data, diags := schema.DataFunc(s.ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *HTTPClientTestSuite) TestRetry() {
+	attempts := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = %q
+		retry {
+			initial_backoff = "1ms"
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Equal(plugindata.String("ok"), data)
+	s.Equal(3, attempts)
+}
+
+func (s *HTTPClientTestSuite) TestRetryExhausted() {
+	attempts := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		retry {
+			max_retries = 2
+			initial_backoff = "1ms"
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to fetch data with HTTP request"),
+		diagtest.DetailContains("status code 502"),
+	}})
+	s.Equal(3, attempts)
+}
+
+func (s *HTTPClientTestSuite) TestNoRetryOnClientError() {
+	attempts := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		retry {
+			initial_backoff = "1ms"
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("status code 404"),
+	}})
+	s.Equal(1, attempts)
+}
+
+func (s *HTTPClientTestSuite) TestParseRetryAfter() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("120", now)
+	s.True(ok)
+	s.Equal(2*time.Minute, delay)
+
+	delay, ok = parseRetryAfter("Wed, 01 May 2024 12:00:30 GMT", now)
+	s.True(ok)
+	s.Equal(30*time.Second, delay)
+
+	delay, ok = parseRetryAfter("Wed, 01 May 2024 11:00:00 GMT", now)
+	s.True(ok)
+	s.Equal(time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("", now)
+	s.False(ok)
+	_, ok = parseRetryAfter("soon", now)
+	s.False(ok)
+}
+
+func (s *HTTPClientTestSuite) TestBearerAuth() {
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer test-token", r.Header.Get("Authorization"))
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		bearer_auth {
+			token = "test-token"
+		}
+	`, srv.URL), diagtest.Asserts{})
+}
+
+func (s *HTTPClientTestSuite) TestOAuth2() {
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		s.Require().NoError(r.ParseForm())
+		s.Equal("client_credentials", r.PostForm.Get("grant_type"))
+		s.Equal("read write", r.PostForm.Get("scope"))
+		s.Equal("api", r.PostForm.Get("audience"))
+		clientID, clientSecret, _ := r.BasicAuth()
+		s.Equal("test-client", clientID)
+		s.Equal("test-secret", clientSecret)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "test-token", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer test-token", r.Header.Get("Authorization"))
+		w.Header().Set("Link", "</items?page=2>; rel=\"next\"")
+		if r.URL.Query().Get("page") == "2" {
+			w.Header().Del("Link")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 1}]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = "%[1]s/items"
+		oauth2 {
+			token_url = "%[1]s/token"
+			client_id = "test-client"
+			client_secret = "test-secret"
+			scopes = ["read", "write"]
+			params = {
+				audience = "api"
+			}
+		}
+		pagination {
+			type = "link"
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"id": plugindata.Number(1)},
+		plugindata.Map{"id": plugindata.Number(1)},
+	}, data)
+	// the token is reused for the pages
+	s.Equal(1, tokenRequests)
+}
+
+func (s *HTTPClientTestSuite) TestPaginationLink() {
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			w.Header().Add("Link", `<https://example.localhost/items?page=9>; rel="last"`)
+			w.Header().Add("Link", `</items?page=2>; rel="next"`)
+		case "2":
+			w.Header().Set("Link", `</items?page=1>; rel="prev first", </items?page=3>; rel="next"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data": {"items": [{"page": %q}]}}`, page)
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = "%s/items"
+		pagination {
+			type = "link"
+			items_field = "data.items"
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"page": plugindata.String("")},
+		plugindata.Map{"page": plugindata.String("2")},
+		plugindata.Map{"page": plugindata.String("3")},
+	}, data)
+}
+
+func (s *HTTPClientTestSuite) TestPaginationCursor() {
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("open", r.URL.Query().Get("status"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"items": [1, 2], "next": "abc"}`))
+		case "abc":
+			w.Write([]byte(`{"items": [3], "next": null}`))
+		default:
+			s.Fail("unexpected cursor")
+		}
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = "%s/items?status=open"
+		pagination {
+			type = "cursor"
+			items_field = "items"
+			cursor_field = "next"
+			cursor_param = "after"
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Number(1),
+		plugindata.Number(2),
+		plugindata.Number(3),
+	}, data)
+}
+
+func (s *HTTPClientTestSuite) TestPaginationCursorRequiresField() {
+	s.fetch(`
+		url = "http://localhost"
+		pagination {
+			type = "cursor"
+		}
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("cursor_field is required"),
+	}})
+}
+
+func (s *HTTPClientTestSuite) TestPaginationOffset() {
+	items := []string{`{"id": 1}`, `{"id": 2}`, `{"id": 3}`, `{"id": 4}`, `{"id": 5}`}
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("2", r.URL.Query().Get("size"))
+		var offset int
+		fmt.Sscan(r.URL.Query().Get("from"), &offset)
+		end := min(offset+2, len(items))
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(strings.Join(items[offset:end], "\n")))
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = %q
+		pagination {
+			type = "offset"
+			offset_param = "from"
+			limit_param = "size"
+			page_size = 2
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Len(data, 5)
+	s.Equal(plugindata.Map{"id": plugindata.Number(5)}, data.(plugindata.List)[4])
+}
+
+func (s *HTTPClientTestSuite) TestPaginationMaxPages() {
+	requests := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", `</next>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[1]`))
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = %q
+		pagination {
+			type = "link"
+			max_pages = 3
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Len(data, 3)
+	s.Equal(3, requests)
+}
+
+func (s *HTTPClientTestSuite) TestPaginationNotAList() {
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": []}`))
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		pagination {
+			type = "link"
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("page 1", "not a list"),
+	}})
+}
+
+func (s *HTTPClientTestSuite) TestTLS() {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Len(r.TLS.PeerCertificates, 1)
+		w.Write([]byte("ok"))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// the server certificate is self-signed, so it's used as CA and as the client certificate
+	dir := s.T().TempDir()
+	cert := srv.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	s.Require().NoError(err)
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	s.Require().NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	s.Require().NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+
+	data := s.fetch(fmt.Sprintf(`
+		url = %q
+		tls {
+			ca_cert_file = %q
+			cert_file = %q
+			key_file = %q
+		}
+	`, srv.URL, certFile, certFile, keyFile), diagtest.Asserts{})
+	s.Equal(plugindata.String("ok"), data)
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		tls {
+			cert_file = %q
+			key_file = %q
+		}
+	`, srv.URL, certFile, keyFile), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("certificate"),
+	}})
+}
+
+func (s *HTTPClientTestSuite) TestDecodeFormats() {
+	tt := []struct {
+		contentType string
+		body        string
+		expected    plugindata.Data
+	}{
+		{
+			contentType: "application/yaml",
+			body:        "items:\n  - name: a\n    count: 1\n",
+			expected: plugindata.Map{
+				"items": plugindata.List{
+					plugindata.Map{"name": plugindata.String("a"), "count": plugindata.Number(1)},
+				},
+			},
+		},
+		{
+			contentType: "application/x-ndjson",
+			body:        "{\"id\": 1}\n\n{\"id\": 2}\n",
+			expected: plugindata.List{
+				plugindata.Map{"id": plugindata.Number(1)},
+				plugindata.Map{"id": plugindata.Number(2)},
+			},
+		},
+		{
+			contentType: "application/problem+json",
+			body:        `{"title": "Not found"}`,
+			expected:    plugindata.Map{"title": plugindata.String("Not found")},
+		},
+		{
+			contentType: "application/xml; charset=utf-8",
+			body: `<?xml version="1.0"?>
+				<feed xmlns="http://www.w3.org/2005/Atom" lang="en">
+					<title>Alerts</title>
+					<entry id="1"><title>First</title></entry>
+					<entry id="2"><title>Second</title><note/></entry>
+					<link href="/feed">text</link>
+				</feed>`,
+			expected: plugindata.Map{
+				"feed": plugindata.Map{
+					"@lang": plugindata.String("en"),
+					"title": plugindata.String("Alerts"),
+					"entry": plugindata.List{
+						plugindata.Map{"@id": plugindata.String("1"), "title": plugindata.String("First")},
+						plugindata.Map{"@id": plugindata.String("2"), "title": plugindata.String("Second"), "note": nil},
+					},
+					"link": plugindata.Map{"@href": plugindata.String("/feed"), "#text": plugindata.String("text")},
+				},
+			},
+		},
+	}
+	for _, tc := range tt {
+		s.Run(tc.contentType, func() {
+			srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.Write([]byte(tc.body))
+			})
+			defer srv.Close()
+
+			data := s.fetch(fmt.Sprintf(`url = %q`, srv.URL), diagtest.Asserts{})
+			s.Equal(tc.expected, data)
+		})
+	}
+}
+
+func (s *HTTPClientTestSuite) TestNoRetryOnPost() {
+	attempts := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		method = "POST"
+		retry {
+			initial_backoff = "1ms"
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("status code 503"),
+	}})
+	s.Equal(1, attempts)
+
+	attempts = 0
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		method = "POST"
+		retry {
+			max_retries = 1
+			initial_backoff = "1ms"
+			non_idempotent = true
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("status code 503"),
+	}})
+	s.Equal(2, attempts)
+}
+
+func (s *HTTPClientTestSuite) TestRetryAfterCapped() {
+	attempts := 0
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	defer srv.Close()
+
+	data := s.fetch(fmt.Sprintf(`
+		url = %q
+		retry {
+			max_backoff = "1ms"
+		}
+	`, srv.URL), diagtest.Asserts{})
+	s.Equal(plugindata.String("ok"), data)
+	s.Equal(2, attempts)
+}
+
+func (s *HTTPClientTestSuite) TestPaginationLinkOtherOrigin() {
+	other := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("the request must not be sent to another origin")
+	})
+	defer other.Close()
+	srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+other.URL+`/items?page=2>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[1]`))
+	})
+	defer srv.Close()
+
+	s.fetch(fmt.Sprintf(`
+		url = %q
+		bearer_auth {
+			token = "secret"
+		}
+		pagination {
+			type = "link"
+		}
+	`, srv.URL), diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.DetailContains("page 1", "another origin"),
+	}})
+}
@@ -0,0 +1,527 @@
+package builtin
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/builtin/utils"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+const (
+	// logTimeField is the field with the timestamps of the built-in formats.
+	logTimeField = "timestamp"
+	// maxLogLineSize is the maximum length of a line of a log file.
+	maxLogLineSize = 1024 * 1024
+	logSyslogTime  = `(?:%{TIMESTAMP_ISO8601:timestamp}|%{SYSLOGTIMESTAMP:timestamp})`
+)
+
+// logFormats are the grok expressions of the built-in formats.
+var logFormats = map[string]string{
+	"nginx_access":  logAccessFormat,
+	"apache_access": logAccessFormat,
+	"syslog":        `^` + logSyslogTime + ` %{SYSLOGHOST:host} %{SYSLOGPROG}: %{GREEDYDATA:message}`,
+	"sshd": `^` + logSyslogTime + ` %{SYSLOGHOST:host} sshd(?:\[%{POSINT:pid:int}\])?: ` +
+		`%{WORD:event} %{WORD:method} for (?:invalid user )?%{USERNAME:user} from %{IP:source_ip} port %{INT:port:int}`,
+	"iptables": `^` + logSyslogTime + ` %{SYSLOGHOST:host} kernel: (?:\[\s*%{NUMBER}\] )?%{DATA:prefix}\s*` +
+		`IN=%{NOTSPACE:in_interface}? OUT=%{NOTSPACE:out_interface}? .*?SRC=%{IP:source_ip} DST=%{IP:destination_ip} ` +
+		`.*?PROTO=%{WORD:protocol}(?: SPT=%{INT:source_port:int} DPT=%{INT:destination_port:int})?`,
+	"iso8601": `^%{TIMESTAMP_ISO8601:timestamp}\s+(?:\[?%{LOGLEVEL:level}\]?:?\s+)?%{GREEDYDATA:message}`,
+}
+
+const logAccessFormat = `^%{IPORHOST:remote_addr} %{NOTSPACE:ident} %{NOTSPACE:remote_user} \[%{HTTPDATE:timestamp}\] ` +
+	`"(?:%{WORD:method} %{NOTSPACE:path}(?: HTTP/%{NUMBER:http_version})?|%{DATA:request})" ` +
+	`%{INT:status:int} (?:%{INT:bytes:int}|-)(?: "%{DATA:referrer}" "%{DATA:user_agent}")?`
+
+// logTimeLayouts are tried in order to parse the timestamps if no format is provided.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05,999999999",
+	"02/Jan/2006:15:04:05 -0700",
+	time.Stamp,
+	time.StampMicro,
+}
+
+func makeLogDataSource() *plugin.DataSource {
+	formats := make([]string, 0, len(logFormats))
+	for name := range logFormats {
+		formats = append(formats, name)
+	}
+	slices.Sort(formats)
+	formatVals := make([]cty.Value, 0, len(formats))
+	for _, name := range formats {
+		formatVals = append(formatVals, cty.StringVal(name))
+	}
+	return &plugin.DataSource{
+		DataFunc: fetchLogData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("log", "path/to/access.log*", "path/to/access.log"),
+				&dataspec.AttrSpec{
+					Name:       "format",
+					Type:       cty.String,
+					OneOf:      formatVals,
+					ExampleVal: cty.StringVal("nginx_access"),
+					Doc:        `A built-in format of the log lines`,
+				},
+				&dataspec.AttrSpec{
+					Name:       "pattern",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal(`^%{TIMESTAMP_ISO8601:timestamp} %{LOGLEVEL:level} %{GREEDYDATA:message}`),
+					Doc: u.Dedent(`
+						A grok expression or a regular expression with named groups to parse the log lines with.
+						Either ` + "`format`" + ` or ` + "`pattern`" + ` must be set
+					`),
+				},
+				&dataspec.AttrSpec{
+					Name: "patterns",
+					Type: cty.Map(cty.String),
+					ExampleVal: cty.MapVal(map[string]cty.Value{
+						"REQUEST_ID": cty.StringVal(`req-[0-9a-f]+`),
+					}),
+					Doc: `Custom grok patterns that can be referenced in ` + "`pattern`",
+				},
+				&dataspec.AttrSpec{
+					Name:       "time_field",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal(logTimeField),
+					Doc:        `The field with the timestamps of the lines`,
+				},
+				&dataspec.AttrSpec{
+					Name:       "time_format",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("2006-01-02 15:04:05"),
+					Doc: u.Dedent(`
+						A Go layout of the timestamps. If not set, RFC 3339, HTTP access log and syslog timestamps
+						are detected automatically
+					`),
+				},
+				&dataspec.AttrSpec{
+					Name:       "since",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("2024-01-01T00:00:00Z"),
+					Doc:        `Only the lines logged at or after the time in RFC 3339 format are returned`,
+				},
+				&dataspec.AttrSpec{
+					Name:       "until",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("2024-02-01T00:00:00Z"),
+					Doc:        `Only the lines logged before the time in RFC 3339 format are returned`,
+				},
+				&dataspec.AttrSpec{
+					Name:         "tail",
+					Type:         cty.Number,
+					ExampleVal:   cty.NumberIntVal(1000),
+					MinInclusive: cty.NumberIntVal(1),
+					Doc:          `Only the last N parsed lines, of the latest files, are returned`,
+				},
+				&dataspec.AttrSpec{
+					Name:        "strict",
+					Type:        cty.Bool,
+					DefaultVal:  cty.False,
+					Constraints: constraint.NonNull,
+					Doc:         `Fail on the lines that don't match the pattern instead of skipping them`,
+				},
+				&dataspec.AttrSpec{
+					Name:       "group_by",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("remote_addr"), cty.StringVal("status")}),
+					Doc: u.Dedent(`
+						Fields to aggregate the lines by. If set, a row per distinct combination of the values is
+						returned, with ` + "`count`" + `, ` + "`first_seen`" + ` and ` + "`last_seen`" + ` fields, sorted by ` + "`count`" + `
+					`),
+				},
+				&dataspec.AttrSpec{
+					Name:       "sum",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("bytes")}),
+					Doc: u.Dedent(`
+						Numeric fields to sum up when the lines are aggregated. The sums are returned in
+						` + "`<field>_sum`" + ` fields
+					`),
+				},
+			),
+		},
+		Doc: u.Dedent(`
+			Reads log files and parses each line into a row.
+
+			The files are selected with ` + "`glob`" + ` pattern or a single file is read from ` + "`path`" + ` value. Either ` + "`glob`" + ` or
+			` + "`path`" + ` argument must be set. The lines of all matching files are merged into one list, the files are
+			ordered by their first timestamp, so that the rotated files are merged from the oldest to the latest.
+			Gzip-compressed files are decompressed automatically.
+
+			The lines are parsed with a built-in ` + "`format`" + ` or a custom ` + "`pattern`" + `. The patterns are regular expressions
+			with grok-style references to the named patterns: ` + "`%{PATTERN}`" + `, ` + "`%{PATTERN:field}`" + ` or
+			` + "`%{PATTERN:field:int}`" + ` (` + "`int`" + ` and ` + "`float`" + ` fields are converted to numbers). Named groups,
+			` + "`(?P<field>...)`" + `, are captured too. The base patterns include ` + "`WORD`" + `, ` + "`NOTSPACE`" + `, ` + "`DATA`" + `,
+			` + "`GREEDYDATA`" + `, ` + "`INT`" + `, ` + "`NUMBER`" + `, ` + "`IP`" + `, ` + "`IPORHOST`" + `, ` + "`USERNAME`" + `, ` + "`QS`" + `,
+			` + "`LOGLEVEL`" + `, ` + "`UUID`" + `, ` + "`TIMESTAMP_ISO8601`" + `, ` + "`HTTPDATE`" + `, ` + "`SYSLOGTIMESTAMP`" + ` and ` + "`SYSLOGBASE`" + `.
+
+			Note that ` + "`%{`" + ` starts a template directive in HCL strings, so the references must be escaped as
+			` + "`%%{PATTERN:field}`" + `, for example:
+
+			` + "```hcl" + `
+			data log "errors" {
+			  path    = "app.log"
+			  pattern = "^%%{TIMESTAMP_ISO8601:timestamp} %%{LOGLEVEL:level} %%{GREEDYDATA:message}"
+			}
+			` + "```" + `
+
+			The built-in formats are:
+			- ` + "`nginx_access`" + `, ` + "`apache_access`" + ` – combined and common access log formats: ` + "`remote_addr`" + `, ` + "`remote_user`" + `,
+			  ` + "`timestamp`" + `, ` + "`method`" + `, ` + "`path`" + `, ` + "`http_version`" + `, ` + "`status`" + `, ` + "`bytes`" + `, ` + "`referrer`" + `, ` + "`user_agent`" + `
+			- ` + "`syslog`" + ` – ` + "`timestamp`" + `, ` + "`host`" + `, ` + "`program`" + `, ` + "`pid`" + ` and ` + "`message`" + `
+			- ` + "`sshd`" + ` – SSH logins from auth.log: ` + "`timestamp`" + `, ` + "`host`" + `, ` + "`pid`" + `, ` + "`event`" + ` (` + "`Accepted`" + ` or ` + "`Failed`" + `),
+			  ` + "`method`" + `, ` + "`user`" + `, ` + "`source_ip`" + ` and ` + "`port`" + `
+			- ` + "`iptables`" + ` – netfilter kernel logs: ` + "`timestamp`" + `, ` + "`host`" + `, ` + "`prefix`" + `, ` + "`in_interface`" + `, ` + "`out_interface`" + `,
+			  ` + "`source_ip`" + `, ` + "`destination_ip`" + `, ` + "`protocol`" + `, ` + "`source_port`" + ` and ` + "`destination_port`" + `
+			- ` + "`iso8601`" + ` – application logs starting with an ISO 8601 timestamp: ` + "`timestamp`" + `, ` + "`level`" + ` and ` + "`message`" + `
+
+			The values of ` + "`time_field`" + ` are converted to timestamps. The timestamps without a time zone are in UTC,
+			the syslog timestamps without a year are in the last 12 months. With ` + "`since`" + ` or ` + "`until`" + ` set,
+			the lines without a valid timestamp are skipped.
+		`),
+	}
+}
+
+type logOptions struct {
+	grok       *utils.Grok
+	timeField  string
+	timeFormat string
+	since      time.Time
+	until      time.Time
+	tail       int
+	strict     bool
+	groupBy    []string
+	sum        []string
+	now        time.Time
+}
+
+func fetchLogData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	opts, diags := parseLogOptions(params.Args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	// unlike the other file data sources, the rows of the files are merged into one list
+	// instead of being wrapped with the file paths
+	paths, glob, diags := selectFiles(ctx, params.Args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	files := make([]*logFile, 0, len(paths))
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the files",
+				Detail:   err.Error(),
+			}}
+		}
+		slog.DebugContext(ctx, "Reading a log file", "path", path)
+		file, err := readLogFile(path, opts)
+		if err != nil {
+			summary := "Failed to read the file"
+			if glob != "" {
+				summary = "Failed to read the files"
+			}
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  summary,
+				Detail:   fmt.Sprintf("%s: %s", path, err),
+			}}
+		}
+		files = append(files, file)
+	}
+	// the rotated files are merged from the oldest to the latest, so that tail returns the latest lines
+	slices.SortStableFunc(files, func(a, b *logFile) int {
+		return a.firstSeen.Compare(b.firstSeen)
+	})
+	tail := &logTail{limit: opts.tail}
+	for _, file := range files {
+		for _, row := range file.rows {
+			tail.add(row)
+		}
+	}
+	rows := tail.list()
+	if len(opts.groupBy) > 0 {
+		return aggregateLogRows(rows, opts), nil
+	}
+	return rows, nil
+}
+
+func parseLogOptions(args *dataspec.Block) (*logOptions, diagnostics.Diag) {
+	format := stringAttr(args, "format")
+	pattern := stringAttr(args, "pattern")
+	switch {
+	case format != "" && pattern != "":
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Either \"format\" or \"pattern\" must be provided, not both",
+		}}
+	case format != "":
+		pattern = logFormats[format]
+	case pattern == "":
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Either \"format\" value or \"pattern\" value must be provided",
+		}}
+	}
+	custom := map[string]string{}
+	if val := args.GetAttrVal("patterns"); !val.IsNull() {
+		for name, p := range val.AsValueMap() {
+			custom[name] = p.AsString()
+		}
+	}
+	grok, err := utils.CompileGrok(pattern, custom)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   fmt.Sprintf("Invalid pattern: %s", err),
+		}}
+	}
+	opts := &logOptions{
+		grok:       grok,
+		timeField:  stringAttr(args, "time_field"),
+		timeFormat: stringAttr(args, "time_format"),
+		strict:     args.GetAttrVal("strict").True(),
+		now:        time.Now().UTC(),
+	}
+	for name, dst := range map[string]*time.Time{"since": &opts.since, "until": &opts.until} {
+		val := stringAttr(args, name)
+		if val == "" {
+			continue
+		}
+		*dst, err = time.Parse(time.RFC3339, val)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse provided arguments",
+				Detail:   fmt.Sprintf("Invalid %q value: %s", name, err),
+			}}
+		}
+	}
+	if val := args.GetAttrVal("tail"); !val.IsNull() {
+		n, _ := val.AsBigFloat().Int64()
+		opts.tail = int(n)
+	}
+	for _, name := range []string{"group_by", "sum"} {
+		val := args.GetAttrVal(name)
+		if val.IsNull() {
+			continue
+		}
+		for _, field := range val.AsValueSlice() {
+			if name == "group_by" {
+				opts.groupBy = append(opts.groupBy, field.AsString())
+			} else {
+				opts.sum = append(opts.sum, field.AsString())
+			}
+		}
+	}
+	return opts, nil
+}
+
+// logFile holds the parsed lines of a log file.
+type logFile struct {
+	rows plugindata.List
+	// firstSeen is the first timestamp of the lines, zero if none of them has one
+	firstSeen time.Time
+}
+
+// logTail keeps the last lines added to it, or all of them if the limit is 0.
+type logTail struct {
+	limit int
+	rows  plugindata.List
+	// next is the index of the oldest line once the buffer is full
+	next int
+}
+
+func (t *logTail) add(row plugindata.Data) {
+	if t.limit == 0 || len(t.rows) < t.limit {
+		t.rows = append(t.rows, row)
+		return
+	}
+	t.rows[t.next] = row
+	t.next = (t.next + 1) % t.limit
+}
+
+func (t *logTail) list() plugindata.List {
+	return slices.Concat(plugindata.List{}, t.rows[t.next:], t.rows[:t.next])
+}
+
+func readLogFile(path string, opts *logOptions) (*logFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReader(f)
+	if magic, _ := r.(*bufio.Reader).Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	file := &logFile{}
+	tail := &logTail{limit: opts.tail}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		row, ok := opts.grok.Parse(line)
+		if !ok {
+			if opts.strict {
+				return nil, fmt.Errorf("line %d doesn't match the pattern", n)
+			}
+			continue
+		}
+		ts, ok := parseLogTime(row[opts.timeField], opts)
+		if ok {
+			row[opts.timeField] = plugindata.Time(ts)
+		}
+		if !opts.since.IsZero() || !opts.until.IsZero() {
+			if !ok || (!opts.since.IsZero() && ts.Before(opts.since)) || (!opts.until.IsZero() && !ts.Before(opts.until)) {
+				continue
+			}
+		}
+		if ok && file.firstSeen.IsZero() {
+			file.firstSeen = ts
+		}
+		tail.add(row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	file.rows = tail.list()
+	return file, nil
+}
+
+func parseLogTime(val plugindata.Data, opts *logOptions) (time.Time, bool) {
+	s, ok := val.(plugindata.String)
+	if !ok {
+		return time.Time{}, false
+	}
+	layouts := logTimeLayouts
+	if opts.timeFormat != "" {
+		layouts = []string{opts.timeFormat}
+	}
+	for _, layout := range layouts {
+		ts, err := time.ParseInLocation(layout, string(s), time.UTC)
+		if err != nil {
+			continue
+		}
+		if ts.Year() == 0 {
+			ts = logTimeWithYear(ts, opts.now)
+		}
+		return ts, true
+	}
+	return time.Time{}, false
+}
+
+// logTimeWithYear sets the year of the timestamp without one, such as in syslog,
+// to the latest year that doesn't put the timestamp in the future.
+func logTimeWithYear(ts, now time.Time) time.Time {
+	ts = ts.AddDate(now.Year(), 0, 0)
+	// allow for the clock skew between the hosts
+	if ts.After(now.Add(24 * time.Hour)) {
+		ts = ts.AddDate(-1, 0, 0)
+	}
+	return ts
+}
+
+type logGroup struct {
+	row       plugindata.Map
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+	sums      []float64
+}
+
+func aggregateLogRows(rows plugindata.List, opts *logOptions) plugindata.List {
+	var groups []*logGroup
+	index := map[string]*logGroup{}
+	for _, item := range rows {
+		row := item.(plugindata.Map)
+		var key strings.Builder
+		for _, field := range opts.groupBy {
+			fmt.Fprintf(&key, "%#v\x00", row[field])
+		}
+		group, ok := index[key.String()]
+		if !ok {
+			group = &logGroup{
+				row:  plugindata.Map{},
+				sums: make([]float64, len(opts.sum)),
+			}
+			for _, field := range opts.groupBy {
+				group.row[field] = row[field]
+			}
+			index[key.String()] = group
+			groups = append(groups, group)
+		}
+		group.count++
+		if ts, ok := row[opts.timeField].(plugindata.Time); ok {
+			t := time.Time(ts)
+			if group.firstSeen.IsZero() || t.Before(group.firstSeen) {
+				group.firstSeen = t
+			}
+			if t.After(group.lastSeen) {
+				group.lastSeen = t
+			}
+		}
+		for i, field := range opts.sum {
+			if n, ok := row[field].(plugindata.Number); ok {
+				group.sums[i] += float64(n)
+			}
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b *logGroup) int {
+		return b.count - a.count
+	})
+
+	result := make(plugindata.List, 0, len(groups))
+	for _, group := range groups {
+		row := group.row
+		row["count"] = plugindata.Number(group.count)
+		row["first_seen"] = nil
+		row["last_seen"] = nil
+		if !group.firstSeen.IsZero() {
+			row["first_seen"] = plugindata.Time(group.firstSeen)
+			row["last_seen"] = plugindata.Time(group.lastSeen)
+		}
+		for i, field := range opts.sum {
+			row[field+"_sum"] = plugindata.Number(group.sums[i])
+		}
+		result = append(result, row)
+	}
+	return result
+}
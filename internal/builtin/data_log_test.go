@@ -0,0 +1,360 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type LogDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestLogDataSourceSuite(t *testing.T) {
+	suite.Run(t, &LogDataSourceTestSuite{})
+}
+
+func (s *LogDataSourceTestSuite) SetupSuite() {
+	s.schema = makeLogDataSource()
+}
+
+func (s *LogDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *LogDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func logTime(value string) plugindata.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		panic(err)
+	}
+	return plugindata.Time(t)
+}
+
+func (s *LogDataSourceTestSuite) TestAccessLog() {
+	data := s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"remote_addr":  plugindata.String("10.0.0.1"),
+			"ident":        plugindata.String("-"),
+			"remote_user":  plugindata.String("-"),
+			"timestamp":    logTime("2024-03-10T13:55:36Z"),
+			"method":       plugindata.String("GET"),
+			"path":         plugindata.String("/index.html"),
+			"http_version": plugindata.String("1.1"),
+			"request":      nil,
+			"status":       plugindata.Number(200),
+			"bytes":        plugindata.Number(2326),
+			"referrer":     plugindata.String("-"),
+			"user_agent":   plugindata.String("Mozilla/5.0"),
+		},
+		plugindata.Map{
+			"remote_addr":  plugindata.String("10.0.0.2"),
+			"ident":        plugindata.String("-"),
+			"remote_user":  plugindata.String("alice"),
+			"timestamp":    logTime("2024-03-10T13:56:01Z"),
+			"method":       plugindata.String("POST"),
+			"path":         plugindata.String("/login"),
+			"http_version": plugindata.String("1.1"),
+			"request":      nil,
+			"status":       plugindata.Number(401),
+			"bytes":        plugindata.Number(120),
+			"referrer":     plugindata.String("https://example.com/"),
+			"user_agent":   plugindata.String("curl/8.5.0"),
+		},
+		plugindata.Map{
+			"remote_addr":  plugindata.String("10.0.0.1"),
+			"ident":        plugindata.String("-"),
+			"remote_user":  plugindata.String("-"),
+			"timestamp":    logTime("2024-03-10T14:01:12Z"),
+			"method":       plugindata.String("GET"),
+			"path":         plugindata.String("/admin"),
+			"http_version": plugindata.String("1.1"),
+			"request":      nil,
+			"status":       plugindata.Number(403),
+			"bytes":        nil,
+			"referrer":     plugindata.String("-"),
+			"user_agent":   plugindata.String("Mozilla/5.0"),
+		},
+		plugindata.Map{
+			"remote_addr":  plugindata.String("10.0.0.3"),
+			"ident":        plugindata.String("-"),
+			"remote_user":  plugindata.String("-"),
+			"timestamp":    logTime("2024-03-10T15:30:00Z"),
+			"method":       nil,
+			"path":         nil,
+			"http_version": nil,
+			"request":      plugindata.String(`\x16\x03\x01`),
+			"status":       plugindata.Number(400),
+			"bytes":        plugindata.Number(157),
+			"referrer":     nil,
+			"user_agent":   nil,
+		},
+	}, data)
+}
+
+func (s *LogDataSourceTestSuite) TestGlobGzipTailAndTimeRange() {
+	data := s.fetch(`
+		glob = "testdata/log/access.log*"
+		format = "apache_access"
+		since = "2024-03-09T00:00:00Z"
+		until = "2024-03-10T14:00:00Z"
+	`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Require().Len(list, 3)
+	// the rotated file is merged first
+	s.Equal(plugindata.String("/robots.txt"), list[0].(plugindata.Map)["path"])
+	s.Equal(plugindata.String("/index.html"), list[1].(plugindata.Map)["path"])
+	s.Equal(plugindata.String("/login"), list[2].(plugindata.Map)["path"])
+
+	data = s.fetch(`
+		glob = "testdata/log/access.log*"
+		format = "nginx_access"
+		tail = 2
+	`, diagtest.Asserts{})
+	list = data.(plugindata.List)
+	s.Require().Len(list, 2)
+	s.Equal(plugindata.String("/admin"), list[0].(plugindata.Map)["path"])
+	s.Equal(plugindata.String("10.0.0.3"), list[1].(plugindata.Map)["remote_addr"])
+
+	data = s.fetch(`
+		glob = "testdata/log/access.log*"
+		format = "nginx_access"
+		tail = 5
+	`, diagtest.Asserts{})
+	list = data.(plugindata.List)
+	s.Require().Len(list, 5)
+	s.Equal(plugindata.String("/robots.txt"), list[0].(plugindata.Map)["path"])
+	s.Equal(plugindata.String("10.0.0.3"), list[4].(plugindata.Map)["remote_addr"])
+
+	data = s.fetch(`
+		path = "testdata/log/access.log.1.gz"
+		format = "nginx_access"
+	`, diagtest.Asserts{})
+	s.Equal(logTime("2024-03-09T23:59:59Z"), data.(plugindata.List)[0].(plugindata.Map)["timestamp"])
+
+	data = s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+		tail = 2
+	`, diagtest.Asserts{})
+	list = data.(plugindata.List)
+	s.Require().Len(list, 2)
+	s.Equal(plugindata.String("10.0.0.1"), list[0].(plugindata.Map)["remote_addr"])
+	s.Equal(plugindata.String("10.0.0.3"), list[1].(plugindata.Map)["remote_addr"])
+}
+
+func (s *LogDataSourceTestSuite) TestGroupBy() {
+	data := s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+		group_by = ["remote_addr"]
+		sum = ["bytes"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"remote_addr": plugindata.String("10.0.0.1"),
+			"count":       plugindata.Number(2),
+			"first_seen":  logTime("2024-03-10T13:55:36Z"),
+			"last_seen":   logTime("2024-03-10T14:01:12Z"),
+			"bytes_sum":   plugindata.Number(2326),
+		},
+		plugindata.Map{
+			"remote_addr": plugindata.String("10.0.0.2"),
+			"count":       plugindata.Number(1),
+			"first_seen":  logTime("2024-03-10T13:56:01Z"),
+			"last_seen":   logTime("2024-03-10T13:56:01Z"),
+			"bytes_sum":   plugindata.Number(120),
+		},
+		plugindata.Map{
+			"remote_addr": plugindata.String("10.0.0.3"),
+			"count":       plugindata.Number(1),
+			"first_seen":  logTime("2024-03-10T15:30:00Z"),
+			"last_seen":   logTime("2024-03-10T15:30:00Z"),
+			"bytes_sum":   plugindata.Number(157),
+		},
+	}, data)
+}
+
+func (s *LogDataSourceTestSuite) TestSyslogFormats() {
+	now := time.Now().UTC()
+	ts, err := time.Parse(time.Stamp, "Mar 10 08:01:02")
+	s.Require().NoError(err)
+
+	data := s.fetch(`
+		path = "testdata/log/auth.log"
+		format = "sshd"
+		group_by = ["event", "source_ip"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"event":      plugindata.String("Failed"),
+			"source_ip":  plugindata.String("203.0.113.7"),
+			"count":      plugindata.Number(2),
+			"first_seen": plugindata.Time(logTimeWithYear(ts, now)),
+			"last_seen":  plugindata.Time(logTimeWithYear(ts.Add(3*time.Second), now)),
+		},
+		plugindata.Map{
+			"event":      plugindata.String("Accepted"),
+			"source_ip":  plugindata.String("198.51.100.4"),
+			"count":      plugindata.Number(1),
+			"first_seen": plugindata.Time(logTimeWithYear(ts.Add(58*time.Second), now)),
+			"last_seen":  plugindata.Time(logTimeWithYear(ts.Add(58*time.Second), now)),
+		},
+	}, data)
+
+	data = s.fetch(`
+		path = "testdata/log/auth.log"
+		format = "syslog"
+	`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Require().Len(list, 5)
+	s.Equal(plugindata.String("CRON"), list[4].(plugindata.Map)["program"])
+	s.Equal(plugindata.Number(1300), list[4].(plugindata.Map)["pid"])
+	s.Equal(plugindata.String("pam_unix(cron:session): session closed for user root"), list[4].(plugindata.Map)["message"])
+
+	data = s.fetch(`
+		path = "testdata/log/kern.log"
+		format = "iptables"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"timestamp":        logTime("2024-03-10T09:00:00Z"),
+			"host":             plugindata.String("fw1"),
+			"prefix":           plugindata.String("DROP"),
+			"in_interface":     plugindata.String("eth0"),
+			"out_interface":    nil,
+			"source_ip":        plugindata.String("203.0.113.7"),
+			"destination_ip":   plugindata.String("10.0.0.5"),
+			"protocol":         plugindata.String("TCP"),
+			"source_port":      plugindata.Number(51234),
+			"destination_port": plugindata.Number(22),
+		},
+		plugindata.Map{
+			"timestamp":        logTime("2024-03-10T09:00:05Z"),
+			"host":             plugindata.String("fw1"),
+			"prefix":           plugindata.String("ACCEPT"),
+			"in_interface":     plugindata.String("eth1"),
+			"out_interface":    plugindata.String("eth0"),
+			"source_ip":        plugindata.String("10.0.0.5"),
+			"destination_ip":   plugindata.String("8.8.8.8"),
+			"protocol":         plugindata.String("ICMP"),
+			"source_port":      nil,
+			"destination_port": nil,
+		},
+	}, data)
+}
+
+func (s *LogDataSourceTestSuite) TestCustomPattern() {
+	data := s.fetch(`
+		path = "testdata/log/app.log"
+		pattern = "^%%{TIMESTAMP_ISO8601:time} \\[?%%{LOGLEVEL:level}\\]?\\s+(?P<message>.*?) %%{REQUEST_ID:request_id}$"
+		patterns = {
+			REQUEST_ID = "req-[0-9a-f]+"
+		}
+		time_field = "time"
+		time_format = "2006-01-02 15:04:05.000"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"time":       logTime("2024-03-10T10:00:00.123Z"),
+			"level":      plugindata.String("INFO"),
+			"message":    plugindata.String("service started"),
+			"request_id": plugindata.String("req-1a2b"),
+		},
+		plugindata.Map{
+			"time":       logTime("2024-03-10T10:00:01.5Z"),
+			"level":      plugindata.String("ERROR"),
+			"message":    plugindata.String("database unavailable"),
+			"request_id": plugindata.String("req-3c4d"),
+		},
+	}, data)
+
+	data = s.fetch(`
+		path = "testdata/log/app.log"
+		format = "iso8601"
+	`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Require().Len(list, 3)
+	s.Equal(plugindata.Map{
+		"timestamp": logTime("2024-03-10T10:00:02Z"),
+		"level":     nil,
+		"message":   plugindata.String("no level here"),
+	}, list[2])
+}
+
+func (s *LogDataSourceTestSuite) TestErrors() {
+	s.fetch(`path = "testdata/log/access.log"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Either \"format\" value or \"pattern\" value must be provided"),
+	}})
+	s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+		pattern = ".*"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Either \"format\" or \"pattern\" must be provided, not both"),
+	}})
+	s.fetch(`format = "syslog"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Either \"glob\" value or \"path\" value must be provided"),
+	}})
+	s.fetch(`
+		path = "testdata/log/access.log"
+		pattern = "%%{MISSING:field}"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains(`unknown pattern "MISSING"`),
+	}})
+	s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+		since = "yesterday"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains(`Invalid "since" value`),
+	}})
+	s.fetch(`
+		path = "testdata/log/access.log"
+		format = "nginx_access"
+		strict = true
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailEquals("testdata/log/access.log: line 5 doesn't match the pattern"),
+	}})
+	s.fetch(`
+		path = "testdata/log/missing.log"
+		format = "syslog"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+}
@@ -0,0 +1,549 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeOpenAPIDataSource(version string) *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchOpenAPIDataWrapper(version),
+		Config: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "spec",
+					Type:        cty.String,
+					ExampleVal:  cty.StringVal("path/to/openapi.yaml"),
+					Constraints: constraint.RequiredMeaningful,
+					Doc:         "Path to OpenAPI 3 document, in YAML or JSON format",
+				},
+				{
+					Name:       "base_url",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("https://api.example.localhost/v1"),
+					Doc:        "Base URL of the API. Defaults to the URL of the first server in the document",
+				},
+				{
+					Name:       "timeout",
+					Type:       cty.String,
+					DefaultVal: cty.StringVal("30s"),
+					Doc:        "The duration of a timeout for a request",
+				},
+				{
+					Name:   "api_key",
+					Type:   cty.String,
+					Secret: true,
+					Doc:    "API key for `apiKey` security schemes",
+				},
+				{
+					Name:   "bearer_token",
+					Type:   cty.String,
+					Secret: true,
+					Doc:    "Token for `http` security schemes with `bearer` scheme",
+				},
+				{
+					Name: "username",
+					Type: cty.String,
+					Doc:  "Username for `http` security schemes with `basic` scheme",
+				},
+				{
+					Name:   "password",
+					Type:   cty.String,
+					Secret: true,
+					Doc:    "Password for `http` security schemes with `basic` scheme",
+				},
+				{
+					Name: "client_id",
+					Type: cty.String,
+					Doc:  "Client ID for `oauth2` security schemes with client credentials flow",
+				},
+				{
+					Name:   "client_secret",
+					Type:   cty.String,
+					Secret: true,
+					Doc:    "Client secret for `oauth2` security schemes with client credentials flow",
+				},
+			},
+		},
+		Args: &dataspec.RootSpec{
+			Blocks: []*dataspec.BlockSpec{
+				makeHTTPRetryBlockSpec(),
+				makeHTTPPaginationBlockSpec(),
+			},
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "operation_id",
+					Type:        cty.String,
+					ExampleVal:  cty.StringVal("listIssues"),
+					Constraints: constraint.RequiredMeaningful,
+					Doc:         "ID of the operation to call",
+				},
+				{
+					Name: "parameters",
+					Type: cty.DynamicPseudoType,
+					ExampleVal: cty.ObjectVal(map[string]cty.Value{
+						"project_id": cty.StringVal("fabric"),
+						"state":      cty.StringVal("open"),
+					}),
+					Doc: "Parameters of the operation: path, query, header and cookie ones. " +
+						"The values are validated against the schemas of the parameters",
+				},
+				{
+					Name: "body",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc:  "Request body, sent as JSON",
+				},
+			},
+		},
+		Doc: u.Dedent(`
+			Calls an operation of a REST API described with OpenAPI 3 document.
+
+			The operation is found by its ` + "`operationId`" + `. The parameters are validated against their schemas
+			in the document: the types, the required parameters, enums and ranges.
+
+			The credentials set in the configuration are used according to the security requirements
+			of the operation. Supported security schemes are ` + "`http`" + ` with ` + "`basic`" + ` and ` + "`bearer`" + ` schemes,
+			` + "`apiKey`" + ` and ` + "`oauth2`" + ` with client credentials flow.
+
+			The responses are parsed the same way as in ` + "`http`" + ` data source. The pages of the response are
+			fetched and merged into a single list if the operation has pagination hints: ` + "`Link`" + ` header
+			in the successful response, or ` + "`x-pagination`" + ` extension of the operation or the document with the
+			same options as ` + "`pagination`" + ` block. The block, if set, overrides the hints.
+		`),
+	}
+}
+
+func fetchOpenAPIDataWrapper(version string) plugin.RetrieveDataFunc {
+	return func(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+		return fetchOpenAPIData(ctx, params, version)
+	}
+}
+
+func fetchOpenAPIData(ctx context.Context, params *plugin.RetrieveDataParams, version string) (plugindata.Data, diagnostics.Diag) {
+	doc, err := loadOpenAPIDocument(params.Config.GetAttrVal("spec").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to load OpenAPI document",
+			Detail:   err.Error(),
+		}}
+	}
+	op, err := doc.findOperation(params.Args.GetAttrVal("operation_id").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to find the operation",
+			Detail:   err.Error(),
+		}}
+	}
+	req, diags := makeOpenAPIRequest(doc, op, params.Config, params.Args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	req.Headers["User-Agent"] = fmt.Sprintf("fabric-data-openapi/%s", version)
+	if retry := params.Args.Blocks.GetFirstMatching("retry"); retry != nil {
+		req.Retry, err = parseRetryOptions(retry)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse retry options",
+				Detail:   err.Error(),
+			}}
+		}
+	}
+
+	var pagination *paginationOptions
+	if block := params.Args.Blocks.GetFirstMatching("pagination"); block != nil {
+		pagination, err = parsePaginationOptions(block)
+	} else if op.Pagination != nil {
+		pagination, err = hintedPaginationOptions(op.Pagination)
+	}
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse pagination options",
+			Detail:   err.Error(),
+		}}
+	}
+
+	client, err := newHTTPClient(ctx, req)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to create HTTP client",
+			Detail:   err.Error(),
+		}}
+	}
+	var result plugindata.Data
+	if pagination != nil {
+		result, err = fetchPages(ctx, client, req, pagination)
+	} else {
+		var response *Response
+		response, err = sendRequest(ctx, client, req)
+		if err == nil {
+			result, err = decodeResponse(ctx, response)
+		}
+	}
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to call the operation",
+			Detail:   err.Error(),
+		}}
+	}
+	return result, nil
+}
+
+// makeOpenAPIRequest validates the parameters and builds the request of the operation.
+func makeOpenAPIRequest(doc *openAPIDocument, op *openAPIResolvedOperation, cfg, args *dataspec.Block) (_ *Request, diags diagnostics.Diag) {
+	baseURL := stringAttr(cfg, "base_url")
+	if baseURL == "" && len(doc.Servers) > 0 {
+		baseURL = doc.Servers[0].URL
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || !base.IsAbs() {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid base URL",
+			Detail:   fmt.Sprintf("The base URL %q is not an absolute URL, set base_url in the configuration", baseURL),
+		}}
+	}
+	timeout, err := time.ParseDuration(cfg.GetAttrVal("timeout").AsString())
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse a timeout duration value",
+			Detail:   err.Error(),
+		}}
+	}
+
+	values, diags := validateOpenAPIParams(op, args.GetAttrVal("parameters"))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	path := op.Path
+	query := url.Values{}
+	req := &Request{
+		Method:  op.Method,
+		Timeout: timeout,
+		Headers: map[string]string{},
+	}
+	var cookies []string
+	for _, param := range op.Parameters {
+		val, found := values[param.Name]
+		if !found {
+			continue
+		}
+		strs, err := paramStrings(val)
+		if err != nil {
+			diags.Add("Invalid parameter value", fmt.Sprintf("Parameter %q: %s", param.Name, err))
+			continue
+		}
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(strings.Join(strs, ",")))
+		case "query":
+			query[param.Name] = strs
+		case "header":
+			req.Headers[param.Name] = strings.Join(strs, ",")
+		case "cookie":
+			cookies = append(cookies, param.Name+"="+strings.Join(strs, ","))
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body := args.GetAttrVal("body")
+	switch {
+	case !body.IsNull() && !op.HasBody:
+		diags.Add("Unexpected request body", "The operation has no request body")
+	case !body.IsNull():
+		data, err := plugindata.Encapsulated.FromCty(body)
+		if err != nil {
+			diags.Add("Invalid request body", err.Error())
+			break
+		}
+		var raw []byte
+		if data != nil && *data != nil {
+			raw, err = json.Marshal((*data).Any())
+		} else {
+			raw = []byte("null")
+		}
+		if err != nil {
+			diags.Add("Invalid request body", err.Error())
+			break
+		}
+		req.Body = StringPtr(string(raw))
+		req.Headers["Content-Type"] = "application/json"
+	case op.BodyRequired:
+		diags.Add("Missing request body", "The operation requires a request body")
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	err = applyOpenAPISecurity(doc, op, cfg, base, req, query, &cookies)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to authenticate the request",
+			Detail:   err.Error(),
+		}}
+	}
+	if len(cookies) > 0 {
+		req.Headers["Cookie"] = strings.Join(cookies, "; ")
+	}
+
+	// the values in the path are escaped already
+	target, err := url.Parse(strings.TrimSuffix(base.String(), "/") + path)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid operation URL",
+			Detail:   err.Error(),
+		}}
+	}
+	if len(query) > 0 {
+		target.RawQuery = query.Encode()
+	}
+	req.Url = target.String()
+	return req, diags
+}
+
+// validateOpenAPIParams validates the values of the parameters against the specs made from their schemas.
+func validateOpenAPIParams(op *openAPIResolvedOperation, params cty.Value) (map[string]cty.Value, diagnostics.Diag) {
+	var diags diagnostics.Diag
+	given := map[string]cty.Value{}
+	if !params.IsNull() {
+		if !params.Type().IsObjectType() && !params.Type().IsMapType() {
+			diags.Add("Invalid parameters", "The parameters must be an object")
+			return nil, diags
+		}
+		given = params.AsValueMap()
+	}
+	known := map[string]bool{}
+	values := map[string]cty.Value{}
+	for _, param := range op.Parameters {
+		known[param.Name] = true
+		spec := paramAttrSpec(param)
+		val, found := given[param.Name]
+		if !found || val.IsNull() {
+			if param.Required {
+				diags.Extend(spec.ValidateValue(cty.NullVal(spec.Type)))
+			}
+			continue
+		}
+		val, err := convert.Convert(val, spec.Type)
+		if err != nil {
+			diags.Add("Incorrect parameter value type", fmt.Sprintf("Parameter %q: %s", param.Name, err))
+			continue
+		}
+		if diags.Extend(spec.ValidateValue(val)) {
+			continue
+		}
+		values[param.Name] = val
+	}
+	var unknown []string
+	for name := range given {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		diags.Add("Unknown parameters", fmt.Sprintf("The operation has no parameters %s", strings.Join(unknown, ", ")))
+	}
+	return values, diags
+}
+
+// paramStrings formats the value of the parameter, the lists are formatted element-wise.
+func paramStrings(val cty.Value) ([]string, error) {
+	if val.Type().IsListType() || val.Type().IsTupleType() || val.Type().IsSetType() {
+		var res []string
+		for _, el := range val.AsValueSlice() {
+			str, err := paramString(el)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, str)
+		}
+		return res, nil
+	}
+	str, err := paramString(val)
+	if err != nil {
+		return nil, err
+	}
+	return []string{str}, nil
+}
+
+func paramString(val cty.Value) (string, error) {
+	switch val.Type() {
+	case cty.String:
+		return val.AsString(), nil
+	case cty.Number:
+		return val.AsBigFloat().Text('f', -1), nil
+	case cty.Bool:
+		if val.True() {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		raw, err := ctyjson.Marshal(val, val.Type())
+		return string(raw), err
+	}
+}
+
+// applyOpenAPISecurity authenticates the request with the first security requirement of the operation
+// satisfied by the credentials in the configuration.
+func applyOpenAPISecurity(doc *openAPIDocument, op *openAPIResolvedOperation, cfg *dataspec.Block, base *url.URL, req *Request, query url.Values, cookies *[]string) error {
+	if len(op.Security) == 0 {
+		return nil
+	}
+	var names []string
+	for _, requirement := range op.Security {
+		if !openAPISecuritySatisfied(doc, requirement, cfg) {
+			for name := range requirement {
+				names = append(names, name)
+			}
+			continue
+		}
+		for name, scopes := range requirement {
+			scheme := doc.Components.SecuritySchemes[name]
+			switch scheme.Type {
+			case "http":
+				if strings.EqualFold(scheme.Scheme, "basic") {
+					req.BasicAuthUsername = StringPtr(stringAttr(cfg, "username"))
+					req.BasicAuthPassword = StringPtr(stringAttr(cfg, "password"))
+				} else {
+					req.BearerToken = StringPtr(stringAttr(cfg, "bearer_token"))
+				}
+			case "apiKey":
+				apiKey := stringAttr(cfg, "api_key")
+				switch scheme.In {
+				case "header":
+					req.Headers[scheme.Name] = apiKey
+				case "query":
+					query.Set(scheme.Name, apiKey)
+				case "cookie":
+					*cookies = append(*cookies, scheme.Name+"="+apiKey)
+				}
+			case "oauth2":
+				tokenURL, err := base.Parse(scheme.Flows.ClientCredentials.TokenURL)
+				if err != nil {
+					return fmt.Errorf("invalid token URL of security scheme %q: %w", name, err)
+				}
+				req.OAuth2 = &clientcredentials.Config{
+					ClientID:     stringAttr(cfg, "client_id"),
+					ClientSecret: stringAttr(cfg, "client_secret"),
+					TokenURL:     tokenURL.String(),
+					Scopes:       scopes,
+				}
+			}
+		}
+		slog.Debug("Using security requirement of the operation", "path", op.Path, "schemes", len(requirement))
+		return nil
+	}
+	sort.Strings(names)
+	return errors.New("no credentials configured for the security schemes of the operation: " + strings.Join(names, ", "))
+}
+
+func openAPISecuritySatisfied(doc *openAPIDocument, requirement map[string][]string, cfg *dataspec.Block) bool {
+	for name := range requirement {
+		scheme := doc.Components.SecuritySchemes[name]
+		if scheme == nil {
+			return false
+		}
+		var attrs []string
+		switch scheme.Type {
+		case "http":
+			switch strings.ToLower(scheme.Scheme) {
+			case "basic":
+				attrs = []string{"username", "password"}
+			case "bearer":
+				attrs = []string{"bearer_token"}
+			default:
+				return false
+			}
+		case "apiKey":
+			if scheme.In != "header" && scheme.In != "query" && scheme.In != "cookie" {
+				return false
+			}
+			attrs = []string{"api_key"}
+		case "oauth2":
+			if scheme.Flows.ClientCredentials == nil {
+				return false
+			}
+			attrs = []string{"client_id", "client_secret"}
+		default:
+			return false
+		}
+		for _, attr := range attrs {
+			if stringAttr(cfg, attr) == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hintedPaginationOptions makes the pagination options from the hints in the document,
+// with the defaults of the pagination block.
+func hintedPaginationOptions(hint *openAPIPagination) (*paginationOptions, error) {
+	opts := &paginationOptions{
+		Type:        hint.Type,
+		ItemsField:  hint.ItemsField,
+		MaxPages:    10,
+		CursorField: hint.CursorField,
+		CursorParam: "cursor",
+		OffsetParam: "offset",
+		LimitParam:  "limit",
+		PageSize:    100,
+	}
+	if hint.MaxPages > 0 {
+		opts.MaxPages = hint.MaxPages
+	}
+	if hint.CursorParam != "" {
+		opts.CursorParam = hint.CursorParam
+	}
+	if hint.OffsetParam != "" {
+		opts.OffsetParam = hint.OffsetParam
+	}
+	if hint.LimitParam != "" {
+		opts.LimitParam = hint.LimitParam
+	}
+	if hint.PageSize > 0 {
+		opts.PageSize = hint.PageSize
+	}
+	switch opts.Type {
+	case paginationLink, paginationOffset:
+	case paginationCursor:
+		if opts.CursorField == "" {
+			return nil, errors.New("cursor_field is required for cursor pagination")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pagination type %q in x-pagination", opts.Type)
+	}
+	return opts, nil
+}
@@ -0,0 +1,385 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+)
+
+// openAPIDocument is the subset of OpenAPI 3 document used to make the requests.
+type openAPIDocument struct {
+	OpenAPI    string                      `yaml:"openapi"`
+	Servers    []openAPIServer             `yaml:"servers"`
+	Paths      map[string]*openAPIPathItem `yaml:"paths"`
+	Components openAPIComponents           `yaml:"components"`
+	Security   []map[string][]string       `yaml:"security"`
+	Pagination *openAPIPagination          `yaml:"x-pagination"`
+}
+
+type openAPIServer struct {
+	URL string `yaml:"url"`
+}
+
+type openAPIComponents struct {
+	Parameters      map[string]*openAPIParameter      `yaml:"parameters"`
+	Schemas         map[string]*openAPISchema         `yaml:"schemas"`
+	Responses       map[string]*openAPIResponse       `yaml:"responses"`
+	SecuritySchemes map[string]*openAPISecurityScheme `yaml:"securitySchemes"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation   `yaml:"get"`
+	Put        *openAPIOperation   `yaml:"put"`
+	Post       *openAPIOperation   `yaml:"post"`
+	Delete     *openAPIOperation   `yaml:"delete"`
+	Patch      *openAPIOperation   `yaml:"patch"`
+	Head       *openAPIOperation   `yaml:"head"`
+	Options    *openAPIOperation   `yaml:"options"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `yaml:"operationId"`
+	Parameters  []*openAPIParameter         `yaml:"parameters"`
+	RequestBody *openAPIRequestBody         `yaml:"requestBody"`
+	Responses   map[string]*openAPIResponse `yaml:"responses"`
+	// Security is nil if the operation uses the security requirements of the document
+	Security   *[]map[string][]string `yaml:"security"`
+	Pagination *openAPIPagination     `yaml:"x-pagination"`
+}
+
+type openAPIRequestBody struct {
+	Required bool `yaml:"required"`
+}
+
+type openAPIResponse struct {
+	Ref     string         `yaml:"$ref"`
+	Headers map[string]any `yaml:"headers"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref       string         `yaml:"$ref"`
+	Type      openAPIType    `yaml:"type"`
+	Enum      []any          `yaml:"enum"`
+	Items     *openAPISchema `yaml:"items"`
+	Minimum   *float64       `yaml:"minimum"`
+	Maximum   *float64       `yaml:"maximum"`
+	MinLength *int           `yaml:"minLength"`
+	MaxLength *int           `yaml:"maxLength"`
+	MinItems  *int           `yaml:"minItems"`
+	MaxItems  *int           `yaml:"maxItems"`
+}
+
+// openAPIType is the type of the schema. OpenAPI 3.1 allows a list of types, for example,
+// `[string, "null"]`, the first non-null one is used.
+type openAPIType string
+
+func (t *openAPIType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = openAPIType(node.Value)
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	for _, ty := range types {
+		if ty != "null" {
+			*t = openAPIType(ty)
+			return nil
+		}
+	}
+	return nil
+}
+
+type openAPISecurityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	In     string `yaml:"in"`
+	Name   string `yaml:"name"`
+	Flows  struct {
+		ClientCredentials *struct {
+			TokenURL string `yaml:"tokenUrl"`
+		} `yaml:"clientCredentials"`
+	} `yaml:"flows"`
+}
+
+// openAPIPagination is `x-pagination` extension of the document or the operation
+// with the same options as the pagination block of the data source.
+type openAPIPagination struct {
+	Type        string `yaml:"type"`
+	ItemsField  string `yaml:"items_field"`
+	CursorField string `yaml:"cursor_field"`
+	CursorParam string `yaml:"cursor_param"`
+	OffsetParam string `yaml:"offset_param"`
+	LimitParam  string `yaml:"limit_param"`
+	PageSize    int    `yaml:"page_size"`
+	MaxPages    int    `yaml:"max_pages"`
+}
+
+// openAPIResolvedOperation is the operation with the references resolved.
+type openAPIResolvedOperation struct {
+	Method     string
+	Path       string
+	Parameters []*openAPIParameter
+	HasBody    bool
+	// BodyRequired is set if the request body is required by the operation
+	BodyRequired bool
+	Security     []map[string][]string
+	Pagination   *openAPIPagination
+}
+
+// loadOpenAPIDocument reads OpenAPI 3 document from YAML or JSON file.
+func loadOpenAPIDocument(path string) (*openAPIDocument, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc openAPIDocument
+	// JSON is a subset of YAML, so both formats are decoded the same way
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, only OpenAPI 3 is supported", doc.OpenAPI)
+	}
+	return &doc, nil
+}
+
+// findOperation finds the operation by its ID.
+func (doc *openAPIDocument) findOperation(operationID string) (*openAPIResolvedOperation, error) {
+	for path, item := range doc.Paths {
+		if item == nil {
+			continue
+		}
+		ops := []struct {
+			method string
+			op     *openAPIOperation
+		}{
+			{"GET", item.Get}, {"PUT", item.Put}, {"POST", item.Post}, {"DELETE", item.Delete},
+			{"PATCH", item.Patch}, {"HEAD", item.Head}, {"OPTIONS", item.Options},
+		}
+		for _, entry := range ops {
+			if entry.op == nil || entry.op.OperationID != operationID {
+				continue
+			}
+			return doc.resolveOperation(entry.method, path, item, entry.op)
+		}
+	}
+	return nil, fmt.Errorf("operation %q not found", operationID)
+}
+
+func (doc *openAPIDocument) resolveOperation(method, path string, item *openAPIPathItem, op *openAPIOperation) (*openAPIResolvedOperation, error) {
+	res := &openAPIResolvedOperation{
+		Method:     method,
+		Path:       path,
+		HasBody:    op.RequestBody != nil,
+		Security:   doc.Security,
+		Pagination: doc.Pagination,
+	}
+	if op.RequestBody != nil {
+		res.BodyRequired = op.RequestBody.Required
+	}
+	if op.Security != nil {
+		res.Security = *op.Security
+	}
+	if op.Pagination != nil {
+		res.Pagination = op.Pagination
+	}
+	// the parameters of the operation override the parameters of the path with the same name and location
+	index := map[string]int{}
+	for _, params := range [][]*openAPIParameter{item.Parameters, op.Parameters} {
+		for _, param := range params {
+			param, err := doc.resolveParameter(param)
+			if err != nil {
+				return nil, err
+			}
+			key := param.In + ":" + param.Name
+			if i, found := index[key]; found {
+				res.Parameters[i] = param
+				continue
+			}
+			index[key] = len(res.Parameters)
+			res.Parameters = append(res.Parameters, param)
+		}
+	}
+	if res.Pagination == nil && doc.declaresLinkHeader(op) {
+		res.Pagination = &openAPIPagination{Type: paginationLink}
+	}
+	return res, nil
+}
+
+// declaresLinkHeader reports whether the successful response of the operation has `Link` header,
+// a hint for link pagination.
+func (doc *openAPIDocument) declaresLinkHeader(op *openAPIOperation) bool {
+	res := op.Responses["200"]
+	for depth := 0; res != nil && res.Ref != ""; depth++ {
+		if depth > maxOpenAPIRefDepth {
+			return false
+		}
+		res = doc.Components.Responses[strings.TrimPrefix(res.Ref, "#/components/responses/")]
+	}
+	if res == nil {
+		return false
+	}
+	for name := range res.Headers {
+		if strings.EqualFold(name, "Link") {
+			return true
+		}
+	}
+	return false
+}
+
+// maxOpenAPIRefDepth limits the chains of references, to stop on the cyclic ones.
+const maxOpenAPIRefDepth = 32
+
+func (doc *openAPIDocument) resolveParameter(param *openAPIParameter) (*openAPIParameter, error) {
+	for depth := 0; param != nil && param.Ref != ""; depth++ {
+		if depth > maxOpenAPIRefDepth {
+			return nil, fmt.Errorf("too deep references of parameter %q", param.Ref)
+		}
+		name, found := strings.CutPrefix(param.Ref, "#/components/parameters/")
+		if !found {
+			return nil, fmt.Errorf("unsupported reference %q, only local references are supported", param.Ref)
+		}
+		param = doc.Components.Parameters[name]
+	}
+	if param == nil {
+		return nil, fmt.Errorf("parameter not found")
+	}
+	res := *param
+	var err error
+	res.Schema, err = doc.resolveSchema(param.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", param.Name, err)
+	}
+	return &res, nil
+}
+
+func (doc *openAPIDocument) resolveSchema(schema *openAPISchema) (*openAPISchema, error) {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		if depth > maxOpenAPIRefDepth {
+			return nil, fmt.Errorf("too deep references of schema %q", schema.Ref)
+		}
+		name, found := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+		if !found {
+			return nil, fmt.Errorf("unsupported reference %q, only local references are supported", schema.Ref)
+		}
+		next, found := doc.Components.Schemas[name]
+		if !found {
+			return nil, fmt.Errorf("schema %q not found", schema.Ref)
+		}
+		schema = next
+	}
+	if schema == nil || schema.Items == nil {
+		return schema, nil
+	}
+	items, err := doc.resolveSchema(schema.Items)
+	if err != nil {
+		return nil, err
+	}
+	res := *schema
+	res.Items = items
+	return &res, nil
+}
+
+// paramAttrSpec converts the parameter of the operation into the spec of the attribute,
+// so the values are validated the same way as the arguments of the data source.
+func paramAttrSpec(param *openAPIParameter) *dataspec.AttrSpec {
+	spec := &dataspec.AttrSpec{
+		Name: param.Name,
+		Type: schemaCtyType(param.Schema),
+		Doc:  param.Description,
+	}
+	if param.Required {
+		spec.Constraints |= constraint.NonNull
+	}
+	schema := param.Schema
+	if schema == nil {
+		return spec
+	}
+	switch schema.Type {
+	case "integer":
+		spec.Constraints |= constraint.Integer
+		fallthrough
+	case "number":
+		if schema.Minimum != nil {
+			spec.MinInclusive = cty.NumberFloatVal(*schema.Minimum)
+		}
+		if schema.Maximum != nil {
+			spec.MaxInclusive = cty.NumberFloatVal(*schema.Maximum)
+		}
+	case "string":
+		spec.MinInclusive = optionalIntVal(schema.MinLength)
+		spec.MaxInclusive = optionalIntVal(schema.MaxLength)
+	case "array":
+		spec.MinInclusive = optionalIntVal(schema.MinItems)
+		spec.MaxInclusive = optionalIntVal(schema.MaxItems)
+	}
+	for _, val := range schema.Enum {
+		if enumVal, err := convert.Convert(anyToCty(val), spec.Type); err == nil {
+			spec.OneOf = append(spec.OneOf, enumVal)
+		}
+	}
+	return spec
+}
+
+func optionalIntVal(v *int) cty.Value {
+	if v == nil {
+		return cty.NilVal
+	}
+	return cty.NumberIntVal(int64(*v))
+}
+
+func schemaCtyType(schema *openAPISchema) cty.Type {
+	if schema == nil {
+		return cty.DynamicPseudoType
+	}
+	switch schema.Type {
+	case "string":
+		return cty.String
+	case "integer", "number":
+		return cty.Number
+	case "boolean":
+		return cty.Bool
+	case "array":
+		return cty.List(schemaCtyType(schema.Items))
+	default:
+		return cty.DynamicPseudoType
+	}
+}
+
+func anyToCty(val any) cty.Value {
+	switch val := val.(type) {
+	case string:
+		return cty.StringVal(val)
+	case bool:
+		return cty.BoolVal(val)
+	case int:
+		return cty.NumberIntVal(int64(val))
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return cty.NullVal(cty.Number)
+		}
+		return cty.NumberFloatVal(val)
+	default:
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+}
@@ -0,0 +1,254 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type OpenAPIDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+	mux    *http.ServeMux
+	srv    *httptest.Server
+}
+
+func TestOpenAPIDataSourceSuite(t *testing.T) {
+	suite.Run(t, &OpenAPIDataSourceTestSuite{})
+}
+
+func (s *OpenAPIDataSourceTestSuite) SetupSuite() {
+	s.schema = makeOpenAPIDataSource("1.2.3")
+}
+
+func (s *OpenAPIDataSourceTestSuite) SetupTest() {
+	s.mux = http.NewServeMux()
+	s.srv = httptest.NewServer(s.mux)
+}
+
+func (s *OpenAPIDataSourceTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.NotNil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *OpenAPIDataSourceTestSuite) fetch(cfg, args string, asserts diagtest.Asserts) plugindata.Data {
+	if cfg == "" {
+		cfg = fmt.Sprintf(`
+			spec = "testdata/openapi/issues.yaml"
+			base_url = "%s/api"
+			api_key = "test-key"
+		`, s.srv.URL)
+	}
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, cfg, nil, diagtest.Asserts{}),
+		Args:   plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestListIssues() {
+	s.mux.HandleFunc("/api/projects/my%20project/issues", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodGet, r.Method)
+		s.Equal("test-key", r.Header.Get("X-API-Key"))
+		s.Equal("req-1", r.Header.Get("X-Request-ID"))
+		s.Equal("fabric-data-openapi/1.2.3", r.Header.Get("User-Agent"))
+		s.Equal("open", r.URL.Query().Get("state"))
+		s.Equal([]string{"bug", "ui"}, r.URL.Query()["labels"])
+		s.Equal("2", r.URL.Query().Get("per_page"))
+		if r.URL.Query().Get("page") == "" {
+			// the response declares Link header, so the pages are followed
+			w.Header().Set("Link", `<?state=open&labels=bug&labels=ui&per_page=2&page=2>; rel="next"`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"page": %q}]`, r.URL.Query().Get("page"))
+	})
+
+	data := s.fetch("", `
+		operation_id = "listIssues"
+		parameters = {
+			project_id = "my project"
+			state = "open"
+			labels = ["bug", "ui"]
+			per_page = 2
+			X-Request-ID = "req-1"
+		}
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"page": plugindata.String("")},
+		plugindata.Map{"page": plugindata.String("2")},
+	}, data)
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestInvalidParameters() {
+	s.fetch("", `
+		operation_id = "listIssues"
+		parameters = {
+			state = "pending"
+			per_page = 500
+			sort = "asc"
+		}
+	`, diagtest.Asserts{
+		{diagtest.IsError, diagtest.DetailContains(`"project_id"`)},
+		{diagtest.IsError, diagtest.DetailContains(`"state"`, "must be one of")},
+		{diagtest.IsError, diagtest.DetailContains(`"per_page"`, "[1; 100]")},
+		{diagtest.IsError, diagtest.SummaryEquals("Unknown parameters"), diagtest.DetailContains("sort")},
+	})
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestCreateIssue() {
+	s.mux.HandleFunc("/api/projects/fabric/issues", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("Bearer test-token", r.Header.Get("Authorization"))
+		s.Empty(r.Header.Get("X-API-Key"))
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+		s.JSONEq(`{"title": "Broken link"}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 7}`))
+	})
+
+	data := s.fetch(fmt.Sprintf(`
+		spec = "testdata/openapi/issues.yaml"
+		base_url = "%s/api"
+		bearer_token = "test-token"
+	`, s.srv.URL), `
+		operation_id = "createIssue"
+		parameters = {
+			project_id = "fabric"
+		}
+		body = {
+			title = "Broken link"
+		}
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{"id": plugindata.Number(7)}, data)
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestMissingBody() {
+	s.fetch("", `
+		operation_id = "createIssue"
+		parameters = {
+			project_id = "fabric"
+		}
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Missing request body"),
+	}})
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestMissingCredentials() {
+	s.fetch(fmt.Sprintf(`
+		spec = "testdata/openapi/issues.yaml"
+		base_url = "%s/api"
+	`, s.srv.URL), `
+		operation_id = "listIssues"
+		parameters = {
+			project_id = "fabric"
+		}
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to authenticate the request"),
+		diagtest.DetailContains("apiKey"),
+	}})
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestOAuth2CursorPagination() {
+	s.mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(r.ParseForm())
+		s.Equal("events:read", r.PostForm.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "oauth-token", "token_type": "Bearer"}`))
+	})
+	s.mux.HandleFunc("/api/events", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer oauth-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"data": [{"id": 1}], "meta": {"next": "c1"}}`))
+			return
+		}
+		s.Equal("c1", r.URL.Query().Get("after"))
+		w.Write([]byte(`{"data": [{"id": 2}], "meta": {}}`))
+	})
+
+	data := s.fetch(fmt.Sprintf(`
+		spec = "testdata/openapi/issues.yaml"
+		base_url = "%s/api"
+		client_id = "fabric"
+		client_secret = "secret"
+	`, s.srv.URL), `
+		operation_id = "listEvents"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{"id": plugindata.Number(1)},
+		plugindata.Map{"id": plugindata.Number(2)},
+	}, data)
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestPaginationOverride() {
+	s.mux.HandleFunc("/api/projects/fabric/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<?page=2>; rel="next"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [1]}`))
+	})
+
+	data := s.fetch("", `
+		operation_id = "listIssues"
+		parameters = {
+			project_id = "fabric"
+		}
+		pagination {
+			type = "link"
+			items_field = "items"
+			max_pages = 2
+		}
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{plugindata.Number(1), plugindata.Number(1)}, data)
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestRelativeServerURL() {
+	s.fetch(`
+		spec = "testdata/openapi/issues.yaml"
+		api_key = "test-key"
+	`, `
+		operation_id = "listIssues"
+		parameters = {
+			project_id = "fabric"
+		}
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Invalid base URL"),
+	}})
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestUnknownOperation() {
+	s.fetch("", `operation_id = "deleteIssue"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to find the operation"),
+		diagtest.DetailContains("deleteIssue"),
+	}})
+}
+
+func (s *OpenAPIDataSourceTestSuite) TestUnsupportedVersion() {
+	s.fetch(`spec = "testdata/openapi/swagger.json"`, `operation_id = "listIssues"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to load OpenAPI document"),
+		diagtest.DetailContains("only OpenAPI 3 is supported"),
+	}})
+}
@@ -0,0 +1,111 @@
+package builtin
+
+import (
+	"context"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/parquet"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeParquetDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchParquetData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("Parquet", "path/to/file*.parquet", "path/to/file.parquet"),
+				[]*dataspec.AttrSpec{
+					{
+						Name:       "columns",
+						Type:       cty.List(cty.String),
+						ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("host"), cty.StringVal("severity")}),
+						Doc:        `Names of the top-level columns to read. If not set, all columns are read`,
+					},
+				}...,
+			),
+		},
+		Doc: u.Dedent(`
+			Loads Parquet files with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + ` value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The rows of the file are returned as a list of dicts with the column names as keys. Nested groups
+			become dicts, ` + "`LIST`" + ` and ` + "`MAP`" + ` annotated groups become lists and dicts. Dates and timestamps
+			become dates, decimals become numbers.
+
+			The files are read one row group at a time and only the columns listed in ` + "`columns`" + ` are read,
+			so selecting the columns reduces the memory used for wide files.
+
+			Supported compression codecs are Snappy, gzip and zstd.
+
+			When ` + "`path`" + ` argument is specified, the data source returns only the content of a file.
+			When ` + "`glob`" + ` argument is specified, the data source returns a list of dicts that contain the content of a file and file's metadata. For example:
+
+			` + "```json" + `
+			[
+			  {
+			    "file_path": "path/file-a.parquet",
+			    "file_name": "file-a.parquet",
+			    "content": [
+			      {"host": "10.0.0.1", "severity": "high"}
+			    ]
+			  }
+			]
+			` + "```",
+		),
+	}
+}
+
+func fetchParquetData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	var columns []string
+	if val := params.Args.GetAttrVal("columns"); !val.IsNull() {
+		for _, column := range val.AsValueSlice() {
+			columns = append(columns, column.AsString())
+		}
+	}
+
+	return fetchFiles(ctx, params.Args, "Parquet", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readAndDecodeParquetFile(ctx, path, columns)
+	})
+}
+
+func readAndDecodeParquetFile(ctx context.Context, path string, columns []string) (plugindata.List, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	file, err := parquet.Open(f, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := file.Rows(columns...)
+	if err != nil {
+		return nil, err
+	}
+	result := plugindata.List{}
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		row, err := plugindata.ParseMapAny(rows.Row())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
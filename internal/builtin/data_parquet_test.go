@@ -0,0 +1,144 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type ParquetDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestParquetDataSourceSuite(t *testing.T) {
+	suite.Run(t, &ParquetDataSourceTestSuite{})
+}
+
+func (s *ParquetDataSourceTestSuite) SetupSuite() {
+	s.schema = makeParquetDataSource()
+}
+
+func (s *ParquetDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *ParquetDataSourceTestSuite) fetch(ctx context.Context, args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *ParquetDataSourceTestSuite) TestPath() {
+	data := s.fetch(context.Background(), `path = "../../pkg/parquet/testdata/nested.parquet"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"id":      plugindata.Number(1),
+			"tags":    plugindata.List{plugindata.String("a"), plugindata.String("b"), nil},
+			"address": plugindata.Map{"city": plugindata.String("berlin"), "zip": plugindata.String("10115")},
+			"attrs":   plugindata.Map{"x": plugindata.Number(1), "y": nil},
+		},
+		plugindata.Map{
+			"id":      plugindata.Number(2),
+			"tags":    plugindata.List{},
+			"address": nil,
+			"attrs":   nil,
+		},
+		plugindata.Map{
+			"id":      plugindata.Number(3),
+			"tags":    nil,
+			"address": plugindata.Map{"city": plugindata.String("paris"), "zip": nil},
+			"attrs":   plugindata.Map{},
+		},
+	}, data)
+}
+
+func (s *ParquetDataSourceTestSuite) TestColumns() {
+	data := s.fetch(context.Background(), `
+		path = "../../pkg/parquet/testdata/flat.parquet"
+		columns = ["name", "created"]
+	`, diagtest.Asserts{})
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s.Equal(plugindata.List{
+		plugindata.Map{"name": plugindata.String("alice"), "created": plugindata.Time(created)},
+		plugindata.Map{"name": plugindata.String("bob"), "created": plugindata.Time(created.Add(time.Hour))},
+		plugindata.Map{"name": nil, "created": plugindata.Time(created.Add(2 * time.Hour))},
+		plugindata.Map{"name": plugindata.String("alice"), "created": nil},
+		plugindata.Map{"name": plugindata.String("carol"), "created": plugindata.Time(created.AddDate(0, 0, 1))},
+	}, data)
+}
+
+func (s *ParquetDataSourceTestSuite) TestGlob() {
+	data := s.fetch(context.Background(), `
+		glob = "../../pkg/parquet/testdata/*.parquet"
+		columns = ["id"]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"file_path": plugindata.String("../../pkg/parquet/testdata/flat.parquet"),
+			"file_name": plugindata.String("flat.parquet"),
+			"content": plugindata.List{
+				plugindata.Map{"id": plugindata.Number(1)},
+				plugindata.Map{"id": plugindata.Number(2)},
+				plugindata.Map{"id": plugindata.Number(3)},
+				plugindata.Map{"id": plugindata.Number(4)},
+				plugindata.Map{"id": plugindata.Number(5)},
+			},
+		},
+		plugindata.Map{
+			"file_path": plugindata.String("../../pkg/parquet/testdata/nested.parquet"),
+			"file_name": plugindata.String("nested.parquet"),
+			"content": plugindata.List{
+				plugindata.Map{"id": plugindata.Number(1)},
+				plugindata.Map{"id": plugindata.Number(2)},
+				plugindata.Map{"id": plugindata.Number(3)},
+			},
+		},
+	}, data)
+}
+
+func (s *ParquetDataSourceTestSuite) TestErrors() {
+	s.fetch(context.Background(), `
+		path = "../../pkg/parquet/testdata/flat.parquet"
+		columns = ["missing"]
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains(`column "missing" not found`),
+	}})
+	s.fetch(context.Background(), `path = "testdata/csv/comma.csv"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("not a Parquet file"),
+	}})
+	s.fetch(context.Background(), `
+		path = "../../pkg/parquet/testdata/flat.parquet"
+		glob = "../../pkg/parquet/testdata/*.parquet"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains("not both"),
+	}})
+}
+
+func (s *ParquetDataSourceTestSuite) TestCancellation() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.fetch(ctx, `path = "../../pkg/parquet/testdata/flat.parquet"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("context canceled"),
+	}})
+}
@@ -0,0 +1,396 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// sarifLevelSeverities maps SARIF levels to the severities of the results without a security severity.
+var sarifLevelSeverities = map[string]string{
+	"error":   "high",
+	"warning": "medium",
+	"note":    "low",
+	"none":    "info",
+}
+
+var sarifPlaceholderRe = regexp.MustCompile(`\{(\d+)\}`)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver     sarifToolComponent   `json:"driver"`
+		Extensions []sarifToolComponent `json:"extensions"`
+	} `json:"tool"`
+	OriginalURIBaseIDs map[string]struct {
+		URI string `json:"uri"`
+	} `json:"originalUriBaseIds"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifToolComponent struct {
+	Name            string      `json:"name"`
+	Version         string      `json:"version"`
+	SemanticVersion string      `json:"semanticVersion"`
+	Rules           []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name"`
+	ShortDescription     *sarifMessage           `json:"shortDescription"`
+	FullDescription      *sarifMessage           `json:"fullDescription"`
+	MessageStrings       map[string]sarifMessage `json:"messageStrings"`
+	HelpURI              string                  `json:"helpUri"`
+	DefaultConfiguration *struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	Properties map[string]any `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text      string   `json:"text"`
+	ID        string   `json:"id"`
+	Arguments []string `json:"arguments"`
+}
+
+type sarifResult struct {
+	RuleID    string `json:"ruleId"`
+	RuleIndex *int   `json:"ruleIndex"`
+	Rule      *struct {
+		ID            string `json:"id"`
+		Index         *int   `json:"index"`
+		ToolComponent *struct {
+			Index *int `json:"index"`
+		} `json:"toolComponent"`
+	} `json:"rule"`
+	Kind                string            `json:"kind"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	Fingerprints        map[string]string `json:"fingerprints"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	BaselineState       string            `json:"baselineState"`
+	Suppressions        []struct {
+		Kind   string `json:"kind"`
+		Status string `json:"status"`
+	} `json:"suppressions"`
+	Properties map[string]any `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *struct {
+		ArtifactLocation *struct {
+			URI       string `json:"uri"`
+			URIBaseID string `json:"uriBaseId"`
+		} `json:"artifactLocation"`
+		Region *struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+			EndLine     int `json:"endLine"`
+			EndColumn   int `json:"endColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+func makeSARIFDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchSARIFData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("SARIF", "path/to/*.sarif", "path/to/results.sarif"),
+				vexAttr,
+			),
+		},
+		Doc: u.Dedent(`
+			Loads SARIF 2.1 logs with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + ` value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The results of all runs in a log are normalized to a flat list of dicts with the following fields:
+			- ` + "`tool`" + ` and ` + "`tool_version`" + ` – the analysis tool that produced the result
+			- ` + "`rule_id`" + `, ` + "`rule_name`" + `, ` + "`rule_description`" + `, ` + "`help_uri`" + ` and ` + "`tags`" + ` of the rule
+			- ` + "`level`" + ` – SARIF level of the result: ` + "`error`" + `, ` + "`warning`" + `, ` + "`note`" + ` or ` + "`none`" + `
+			- ` + "`security_severity`" + ` – the numeric ` + "`security-severity`" + ` property of the result or the rule, if any
+			- ` + "`severity`" + ` – ` + "`critical`" + `, ` + "`high`" + `, ` + "`medium`" + `, ` + "`low`" + ` or ` + "`info`" + `, derived from the security
+			  severity with CVSS ranges or, if there is none, from the level
+			- ` + "`message`" + ` – the text of the message, with the arguments substituted
+			- ` + "`location`" + ` and ` + "`locations`" + ` – the first and all physical locations with ` + "`uri`" + `, ` + "`start_line`" + `,
+			  ` + "`start_column`" + `, ` + "`end_line`" + ` and ` + "`end_column`" + ` fields
+			- ` + "`fingerprints`" + ` and ` + "`partial_fingerprints`" + ` – the dicts of the fingerprints
+			- ` + "`kind`" + `, ` + "`baseline_state`" + ` and ` + "`suppressed`" + `
+			- ` + "`vex`" + ` – if ` + "`vex`" + ` argument is set, the status of the vulnerability with the ID matching ` + "`rule_id`" + `,
+			  in the same shape as in ` + "`cyclonedx`" + ` data source
+		`),
+	}
+}
+
+func fetchSARIFData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	vex, diags := readVEXAttr(params.Args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return fetchFiles(ctx, params.Args, "SARIF", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readSARIFFile(path, vex)
+	})
+}
+
+func readSARIFFile(path string, vex vexIndex) (plugindata.Data, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var log sarifLog
+	if err := json.Unmarshal(raw, &log); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(log.Version, "2.1") {
+		return nil, fmt.Errorf("unsupported SARIF version %q", log.Version)
+	}
+	results := plugindata.List{}
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		for j := range run.Results {
+			results = append(results, run.resultData(&run.Results[j], vex))
+		}
+	}
+	return results, nil
+}
+
+// rule finds the rule of the result by its index or ID.
+func (run *sarifRun) rule(res *sarifResult) *sarifRule {
+	component := &run.Tool.Driver
+	index := res.RuleIndex
+	id := res.RuleID
+	if res.Rule != nil {
+		if res.Rule.Index != nil {
+			index = res.Rule.Index
+		}
+		if id == "" {
+			id = res.Rule.ID
+		}
+		if tc := res.Rule.ToolComponent; tc != nil && tc.Index != nil {
+			if *tc.Index < 0 || *tc.Index >= len(run.Tool.Extensions) {
+				return nil
+			}
+			component = &run.Tool.Extensions[*tc.Index]
+		}
+	}
+	if index != nil && *index >= 0 && *index < len(component.Rules) {
+		return &component.Rules[*index]
+	}
+	for i := range component.Rules {
+		if component.Rules[i].ID == id {
+			return &component.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (run *sarifRun) resultData(res *sarifResult, vex vexIndex) plugindata.Map {
+	rule := run.rule(res)
+	ruleID := res.RuleID
+	if ruleID == "" && res.Rule != nil {
+		ruleID = res.Rule.ID
+	}
+	if ruleID == "" && rule != nil {
+		ruleID = rule.ID
+	}
+
+	kind := res.Kind
+	if kind == "" {
+		kind = "fail"
+	}
+	level := res.Level
+	if level == "" && rule != nil && rule.DefaultConfiguration != nil {
+		level = rule.DefaultConfiguration.Level
+	}
+	switch {
+	case kind != "fail":
+		level = "none"
+	case level == "":
+		level = "warning"
+	}
+
+	var (
+		ruleName, ruleDescription, helpURI plugindata.Data
+		tags                               = plugindata.List{}
+		securitySeverity                   plugindata.Data
+	)
+	score, hasScore := sarifSecuritySeverity(res.Properties)
+	if rule != nil {
+		ruleName = optionalString(rule.Name)
+		if rule.ShortDescription != nil {
+			ruleDescription = optionalString(rule.ShortDescription.Text)
+		} else if rule.FullDescription != nil {
+			ruleDescription = optionalString(rule.FullDescription.Text)
+		}
+		helpURI = optionalString(rule.HelpURI)
+		if list, ok := rule.Properties["tags"].([]any); ok {
+			for _, tag := range list {
+				if s, ok := tag.(string); ok {
+					tags = append(tags, plugindata.String(s))
+				}
+			}
+		}
+		if !hasScore {
+			score, hasScore = sarifSecuritySeverity(rule.Properties)
+		}
+	}
+	severity := sarifLevelSeverities[level]
+	if hasScore {
+		securitySeverity = plugindata.Number(score)
+		severity = cvssSeverity(score)
+	}
+
+	locations := make(plugindata.List, 0, len(res.Locations))
+	for _, loc := range res.Locations {
+		if data := run.locationData(loc); data != nil {
+			locations = append(locations, data)
+		}
+	}
+	var location plugindata.Data
+	if len(locations) > 0 {
+		location = locations[0]
+	}
+
+	var status plugindata.Data
+	if stmt, ok := vex.lookup(ruleID); ok {
+		status = stmt.data()
+	}
+
+	return plugindata.Map{
+		"tool":                 plugindata.String(run.Tool.Driver.Name),
+		"tool_version":         run.toolVersion(),
+		"rule_id":              optionalString(ruleID),
+		"rule_name":            ruleName,
+		"rule_description":     ruleDescription,
+		"help_uri":             helpURI,
+		"tags":                 tags,
+		"kind":                 plugindata.String(kind),
+		"level":                plugindata.String(level),
+		"security_severity":    securitySeverity,
+		"severity":             plugindata.String(severity),
+		"message":              plugindata.String(sarifMessageText(res.Message, rule)),
+		"location":             location,
+		"locations":            locations,
+		"fingerprints":         stringMapData(res.Fingerprints),
+		"partial_fingerprints": stringMapData(res.PartialFingerprints),
+		"baseline_state":       optionalString(res.BaselineState),
+		"suppressed":           plugindata.Bool(sarifSuppressed(res)),
+		"vex":                  status,
+	}
+}
+
+func (run *sarifRun) toolVersion() plugindata.Data {
+	if run.Tool.Driver.SemanticVersion != "" {
+		return plugindata.String(run.Tool.Driver.SemanticVersion)
+	}
+	return optionalString(run.Tool.Driver.Version)
+}
+
+func (run *sarifRun) locationData(loc sarifLocation) plugindata.Data {
+	phys := loc.PhysicalLocation
+	if phys == nil || phys.ArtifactLocation == nil {
+		return nil
+	}
+	uri := phys.ArtifactLocation.URI
+	if base, ok := run.OriginalURIBaseIDs[phys.ArtifactLocation.URIBaseID]; ok && base.URI != "" {
+		uri = strings.TrimSuffix(base.URI, "/") + "/" + strings.TrimPrefix(uri, "/")
+	}
+	data := plugindata.Map{
+		"uri":          plugindata.String(uri),
+		"start_line":   nil,
+		"start_column": nil,
+		"end_line":     nil,
+		"end_column":   nil,
+	}
+	if region := phys.Region; region != nil {
+		for key, val := range map[string]int{
+			"start_line":   region.StartLine,
+			"start_column": region.StartColumn,
+			"end_line":     region.EndLine,
+			"end_column":   region.EndColumn,
+		} {
+			if val > 0 {
+				data[key] = plugindata.Number(val)
+			}
+		}
+	}
+	return data
+}
+
+// sarifSuppressed reports whether the result has a suppression that isn't rejected or under review.
+func sarifSuppressed(res *sarifResult) bool {
+	for _, s := range res.Suppressions {
+		if s.Status == "" || s.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
+
+func sarifMessageText(msg sarifMessage, rule *sarifRule) string {
+	text := msg.Text
+	if text == "" && msg.ID != "" && rule != nil {
+		text = rule.MessageStrings[msg.ID].Text
+	}
+	return sarifPlaceholderRe.ReplaceAllStringFunc(text, func(placeholder string) string {
+		n, err := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		if err != nil || n >= len(msg.Arguments) {
+			return placeholder
+		}
+		return msg.Arguments[n]
+	})
+}
+
+// sarifSecuritySeverity returns `security-severity` property used by GitHub code scanning.
+func sarifSecuritySeverity(props map[string]any) (float64, bool) {
+	switch val := props["security-severity"].(type) {
+	case float64:
+		return val, true
+	case string:
+		score, err := strconv.ParseFloat(val, 64)
+		return score, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// cvssSeverity maps the score to CVSS v3 qualitative severity rating.
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return "critical"
+	case score >= 7:
+		return "high"
+	case score >= 4:
+		return "medium"
+	case score > 0:
+		return "low"
+	default:
+		return "info"
+	}
+}
+
+func stringMapData(m map[string]string) plugindata.Map {
+	result := make(plugindata.Map, len(m))
+	for key, val := range m {
+		result[key] = plugindata.String(val)
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type SARIFDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestSARIFDataSourceSuite(t *testing.T) {
+	suite.Run(t, &SARIFDataSourceTestSuite{})
+}
+
+func (s *SARIFDataSourceTestSuite) SetupSuite() {
+	s.schema = makeSARIFDataSource()
+}
+
+func (s *SARIFDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *SARIFDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *SARIFDataSourceTestSuite) TestPath() {
+	data := s.fetch(`path = "testdata/sarif/semgrep.sarif"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"tool":              plugindata.String("Semgrep"),
+			"tool_version":      plugindata.String("1.70.0"),
+			"rule_id":           plugindata.String("python.lang.security.audit.eval-detected"),
+			"rule_name":         plugindata.String("eval-detected"),
+			"rule_description":  plugindata.String("Detected the use of eval()"),
+			"help_uri":          plugindata.String("https://semgrep.dev/r/python.lang.security.audit.eval-detected"),
+			"tags":              plugindata.List{plugindata.String("security"), plugindata.String("CWE-95")},
+			"kind":              plugindata.String("fail"),
+			"level":             plugindata.String("error"),
+			"security_severity": plugindata.Number(9.8),
+			"severity":          plugindata.String("critical"),
+			"message":           plugindata.String("Avoid eval() with user input"),
+			"location": plugindata.Map{
+				"uri":          plugindata.String("file:///src/app/views.py"),
+				"start_line":   plugindata.Number(12),
+				"start_column": plugindata.Number(5),
+				"end_line":     plugindata.Number(12),
+				"end_column":   plugindata.Number(30),
+			},
+			"locations": plugindata.List{
+				plugindata.Map{
+					"uri":          plugindata.String("file:///src/app/views.py"),
+					"start_line":   plugindata.Number(12),
+					"start_column": plugindata.Number(5),
+					"end_line":     plugindata.Number(12),
+					"end_column":   plugindata.Number(30),
+				},
+			},
+			"fingerprints":         plugindata.Map{"matchBasedId/v1": plugindata.String("abc123")},
+			"partial_fingerprints": plugindata.Map{"primaryLocationLineHash": plugindata.String("f00d:1")},
+			"baseline_state":       plugindata.String("new"),
+			"suppressed":           plugindata.Bool(false),
+			"vex":                  nil,
+		},
+		plugindata.Map{
+			"tool":              plugindata.String("Semgrep"),
+			"tool_version":      plugindata.String("1.70.0"),
+			"rule_id":           plugindata.String("python.lang.maintainability.useless-ifelse"),
+			"rule_name":         nil,
+			"rule_description":  plugindata.String("Useless if/else"),
+			"help_uri":          nil,
+			"tags":              plugindata.List{},
+			"kind":              plugindata.String("fail"),
+			"level":             plugindata.String("note"),
+			"security_severity": nil,
+			"severity":          plugindata.String("low"),
+			"message":           plugindata.String("Both branches of the statement at line 40 are equal"),
+			"location": plugindata.Map{
+				"uri":          plugindata.String("app/utils.py"),
+				"start_line":   plugindata.Number(40),
+				"start_column": nil,
+				"end_line":     nil,
+				"end_column":   nil,
+			},
+			"locations": plugindata.List{
+				plugindata.Map{
+					"uri":          plugindata.String("app/utils.py"),
+					"start_line":   plugindata.Number(40),
+					"start_column": nil,
+					"end_line":     nil,
+					"end_column":   nil,
+				},
+			},
+			"fingerprints":         plugindata.Map{},
+			"partial_fingerprints": plugindata.Map{},
+			"baseline_state":       nil,
+			"suppressed":           plugindata.Bool(true),
+			"vex":                  nil,
+		},
+	}, data)
+}
+
+func (s *SARIFDataSourceTestSuite) TestGlobAndVEX() {
+	data := s.fetch(`
+		glob = "testdata/sarif/tri*.sarif"
+		vex = "testdata/vex/openvex.json"
+	`, diagtest.Asserts{})
+	list, ok := data.(plugindata.List)
+	s.Require().True(ok)
+	s.Require().Len(list, 1)
+	file := list[0].(plugindata.Map)
+	s.Equal(plugindata.String("testdata/sarif/trivy.sarif"), file["file_path"])
+	s.Equal(plugindata.String("trivy.sarif"), file["file_name"])
+
+	results := file["content"].(plugindata.List)
+	s.Require().Len(results, 2)
+	first := results[0].(plugindata.Map)
+	s.Equal(plugindata.String("0.50.1"), first["tool_version"])
+	s.Equal(plugindata.String("warning"), first["level"])
+	s.Equal(plugindata.Number(7.5), first["security_severity"])
+	s.Equal(plugindata.String("high"), first["severity"])
+	s.Equal(plugindata.Map{
+		"status":        plugindata.String("not_affected"),
+		"justification": plugindata.String("vulnerable_code_not_in_execute_path"),
+		"detail":        plugindata.String("HTTP/2 server is not used"),
+		"action":        nil,
+		"response":      plugindata.List{},
+	}, first["vex"])
+
+	second := results[1].(plugindata.Map)
+	s.Equal(plugindata.String("pass"), second["kind"])
+	s.Equal(plugindata.String("none"), second["level"])
+	s.Equal(plugindata.String("info"), second["severity"])
+	s.Nil(second["location"])
+	s.Equal(plugindata.List{}, second["locations"])
+	s.Nil(second["vex"])
+
+	data = s.fetch(`
+		path = "testdata/sarif/semgrep.sarif"
+		vex = "testdata/vex/openvex.json"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"status":        plugindata.String("affected"),
+		"justification": nil,
+		"detail":        nil,
+		"action":        plugindata.String("Replace eval() with ast.literal_eval()"),
+		"response":      plugindata.List{},
+	}, data.(plugindata.List)[0].(plugindata.Map)["vex"])
+}
+
+func (s *SARIFDataSourceTestSuite) TestErrors() {
+	s.fetch(``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Either \"glob\" value or \"path\" value must be provided"),
+	}})
+	s.fetch(`
+		path = "testdata/sarif/trivy.sarif"
+		glob = "testdata/sarif/*.sarif"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Either \"glob\" or \"path\" must be provided, not both"),
+	}})
+	s.fetch(`path = "testdata/sarif/legacy.sarif"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains(`unsupported SARIF version "1.0.0"`),
+	}})
+	s.fetch(`glob = "testdata/sarif/*.sarif"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the files"),
+		diagtest.DetailContains("legacy.sarif"),
+	}})
+	s.fetch(`path = "testdata/sarif/missing.sarif"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+	s.fetch(`
+		path = "testdata/sarif/trivy.sarif"
+		vex = "testdata/vex/invalid.json"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the VEX document"),
+		diagtest.DetailContains("neither OpenVEX statements nor CycloneDX vulnerabilities found"),
+	}})
+}
@@ -0,0 +1,215 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      *struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	DocumentDescribes []string           `json:"documentDescribes"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	Supplier         string `json:"supplier"`
+	DownloadLocation string `json:"downloadLocation"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	Purpose          string `json:"primaryPackagePurpose"`
+	ExternalRefs     []struct {
+		Category string `json:"referenceCategory"`
+		Type     string `json:"referenceType"`
+		Locator  string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+	Checksums []struct {
+		Algorithm string `json:"algorithm"`
+		Value     string `json:"checksumValue"`
+	} `json:"checksums"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+func makeSPDXDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchSPDXData,
+		Args: &dataspec.RootSpec{
+			Attrs: fileAttrs("SPDX", "path/to/*.spdx.json", "path/to/sbom.spdx.json"),
+		},
+		Doc: u.Dedent(`
+			Loads SPDX 2.x SBOMs in JSON format with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + ` value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The document is normalized to a dict with the following fields:
+			- ` + "`spdx_version`" + `, ` + "`name`" + `, ` + "`namespace`" + `, ` + "`created`" + ` and ` + "`creators`" + ` of the document
+			- ` + "`describes`" + ` – the SPDX IDs of the elements the document describes
+			- ` + "`packages`" + ` – a list of the packages with ` + "`spdx_id`" + `, ` + "`name`" + `, ` + "`version`" + `, ` + "`supplier`" + `, ` + "`purpose`" + `,
+			  ` + "`download_location`" + `, ` + "`license_concluded`" + `, ` + "`license_declared`" + `, ` + "`purl`" + `, ` + "`cpe`" + ` and ` + "`checksums`" + ` fields
+			- ` + "`relationships`" + ` – a list of dicts with ` + "`element`" + `, ` + "`type`" + ` and ` + "`related`" + ` fields
+			- ` + "`dependencies`" + ` – a list of dicts with ` + "`ref`" + ` and ` + "`depends_on`" + ` fields, built from ` + "`DEPENDS_ON`" + `
+			  and ` + "`DEPENDENCY_OF`" + ` relationships, in the same shape as in ` + "`cyclonedx`" + ` data source
+
+			` + "`NOASSERTION`" + ` and ` + "`NONE`" + ` values become nulls.
+		`),
+	}
+}
+
+func fetchSPDXData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	return fetchFiles(ctx, params.Args, "SPDX", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readSPDXFile(path)
+	})
+}
+
+func readSPDXFile(path string) (plugindata.Data, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc.SPDXVersion == "" {
+		return nil, errors.New("not a SPDX document")
+	}
+	return doc.data(), nil
+}
+
+func (doc *spdxDocument) data() plugindata.Map {
+	var (
+		created  plugindata.Data
+		creators = plugindata.List{}
+	)
+	if doc.CreationInfo != nil {
+		created = parseOptionalTime(doc.CreationInfo.Created)
+		for _, c := range doc.CreationInfo.Creators {
+			creators = append(creators, plugindata.String(c))
+		}
+	}
+	describes := make(plugindata.List, 0, len(doc.DocumentDescribes))
+	for _, id := range doc.DocumentDescribes {
+		describes = append(describes, plugindata.String(id))
+	}
+	packages := make(plugindata.List, 0, len(doc.Packages))
+	for _, pkg := range doc.Packages {
+		packages = append(packages, pkg.data())
+	}
+
+	relationships := make(plugindata.List, 0, len(doc.Relationships))
+	var refs []string
+	dependsOn := map[string][]string{}
+	for _, rel := range doc.Relationships {
+		relationships = append(relationships, plugindata.Map{
+			"element": plugindata.String(rel.Element),
+			"type":    plugindata.String(rel.Type),
+			"related": plugindata.String(rel.Related),
+		})
+		ref, dep := rel.Element, rel.Related
+		switch rel.Type {
+		case "DEPENDS_ON":
+		case "DEPENDENCY_OF":
+			ref, dep = dep, ref
+		case "DESCRIBES":
+			if !slices.Contains(doc.DocumentDescribes, rel.Related) {
+				describes = append(describes, plugindata.String(rel.Related))
+			}
+			continue
+		default:
+			continue
+		}
+		if _, ok := dependsOn[ref]; !ok {
+			refs = append(refs, ref)
+		}
+		if !slices.Contains(dependsOn[ref], dep) {
+			dependsOn[ref] = append(dependsOn[ref], dep)
+		}
+	}
+	dependencies := make(plugindata.List, 0, len(refs))
+	for _, ref := range refs {
+		deps := make(plugindata.List, 0, len(dependsOn[ref]))
+		for _, dep := range dependsOn[ref] {
+			deps = append(deps, plugindata.String(dep))
+		}
+		dependencies = append(dependencies, plugindata.Map{
+			"ref":        plugindata.String(ref),
+			"depends_on": deps,
+		})
+	}
+
+	return plugindata.Map{
+		"spdx_version":  plugindata.String(doc.SPDXVersion),
+		"name":          optionalString(doc.Name),
+		"namespace":     optionalString(doc.DocumentNamespace),
+		"created":       created,
+		"creators":      creators,
+		"describes":     describes,
+		"packages":      packages,
+		"relationships": relationships,
+		"dependencies":  dependencies,
+	}
+}
+
+func (pkg *spdxPackage) data() plugindata.Map {
+	var purl, cpe plugindata.Data
+	for _, ref := range pkg.ExternalRefs {
+		switch ref.Type {
+		case "purl":
+			if purl == nil {
+				purl = plugindata.String(ref.Locator)
+			}
+		case "cpe23Type", "cpe22Type":
+			if cpe == nil {
+				cpe = plugindata.String(ref.Locator)
+			}
+		}
+	}
+	checksums := plugindata.Map{}
+	for _, c := range pkg.Checksums {
+		checksums[c.Algorithm] = plugindata.String(c.Value)
+	}
+	return plugindata.Map{
+		"spdx_id":           plugindata.String(pkg.SPDXID),
+		"name":              plugindata.String(pkg.Name),
+		"version":           optionalString(pkg.VersionInfo),
+		"supplier":          spdxValue(pkg.Supplier),
+		"purpose":           optionalString(pkg.Purpose),
+		"download_location": spdxValue(pkg.DownloadLocation),
+		"license_concluded": spdxValue(pkg.LicenseConcluded),
+		"license_declared":  spdxValue(pkg.LicenseDeclared),
+		"purl":              purl,
+		"cpe":               cpe,
+		"checksums":         checksums,
+	}
+}
+
+func spdxValue(s string) plugindata.Data {
+	if s == "NOASSERTION" || s == "NONE" {
+		return nil
+	}
+	return optionalString(s)
+}
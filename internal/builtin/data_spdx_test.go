@@ -0,0 +1,114 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type SPDXDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestSPDXDataSourceSuite(t *testing.T) {
+	suite.Run(t, &SPDXDataSourceTestSuite{})
+}
+
+func (s *SPDXDataSourceTestSuite) SetupSuite() {
+	s.schema = makeSPDXDataSource()
+}
+
+func (s *SPDXDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *SPDXDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *SPDXDataSourceTestSuite) TestPath() {
+	data := s.fetch(`path = "testdata/spdx/sbom.spdx.json"`, diagtest.Asserts{})
+	doc, ok := data.(plugindata.Map)
+	s.Require().True(ok)
+	s.Equal(plugindata.String("SPDX-2.3"), doc["spdx_version"])
+	s.Equal(plugindata.String("webapp"), doc["name"])
+	s.Equal(plugindata.String("https://example.com/spdx/webapp-2.0.0"), doc["namespace"])
+	s.Equal(plugindata.Time(time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)), doc["created"])
+	s.Equal(plugindata.List{
+		plugindata.String("Tool: syft-1.4.0"),
+		plugindata.String("Organization: Example"),
+	}, doc["creators"])
+	s.Equal(plugindata.List{plugindata.String("SPDXRef-webapp")}, doc["describes"])
+
+	packages := doc["packages"].(plugindata.List)
+	s.Require().Len(packages, 3)
+	s.Equal(plugindata.Map{
+		"spdx_id":           plugindata.String("SPDXRef-net"),
+		"name":              plugindata.String("golang.org/x/net"),
+		"version":           plugindata.String("v0.15.0"),
+		"supplier":          plugindata.String("Organization: Go"),
+		"purpose":           nil,
+		"download_location": plugindata.String("https://proxy.golang.org"),
+		"license_concluded": nil,
+		"license_declared":  plugindata.String("BSD-3-Clause"),
+		"purl":              plugindata.String("pkg:golang/golang.org/x/net@v0.15.0"),
+		"cpe":               plugindata.String("cpe:2.3:a:golang:net:v0.15.0:*:*:*:*:*:*:*"),
+		"checksums":         plugindata.Map{"SHA256": plugindata.String("deadbeef")},
+	}, packages[1])
+	s.Equal(plugindata.String("APPLICATION"), packages[0].(plugindata.Map)["purpose"])
+	s.Nil(packages[2].(plugindata.Map)["download_location"])
+	s.Nil(packages[2].(plugindata.Map)["license_concluded"])
+
+	s.Len(doc["relationships"], 5)
+	s.Equal(plugindata.Map{
+		"element": plugindata.String("SPDXRef-DOCUMENT"),
+		"type":    plugindata.String("DESCRIBES"),
+		"related": plugindata.String("SPDXRef-webapp"),
+	}, doc["relationships"].(plugindata.List)[0])
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"ref": plugindata.String("SPDXRef-webapp"),
+			"depends_on": plugindata.List{
+				plugindata.String("SPDXRef-net"),
+				plugindata.String("SPDXRef-openssl"),
+			},
+		},
+	}, doc["dependencies"])
+}
+
+func (s *SPDXDataSourceTestSuite) TestGlob() {
+	data := s.fetch(`glob = "testdata/spdx/*.spdx.json"`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Require().Len(list, 1)
+	s.Equal(plugindata.String("sbom.spdx.json"), list[0].(plugindata.Map)["file_name"])
+
+	data = s.fetch(`glob = "testdata/spdx/*.missing"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{}, data)
+}
+
+func (s *SPDXDataSourceTestSuite) TestErrors() {
+	s.fetch(`path = "testdata/cyclonedx/bom.cdx.json"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("not a SPDX document"),
+	}})
+	s.fetch(``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+	}})
+}
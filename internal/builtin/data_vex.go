@@ -0,0 +1,158 @@
+package builtin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// vexStatement is a status of a vulnerability, normalized to OpenVEX vocabulary.
+type vexStatement struct {
+	status        string
+	justification string
+	detail        string
+	action        string
+	response      []string
+}
+
+func (s vexStatement) data() plugindata.Map {
+	response := make(plugindata.List, 0, len(s.response))
+	for _, r := range s.response {
+		response = append(response, plugindata.String(r))
+	}
+	return plugindata.Map{
+		"status":        optionalString(s.status),
+		"justification": optionalString(s.justification),
+		"detail":        optionalString(s.detail),
+		"action":        optionalString(s.action),
+		"response":      response,
+	}
+}
+
+// vexIndex maps the upper-cased vulnerability IDs and aliases to their statements.
+type vexIndex map[string]vexStatement
+
+func (idx vexIndex) lookup(ids ...string) (vexStatement, bool) {
+	for _, id := range ids {
+		if s, ok := idx[strings.ToUpper(id)]; ok {
+			return s, true
+		}
+	}
+	return vexStatement{}, false
+}
+
+var vexAttr = &dataspec.AttrSpec{
+	Name:       "vex",
+	Type:       cty.String,
+	ExampleVal: cty.StringVal("path/to/vex.json"),
+	Doc: u.Dedent(`
+		A path to a VEX document in OpenVEX or CycloneDX format. The statuses of the vulnerabilities
+		from the document are set in ` + "`vex`" + ` fields of the matching items
+	`),
+}
+
+// readVEXAttr reads the VEX document from "vex" argument, if it's set.
+func readVEXAttr(args *dataspec.Block) (vexIndex, diagnostics.Diag) {
+	path := stringAttr(args, "vex")
+	if path == "" {
+		return nil, nil
+	}
+	idx, err := readVEX(path)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read the VEX document",
+			Detail:   err.Error(),
+		}}
+	}
+	return idx, nil
+}
+
+type openVEXStatement struct {
+	Vulnerability   json.RawMessage `json:"vulnerability"`
+	Status          string          `json:"status"`
+	Justification   string          `json:"justification"`
+	ImpactStatement string          `json:"impact_statement"`
+	ActionStatement string          `json:"action_statement"`
+}
+
+type openVEXVulnerability struct {
+	Name    string   `json:"name"`
+	ID      string   `json:"@id"`
+	Aliases []string `json:"aliases"`
+}
+
+func readVEX(path string) (vexIndex, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Statements      []openVEXStatement `json:"statements"`
+		Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Statements == nil && doc.Vulnerabilities == nil {
+		return nil, fmt.Errorf("%s: neither OpenVEX statements nor CycloneDX vulnerabilities found", path)
+	}
+	idx := vexIndex{}
+	// the later statements override the earlier ones
+	for _, stmt := range doc.Statements {
+		ids, err := parseOpenVEXVulnerability(stmt.Vulnerability)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, id := range ids {
+			idx[strings.ToUpper(id)] = vexStatement{
+				status:        stmt.Status,
+				justification: stmt.Justification,
+				detail:        stmt.ImpactStatement,
+				action:        stmt.ActionStatement,
+			}
+		}
+	}
+	for _, vuln := range doc.Vulnerabilities {
+		if vuln.Analysis == nil || vuln.ID == "" {
+			continue
+		}
+		idx[strings.ToUpper(vuln.ID)] = vuln.Analysis.statement()
+	}
+	return idx, nil
+}
+
+func parseOpenVEXVulnerability(raw json.RawMessage) ([]string, error) {
+	// OpenVEX before v0.2.0 used plain strings
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return []string{name}, nil
+	}
+	var vuln openVEXVulnerability
+	if err := json.Unmarshal(raw, &vuln); err != nil {
+		return nil, fmt.Errorf("invalid vulnerability in a statement: %w", err)
+	}
+	ids := make([]string, 0, len(vuln.Aliases)+2)
+	for _, id := range append([]string{vuln.Name, vuln.ID}, vuln.Aliases...) {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func optionalString(s string) plugindata.Data {
+	if s == "" {
+		return nil
+	}
+	return plugindata.String(s)
+}
@@ -0,0 +1,239 @@
+package builtin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/pkg/xlsx"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// maxXLSXHeaderCandidates is the number of the first non-empty rows considered as the header row.
+const maxXLSXHeaderCandidates = 10
+
+func makeXLSXDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchXLSXData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("XLSX", "path/to/file*.xlsx", "path/to/file.xlsx"),
+				[]*dataspec.AttrSpec{
+					{
+						Name:       "sheet",
+						Type:       cty.String,
+						ExampleVal: cty.StringVal("Findings"),
+						Doc:        `Name of the sheet to read. If not set, the first sheet of the workbook is read`,
+					},
+					{
+						Name:         "header_row",
+						Type:         cty.Number,
+						MinInclusive: cty.NumberIntVal(0),
+						ExampleVal:   cty.NumberIntVal(3),
+						Doc: u.Dedent(`
+							Number of the header row, starting from 1. The rows above the header row are skipped.
+							If set to 0, the sheet has no header and the columns are named by their letters.
+							If not set, the header row is detected.
+						`),
+					},
+				}...,
+			),
+		},
+		Doc: u.Dedent(`
+			Loads XLSX files with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + ` value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The rows of the sheet are returned as a list of dicts with the names of the columns from the header row
+			as keys. The header row, if ` + "`header_row`" + ` is not set, is the first of the top rows with only text
+			cells that has the most non-empty cells, so the titles above the tables are skipped. The columns without
+			a name are named by their letters, for example, ` + "`C`" + `. Empty rows are skipped.
+
+			The values keep the types of the cells: text, numbers, booleans and dates, for the numbers formatted as dates
+			or times. For example, a sheet with the following cells:
+
+			| Vendor | Score | Reviewed   |
+			| ------ | ----- | ---------- |
+			| Acme   | 87.5  | 2024-03-01 |
+
+			is represented as:
+			` + "```json" + `
+			[
+			  {"Vendor": "Acme", "Score": 87.5, "Reviewed": "2024-03-01T00:00:00Z"}
+			]
+			` + "```" + `
+
+			The sheets are read row by row, so large files are not loaded into memory.
+
+			When ` + "`path`" + ` argument is specified, the data source returns only the content of a file.
+			When ` + "`glob`" + ` argument is specified, the data source returns a list of dicts that contain the content of a file and file's metadata. For example:
+
+			` + "```json" + `
+			[
+			  {
+			    "file_path": "path/file-a.xlsx",
+			    "file_name": "file-a.xlsx",
+			    "content": [
+			      {"Vendor": "Acme", "Score": 87.5}
+			    ]
+			  }
+			]
+			` + "```",
+		),
+	}
+}
+
+type xlsxOptions struct {
+	sheet string
+	// headerRow is -1 if the header row is detected
+	headerRow int
+}
+
+func fetchXLSXData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	opts := xlsxOptions{
+		sheet:     stringAttr(params.Args, "sheet"),
+		headerRow: -1,
+	}
+	if val := params.Args.GetAttrVal("header_row"); !val.IsNull() {
+		n, _ := val.AsBigFloat().Int64()
+		opts.headerRow = int(n)
+	}
+
+	return fetchFiles(ctx, params.Args, "XLSX", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readAndDecodeXLSXFile(ctx, path, opts)
+	})
+}
+
+func readAndDecodeXLSXFile(ctx context.Context, path string, opts xlsxOptions) (plugindata.List, error) {
+	wb, err := xlsx.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+	rows, err := wb.Rows(opts.sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := plugindata.List{}
+	var headers []string
+	// candidates are the first rows buffered until the header row is detected
+	var candidates [][]any
+	detect := opts.headerRow < 0
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		values := rows.Values()
+		switch {
+		case detect:
+			if xlsxRowEmpty(values) {
+				continue
+			}
+			candidates = append(candidates, append([]any{}, values...))
+			if len(candidates) == maxXLSXHeaderCandidates {
+				headers, result = xlsxDetectHeader(candidates, result)
+				candidates, detect = nil, false
+			}
+		case rows.Number() < opts.headerRow:
+			continue
+		case rows.Number() == opts.headerRow:
+			headers = xlsxHeaders(values)
+		default:
+			result = appendXLSXRow(result, headers, values)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if detect && len(candidates) > 0 {
+		_, result = xlsxDetectHeader(candidates, result)
+	}
+	return result, nil
+}
+
+// xlsxDetectHeader finds the header row among the first rows: the first of the rows with only text
+// cells that has the most cells. The rows below the header are added to the result.
+func xlsxDetectHeader(candidates [][]any, result plugindata.List) ([]string, plugindata.List) {
+	header, most := 0, 0
+	for i, values := range candidates {
+		if n, text := xlsxCountCells(values); text && n > most {
+			header, most = i, n
+		}
+	}
+	headers := xlsxHeaders(candidates[header])
+	for _, values := range candidates[header+1:] {
+		result = appendXLSXRow(result, headers, values)
+	}
+	return headers, result
+}
+
+// xlsxCountCells returns the number of non-empty cells and whether all of them are text.
+func xlsxCountCells(values []any) (n int, text bool) {
+	text = true
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		n++
+		_, ok := v.(string)
+		text = text && ok
+	}
+	return n, text
+}
+
+func xlsxHeaders(values []any) []string {
+	headers := make([]string, len(values))
+	for j, v := range values {
+		switch v := v.(type) {
+		case string:
+			headers[j] = strings.TrimSpace(v)
+		case float64:
+			headers[j] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			headers[j] = strconv.FormatBool(v)
+		case time.Time:
+			headers[j] = v.Format(time.DateOnly)
+		}
+		if headers[j] == "" {
+			headers[j] = xlsx.ColumnName(j)
+		}
+	}
+	return numberDuplicateNames(headers)
+}
+
+func xlsxRowEmpty(values []any) bool {
+	for _, v := range values {
+		if v != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func appendXLSXRow(result plugindata.List, headers []string, values []any) plugindata.List {
+	if xlsxRowEmpty(values) {
+		return result
+	}
+	row := make(plugindata.Map, max(len(headers), len(values)))
+	for j := range max(len(headers), len(values)) {
+		var value any
+		if j < len(values) {
+			value = values[j]
+		}
+		if j < len(headers) {
+			row[headers[j]], _ = plugindata.ParseAny(value)
+		} else if value != nil {
+			row[xlsx.ColumnName(j)], _ = plugindata.ParseAny(value)
+		}
+	}
+	return append(result, row)
+}
@@ -0,0 +1,195 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type XLSXDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestXLSXDataSourceSuite(t *testing.T) {
+	suite.Run(t, &XLSXDataSourceTestSuite{})
+}
+
+func (s *XLSXDataSourceTestSuite) SetupSuite() {
+	s.schema = makeXLSXDataSource()
+}
+
+func (s *XLSXDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *XLSXDataSourceTestSuite) fetch(ctx context.Context, args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func xlsxDate(year int, month time.Month, day int) plugindata.Time {
+	return plugindata.Time(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *XLSXDataSourceTestSuite) TestDetectHeader() {
+	// the title above the table is skipped
+	data := s.fetch(context.Background(), `path = "../../pkg/xlsx/testdata/report.xlsx"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"Vendor":   plugindata.String("Acme"),
+			"Score":    plugindata.Number(87.5),
+			"Reviewed": xlsxDate(2024, time.March, 1),
+			"Active":   plugindata.Bool(true),
+			"Notes":    plugindata.String("renewal due"),
+			"F":        plugindata.Number(0.25),
+		},
+		plugindata.Map{
+			"Vendor":   plugindata.String("Globex"),
+			"Score":    plugindata.Number(42),
+			"Reviewed": xlsxDate(2024, time.February, 15),
+			"Active":   plugindata.Bool(false),
+			"Notes":    nil,
+		},
+		plugindata.Map{
+			"Vendor":   plugindata.String("Initech"),
+			"Score":    nil,
+			"Reviewed": nil,
+			"Active":   plugindata.Bool(true),
+			"Notes":    nil,
+		},
+	}, data)
+}
+
+func (s *XLSXDataSourceTestSuite) TestSheet() {
+	data := s.fetch(context.Background(), `
+		path = "../../pkg/xlsx/testdata/report.xlsx"
+		sheet = "Findings"
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"ID":         plugindata.String("F-1"),
+			"Severity":   plugindata.String("high"),
+			"Found at":   plugindata.Time(time.Date(2024, time.January, 2, 13, 30, 0, 0, time.UTC)),
+			"D":          plugindata.String("x"),
+			"Severity_2": plugindata.String("critical"),
+		},
+		plugindata.Map{
+			"ID":         nil,
+			"Severity":   plugindata.String("low"),
+			"Found at":   nil,
+			"D":          nil,
+			"Severity_2": nil,
+		},
+	}, data)
+}
+
+func (s *XLSXDataSourceTestSuite) TestHeaderRow() {
+	data := s.fetch(context.Background(), `
+		path = "../../pkg/xlsx/testdata/report.xlsx"
+		header_row = 4
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"Acme":        plugindata.String("Globex"),
+			"87.5":        plugindata.Number(42),
+			"2024-03-01":  xlsxDate(2024, time.February, 15),
+			"true":        plugindata.Bool(false),
+			"renewal due": nil,
+			"0.25":        nil,
+		},
+		plugindata.Map{
+			"Acme":        plugindata.String("Initech"),
+			"87.5":        nil,
+			"2024-03-01":  nil,
+			"true":        plugindata.Bool(true),
+			"renewal due": nil,
+			"0.25":        nil,
+		},
+	}, data)
+}
+
+func (s *XLSXDataSourceTestSuite) TestNoHeader() {
+	data := s.fetch(context.Background(), `
+		path = "../../pkg/xlsx/testdata/report.xlsx"
+		sheet = "Findings"
+		header_row = 0
+	`, diagtest.Asserts{})
+	s.Require().IsType(plugindata.List{}, data)
+	s.Len(data, 3)
+	s.Equal(plugindata.Map{
+		"A": plugindata.String("ID"),
+		"B": plugindata.String("Severity"),
+		"C": plugindata.String("Found at"),
+		"D": plugindata.String(""),
+		"E": plugindata.String("Severity"),
+	}, data.(plugindata.List)[0])
+}
+
+func (s *XLSXDataSourceTestSuite) TestGlob() {
+	data := s.fetch(context.Background(), `glob = "../../pkg/xlsx/testdata/*.xlsx"`, diagtest.Asserts{})
+	s.Require().IsType(plugindata.List{}, data)
+	files := data.(plugindata.List)
+	s.Require().Len(files, 2)
+	s.Equal(plugindata.Map{
+		"file_path": plugindata.String("../../pkg/xlsx/testdata/legacy.xlsx"),
+		"file_name": plugindata.String("legacy.xlsx"),
+		"content": plugindata.List{
+			plugindata.Map{
+				"name":  plugindata.String("legacy"),
+				"since": plugindata.Time(time.Date(1924, time.January, 2, 12, 0, 0, 0, time.UTC)),
+			},
+		},
+	}, files[0])
+	s.Equal(plugindata.String("report.xlsx"), files[1].(plugindata.Map)["file_name"])
+}
+
+func (s *XLSXDataSourceTestSuite) TestErrors() {
+	s.fetch(context.Background(), `
+		path = "../../pkg/xlsx/testdata/report.xlsx"
+		sheet = "Missing"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains(`sheet "Missing" not found`),
+	}})
+	s.fetch(context.Background(), `path = "testdata/csv/comma.csv"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+	s.fetch(context.Background(), `
+		path = "../../pkg/xlsx/testdata/report.xlsx"
+		glob = "../../pkg/xlsx/testdata/*.xlsx"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains("not both"),
+	}})
+	s.fetch(context.Background(), ``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+	}})
+}
+
+func (s *XLSXDataSourceTestSuite) TestCancellation() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.fetch(ctx, `path = "../../pkg/xlsx/testdata/report.xlsx"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("context canceled"),
+	}})
+}
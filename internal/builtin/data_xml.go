@@ -0,0 +1,123 @@
+package builtin
+
+import (
+	"context"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/builtin/utils"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeXMLDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: fetchXMLData,
+		Args: &dataspec.RootSpec{
+			Attrs: append(
+				fileAttrs("XML", "path/to/file*.xml", "path/to/file.xml"),
+				[]*dataspec.AttrSpec{
+					{
+						Name:        "attribute_prefix",
+						Type:        cty.String,
+						DefaultVal:  cty.StringVal(utils.DefaultXMLAttrPrefix),
+						Constraints: constraint.NonNull,
+						Doc:         `A prefix of the keys of the attributes, to tell them from the child elements`,
+					},
+					{
+						Name:        "text_key",
+						Type:        cty.String,
+						DefaultVal:  cty.StringVal(utils.DefaultXMLTextKey),
+						Constraints: constraint.Meaningful,
+						Doc:         `A key of the text of the elements with attributes or child elements`,
+					},
+					{
+						Name:       "list_elements",
+						Type:       cty.List(cty.String),
+						ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("host"), cty.StringVal("port")}),
+						Doc: u.Dedent(`
+							Names of the elements that are always collected into lists, even if there is only one
+							element with the name. Useful to query the documents with the same shape regardless of
+							the number of elements.
+						`),
+					},
+				}...,
+			),
+		},
+		Doc: u.Dedent(`
+			Loads XML files with the names that match provided ` + "`glob`" + ` pattern or a single file from provided ` + "`path`" + `value.
+
+			Either ` + "`glob`" + ` or ` + "`path`" + ` argument must be set.
+
+			The XML document is converted to a dict with the name of the root element as the only key:
+			- the elements without attributes and child elements become strings, or nulls if they are empty
+			- the other elements become dicts: the attributes are prefixed with ` + "`attribute_prefix`" + `, the child
+			  elements are keyed by their names and the text, if any, is stored under ` + "`text_key`" + `
+			- the repeated child elements with the same name are collected into lists
+
+			For example, ` + "`<host starttime=\"1\"><address addr=\"10.0.0.1\"/><status>up</status></host>`" + ` becomes:
+
+			` + "```json" + `
+			{
+			  "host": {
+			    "@starttime": "1",
+			    "address": {"@addr": "10.0.0.1"},
+			    "status": "up"
+			  }
+			}
+			` + "```" + `
+
+			The values are not converted to numbers or booleans, XML carries no types.
+
+			When ` + "`path`" + ` argument is specified, the data source returns only the content of a file.
+			When ` + "`glob`" + ` argument is specified, the data source returns a list of dicts that contain the content of a file and file's metadata. For example:
+
+			` + "```json" + `
+			[
+			  {
+			    "file_path": "path/file-a.xml",
+			    "file_name": "file-a.xml",
+			    "content": {
+			      "report": {"@version": "1"}
+			    }
+			  }
+			]
+			` + "```",
+		),
+	}
+}
+
+func fetchXMLData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	opts := parseXMLOptions(params.Args)
+
+	return fetchFiles(ctx, params.Args, "XML", func(ctx context.Context, path string) (plugindata.Data, error) {
+		return readAndDecodeXMLFile(ctx, path, opts)
+	})
+}
+
+func parseXMLOptions(args *dataspec.Block) utils.XMLOptions {
+	opts := utils.XMLOptions{
+		AttrPrefix: args.GetAttrVal("attribute_prefix").AsString(),
+		TextKey:    args.GetAttrVal("text_key").AsString(),
+	}
+	if list := args.GetAttrVal("list_elements"); !list.IsNull() {
+		for _, name := range list.AsValueSlice() {
+			opts.ListElements = append(opts.ListElements, name.AsString())
+		}
+	}
+	return opts
+}
+
+func readAndDecodeXMLFile(ctx context.Context, path string, opts utils.XMLOptions) (plugindata.Data, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return utils.ParseXMLContentWithOptions(ctx, f, opts)
+}
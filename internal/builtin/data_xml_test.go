@@ -0,0 +1,186 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type XMLDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestXMLDataSourceSuite(t *testing.T) {
+	suite.Run(t, &XMLDataSourceTestSuite{})
+}
+
+func (s *XMLDataSourceTestSuite) SetupSuite() {
+	s.schema = makeXMLDataSource()
+}
+
+func (s *XMLDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *XMLDataSourceTestSuite) fetch(ctx context.Context, args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(ctx, &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func (s *XMLDataSourceTestSuite) TestPath() {
+	data := s.fetch(context.Background(), `path = "testdata/xml/nmap.xml"`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"nmaprun": plugindata.Map{
+			"@scanner": plugindata.String("nmap"),
+			"@version": plugindata.String("7.94"),
+			"host": plugindata.Map{
+				"status": plugindata.Map{"@state": plugindata.String("up")},
+				"address": plugindata.Map{
+					"@addr":     plugindata.String("10.0.0.1"),
+					"@addrtype": plugindata.String("ipv4"),
+				},
+				"ports": plugindata.Map{
+					"port": plugindata.Map{
+						"@protocol": plugindata.String("tcp"),
+						"@portid":   plugindata.String("22"),
+						"state":     plugindata.Map{"@state": plugindata.String("open")},
+						"service":   plugindata.Map{"@name": plugindata.String("ssh")},
+					},
+				},
+			},
+			"runstats": plugindata.Map{
+				"finished": plugindata.Map{
+					"@elapsed": plugindata.String("1.23"),
+					"#text":    plugindata.String("done"),
+				},
+			},
+		},
+	}, data)
+}
+
+func (s *XMLDataSourceTestSuite) TestOptions() {
+	data := s.fetch(context.Background(), `
+		path = "testdata/xml/nmap.xml"
+		attribute_prefix = ""
+		text_key = "value"
+		list_elements = ["host", "port"]
+	`, diagtest.Asserts{})
+	nmaprun := data.(plugindata.Map)["nmaprun"].(plugindata.Map)
+	hosts, ok := nmaprun["host"].(plugindata.List)
+	s.Require().True(ok, "host must be a list")
+	s.Require().Len(hosts, 1)
+	ports := hosts[0].(plugindata.Map)["ports"].(plugindata.Map)["port"]
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"protocol": plugindata.String("tcp"),
+			"portid":   plugindata.String("22"),
+			"state":    plugindata.Map{"state": plugindata.String("open")},
+			"service":  plugindata.Map{"name": plugindata.String("ssh")},
+		},
+	}, ports)
+	s.Equal(plugindata.Map{
+		"elapsed": plugindata.String("1.23"),
+		"value":   plugindata.String("done"),
+	}, nmaprun["runstats"].(plugindata.Map)["finished"])
+}
+
+func (s *XMLDataSourceTestSuite) TestEncoding() {
+	data := s.fetch(context.Background(), `path = "testdata/xml/latin1.xml"`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"note": plugindata.Map{"to": plugindata.String("Jürgen")},
+	}, data)
+}
+
+func (s *XMLDataSourceTestSuite) TestGlob() {
+	data := s.fetch(context.Background(), `glob = "testdata/xml/dir/*.xml"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{
+		plugindata.Map{
+			"file_path": plugindata.String("testdata/xml/dir/a.xml"),
+			"file_name": plugindata.String("a.xml"),
+			"content": plugindata.Map{
+				"testsuite": plugindata.Map{
+					"@name":  plugindata.String("a"),
+					"@tests": plugindata.String("2"),
+					"testcase": plugindata.List{
+						plugindata.Map{"@name": plugindata.String("first")},
+						plugindata.Map{
+							"@name": plugindata.String("second"),
+							"failure": plugindata.Map{
+								"@message": plugindata.String("boom"),
+								"#text":    plugindata.String("trace"),
+							},
+						},
+					},
+				},
+			},
+		},
+		plugindata.Map{
+			"file_path": plugindata.String("testdata/xml/dir/b.xml"),
+			"file_name": plugindata.String("b.xml"),
+			"content": plugindata.Map{
+				"testsuite": plugindata.Map{
+					"@name":  plugindata.String("b"),
+					"@tests": plugindata.String("0"),
+				},
+			},
+		},
+	}, data)
+}
+
+func (s *XMLDataSourceTestSuite) TestGlobNoMatches() {
+	data := s.fetch(context.Background(), `glob = "testdata/xml/unknown*.xml"`, diagtest.Asserts{})
+	s.Equal(plugindata.List{}, data)
+}
+
+func (s *XMLDataSourceTestSuite) TestInvalidFile() {
+	s.fetch(context.Background(), `path = "testdata/xml/invalid.txt"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+}
+
+func (s *XMLDataSourceTestSuite) TestMissingFile() {
+	s.fetch(context.Background(), `path = "testdata/xml/missing.xml"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailContains("no such file or directory"),
+	}})
+}
+
+func (s *XMLDataSourceTestSuite) TestArgs() {
+	s.fetch(context.Background(), `
+		path = "testdata/xml/nmap.xml"
+		glob = "testdata/xml/dir/*.xml"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailContains("not both"),
+	}})
+	s.fetch(context.Background(), ``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+	}})
+}
+
+func (s *XMLDataSourceTestSuite) TestCancellation() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.fetch(ctx, `glob = "testdata/xml/dir/*.xml"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the files"),
+		diagtest.DetailContains("context canceled"),
+	}})
+}
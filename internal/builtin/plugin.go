@@ -15,13 +15,24 @@ func Plugin(version string, logger *slog.Logger, tracer trace.Tracer) *plugin.Sc
 		Name:    Name,
 		Version: version,
 		DataSources: plugin.DataSources{
-			"csv":   makeCSVDataSource(),
-			"txt":   makeTXTDataSource(),
-			"rss":   makeRSSDataSource(),
-			"json":  makeJSONDataSource(),
-			"yaml":  makeYAMLDataSource(),
-			"http":  makeHTTPDataSource(version),
-			"sleep": makeSleepDataSource(logger),
+			"csv":        makeCSVDataSource(),
+			"txt":        makeTXTDataSource(),
+			"rss":        makeRSSDataSource(),
+			"json":       makeJSONDataSource(),
+			"yaml":       makeYAMLDataSource(),
+			"xml":        makeXMLDataSource(),
+			"xlsx":       makeXLSXDataSource(),
+			"parquet":    makeParquetDataSource(),
+			"sarif":      makeSARIFDataSource(),
+			"cyclonedx":  makeCycloneDXDataSource(),
+			"spdx":       makeSPDXDataSource(),
+			"http":       makeHTTPDataSource(version),
+			"openapi":    makeOpenAPIDataSource(version),
+			"html_table": makeHTMLTableDataSource(version),
+			"git":        makeGitDataSource(),
+			"log":        makeLogDataSource(),
+			"exec":       makeExecDataSource(),
+			"sleep":      makeSleepDataSource(logger),
 		},
 		ContentProviders: plugin.ContentProviders{
 			"toc":         makeTOCContentProvider(),
@@ -29,15 +40,30 @@ func Plugin(version string, logger *slog.Logger, tracer trace.Tracer) *plugin.Sc
 			"title":       makeTitleContentProvider(),
 			"code":        makeCodeContentProvider(),
 			"blockquote":  makeBlockQuoteContentProvider(),
+			"callout":     makeCalloutContentProvider(),
+			"details":     makeDetailsContentProvider(),
 			"image":       makeImageContentProvider(),
 			"list":        makeListContentProvider(),
 			"table":       makeTableContentProvider(),
+			"chart":       makeChartContentProvider(),
+			"include":     makeIncludeContentProvider(),
+			"metrics":     makeMetricsContentProvider(),
+			"timeline":    makeTimelineContentProvider(),
+			"diff":        makeDiffContentProvider(),
 			"frontmatter": makeFrontMatterContentProvider(),
 			"sleep":       makeSleepContentProvider(logger),
 		},
 		Publishers: plugin.Publishers{
-			"local_file": makeLocalFilePublisher(logger, tracer),
-			"hub":        makeHubPublisher(version, defaultHubClientLoader, logger, tracer),
+			"local_file":    makeLocalFilePublisher(logger, tracer),
+			"hub":           makeHubPublisher(version, defaultHubClientLoader, logger, tracer),
+			"slack_webhook": makeSlackWebhookPublisher(logger, tracer),
+			"teams_webhook": makeTeamsWebhookPublisher(logger, tracer),
+		},
+		NodeRenderers: plugin.NodeRenderers{
+			chartTypeURL:      makeChartNodeRenderer(),
+			metricsTypeURL:    makeMetricsNodeRenderer(),
+			timelineTypeURL:   makeTimelineNodeRenderer(),
+			styledTextTypeURL: makeStyledTextNodeRenderer(),
 		},
 	}
 }
@@ -16,17 +16,42 @@ func TestPluginSchema(t *testing.T) {
 	assert.NotNil(t, schema.DataSources["txt"])
 	assert.NotNil(t, schema.DataSources["json"])
 	assert.NotNil(t, schema.DataSources["rss"])
+	assert.NotNil(t, schema.DataSources["openapi"])
+	assert.NotNil(t, schema.DataSources["xml"])
+	assert.NotNil(t, schema.DataSources["html_table"])
+	assert.NotNil(t, schema.DataSources["xlsx"])
+	assert.NotNil(t, schema.DataSources["parquet"])
+	assert.NotNil(t, schema.DataSources["sarif"])
+	assert.NotNil(t, schema.DataSources["cyclonedx"])
+	assert.NotNil(t, schema.DataSources["spdx"])
+	assert.NotNil(t, schema.DataSources["git"])
+	assert.NotNil(t, schema.DataSources["exec"])
+	assert.NotNil(t, schema.DataSources["log"])
 	// Content Providers
 	assert.NotNil(t, schema.ContentProviders["toc"])
 	assert.NotNil(t, schema.ContentProviders["text"])
 	assert.NotNil(t, schema.ContentProviders["title"])
 	assert.NotNil(t, schema.ContentProviders["code"])
 	assert.NotNil(t, schema.ContentProviders["blockquote"])
+	assert.NotNil(t, schema.ContentProviders["callout"])
+	assert.NotNil(t, schema.ContentProviders["details"])
 	assert.NotNil(t, schema.ContentProviders["image"])
 	assert.NotNil(t, schema.ContentProviders["list"])
 	assert.NotNil(t, schema.ContentProviders["table"])
+	assert.NotNil(t, schema.ContentProviders["chart"])
+	assert.NotNil(t, schema.ContentProviders["include"])
+	assert.NotNil(t, schema.ContentProviders["metrics"])
+	assert.NotNil(t, schema.ContentProviders["timeline"])
+	assert.NotNil(t, schema.ContentProviders["diff"])
 	assert.NotNil(t, schema.ContentProviders["frontmatter"])
 	// Publishers
 	assert.NotNil(t, schema.Publishers["local_file"])
 	assert.NotNil(t, schema.Publishers["hub"])
+	assert.NotNil(t, schema.Publishers["slack_webhook"])
+	assert.NotNil(t, schema.Publishers["teams_webhook"])
+	// Node renderers
+	assert.NotNil(t, schema.NodeRenderers[chartTypeURL])
+	assert.NotNil(t, schema.NodeRenderers[metricsTypeURL])
+	assert.NotNil(t, schema.NodeRenderers[timelineTypeURL])
+	assert.NotNil(t, schema.NodeRenderers[styledTextTypeURL])
 }
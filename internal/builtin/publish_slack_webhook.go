@@ -0,0 +1,182 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/print/slackprint"
+)
+
+const webhookTimeout = 30 * time.Second
+
+func makeSlackWebhookPublisher(logger *slog.Logger, tracer trace.Tracer) *plugin.Publisher {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if tracer == nil {
+		tracer = nooptrace.Tracer{}
+	}
+	return &plugin.Publisher{
+		Doc: "Publishes content to Slack using an incoming webhook. " +
+			"The document is converted to Block Kit messages, long documents are split into several messages.",
+		Tags:   []string{},
+		Config: makeWebhookConfigSpec(),
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				webhookURLArgSpec(),
+				{
+					Name:       "channel",
+					Doc:        "Channel override. Supported by the legacy webhooks only.",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("#security-alerts"),
+				},
+				{
+					Name:       "username",
+					Doc:        "Name of the bot override. Supported by the legacy webhooks only.",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("Fabric"),
+				},
+				{
+					Name:       "icon_emoji",
+					Doc:        "Icon of the bot override. Supported by the legacy webhooks only.",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal(":robot_face:"),
+				},
+			},
+		},
+		AllowedFormats: []plugin.OutputFormat{plugin.OutputFormatUnspecified},
+		PublishFunc:    publishSlackWebhook(logger, tracer),
+	}
+}
+
+func publishSlackWebhook(logger *slog.Logger, tracer trace.Tracer) plugin.PublishFunc {
+	return func(ctx context.Context, params *plugin.PublishParams) diagnostics.Diag {
+		ctx, span := tracer.Start(ctx, "publishSlackWebhook")
+		defer span.End()
+
+		url, diags := parseWebhookURL(params)
+		if diags.HasErrors() {
+			return diags
+		}
+		document, _ := parseScope(params.DataContext)
+		if document == nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse document",
+				Detail:   "document is required",
+			}}
+		}
+		msgs, err := slackprint.New().PrintMessages(ctx, document)
+		if err != nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to print Slack messages",
+				Detail:   err.Error(),
+			}}
+		}
+		for _, msg := range msgs {
+			msg.Channel = stringAttr(params.Args, "channel")
+			msg.Username = stringAttr(params.Args, "username")
+			msg.IconEmoji = stringAttr(params.Args, "icon_emoji")
+		}
+		logger.InfoContext(ctx, "Publishing to Slack", "messages", len(msgs))
+		for i, msg := range msgs {
+			err = postWebhook(ctx, url, msg)
+			if err != nil {
+				return diagnostics.Diag{{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to send Slack message",
+					Detail:   fmt.Sprintf("message %d of %d: %s", i+1, len(msgs), err),
+				}}
+			}
+		}
+		return nil
+	}
+}
+
+func makeWebhookConfigSpec() *dataspec.RootSpec {
+	return &dataspec.RootSpec{
+		Attrs: []*dataspec.AttrSpec{
+			{
+				Name:   "url",
+				Doc:    "Webhook URL.",
+				Type:   cty.String,
+				Secret: true,
+			},
+		},
+	}
+}
+
+func webhookURLArgSpec() *dataspec.AttrSpec {
+	return &dataspec.AttrSpec{
+		Name:        "url",
+		Doc:         "Webhook URL override, to post to another channel than the one configured.",
+		Type:        cty.String,
+		Constraints: constraint.Meaningful,
+		Secret:      true,
+	}
+}
+
+// parseWebhookURL returns the webhook url from the args, or from the config if not overridden.
+func parseWebhookURL(params *plugin.PublishParams) (string, diagnostics.Diag) {
+	if url := stringAttr(params.Args, "url"); url != "" {
+		return url, nil
+	}
+	if params.Config != nil {
+		if url := stringAttr(params.Config, "url"); url != "" {
+			return url, nil
+		}
+	}
+	return "", diagnostics.Diag{{
+		Severity: hcl.DiagError,
+		Summary:  "Missing webhook URL",
+		Detail:   "url must be set in the publisher configuration or arguments",
+	}}
+}
+
+func stringAttr(block *dataspec.Block, name string) string {
+	val := block.GetAttrVal(name)
+	if val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
+
+// postWebhook sends the payload as JSON to the webhook url.
+func postWebhook(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: webhookTimeout}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
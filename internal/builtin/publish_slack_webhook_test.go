@@ -0,0 +1,117 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func chatDocument(markdown string) plugindata.Map {
+	return plugindata.Map{
+		"document": plugindata.Map{
+			"content": plugindata.Map{
+				"type": plugindata.String("section"),
+				"children": plugindata.List{
+					plugindata.Map{
+						"type":     plugindata.String("element"),
+						"markdown": plugindata.String(markdown),
+					},
+				},
+			},
+		},
+	}
+}
+
+// webhookStandIn records the JSON payloads posted to the webhook.
+func webhookStandIn(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var payloads []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+		w.WriteHeader(status)
+		w.Write([]byte("invalid_payload"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &payloads
+}
+
+func Test_makeSlackWebhookPublisher(t *testing.T) {
+	schema := makeSlackWebhookPublisher(nil, nil)
+	assert.NotNil(t, schema.Doc)
+	assert.NotNil(t, schema.Config)
+	assert.NotNil(t, schema.Args)
+	assert.Equal(t, []plugin.OutputFormat{plugin.OutputFormatUnspecified}, schema.AllowedFormats)
+	assert.NotNil(t, schema.PublishFunc)
+}
+
+func Test_publishSlackWebhook(t *testing.T) {
+	srv, payloads := webhookStandIn(t, http.StatusOK)
+	schema := makeSlackWebhookPublisher(nil, nil)
+	diags := schema.PublishFunc(context.Background(), &plugin.PublishParams{
+		Config: dataspec.NewBlock([]string{"config"}, map[string]cty.Value{
+			"url": cty.StringVal(srv.URL),
+		}),
+		Args: dataspec.NewBlock([]string{"slack_webhook"}, map[string]cty.Value{
+			"url":        cty.NullVal(cty.String),
+			"channel":    cty.StringVal("#alerts"),
+			"username":   cty.NullVal(cty.String),
+			"icon_emoji": cty.NullVal(cty.String),
+		}),
+		DataContext: chatDocument("Hello *world*"),
+	})
+	require.Nil(t, diags)
+	require.Len(t, *payloads, 1)
+	assert.Equal(t, map[string]any{
+		"text":    "Hello _world_",
+		"channel": "#alerts",
+		"blocks": []any{
+			map[string]any{
+				"type": "section",
+				"text": map[string]any{"type": "mrkdwn", "text": "Hello _world_"},
+			},
+		},
+	}, (*payloads)[0])
+}
+
+func Test_publishSlackWebhookError(t *testing.T) {
+	srv, _ := webhookStandIn(t, http.StatusBadRequest)
+	schema := makeSlackWebhookPublisher(nil, nil)
+	diags := schema.PublishFunc(context.Background(), &plugin.PublishParams{
+		Args: dataspec.NewBlock([]string{"slack_webhook"}, map[string]cty.Value{
+			"url":        cty.StringVal(srv.URL),
+			"channel":    cty.NullVal(cty.String),
+			"username":   cty.NullVal(cty.String),
+			"icon_emoji": cty.NullVal(cty.String),
+		}),
+		DataContext: chatDocument("Hello"),
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Failed to send Slack message", diags[0].Summary)
+	assert.Equal(t, "message 1 of 1: unexpected status code 400: invalid_payload", diags[0].Detail)
+}
+
+func Test_publishSlackWebhookMissingURL(t *testing.T) {
+	schema := makeSlackWebhookPublisher(nil, nil)
+	diags := schema.PublishFunc(context.Background(), &plugin.PublishParams{
+		Args: dataspec.NewBlock([]string{"slack_webhook"}, map[string]cty.Value{
+			"url": cty.NullVal(cty.String),
+		}),
+		DataContext: chatDocument("Hello"),
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Missing webhook URL", diags[0].Summary)
+}
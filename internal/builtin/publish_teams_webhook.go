@@ -0,0 +1,80 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/hashicorp/hcl/v2"
+	"go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/print/teamsprint"
+)
+
+func makeTeamsWebhookPublisher(logger *slog.Logger, tracer trace.Tracer) *plugin.Publisher {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if tracer == nil {
+		tracer = nooptrace.Tracer{}
+	}
+	return &plugin.Publisher{
+		Doc: "Publishes content to Microsoft Teams using an incoming webhook. " +
+			"The document is converted to Adaptive Cards, long documents are split into several messages. " +
+			"Teams webhooks are bound to a channel, use the `url` argument to post to another channel.",
+		Tags:   []string{},
+		Config: makeWebhookConfigSpec(),
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				webhookURLArgSpec(),
+			},
+		},
+		AllowedFormats: []plugin.OutputFormat{plugin.OutputFormatUnspecified},
+		PublishFunc:    publishTeamsWebhook(logger, tracer),
+	}
+}
+
+func publishTeamsWebhook(logger *slog.Logger, tracer trace.Tracer) plugin.PublishFunc {
+	return func(ctx context.Context, params *plugin.PublishParams) diagnostics.Diag {
+		ctx, span := tracer.Start(ctx, "publishTeamsWebhook")
+		defer span.End()
+
+		url, diags := parseWebhookURL(params)
+		if diags.HasErrors() {
+			return diags
+		}
+		document, _ := parseScope(params.DataContext)
+		if document == nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse document",
+				Detail:   "document is required",
+			}}
+		}
+		msgs, err := teamsprint.New().PrintMessages(ctx, document)
+		if err != nil {
+			return diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to print Teams messages",
+				Detail:   err.Error(),
+			}}
+		}
+		logger.InfoContext(ctx, "Publishing to Teams", "messages", len(msgs))
+		for i, msg := range msgs {
+			err = postWebhook(ctx, url, msg)
+			if err != nil {
+				return diagnostics.Diag{{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to send Teams message",
+					Detail:   fmt.Sprintf("message %d of %d: %s", i+1, len(msgs), err),
+				}}
+			}
+		}
+		return nil
+	}
+}
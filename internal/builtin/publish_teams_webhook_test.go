@@ -0,0 +1,46 @@
+package builtin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+)
+
+func Test_makeTeamsWebhookPublisher(t *testing.T) {
+	schema := makeTeamsWebhookPublisher(nil, nil)
+	assert.NotNil(t, schema.Doc)
+	assert.NotNil(t, schema.Config)
+	assert.NotNil(t, schema.Args)
+	assert.Equal(t, []plugin.OutputFormat{plugin.OutputFormatUnspecified}, schema.AllowedFormats)
+	assert.NotNil(t, schema.PublishFunc)
+}
+
+func Test_publishTeamsWebhook(t *testing.T) {
+	srv, payloads := webhookStandIn(t, http.StatusAccepted)
+	schema := makeTeamsWebhookPublisher(nil, nil)
+	diags := schema.PublishFunc(context.Background(), &plugin.PublishParams{
+		Config: dataspec.NewBlock([]string{"config"}, map[string]cty.Value{
+			"url": cty.StringVal("http://127.0.0.1:1/unused"),
+		}),
+		Args: dataspec.NewBlock([]string{"teams_webhook"}, map[string]cty.Value{
+			"url": cty.StringVal(srv.URL),
+		}),
+		DataContext: chatDocument("Hello **world**"),
+	})
+	require.Nil(t, diags)
+	require.Len(t, *payloads, 1)
+	payload := (*payloads)[0]
+	assert.Equal(t, "message", payload["type"])
+	card := payload["attachments"].([]any)[0].(map[string]any)["content"].(map[string]any)
+	assert.Equal(t, "AdaptiveCard", card["type"])
+	assert.Equal(t, []any{
+		map[string]any{"type": "TextBlock", "text": "Hello **world**", "wrap": true},
+	}, card["body"])
+}
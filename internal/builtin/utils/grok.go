@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// GrokPatterns are the base patterns available in grok expressions.
+var GrokPatterns = map[string]string{
+	"WORD":              `\b\w+\b`,
+	"NOTSPACE":          `\S+`,
+	"SPACE":             `\s*`,
+	"DATA":              `.*?`,
+	"GREEDYDATA":        `.*`,
+	"INT":               `[+-]?\d+`,
+	"POSINT":            `\b[1-9]\d*\b`,
+	"NUMBER":            `[+-]?(?:\d+(?:\.\d*)?|\.\d+)`,
+	"BASE16NUM":         `(?:0[xX])?[0-9A-Fa-f]+`,
+	"QS":                `"(?:[^"\\]|\\.)*"`,
+	"QUOTEDSTRING":      `%{QS}`,
+	"UUID":              `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC":               `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}`,
+	"IPV4":              `(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)`,
+	"IPV6":              `(?:[A-Fa-f0-9]{0,4}:){2,7}(?:[A-Fa-f0-9]{1,4}|%{IPV4})?`,
+	"IP":                `(?:%{IPV6}|%{IPV4})`,
+	"HOSTNAME":          `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?\b`,
+	"IPORHOST":          `(?:%{IP}|%{HOSTNAME})`,
+	"HOSTPORT":          `%{IPORHOST}:%{POSINT}`,
+	"USERNAME":          `[a-zA-Z0-9._-]+`,
+	"USER":              `%{USERNAME}`,
+	"EMAILADDRESS":      `[a-zA-Z0-9!#$%&'*+/=?^_{|}~.-]+@%{HOSTNAME}`,
+	"PATH":              `(?:/[^\s?#]*)+`,
+	"URIPATHPARAM":      `\S+`,
+	"URI":               `[A-Za-z][A-Za-z0-9+.-]*://\S+`,
+	"LOGLEVEL":          `(?i:trace|debug|info|notice|warn(?:ing)?|err(?:or)?|crit(?:ical)?|fatal|severe|alert|emerg(?:ency)?)`,
+	"MONTH":             `\b(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[a-z]*\b`,
+	"MONTHNUM":          `(?:0?[1-9]|1[0-2])`,
+	"MONTHDAY":          `(?:0[1-9]|[12]\d|3[01]|[1-9])`,
+	"DAY":               `\b(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun)[a-z]*\b`,
+	"YEAR":              `\d{4}`,
+	"HOUR":              `(?:2[0-3]|[01]?\d)`,
+	"MINUTE":            `[0-5]\d`,
+	"SECOND":            `(?:[0-5]?\d|60)(?:[.,]\d+)?`,
+	"TIME":              `%{HOUR}:%{MINUTE}:%{SECOND}`,
+	"ISO8601_TIMEZONE":  `(?:Z|[+-]%{HOUR}(?::?%{MINUTE}))`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TIMEZONE}?`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} [+-]\d{4}`,
+	"SYSLOGTIMESTAMP":   `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"PROG":              `[\x21-\x5a\x5c\x5e-\x7e]+`,
+	"SYSLOGPROG":        `%{PROG:program}(?:\[%{POSINT:pid:int}\])?`,
+	"SYSLOGHOST":        `%{IPORHOST}`,
+	"SYSLOGBASE":        `%{SYSLOGTIMESTAMP:timestamp} %{SYSLOGHOST:host} %{SYSLOGPROG}:`,
+}
+
+// grokGroupPrefix prefixes the names of the groups generated for the pattern references.
+const grokGroupPrefix = "_grok_"
+
+var grokRefRe = regexp.MustCompile(`%\{(\w+)(?::([\w.@-]+))?(?::(int|float|string))?\}`)
+
+// Grok is a compiled grok expression: a regular expression with `%{PATTERN:field:type}` references.
+type Grok struct {
+	re     *regexp.Regexp
+	fields []grokField
+}
+
+type grokField struct {
+	group int
+	name  string
+	typ   string
+}
+
+// CompileGrok compiles the expression. Named groups of the regular expression, `(?P<field>...)`,
+// are captured as fields too. The custom patterns override the base ones.
+func CompileGrok(expr string, custom map[string]string) (*Grok, error) {
+	var fields []grokField
+	expanded, err := expandGrok(expr, custom, &fields, 0)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	g := &Grok{re: re}
+	for i, name := range re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		if n, ok := strings.CutPrefix(name, grokGroupPrefix); ok {
+			idx, _ := strconv.Atoi(n)
+			f := fields[idx]
+			f.group = i
+			g.fields = append(g.fields, f)
+			continue
+		}
+		g.fields = append(g.fields, grokField{group: i, name: name, typ: "string"})
+	}
+	return g, nil
+}
+
+func expandGrok(expr string, custom map[string]string, fields *[]grokField, depth int) (string, error) {
+	if depth > 32 {
+		return "", fmt.Errorf("too deeply nested patterns")
+	}
+	var err error
+	result := grokRefRe.ReplaceAllStringFunc(expr, func(ref string) string {
+		if err != nil {
+			return ""
+		}
+		m := grokRefRe.FindStringSubmatch(ref)
+		pattern, ok := custom[m[1]]
+		if !ok {
+			pattern, ok = GrokPatterns[m[1]]
+		}
+		if !ok {
+			err = fmt.Errorf("unknown pattern %q", m[1])
+			return ""
+		}
+		var sub string
+		sub, err = expandGrok(pattern, custom, fields, depth+1)
+		if err != nil {
+			return ""
+		}
+		if m[2] == "" {
+			return "(?:" + sub + ")"
+		}
+		typ := m[3]
+		if typ == "" {
+			typ = "string"
+		}
+		group := fmt.Sprintf("%s%d", grokGroupPrefix, len(*fields))
+		*fields = append(*fields, grokField{name: m[2], typ: typ})
+		return "(?P<" + group + ">" + sub + ")"
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// Parse matches the line and returns the captured fields, converted to the types of the fields.
+// The fields that didn't participate in the match are nulls.
+func (g *Grok) Parse(line string) (plugindata.Map, bool) {
+	m := g.re.FindStringSubmatchIndex(line)
+	if m == nil {
+		return nil, false
+	}
+	result := make(plugindata.Map, len(g.fields))
+	for _, f := range g.fields {
+		start, end := m[2*f.group], m[2*f.group+1]
+		if start < 0 {
+			// the same field may be captured by alternative branches
+			if _, ok := result[f.name]; !ok {
+				result[f.name] = nil
+			}
+			continue
+		}
+		val := line[start:end]
+		switch f.typ {
+		case "int", "float":
+			n, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				result[f.name] = nil
+			} else {
+				result[f.name] = plugindata.Number(n)
+			}
+		default:
+			result[f.name] = plugindata.String(val)
+		}
+	}
+	return result, true
+}
@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/blackstork-io/fabric/plugin/plugindata"
@@ -55,3 +58,30 @@ func ParseCSVContent(ctx context.Context, reader *csv.Reader) (plugindata.List,
 		}
 	}
 }
+
+// ParseNDJSONContent parses newline-delimited JSON: every non-empty line is a JSON value.
+func ParseNDJSONContent(ctx context.Context, reader io.Reader) (plugindata.List, error) {
+	result := make(plugindata.List, 0)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		item, err := plugindata.UnmarshalJSON(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		result = append(result, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
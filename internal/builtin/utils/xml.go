@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// XML documents are converted to data as follows:
+//   - the document is a map with the name of the root element as the only key;
+//   - an element without attributes and child elements is its text, or null if the text is empty;
+//   - other elements are maps: attributes are prefixed with `@`, child elements are keyed by their names
+//     and the text, if any, is stored under `#text` key (the prefix and the key can be changed with XMLOptions);
+//   - repeated child elements with the same name are collected into a list.
+//
+// The values are not converted to numbers or booleans, XML carries no types.
+const (
+	DefaultXMLAttrPrefix = "@"
+	DefaultXMLTextKey    = "#text"
+)
+
+// XMLOptions customize the conversion of XML documents.
+type XMLOptions struct {
+	// AttrPrefix is prepended to the names of the attributes
+	AttrPrefix string
+	// TextKey is the key of the text of the elements with attributes or child elements
+	TextKey string
+	// ListElements are the names of the elements always collected into lists, even if there is one
+	ListElements []string
+}
+
+// DefaultXMLOptions returns the options used if none are given.
+func DefaultXMLOptions() XMLOptions {
+	return XMLOptions{
+		AttrPrefix: DefaultXMLAttrPrefix,
+		TextKey:    DefaultXMLTextKey,
+	}
+}
+
+type xmlParser struct {
+	dec          *xml.Decoder
+	opts         XMLOptions
+	listElements map[string]bool
+}
+
+// ParseXMLContent parses the XML document into data with the default options.
+func ParseXMLContent(ctx context.Context, reader io.Reader) (plugindata.Data, error) {
+	return ParseXMLContentWithOptions(ctx, reader, DefaultXMLOptions())
+}
+
+// ParseXMLContentWithOptions parses the XML document into data.
+func ParseXMLContentWithOptions(ctx context.Context, reader io.Reader, opts XMLOptions) (plugindata.Data, error) {
+	p := &xmlParser{
+		dec:          xml.NewDecoder(reader),
+		opts:         opts,
+		listElements: make(map[string]bool, len(opts.ListElements)),
+	}
+	// documents declaring other encodings, for example, ISO-8859-1, are converted to UTF-8
+	p.dec.CharsetReader = charset.NewReaderLabel
+	for _, name := range opts.ListElements {
+		p.listElements[name] = true
+	}
+	return p.parse(ctx)
+}
+
+func (p *xmlParser) parse(ctx context.Context) (plugindata.Data, error) {
+	for {
+		tok, err := p.dec.Token()
+		if err == io.EOF {
+			return nil, errors.New("no root element found")
+		} else if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := p.parseElement(ctx, start)
+			if err != nil {
+				return nil, err
+			}
+			return plugindata.Map{start.Name.Local: root}, nil
+		}
+	}
+}
+
+func (p *xmlParser) parseElement(ctx context.Context, start xml.StartElement) (plugindata.Data, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	node := plugindata.Map{}
+	for _, attr := range start.Attr {
+		// namespace declarations are not data
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		node[p.opts.AttrPrefix+attr.Name.Local] = plugindata.String(attr.Value)
+	}
+	var text strings.Builder
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			child, err := p.parseElement(ctx, tok)
+			if err != nil {
+				return nil, err
+			}
+			p.addChild(node, tok.Name.Local, child)
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(node) == 0 {
+				if content == "" {
+					return nil, nil
+				}
+				return plugindata.String(content), nil
+			}
+			if content != "" {
+				node[p.opts.TextKey] = plugindata.String(content)
+			}
+			return node, nil
+		}
+	}
+}
+
+func (p *xmlParser) addChild(node plugindata.Map, name string, child plugindata.Data) {
+	prev, found := node[name]
+	if !found {
+		if p.listElements[name] {
+			child = plugindata.List{child}
+		}
+		node[name] = child
+		return
+	}
+	// element values are never lists, so a list is made of the repeated elements
+	if list, ok := prev.(plugindata.List); ok {
+		node[name] = append(list, child)
+		return
+	}
+	node[name] = plugindata.List{prev, child}
+}
@@ -3,6 +3,7 @@ package microsoft
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"text/template"
@@ -14,38 +15,27 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 )
 
+// defaultMaxInputTokens leaves room for the response in the context window of the smaller models
+const defaultMaxInputTokens = 12000
+
 func makeAzureOpenAITextContentSchema(loader AzureOpenAIClientLoadFn) *plugin.ContentProvider {
 	return &plugin.ContentProvider{
 		Config: &dataspec.RootSpec{
-			Attrs: []*dataspec.AttrSpec{
-				{
-					Name:        "api_key",
-					Type:        cty.String,
-					Constraints: constraint.RequiredNonNull,
-					Secret:      true,
-				},
-				{
-					Name:        "resource_endpoint",
-					Type:        cty.String,
-					Constraints: constraint.RequiredNonNull,
-				},
-				{
-					Name:        "deployment_name",
-					Type:        cty.String,
-					Constraints: constraint.RequiredNonNull,
-				},
-				{
-					Name:       "api_version",
-					Type:       cty.String,
-					DefaultVal: cty.StringVal("2024-02-01"),
-				},
-			},
+			Attrs: append(azureOpenAIConfigAttrs(), &dataspec.AttrSpec{
+				Name: "cache_dir",
+				Type: cty.String,
+				Doc: "Directory to cache the responses in. If set, the responses are cached by the request: " +
+					"the deployment, the prompt, the data and the completion parameters, so re-rendering the document reuses them. " +
+					"Responses truncated by the token limit are not cached.",
+				ExampleVal: cty.StringVal(".fabric/azure-openai-cache"),
+			}),
 		},
 		Args: &dataspec.RootSpec{
 			Attrs: []*dataspec.AttrSpec{
@@ -74,12 +64,62 @@ func makeAzureOpenAITextContentSchema(loader AzureOpenAIClientLoadFn) *plugin.Co
 					Type:       cty.Number,
 					DefaultVal: cty.NumberIntVal(1),
 				},
+				{
+					Name: "data",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc: "Data passed to the model after the prompt, serialized as JSON. If the request exceeds " +
+						"`max_input_tokens`, the data is split into chunks: the prompt is run for every chunk " +
+						"and the results are combined with `reduce_prompt` (map-reduce). Lists are split between the items.",
+				},
+				{
+					Name: "max_input_tokens",
+					Type: cty.Number,
+					Doc: "Estimated number of tokens a single request can take, including the prompt and the data. " +
+						"Set to 0 to disable chunking.",
+					Constraints:  constraint.NonNull | constraint.Integer,
+					MinInclusive: cty.NumberIntVal(0),
+					DefaultVal:   cty.NumberIntVal(defaultMaxInputTokens),
+				},
+				{
+					Name: "reduce_prompt",
+					Type: cty.String,
+					Doc: "Instruction to combine the results of the chunks, followed by the results. " +
+						"By default, the model is asked to combine the results into a single response to the prompt.",
+					ExampleVal: cty.StringVal("Merge the summaries of the alerts into a single summary"),
+				},
 			},
 		},
 		ContentFunc: genOpenAIText(loader),
 	}
 }
 
+// azureOpenAIConfigAttrs returns the configuration shared by the data source and the content provider
+func azureOpenAIConfigAttrs() []*dataspec.AttrSpec {
+	return []*dataspec.AttrSpec{
+		{
+			Name:        "api_key",
+			Type:        cty.String,
+			Constraints: constraint.RequiredNonNull,
+			Secret:      true,
+		},
+		{
+			Name:        "resource_endpoint",
+			Type:        cty.String,
+			Constraints: constraint.RequiredNonNull,
+		},
+		{
+			Name:        "deployment_name",
+			Type:        cty.String,
+			Constraints: constraint.RequiredNonNull,
+		},
+		{
+			Name:       "api_version",
+			Type:       cty.String,
+			DefaultVal: cty.StringVal("2024-02-01"),
+		},
+	}
+}
+
 func genOpenAIText(clientLoader AzureOpenAIClientLoadFn) plugin.ProvideContentFunc {
 	return func(ctx context.Context, params *plugin.ProvideContentParams) (*plugin.ContentResult, diagnostics.Diag) {
 		apiKey := params.Config.GetAttrVal("api_key").AsString()
@@ -129,16 +169,52 @@ func renderText(ctx context.Context, cli AzureOpenAIClient, cfg, args *dataspec.
 	if err != nil {
 		return "", err
 	}
-	params.Prompt = []string{renderedPrompt}
-	// TODO: use api version from config
-	resp, err := cli.GetCompletions(ctx, params, nil)
+	items, err := llmtools.DataItems(args.GetAttrVal("data"))
 	if err != nil {
 		return "", err
 	}
-	if len(resp.Choices) == 0 {
-		return "", nil
+	maxInputTokens, _ := args.GetAttrVal("max_input_tokens").AsBigFloat().Int64()
+	opts := llmtools.Options{
+		MaxInputTokens: int(maxInputTokens),
+	}
+	if reducePrompt := args.GetAttrVal("reduce_prompt"); !reducePrompt.IsNull() {
+		opts.ReducePrompt, err = templateText(reducePrompt.AsString(), dataCtx)
+		if err != nil {
+			return "", err
+		}
+	}
+	complete := func(ctx context.Context, prompt string) (llmtools.Completion, error) {
+		req := params
+		req.Prompt = []string{prompt}
+		// TODO: use api version from config
+		resp, err := cli.GetCompletions(ctx, req, nil)
+		if err != nil {
+			return llmtools.Completion{}, err
+		}
+		if len(resp.Choices) == 0 {
+			return llmtools.Completion{}, nil
+		}
+		choice := resp.Choices[0]
+		return llmtools.Completion{
+			Text: *choice.Text,
+			Truncated: choice.FinishReason != nil &&
+				*choice.FinishReason == azopenai.CompletionsFinishReasonTokenLimitReached,
+		}, nil
+	}
+	var cache *llmtools.Cache
+	var key []byte
+	if cacheDir := cfg.GetAttrVal("cache_dir"); !cacheDir.IsNull() && cacheDir.AsString() != "" {
+		cache = llmtools.NewCache(cacheDir.AsString())
+		key, err = json.Marshal(params)
+		if err != nil {
+			return "", err
+		}
 	}
-	return *resp.Choices[0].Text, nil
+	return llmtools.Run(
+		ctx,
+		llmtools.Cached(cache, complete, cfg.GetAttrVal("resource_endpoint").AsString(), string(key)),
+		renderedPrompt, items, opts,
+	)
 }
 
 func templateText(text string, dataCtx plugindata.Map) (string, error) {
@@ -204,3 +204,46 @@ func (s *AzureOpenAITextContentTestSuite) TestFailingClient() {
 		Detail:   "failed to generate text from model",
 	}}, diags)
 }
+
+func (s *AzureOpenAITextContentTestSuite) TestMapReduceCached() {
+	var prompts []string
+	s.cli.On("GetCompletions", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, body azopenai.CompletionsOptions, options *azopenai.GetCompletionsOptions) azopenai.GetCompletionsResponse {
+			prompts = append(prompts, body.Prompt[0])
+			return azopenai.GetCompletionsResponse{
+				Completions: azopenai.Completions{
+					Choices: []azopenai.Choice{
+						{Text: to.Ptr(fmt.Sprintf("summary %d", len(prompts)))},
+					},
+				},
+			}
+		}, nil,
+	)
+	cacheDir := s.T().TempDir()
+	for range 2 {
+		result, diags := s.schema.ContentFunc(context.Background(), &plugin.ProvideContentParams{
+			Config: plugintest.NewTestDecoder(s.T(), s.schema.Config).
+				SetAttr("api_key", cty.StringVal("testtoken")).
+				SetAttr("resource_endpoint", cty.StringVal("http://test")).
+				SetAttr("deployment_name", cty.StringVal("test")).
+				SetAttr("cache_dir", cty.StringVal(cacheDir)).
+				Decode(),
+			Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `
+				prompt = "Summarize the alerts"
+				data = [
+					{title = "Encoded PowerShell command executed on host-1"},
+					{title = "Encoded PowerShell command executed on host-2"},
+					{title = "Encoded PowerShell command executed on host-3"},
+				]
+				max_input_tokens = 45
+				reduce_prompt = "Merge the summaries"
+			`, plugindata.Map{}, diagtest.Asserts{}),
+			DataContext: plugindata.Map{},
+		})
+		s.Empty(diags)
+		s.Equal("summary 4", mdprint.PrintString(result.Content))
+	}
+	// the second render is served from the cache
+	s.Require().Len(prompts, 4)
+	s.Equal("Merge the summaries\n\nResult of part 1:\nsummary 1\n\nResult of part 2:\nsummary 2\n\nResult of part 3:\nsummary 3", prompts[3])
+}
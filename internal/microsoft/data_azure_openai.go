@@ -0,0 +1,207 @@
+package microsoft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/ai/azopenai"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeAzureOpenAIDataSource(loader AzureOpenAIClientLoadFn) *plugin.DataSource {
+	return &plugin.DataSource{
+		Config: &dataspec.RootSpec{
+			Attrs: azureOpenAIConfigAttrs(),
+		},
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "prompt",
+					Type:        cty.String,
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal:  cty.StringVal("Tag the alerts with MITRE ATT&CK technique IDs"),
+				},
+				{
+					Name: "input",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc:  "Data passed to the model after the prompt, serialized as JSON.",
+					ExampleVal: cty.TupleVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"id":    cty.NumberIntVal(1),
+							"title": cty.StringVal("Encoded PowerShell command executed"),
+						}),
+					}),
+				},
+				{
+					Name:       "max_tokens",
+					Type:       cty.Number,
+					DefaultVal: cty.NumberIntVal(1000),
+				},
+				{
+					Name:       "temperature",
+					Type:       cty.Number,
+					DefaultVal: cty.NumberFloatVal(0),
+				},
+				{
+					Name: "response_schema",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc: "JSON schema of the response. If set, the model is asked to respond with JSON matching the schema, " +
+						"and the response is validated and returned as data. Otherwise, the text of the response is returned.",
+					ExampleVal: cty.ObjectVal(map[string]cty.Value{
+						"type": cty.StringVal("object"),
+						"properties": cty.ObjectVal(map[string]cty.Value{
+							"techniques": cty.ObjectVal(map[string]cty.Value{
+								"type":  cty.StringVal("array"),
+								"items": cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("string")}),
+							}),
+						}),
+						"required": cty.TupleVal([]cty.Value{cty.StringVal("techniques")}),
+					}),
+				},
+				{
+					Name:         "max_retries",
+					Type:         cty.Number,
+					Doc:          "Number of retries if the response doesn't match the response schema.",
+					Constraints:  constraint.NonNull | constraint.Integer,
+					MinInclusive: cty.NumberIntVal(0),
+					DefaultVal:   cty.NumberIntVal(2),
+				},
+			},
+		},
+		DataFunc: fetchAzureOpenAIData(loader),
+		Doc: utils.Dedent(`
+			Sends the prompt to the chat model of the deployment and returns the response.
+
+			With ` + "`response_schema`" + ` argument set, the model responds in JSON mode with the schema sent
+			in the system message, so the result can be used as data, for example, in tables. The API version
+			used by the plugin doesn't support the JSON schema response format, so the schema isn't enforced by
+			the API: responses that don't match the schema are retried with the validation error sent to the model.
+		`),
+	}
+}
+
+func fetchAzureOpenAIData(loader AzureOpenAIClientLoadFn) plugin.RetrieveDataFunc {
+	return func(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+		apiKey := params.Config.GetAttrVal("api_key").AsString()
+		resourceEndpoint := params.Config.GetAttrVal("resource_endpoint").AsString()
+		cli, err := loader(apiKey, resourceEndpoint)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to create client",
+				Detail:   err.Error(),
+			}}
+		}
+		req, messages, schema, err := makeAzureOpenAIDataRequest(params.Config, params.Args)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse arguments",
+				Detail:   err.Error(),
+			}}
+		}
+		chat := func(ctx context.Context, messages []llmtools.Message) (string, error) {
+			return generateChatCompletion(ctx, cli, req, messages)
+		}
+		var data plugindata.Data
+		if schema == nil {
+			var text string
+			text, err = chat(ctx, messages)
+			data = plugindata.String(text)
+		} else {
+			maxRetries, _ := params.Args.GetAttrVal("max_retries").AsBigFloat().Int64()
+			data, err = llmtools.GenerateStructured(ctx, chat, messages, schema, int(maxRetries))
+		}
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to generate data",
+				Detail:   err.Error(),
+			}}
+		}
+		return data, nil
+	}
+}
+
+// makeAzureOpenAIDataRequest builds the completion options without the messages, the messages and
+// compiles the response schema, if set.
+func makeAzureOpenAIDataRequest(cfg, args *dataspec.Block) (azopenai.ChatCompletionsOptions, []llmtools.Message, *llmtools.ResponseSchema, error) {
+	req := azopenai.ChatCompletionsOptions{
+		DeploymentName: to.Ptr(cfg.GetAttrVal("deployment_name").AsString()),
+	}
+	maxTokens, _ := args.GetAttrVal("max_tokens").AsBigFloat().Int64()
+	req.MaxTokens = to.Ptr(int32(maxTokens))
+	temperature, _ := args.GetAttrVal("temperature").AsBigFloat().Float32()
+	req.Temperature = to.Ptr(temperature)
+
+	prompt := args.GetAttrVal("prompt").AsString()
+	if input := args.GetAttrVal("input"); !input.IsNull() {
+		data, err := plugindata.Encapsulated.FromCty(input)
+		if err != nil {
+			return req, nil, nil, fmt.Errorf("input: %w", err)
+		}
+		raw, err := json.Marshal((*data).Any())
+		if err != nil {
+			return req, nil, nil, fmt.Errorf("input: %w", err)
+		}
+		prompt += "\n\n" + string(raw)
+	}
+	messages := []llmtools.Message{{
+		Role:    "user",
+		Content: prompt,
+	}}
+
+	schemaVal := args.GetAttrVal("response_schema")
+	if schemaVal.IsNull() {
+		return req, messages, nil, nil
+	}
+	schemaData, err := plugindata.Encapsulated.FromCty(schemaVal)
+	if err != nil {
+		return req, nil, nil, fmt.Errorf("response_schema: %w", err)
+	}
+	schema, err := llmtools.CompileSchema(*schemaData)
+	if err != nil {
+		return req, nil, nil, fmt.Errorf("response_schema: %w", err)
+	}
+	req.ResponseFormat = &azopenai.ChatCompletionsJSONResponseFormat{}
+	// JSON mode requires the messages to mention JSON, the schema is sent as the instruction
+	messages = append([]llmtools.Message{{
+		Role:    "system",
+		Content: "Respond only with JSON matching the following JSON schema:\n" + string(schema.Raw),
+	}}, messages...)
+	return req, messages, schema, nil
+}
+
+func generateChatCompletion(ctx context.Context, cli AzureOpenAIClient, req azopenai.ChatCompletionsOptions, messages []llmtools.Message) (string, error) {
+	req.Messages = make([]azopenai.ChatRequestMessageClassification, len(messages))
+	for i, msg := range messages {
+		switch msg.Role {
+		case "system":
+			req.Messages[i] = &azopenai.ChatRequestSystemMessage{Content: to.Ptr(msg.Content)}
+		case "assistant":
+			req.Messages[i] = &azopenai.ChatRequestAssistantMessage{Content: to.Ptr(msg.Content)}
+		default:
+			req.Messages[i] = &azopenai.ChatRequestUserMessage{Content: azopenai.NewChatRequestUserMessageContent(msg.Content)}
+		}
+	}
+	resp, err := cli.GetChatCompletions(ctx, req, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) < 1 || resp.Choices[0].Message == nil || resp.Choices[0].Message.Content == nil {
+		return "", errors.New("no choices")
+	}
+	return *resp.Choices[0].Message.Content, nil
+}
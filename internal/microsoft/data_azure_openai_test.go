@@ -0,0 +1,176 @@
+package microsoft_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/ai/azopenai"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/internal/microsoft"
+	client_mocks "github.com/blackstork-io/fabric/mocks/internalpkg/microsoft"
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type AzureOpenAIDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+	cli    *client_mocks.AzureOpenAIClient
+}
+
+func TestAzureOpenAIDataSourceSuite(t *testing.T) {
+	suite.Run(t, &AzureOpenAIDataSourceTestSuite{})
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) SetupSuite() {
+	s.schema = microsoft.Plugin("1.0.0", nil, func(apiKey, endPoint string) (microsoft.AzureOpenAIClient, error) {
+		return s.cli, nil
+	}, nil, nil).DataSources["azure_openai"]
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) SetupTest() {
+	s.cli = &client_mocks.AzureOpenAIClient{}
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) TearDownTest() {
+	s.cli.AssertExpectations(s.T())
+}
+
+const azureOpenAITestConfig = `
+	api_key = "testtoken"
+	resource_endpoint = "http://localhost/"
+	deployment_name = "test"
+`
+
+func (s *AzureOpenAIDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+	s.NotNil(s.schema.Config)
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args:   plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+		Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, azureOpenAITestConfig, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func chatCompletion(content string) azopenai.GetChatCompletionsResponse {
+	return azopenai.GetChatCompletionsResponse{
+		ChatCompletions: azopenai.ChatCompletions{
+			Choices: []azopenai.ChatChoice{{
+				Message: &azopenai.ChatResponseMessage{Content: to.Ptr(content)},
+			}},
+		},
+	}
+}
+
+// requestJSON returns the request as it's sent to the API.
+func requestJSON(req azopenai.ChatCompletionsOptions) map[string]any {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		panic(err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) TestText() {
+	s.cli.On("GetChatCompletions", mock.Anything, mock.MatchedBy(func(req azopenai.ChatCompletionsOptions) bool {
+		s.Equal(map[string]any{
+			"model":       "test",
+			"max_tokens":  float64(1000),
+			"temperature": float64(0),
+			"messages": []any{
+				map[string]any{"role": "user", "content": "Summarize the alerts\n\n[{\"id\":1}]"},
+			},
+		}, requestJSON(req))
+		return true
+	}), mock.Anything).Return(chatCompletion("One alert."), nil)
+
+	data := s.fetch(`
+		prompt = "Summarize the alerts"
+		input = [{id = 1}]
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.String("One alert."), data)
+}
+
+const azureOpenAITestSchemaArgs = `
+	prompt = "Tag the alert"
+	response_schema = {
+		type = "object"
+		properties = {
+			techniques = {type = "array", items = {type = "string"}}
+		}
+		required = ["techniques"]
+	}
+`
+
+func (s *AzureOpenAIDataSourceTestSuite) TestStructuredOutput() {
+	s.cli.On("GetChatCompletions", mock.Anything, mock.MatchedBy(func(req azopenai.ChatCompletionsOptions) bool {
+		body := requestJSON(req)
+		s.Equal(map[string]any{"type": "json_object"}, body["response_format"])
+		s.Equal(map[string]any{
+			"role": "system",
+			"content": "Respond only with JSON matching the following JSON schema:\n" +
+				`{"properties":{"techniques":{"items":{"type":"string"},"type":"array"}},"required":["techniques"],"type":"object"}`,
+		}, body["messages"].([]any)[0])
+		return true
+	}), mock.Anything).Return(chatCompletion(`{"techniques": ["T1059.001"]}`), nil)
+
+	data := s.fetch(azureOpenAITestSchemaArgs, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"techniques": plugindata.List{plugindata.String("T1059.001")},
+	}, data)
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) TestRetryInvalidOutput() {
+	s.cli.On("GetChatCompletions", mock.Anything, mock.MatchedBy(func(req azopenai.ChatCompletionsOptions) bool {
+		return len(req.Messages) == 2
+	}), mock.Anything).Return(chatCompletion(`{"technique": "T1059"}`), nil).Once()
+	s.cli.On("GetChatCompletions", mock.Anything, mock.MatchedBy(func(req azopenai.ChatCompletionsOptions) bool {
+		if len(req.Messages) != 4 {
+			return false
+		}
+		assistant, ok := req.Messages[2].(*azopenai.ChatRequestAssistantMessage)
+		return ok && *assistant.Content == `{"technique": "T1059"}`
+	}), mock.Anything).Return(chatCompletion(`{"techniques": ["T1059"]}`), nil).Once()
+
+	data := s.fetch(azureOpenAITestSchemaArgs, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"techniques": plugindata.List{plugindata.String("T1059")},
+	}, data)
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) TestInvalidOutput() {
+	s.cli.On("GetChatCompletions", mock.Anything, mock.Anything, mock.Anything).Return(chatCompletion("not a json"), nil).Twice()
+
+	s.fetch(azureOpenAITestSchemaArgs+`max_retries = 1`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to generate data"),
+		diagtest.DetailContains("invalid response after 2 attempts", "response is not a valid JSON"),
+	}})
+}
+
+func (s *AzureOpenAIDataSourceTestSuite) TestInvalidSchema() {
+	s.fetch(`
+		prompt = "Tag the alert"
+		response_schema = {type = "unknown"}
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains("response_schema"),
+	}})
+}
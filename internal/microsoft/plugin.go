@@ -104,6 +104,12 @@ type AzureOpenAIClient interface {
 		body azopenai.CompletionsOptions,
 		options *azopenai.GetCompletionsOptions,
 	) (azopenai.GetCompletionsResponse, error)
+
+	GetChatCompletions(
+		ctx context.Context,
+		body azopenai.ChatCompletionsOptions,
+		options *azopenai.GetChatCompletionsOptions,
+	) (azopenai.GetChatCompletionsResponse, error)
 }
 
 func MakeAzureOpenAIClientLoader() AzureOpenAIClientLoadFn {
@@ -130,6 +136,7 @@ func Plugin(
 			"microsoft_graph":              makeMicrosoftGraphDataSource(graphClientLoader),
 			"microsoft_security":           makeMicrosoftSecurityDataSource(securityClientLoader),
 			"microsoft_security_query":     makeMicrosoftSecurityQueryDataSource(securityClientLoader),
+			"azure_openai":                 makeAzureOpenAIDataSource(openAIClientLoader),
 		},
 		ContentProviders: plugin.ContentProviders{
 			"azure_openai_text": makeAzureOpenAITextContentSchema(openAIClientLoader),
@@ -26,6 +26,7 @@ func TestPlugin_Schema(t *testing.T) {
 	assert.NotNil(t, schema.DataSources["microsoft_sentinel_incidents"])
 	assert.NotNil(t, schema.DataSources["microsoft_graph"])
 	assert.NotNil(t, schema.DataSources["microsoft_security"])
+	assert.NotNil(t, schema.DataSources["azure_openai"])
 	assert.NotNil(t, schema.ContentProviders["azure_openai_text"])
 }
 
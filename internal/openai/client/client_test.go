@@ -133,3 +133,35 @@ func (s *ClientTestSuite) TestGenerateChatCompletion() {
 	s.NoError(err)
 	s.Equal(&want, result)
 }
+
+func (s *ClientTestSuite) TestResponseFormat() {
+	client, srv := s.mock(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		s.NoError(err)
+		s.JSONEq(`{
+			"model": "gpt-4o",
+			"messages": [],
+			"response_format": {
+				"type": "json_schema",
+				"json_schema": {
+					"name": "response",
+					"schema": {"type": "object"}
+				}
+			}
+		}`, string(body))
+		w.Write([]byte(`{"choices": []}`))
+	})
+	defer srv.Close()
+	_, err := client.GenerateChatCompletion(s.ctx, &ChatCompletionParams{
+		Model:    "gpt-4o",
+		Messages: []ChatCompletionMessage{},
+		ResponseFormat: &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &JSONSchemaFormat{
+				Name:   "response",
+				Schema: []byte(`{"type": "object"}`),
+			},
+		},
+	})
+	s.NoError(err)
+}
@@ -1,10 +1,27 @@
 package client
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type ChatCompletionParams struct {
-	Model    string                  `json:"model"`
-	Messages []ChatCompletionMessage `json:"messages"`
+	Model          string                  `json:"model"`
+	Messages       []ChatCompletionMessage `json:"messages"`
+	ResponseFormat *ResponseFormat         `json:"response_format,omitempty"`
+	MaxTokens      int                     `json:"max_tokens,omitempty"`
+}
+
+// ResponseFormat constrains the output of the model, for example, to JSON matching a schema
+type ResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *JSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+type JSONSchemaFormat struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict,omitempty"`
 }
 
 type ChatCompletionMessage struct {
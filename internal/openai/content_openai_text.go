@@ -3,7 +3,6 @@ package openai
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"text/template"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/blackstork-io/fabric/internal/openai/client"
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
 	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
@@ -22,24 +22,7 @@ import (
 
 func makeOpenAITextContentSchema(loader ClientLoadFn) *plugin.ContentProvider {
 	return &plugin.ContentProvider{
-		Config: &dataspec.RootSpec{
-			Attrs: []*dataspec.AttrSpec{
-				{
-					Name: "system_prompt",
-					Type: cty.String,
-				},
-				{
-					Name:        "api_key",
-					Type:        cty.String,
-					Constraints: constraint.RequiredNonNull,
-					Secret:      true,
-				},
-				{
-					Name: "organization_id",
-					Type: cty.String,
-				},
-			},
-		},
+		Config: makeConfigSpec(),
 		Args: &dataspec.RootSpec{
 			Attrs: []*dataspec.AttrSpec{
 				{
@@ -54,6 +37,36 @@ func makeOpenAITextContentSchema(loader ClientLoadFn) *plugin.ContentProvider {
 					Constraints: constraint.Meaningful,
 					DefaultVal:  cty.StringVal(defaultModel),
 				},
+				{
+					Name: "data",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc: "Data passed to the model after the prompt, serialized as JSON. If the request exceeds " +
+						"`max_input_tokens`, the data is split into chunks: the prompt is run for every chunk " +
+						"and the results are combined with `reduce_prompt` (map-reduce). Lists are split between the items.",
+				},
+				{
+					Name: "max_input_tokens",
+					Type: cty.Number,
+					Doc: "Estimated number of tokens a single request can take, including the prompt and the data. " +
+						"Set to 0 to disable chunking.",
+					Constraints:  constraint.NonNull | constraint.Integer,
+					MinInclusive: cty.NumberIntVal(0),
+					DefaultVal:   cty.NumberIntVal(defaultMaxInputTokens),
+				},
+				{
+					Name:         "max_tokens",
+					Type:         cty.Number,
+					Doc:          "Maximum number of tokens in a response.",
+					Constraints:  constraint.Integer,
+					MinInclusive: cty.NumberIntVal(1),
+				},
+				{
+					Name: "reduce_prompt",
+					Type: cty.String,
+					Doc: "Instruction to combine the results of the chunks, followed by the results. " +
+						"By default, the model is asked to combine the results into a single response to the prompt.",
+					ExampleVal: cty.StringVal("Merge the summaries of the alerts into a single summary"),
+				},
 			},
 		},
 		ContentFunc: genOpenAIText(loader),
@@ -85,12 +98,16 @@ func genOpenAIText(loader ClientLoadFn) plugin.ProvideContentFunc {
 }
 
 func renderText(ctx context.Context, cli client.Client, cfg, args *dataspec.Block, dataCtx plugindata.Map) (string, error) {
-	params := client.ChatCompletionParams{
-		Model: args.GetAttrVal("model").AsString(),
+	model := args.GetAttrVal("model").AsString()
+	var maxTokens int
+	if val := args.GetAttrVal("max_tokens"); !val.IsNull() {
+		n, _ := val.AsBigFloat().Int64()
+		maxTokens = int(n)
 	}
+	var systemMessages []client.ChatCompletionMessage
 	systemPrompt := cfg.GetAttrVal("system_prompt")
 	if !systemPrompt.IsNull() && systemPrompt.AsString() != "" {
-		params.Messages = append(params.Messages, client.ChatCompletionMessage{
+		systemMessages = append(systemMessages, client.ChatCompletionMessage{
 			Role:    "system",
 			Content: systemPrompt.AsString(),
 		})
@@ -99,18 +116,42 @@ func renderText(ctx context.Context, cli client.Client, cfg, args *dataspec.Bloc
 	if err != nil {
 		return "", err
 	}
-	params.Messages = append(params.Messages, client.ChatCompletionMessage{
-		Role:    "user",
-		Content: content,
-	})
-	result, err := cli.GenerateChatCompletion(ctx, &params)
+	items, err := llmtools.DataItems(args.GetAttrVal("data"))
 	if err != nil {
 		return "", err
 	}
-	if len(result.Choices) < 1 {
-		return "", errors.New("no choices")
+	maxInputTokens, _ := args.GetAttrVal("max_input_tokens").AsBigFloat().Int64()
+	var opts llmtools.Options
+	if maxInputTokens > 0 {
+		// the system prompt is sent with every request
+		opts.MaxInputTokens = max(int(maxInputTokens)-estimateMessagesTokens(systemMessages), 1)
+	}
+	if reducePrompt := args.GetAttrVal("reduce_prompt"); !reducePrompt.IsNull() {
+		opts.ReducePrompt, err = templateText(reducePrompt.AsString(), dataCtx)
+		if err != nil {
+			return "", err
+		}
+	}
+	complete := func(ctx context.Context, prompt string) (string, error) {
+		params := client.ChatCompletionParams{
+			Model:     model,
+			MaxTokens: maxTokens,
+		}
+		params.Messages = append(append(params.Messages, systemMessages...), client.ChatCompletionMessage{
+			Role:    "user",
+			Content: prompt,
+		})
+		return generateCompletion(ctx, cli, &params)
+	}
+	return llmtools.Run(ctx, complete, content, items, opts)
+}
+
+func estimateMessagesTokens(messages []client.ChatCompletionMessage) int {
+	tokens := 0
+	for _, msg := range messages {
+		tokens += llmtools.EstimateTokens(msg.Content)
 	}
-	return result.Choices[0].Message.Content, nil
+	return tokens
 }
 
 func templateText(text string, dataCtx plugindata.Map) (string, error) {
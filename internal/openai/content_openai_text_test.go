@@ -2,6 +2,7 @@ package openai
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
@@ -227,3 +228,101 @@ func (s *OpenAITextContentTestSuite) TestErrorEncoding() {
 		Detail:   "openai[invalid_request_error]: message of error",
 	}}, diags)
 }
+
+func (s *OpenAITextContentTestSuite) TestMapReduce() {
+	var prompts []string
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params *client.ChatCompletionParams) *client.ChatCompletionResult {
+			prompts = append(prompts, params.Messages[len(params.Messages)-1].Content)
+			return &client.ChatCompletionResult{
+				Choices: []client.ChatCompletionChoice{{
+					Message: client.ChatCompletionMessage{
+						Role:    "assistant",
+						Content: fmt.Sprintf("summary %d", len(prompts)),
+					},
+				}},
+			}
+		}, nil,
+	)
+	ctx := context.Background()
+	dataCtx := plugindata.Map{}
+	result, diags := s.schema.ContentFunc(ctx, &plugin.ProvideContentParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `
+			prompt = "Summarize the alerts"
+			data = [
+				{title = "Encoded PowerShell command executed on host-1"},
+				{title = "Encoded PowerShell command executed on host-2"},
+				{title = "Encoded PowerShell command executed on host-3"},
+			]
+			max_input_tokens = 40
+			max_tokens = 100
+			reduce_prompt = "Merge the summaries"
+		`, dataCtx, diagtest.Asserts{}),
+		Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, `
+			api_key = "api_key_123"
+		`, dataCtx, diagtest.Asserts{}),
+		DataContext: dataCtx,
+	})
+	s.Empty(diags)
+	s.Require().Len(prompts, 4)
+	s.Equal("summary 4", mdprint.PrintString(result.Content))
+	s.Contains(prompts[0], "The data is split into 3 parts, this is part 1:\n\n[{\"title\":\"Encoded PowerShell command executed on host-1\"}]")
+	s.Equal("Merge the summaries\n\nResult of part 1:\nsummary 1\n\nResult of part 2:\nsummary 2\n\nResult of part 3:\nsummary 3", prompts[3])
+}
+
+func (s *OpenAITextContentTestSuite) TestCache() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.Anything).Return(&client.ChatCompletionResult{
+		Choices: []client.ChatCompletionChoice{{
+			Message: client.ChatCompletionMessage{
+				Role:    "assistant",
+				Content: "Once upon a time.",
+			},
+		}},
+	}, nil).Once()
+	ctx := context.Background()
+	dataCtx := plugindata.Map{}
+	cacheDir := s.T().TempDir()
+	for range 2 {
+		result, diags := s.schema.ContentFunc(ctx, &plugin.ProvideContentParams{
+			Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `
+				prompt = "Tell me a story"
+			`, dataCtx, diagtest.Asserts{}),
+			Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, fmt.Sprintf(`
+				api_key = "api_key_123"
+				cache_dir = %q
+			`, cacheDir), dataCtx, diagtest.Asserts{}),
+			DataContext: dataCtx,
+		})
+		s.Empty(diags)
+		s.Equal("Once upon a time.", mdprint.PrintString(result.Content))
+	}
+}
+
+func (s *OpenAITextContentTestSuite) TestCacheSkipsTruncated() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.Anything).Return(&client.ChatCompletionResult{
+		Choices: []client.ChatCompletionChoice{{
+			FinishedReason: "length",
+			Message: client.ChatCompletionMessage{
+				Role:    "assistant",
+				Content: "Once upon",
+			},
+		}},
+	}, nil).Twice()
+	ctx := context.Background()
+	dataCtx := plugindata.Map{}
+	cacheDir := s.T().TempDir()
+	for range 2 {
+		result, diags := s.schema.ContentFunc(ctx, &plugin.ProvideContentParams{
+			Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, `
+				prompt = "Tell me a story"
+			`, dataCtx, diagtest.Asserts{}),
+			Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, fmt.Sprintf(`
+				api_key = "api_key_123"
+				cache_dir = %q
+			`, cacheDir), dataCtx, diagtest.Asserts{}),
+			DataContext: dataCtx,
+		})
+		s.Empty(diags)
+		s.Equal("Once upon", mdprint.PrintString(result.Content))
+	}
+}
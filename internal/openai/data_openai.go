@@ -0,0 +1,205 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/openai/client"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
+	"github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func makeOpenAIDataSource(loader ClientLoadFn) *plugin.DataSource {
+	return &plugin.DataSource{
+		Config: makeConfigSpec(),
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "prompt",
+					Type:        cty.String,
+					Constraints: constraint.RequiredNonNull,
+					ExampleVal:  cty.StringVal("Tag the alerts with MITRE ATT&CK technique IDs"),
+				},
+				{
+					Name: "input",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc:  "Data passed to the model after the prompt, serialized as JSON.",
+					ExampleVal: cty.TupleVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"id":    cty.NumberIntVal(1),
+							"title": cty.StringVal("Encoded PowerShell command executed"),
+						}),
+					}),
+				},
+				{
+					Name:        "model",
+					Type:        cty.String,
+					Constraints: constraint.Meaningful,
+					DefaultVal:  cty.StringVal(defaultModel),
+				},
+				{
+					Name: "response_schema",
+					Type: plugindata.Encapsulated.CtyType(),
+					Doc: "JSON schema of the response. If set, the model is asked to respond with JSON matching the schema, " +
+						"and the response is validated and returned as data. Otherwise, the text of the response is returned.",
+					ExampleVal: cty.ObjectVal(map[string]cty.Value{
+						"type": cty.StringVal("object"),
+						"properties": cty.ObjectVal(map[string]cty.Value{
+							"techniques": cty.ObjectVal(map[string]cty.Value{
+								"type":  cty.StringVal("array"),
+								"items": cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("string")}),
+							}),
+						}),
+						"required": cty.TupleVal([]cty.Value{cty.StringVal("techniques")}),
+					}),
+				},
+				{
+					Name:         "max_retries",
+					Type:         cty.Number,
+					Doc:          "Number of retries if the response doesn't match the response schema.",
+					Constraints:  constraint.NonNull | constraint.Integer,
+					MinInclusive: cty.NumberIntVal(0),
+					DefaultVal:   cty.NumberIntVal(2),
+				},
+			},
+		},
+		DataFunc: fetchOpenAIData(loader),
+		Doc: utils.Dedent(`
+			Sends the prompt to the model and returns the response.
+
+			With ` + "`response_schema`" + ` argument set, the model is constrained to respond with JSON matching
+			the schema (structured output), so the result can be used as data, for example, in tables.
+			Responses that don't match the schema are retried with the validation error sent to the model.
+		`),
+	}
+}
+
+func fetchOpenAIData(loader ClientLoadFn) plugin.RetrieveDataFunc {
+	return func(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+		cli, err := makeClient(loader, params.Config)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to create client",
+				Detail:   err.Error(),
+			}}
+		}
+		req, schema, err := makeDataRequest(params.Config, params.Args)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to parse arguments",
+				Detail:   err.Error(),
+			}}
+		}
+		if schema == nil {
+			text, err := generateCompletion(ctx, cli, req)
+			if err != nil {
+				return nil, diagnostics.Diag{{
+					Severity: hcl.DiagError,
+					Summary:  "Failed to generate data",
+					Detail:   err.Error(),
+				}}
+			}
+			return plugindata.String(text), nil
+		}
+		maxRetries, _ := params.Args.GetAttrVal("max_retries").AsBigFloat().Int64()
+		data, err := generateStructuredData(ctx, cli, req, schema, int(maxRetries))
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to generate data",
+				Detail:   err.Error(),
+			}}
+		}
+		return data, nil
+	}
+}
+
+// makeDataRequest builds the completion request and compiles the response schema, if set.
+func makeDataRequest(cfg, args *dataspec.Block) (*client.ChatCompletionParams, *llmtools.ResponseSchema, error) {
+	req := &client.ChatCompletionParams{
+		Model: args.GetAttrVal("model").AsString(),
+	}
+	systemPrompt := cfg.GetAttrVal("system_prompt")
+	if !systemPrompt.IsNull() && systemPrompt.AsString() != "" {
+		req.Messages = append(req.Messages, client.ChatCompletionMessage{
+			Role:    "system",
+			Content: systemPrompt.AsString(),
+		})
+	}
+	prompt := args.GetAttrVal("prompt").AsString()
+	if input := args.GetAttrVal("input"); !input.IsNull() {
+		data, err := plugindata.Encapsulated.FromCty(input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("input: %w", err)
+		}
+		raw, err := json.Marshal((*data).Any())
+		if err != nil {
+			return nil, nil, fmt.Errorf("input: %w", err)
+		}
+		prompt += "\n\n" + string(raw)
+	}
+	req.Messages = append(req.Messages, client.ChatCompletionMessage{
+		Role:    "user",
+		Content: prompt,
+	})
+
+	schemaVal := args.GetAttrVal("response_schema")
+	if schemaVal.IsNull() {
+		return req, nil, nil
+	}
+	schemaData, err := plugindata.Encapsulated.FromCty(schemaVal)
+	if err != nil {
+		return nil, nil, fmt.Errorf("response_schema: %w", err)
+	}
+	schema, err := llmtools.CompileSchema(*schemaData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("response_schema: %w", err)
+	}
+	req.ResponseFormat = &client.ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &client.JSONSchemaFormat{
+			Name:   llmtools.SchemaName,
+			Schema: schema.Raw,
+		},
+	}
+	return req, schema, nil
+}
+
+func generateCompletion(ctx context.Context, cli client.Client, req *client.ChatCompletionParams) (string, error) {
+	result, err := cli.GenerateChatCompletion(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if len(result.Choices) < 1 {
+		return "", errors.New("no choices")
+	}
+	return result.Choices[0].Message.Content, nil
+}
+
+// generateStructuredData requests the completion until the response matches the schema.
+func generateStructuredData(ctx context.Context, cli client.Client, req *client.ChatCompletionParams, schema *llmtools.ResponseSchema, maxRetries int) (plugindata.Data, error) {
+	messages := make([]llmtools.Message, len(req.Messages))
+	for i, msg := range req.Messages {
+		messages[i] = llmtools.Message{Role: msg.Role, Content: msg.Content}
+	}
+	return llmtools.GenerateStructured(ctx, func(ctx context.Context, messages []llmtools.Message) (string, error) {
+		params := *req
+		params.Messages = make([]client.ChatCompletionMessage, len(messages))
+		for i, msg := range messages {
+			params.Messages[i] = client.ChatCompletionMessage{Role: msg.Role, Content: msg.Content}
+		}
+		return generateCompletion(ctx, cli, &params)
+	}, messages, schema, maxRetries)
+}
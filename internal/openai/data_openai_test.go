@@ -0,0 +1,164 @@
+package openai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/internal/openai/client"
+	client_mocks "github.com/blackstork-io/fabric/mocks/internalpkg/openai/client"
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type OpenAIDataSourceTestSuite struct {
+	suite.Suite
+	schema  *plugin.DataSource
+	cli     *client_mocks.Client
+	options []client.Option
+}
+
+func TestOpenAIDataSourceSuite(t *testing.T) {
+	suite.Run(t, &OpenAIDataSourceTestSuite{})
+}
+
+func (s *OpenAIDataSourceTestSuite) SetupSuite() {
+	s.schema = makeOpenAIDataSource(func(opts ...client.Option) client.Client {
+		s.options = opts
+		return s.cli
+	})
+}
+
+func (s *OpenAIDataSourceTestSuite) SetupTest() {
+	s.cli = &client_mocks.Client{}
+	s.options = nil
+}
+
+func (s *OpenAIDataSourceTestSuite) TearDownTest() {
+	s.cli.AssertExpectations(s.T())
+}
+
+func (s *OpenAIDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+	s.NotNil(s.schema.Config)
+}
+
+func (s *OpenAIDataSourceTestSuite) fetch(args, cfg string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args:   plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+		Config: plugintest.DecodeAndAssert(s.T(), s.schema.Config, cfg, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+func completion(content string) *client.ChatCompletionResult {
+	return &client.ChatCompletionResult{
+		Choices: []client.ChatCompletionChoice{{
+			FinishedReason: "stop",
+			Message: client.ChatCompletionMessage{
+				Role:    "assistant",
+				Content: content,
+			},
+		}},
+	}
+}
+
+func (s *OpenAIDataSourceTestSuite) TestText() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, &client.ChatCompletionParams{
+		Model: defaultModel,
+		Messages: []client.ChatCompletionMessage{
+			{Role: "system", Content: "You are a SOC analyst."},
+			{Role: "user", Content: "Summarize the alerts\n\n[{\"id\":1}]"},
+		},
+	}).Return(completion("One alert."), nil)
+
+	data := s.fetch(`
+		prompt = "Summarize the alerts"
+		input = [{id = 1}]
+	`, `
+		api_key = "key"
+		system_prompt = "You are a SOC analyst."
+	`, diagtest.Asserts{})
+	s.Equal(plugindata.String("One alert."), data)
+}
+
+const openAITestSchemaArgs = `
+	prompt = "Tag the alert"
+	response_schema = {
+		type = "object"
+		properties = {
+			techniques = {type = "array", items = {type = "string"}}
+		}
+		required = ["techniques"]
+	}
+`
+
+func (s *OpenAIDataSourceTestSuite) TestStructuredOutput() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.MatchedBy(func(params *client.ChatCompletionParams) bool {
+		return params.ResponseFormat != nil &&
+			params.ResponseFormat.Type == "json_schema" &&
+			params.ResponseFormat.JSONSchema.Name == llmtools.SchemaName &&
+			string(params.ResponseFormat.JSONSchema.Schema) ==
+				`{"properties":{"techniques":{"items":{"type":"string"},"type":"array"}},"required":["techniques"],"type":"object"}`
+	})).Return(completion("```json\n{\"techniques\": [\"T1059.001\"]}\n```"), nil)
+
+	data := s.fetch(openAITestSchemaArgs, `api_key = "key"`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"techniques": plugindata.List{plugindata.String("T1059.001")},
+	}, data)
+}
+
+func (s *OpenAIDataSourceTestSuite) TestRetryInvalidOutput() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.MatchedBy(func(params *client.ChatCompletionParams) bool {
+		return len(params.Messages) == 1
+	})).Return(completion(`{"technique": "T1059"}`), nil).Once()
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.MatchedBy(func(params *client.ChatCompletionParams) bool {
+		return len(params.Messages) == 3 &&
+			params.Messages[1].Content == `{"technique": "T1059"}` &&
+			params.Messages[2].Role == "user"
+	})).Return(completion(`{"techniques": ["T1059"]}`), nil).Once()
+
+	data := s.fetch(openAITestSchemaArgs, `api_key = "key"`, diagtest.Asserts{})
+	s.Equal(plugindata.Map{
+		"techniques": plugindata.List{plugindata.String("T1059")},
+	}, data)
+}
+
+func (s *OpenAIDataSourceTestSuite) TestInvalidOutput() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.Anything).Return(completion("not a json"), nil).Twice()
+
+	s.fetch(openAITestSchemaArgs+`max_retries = 1`, `api_key = "key"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to generate data"),
+		diagtest.DetailContains("invalid response after 2 attempts", "response is not a valid JSON"),
+	}})
+}
+
+func (s *OpenAIDataSourceTestSuite) TestInvalidSchema() {
+	s.fetch(`
+		prompt = "Tag the alert"
+		response_schema = {type = "unknown"}
+	`, `api_key = "key"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse arguments"),
+		diagtest.DetailContains("response_schema"),
+	}})
+}
+
+func (s *OpenAIDataSourceTestSuite) TestBaseURL() {
+	s.cli.On("GenerateChatCompletion", mock.Anything, mock.Anything).Return(completion("ok"), nil)
+
+	s.fetch(`prompt = "Hi"`, `
+		api_key = "key"
+		base_url = "http://localhost:11434/"
+	`, diagtest.Asserts{})
+	s.Len(s.options, 2)
+}
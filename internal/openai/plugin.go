@@ -1,13 +1,23 @@
 package openai
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/blackstork-io/fabric/internal/openai/client"
+	"github.com/blackstork-io/fabric/pkg/llmtools"
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
 )
 
 const (
 	defaultModel = "gpt-3.5-turbo"
+	// defaultMaxInputTokens leaves room for the response in the context window of the default model
+	defaultMaxInputTokens = 12000
 )
 
 type ClientLoadFn func(opts ...client.Option) client.Client
@@ -21,12 +31,52 @@ func Plugin(version string, loader ClientLoadFn) *plugin.Schema {
 	return &plugin.Schema{
 		Name:    "blackstork/openai",
 		Version: version,
+		DataSources: plugin.DataSources{
+			"openai": makeOpenAIDataSource(loader),
+		},
 		ContentProviders: plugin.ContentProviders{
 			"openai_text": makeOpenAITextContentSchema(loader),
 		},
 	}
 }
 
+// makeConfigSpec returns the configuration shared by the data source and the content provider
+func makeConfigSpec() *dataspec.RootSpec {
+	return &dataspec.RootSpec{
+		Attrs: []*dataspec.AttrSpec{
+			{
+				Name: "system_prompt",
+				Type: cty.String,
+			},
+			{
+				Name:        "api_key",
+				Type:        cty.String,
+				Constraints: constraint.RequiredNonNull,
+				Secret:      true,
+			},
+			{
+				Name: "organization_id",
+				Type: cty.String,
+			},
+			{
+				Name: "base_url",
+				Type: cty.String,
+				Doc: "Base URL of the API, without `/v1` path. " +
+					"Set it to use an OpenAI-compatible server, for example, a local one. Defaults to OpenAI API URL.",
+				ExampleVal: cty.StringVal("http://localhost:11434"),
+			},
+			{
+				Name: "cache_dir",
+				Type: cty.String,
+				Doc: "Directory to cache the responses in. If set, the responses are cached by the request: " +
+					"the model, the prompts and the data, so re-rendering the document reuses them. " +
+					"Responses truncated by the token limit are not cached.",
+				ExampleVal: cty.StringVal(".fabric/openai-cache"),
+			},
+		},
+	}
+}
+
 func makeClient(loader ClientLoadFn, cfg *dataspec.Block) (client.Client, error) {
 	opts := []client.Option{
 		client.WithAPIKey(cfg.GetAttrVal("api_key").AsString()),
@@ -35,5 +85,55 @@ func makeClient(loader ClientLoadFn, cfg *dataspec.Block) (client.Client, error)
 	if !orgID.IsNull() && orgID.AsString() != "" {
 		opts = append(opts, client.WithOrgID(orgID.AsString()))
 	}
-	return loader(opts...), nil
+	var baseURL string
+	if val := cfg.GetAttrVal("base_url"); !val.IsNull() && val.AsString() != "" {
+		baseURL = strings.TrimSuffix(val.AsString(), "/")
+		opts = append(opts, client.WithBaseURL(baseURL))
+	}
+	cli := loader(opts...)
+	cacheDir := cfg.GetAttrVal("cache_dir")
+	if !cacheDir.IsNull() && cacheDir.AsString() != "" {
+		cli = &cachedClient{
+			Client:  cli,
+			cache:   llmtools.NewCache(cacheDir.AsString()),
+			baseURL: baseURL,
+		}
+	}
+	return cli, nil
+}
+
+// cachedClient returns the cached responses for the requests made before.
+type cachedClient struct {
+	client.Client
+	cache   *llmtools.Cache
+	baseURL string
+}
+
+func (c *cachedClient) GenerateChatCompletion(ctx context.Context, params *client.ChatCompletionParams) (*client.ChatCompletionResult, error) {
+	req, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	key := llmtools.Key(c.baseURL, string(req))
+	content, found, err := c.cache.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &client.ChatCompletionResult{
+			Choices: []client.ChatCompletionChoice{{
+				FinishedReason: "stop",
+				Message: client.ChatCompletionMessage{
+					Role:    "assistant",
+					Content: content,
+				},
+			}},
+		}, nil
+	}
+	res, err := c.Client.GenerateChatCompletion(ctx, params)
+	// truncated responses are not cached, so the request is retried on the next render
+	if err != nil || len(res.Choices) == 0 || res.Choices[0].FinishedReason == "length" {
+		return res, err
+	}
+	return res, c.cache.Put(key, res.Choices[0].Message.Content)
 }
@@ -11,4 +11,5 @@ func TestPlugin_Schema(t *testing.T) {
 	assert.Equal(t, "blackstork/openai", schema.Name)
 	assert.Equal(t, "1.2.3", schema.Version)
 	assert.NotNil(t, schema.ContentProviders["openai_text"])
+	assert.NotNil(t, schema.DataSources["openai"])
 }
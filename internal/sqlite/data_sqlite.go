@@ -111,6 +111,11 @@ func fetchSqliteData(ctx context.Context, params *plugin.RetrieveDataParams) (pl
 		}}
 	}
 	defer db.Close()
+	return queryRows(ctx, db, sqlQuery, sqlArgs)
+}
+
+// queryRows runs the query and returns the rows as a list of maps with column names as keys.
+func queryRows(ctx context.Context, db *sql.DB, sqlQuery string, sqlArgs []any) (plugindata.Data, diagnostics.Diag) {
 	rows, err := db.QueryContext(ctx, sqlQuery, sqlArgs...)
 	if err != nil {
 		return nil, diagnostics.Diag{{
@@ -119,6 +124,7 @@ func fetchSqliteData(ctx context.Context, params *plugin.RetrieveDataParams) (pl
 			Detail:   err.Error(),
 		}}
 	}
+	defer rows.Close()
 	// read columns
 	columns, err := rows.Columns()
 	if err != nil {
@@ -0,0 +1,419 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/internal/builtin/utils"
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/pkg/parquet"
+	u "github.com/blackstork-io/fabric/pkg/utils"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/dataspec/constraint"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+var tableFormats = map[string]string{
+	".csv":     "csv",
+	".json":    "json",
+	".jsonl":   "jsonl",
+	".ndjson":  "jsonl",
+	".parquet": "parquet",
+}
+
+func makeSqliteTablesDataSource() *plugin.DataSource {
+	return &plugin.DataSource{
+		Args: &dataspec.RootSpec{
+			Blocks: []*dataspec.BlockSpec{
+				{
+					Header: dataspec.HeadersSpec{
+						dataspec.ExactMatcher{"table"},
+					},
+					Required:   true,
+					Repeatable: true,
+					Doc: u.Dedent(`
+						A table to load. The rows of the table are read from the files selected with ` + "`path`" + `
+						or ` + "`glob`" + `, or taken from ` + "`data`" + `.
+					`),
+					Attrs: []*dataspec.AttrSpec{
+						{
+							Name:        "name",
+							Type:        cty.String,
+							ExampleVal:  cty.StringVal("assets"),
+							Constraints: constraint.RequiredMeaningful,
+							Doc:         `Name of the table`,
+						},
+						{
+							Name:       "path",
+							Type:       cty.String,
+							ExampleVal: cty.StringVal("path/to/assets.csv"),
+							Doc:        `A file path to a file to read`,
+						},
+						{
+							Name:       "glob",
+							Type:       cty.String,
+							ExampleVal: cty.StringVal("path/to/findings-*.json"),
+							Doc:        `A glob pattern to select files to read. The rows of all files are loaded into the table`,
+						},
+						{
+							Name: "format",
+							Type: cty.String,
+							OneOf: []cty.Value{
+								cty.StringVal("csv"),
+								cty.StringVal("json"),
+								cty.StringVal("jsonl"),
+								cty.StringVal("parquet"),
+							},
+							Doc: `Format of the files. If not set, the format is detected from the file extension`,
+						},
+						{
+							Name:       "csv_delimiter",
+							Type:       cty.String,
+							DefaultVal: cty.StringVal(","),
+							Doc:        `CSV field delimiter`,
+						},
+						{
+							Name: "data",
+							Type: plugindata.Encapsulated.CtyType(),
+							Doc:  `A list of objects to load as the rows of the table`,
+						},
+						{
+							Name:       "columns",
+							Type:       cty.List(cty.String),
+							ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("host"), cty.StringVal("severity")}),
+							Doc: u.Dedent(`
+								Columns of the table. If not set, the columns are detected from the keys of the rows.
+								Must be set for the inputs that may have no rows, to create an empty table.
+								The keys of the rows that are not in the list are ignored
+							`),
+						},
+					},
+				},
+			},
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:        "sql_query",
+					Type:        cty.String,
+					Constraints: constraint.RequiredNonNull,
+					Doc:         `SQL query to execute`,
+				},
+				{
+					Name: "sql_args",
+					Type: cty.DynamicPseudoType,
+					ExampleVal: cty.TupleVal([]cty.Value{
+						cty.StringVal("example argument"),
+						cty.NumberIntVal(2),
+						cty.BoolVal(false),
+					}),
+					Doc: `A tuple (or list) of strings, numbers, or booleans to be used as arguments in the SQL query`,
+				},
+			},
+		},
+		Doc: u.Dedent(`
+			Executes the SQL query over the tables loaded into an in-memory SQLite database.
+
+			The tables are loaded from CSV, JSON, JSON Lines and Parquet files or from the data passed in
+			the arguments, so the data from different sources can be joined.
+
+			The columns of a table are the keys of its rows. The values keep their types; lists and dicts are
+			stored as JSON text, so they can be queried with SQLite JSON functions, for example,
+			` + "`json_extract(tags, '$[0]')`" + `. The values in CSV files that look like numbers or booleans
+			are loaded as numbers or booleans.
+
+			The result is a list of dicts, one per row, the same as ` + "`sqlite`" + ` data source returns.
+		`),
+		DataFunc: fetchSqliteTablesData,
+	}
+}
+
+func fetchSqliteTablesData(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	sqlQuery, sqlArgs, err := parseSqliteArgs(params.Args)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid arguments",
+			Detail:   err.Error(),
+		}}
+	}
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to open database",
+			Detail:   err.Error(),
+		}}
+	}
+	defer db.Close()
+	// every connection to ":memory:" opens a new database
+	db.SetMaxOpenConns(1)
+
+	var diags diagnostics.Diag
+	for _, block := range params.Args.Blocks {
+		name := block.GetAttrVal("name").AsString()
+		rows, err := readTableRows(ctx, block)
+		if err == nil {
+			err = loadTable(ctx, db, name, tableColumns(block), rows)
+		}
+		if err != nil {
+			diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to load the table",
+				Detail:   fmt.Sprintf("Table %q: %s", name, err),
+				Subject:  block.DefRange().Ptr(),
+			})
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return queryRows(ctx, db, sqlQuery, sqlArgs)
+}
+
+func readTableRows(ctx context.Context, block *dataspec.Block) (plugindata.List, error) {
+	path := block.GetAttrVal("path")
+	glob := block.GetAttrVal("glob")
+	data := block.GetAttrVal("data")
+	set := 0
+	for _, val := range []cty.Value{path, glob, data} {
+		if !val.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("exactly one of \"path\", \"glob\" or \"data\" must be provided")
+	}
+	if !data.IsNull() {
+		content, err := plugindata.Encapsulated.FromCty(data)
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			return tableRows(nil)
+		}
+		return tableRows(*content)
+	}
+	var paths []string
+	if !path.IsNull() {
+		paths = []string{path.AsString()}
+	} else {
+		var err error
+		paths, err = filepath.Glob(glob.AsString())
+		if err != nil {
+			return nil, err
+		}
+	}
+	format := block.GetAttrVal("format")
+	delimiter, _ := utf8.DecodeRuneInString(block.GetAttrVal("csv_delimiter").AsString())
+	result := plugindata.List{}
+	for _, path := range paths {
+		var fileFormat string
+		if !format.IsNull() {
+			fileFormat = format.AsString()
+		} else if fileFormat = tableFormats[strings.ToLower(filepath.Ext(path))]; fileFormat == "" {
+			return nil, fmt.Errorf("can't detect the format of %s, set \"format\" argument", path)
+		}
+		content, err := readTableFile(ctx, path, fileFormat, delimiter)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rows, err := tableRows(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		result = append(result, rows...)
+	}
+	return result, nil
+}
+
+func readTableFile(ctx context.Context, path, format string, delimiter rune) (plugindata.Data, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch format {
+	case "csv":
+		reader := csv.NewReader(f)
+		reader.Comma = delimiter
+		return utils.ParseCSVContent(ctx, reader)
+	case "jsonl":
+		return utils.ParseNDJSONContent(ctx, f)
+	case "parquet":
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		file, err := parquet.Open(f, info.Size())
+		if err != nil {
+			return nil, err
+		}
+		rows, err := file.Rows()
+		if err != nil {
+			return nil, err
+		}
+		result := plugindata.List{}
+		for rows.Next() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			row, err := plugindata.ParseMapAny(rows.Row())
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, row)
+		}
+		return result, rows.Err()
+	default:
+		raw, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		return plugindata.UnmarshalJSON(raw)
+	}
+}
+
+// tableRows returns the rows of the content: a list of dicts or a single dict.
+func tableRows(content plugindata.Data) (plugindata.List, error) {
+	switch content := content.(type) {
+	case nil:
+		return plugindata.List{}, nil
+	case plugindata.Map:
+		return plugindata.List{content}, nil
+	case plugindata.List:
+		for i, row := range content {
+			if _, ok := row.(plugindata.Map); !ok {
+				return nil, fmt.Errorf("row %d is not an object", i)
+			}
+		}
+		return content, nil
+	default:
+		return nil, fmt.Errorf("rows must be a list of objects")
+	}
+}
+
+// tableColumns returns the columns set in the arguments, nil if they are detected from the rows.
+func tableColumns(block *dataspec.Block) []string {
+	val := block.GetAttrVal("columns")
+	if val.IsNull() {
+		return nil
+	}
+	columns := []string{}
+	for _, column := range val.AsValueSlice() {
+		columns = append(columns, column.AsString())
+	}
+	return columns
+}
+
+func loadTable(ctx context.Context, db *sql.DB, name string, columns []string, rows plugindata.List) error {
+	if columns == nil {
+		seen := map[string]bool{}
+		for _, row := range rows {
+			for key := range row.(plugindata.Map) {
+				if !seen[key] {
+					seen[key] = true
+					columns = append(columns, key)
+				}
+			}
+		}
+		slices.Sort(columns)
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("no rows to detect the columns from, set \"columns\" argument to create an empty table")
+	}
+	// the column names are case-insensitive in SQLite, for ASCII letters only
+	folded := map[string]string{}
+	for _, column := range columns {
+		key := strings.Map(func(r rune) rune {
+			if 'A' <= r && r <= 'Z' {
+				return r + 'a' - 'A'
+			}
+			return r
+		}, column)
+		if other, ok := folded[key]; ok {
+			return fmt.Errorf("columns %q and %q differ only in case, SQLite column names are case-insensitive", other, column)
+		}
+		folded[key] = column
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quoteIdent(column)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdent(name), strings.Join(quoted, ", ")))
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdent(name),
+		strings.Join(quoted, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	values := make([]any, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			values[i], err = sqlValue(row.(plugindata.Map)[column])
+			if err != nil {
+				return fmt.Errorf("column %q: %w", column, err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// sqlValue converts the data to a value stored in SQLite. Lists and maps are stored as JSON text.
+func sqlValue(data plugindata.Data) (any, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case plugindata.String:
+		return string(v), nil
+	case plugindata.Bool:
+		return bool(v), nil
+	case plugindata.Number:
+		f := float64(v)
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return int64(f), nil
+		}
+		return f, nil
+	case plugindata.Time:
+		return time.Time(v).Format(time.RFC3339Nano), nil
+	default:
+		raw, err := json.Marshal(data.Any())
+		if err != nil {
+			return nil, err
+		}
+		return string(raw), nil
+	}
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
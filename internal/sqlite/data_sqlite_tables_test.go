@@ -0,0 +1,282 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+func TestSqliteTablesDataSchema(t *testing.T) {
+	source := makeSqliteTablesDataSource()
+	assert.Nil(t, source.Config)
+	assert.NotNil(t, source.Args)
+	assert.NotNil(t, source.DataFunc)
+}
+
+func TestSqliteTablesDataCall(t *testing.T) {
+	tt := []struct {
+		name     string
+		args     string
+		expected plugindata.Data
+		diags    diagtest.Asserts
+	}{
+		{
+			name: "join_csv_and_json",
+			args: `
+				table {
+					name = "assets"
+					path = "testdata/assets.csv"
+				}
+				table {
+					name = "findings"
+					glob = "testdata/findings.*"
+				}
+				sql_query = <<-SQL
+					SELECT a.host, a.owner, count(f.title) AS findings, max(f.severity) AS max_severity
+					FROM assets a LEFT JOIN findings f ON f.host = a.host
+					WHERE a.critical OR a.owner = ?
+					GROUP BY a.host ORDER BY a.host
+				SQL
+				sql_args = ["web"]
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"host":         plugindata.String("10.0.0.1"),
+					"owner":        plugindata.String("infra"),
+					"findings":     plugindata.Number(1),
+					"max_severity": plugindata.Number(7.5),
+				},
+				plugindata.Map{
+					"host":         plugindata.String("10.0.0.2"),
+					"owner":        plugindata.String("web"),
+					"findings":     plugindata.Number(1),
+					"max_severity": plugindata.Number(6.1),
+				},
+				plugindata.Map{
+					"host":         plugindata.String("10.0.0.3"),
+					"owner":        plugindata.String("web"),
+					"findings":     plugindata.Number(2),
+					"max_severity": plugindata.Number(5.9),
+				},
+			},
+		},
+		{
+			name: "data_and_json_columns",
+			args: `
+				table {
+					name = "findings"
+					path = "testdata/findings.json"
+				}
+				table {
+					name = "owners"
+					data = [
+						{team = "infra", lead = "Alice"},
+						{team = "web", lead = null},
+					]
+				}
+				sql_query = <<-SQL
+					SELECT title, json_extract(tags, '$[1]') AS tag, tags
+					FROM findings WHERE tags IS NOT NULL
+				SQL
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"title": plugindata.String("Outdated OpenSSH"),
+					"tag":   plugindata.String("cve"),
+					"tags":  plugindata.String(`["ssh","cve"]`),
+				},
+			},
+		},
+		{
+			name: "parquet",
+			args: `
+				table {
+					name = "scores"
+					path = "../../pkg/parquet/testdata/flat.parquet"
+				}
+				sql_query = "SELECT id, name, created FROM scores WHERE score > 8 ORDER BY id"
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"id":      plugindata.Number(1),
+					"name":    plugindata.String("alice"),
+					"created": plugindata.String("2024-01-01T00:00:00Z"),
+				},
+			},
+		},
+		{
+			name: "format_and_delimiter",
+			args: `
+				table {
+					name = "assets"
+					path = "testdata/assets.csv"
+					format = "csv"
+					csv_delimiter = ";"
+				}
+				sql_query = "SELECT * FROM assets LIMIT 1"
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"host,owner,critical": plugindata.String("10.0.0.1,infra,true"),
+				},
+			},
+		},
+		{
+			name: "no_source",
+			args: `
+				table {
+					name = "assets"
+				}
+				sql_query = "SELECT 1"
+			`,
+			diags: diagtest.Asserts{{
+				diagtest.IsError,
+				diagtest.SummaryEquals("Failed to load the table"),
+				diagtest.DetailContains(`Table "assets"`, `exactly one of "path", "glob" or "data"`),
+			}},
+		},
+		{
+			name: "invalid_file",
+			args: `
+				table {
+					name = "findings"
+					path = "testdata/invalid.json"
+				}
+				table {
+					name = "rows"
+					data = [1, 2]
+				}
+				sql_query = "SELECT 1"
+			`,
+			diags: diagtest.Asserts{
+				{
+					diagtest.IsError,
+					diagtest.SummaryEquals("Failed to load the table"),
+					diagtest.DetailContains(`Table "findings"`, "testdata/invalid.json"),
+				},
+				{
+					diagtest.IsError,
+					diagtest.SummaryEquals("Failed to load the table"),
+					diagtest.DetailContains(`Table "rows"`, "row 0 is not an object"),
+				},
+			},
+		},
+		{
+			name: "unknown_format",
+			args: `
+				table {
+					name = "assets"
+					path = "data_sqlite_tables.go"
+				}
+				sql_query = "SELECT 1"
+			`,
+			diags: diagtest.Asserts{{
+				diagtest.IsError,
+				diagtest.DetailContains(`set "format" argument`),
+			}},
+		},
+		{
+			name: "empty_table_with_columns",
+			args: `
+				table {
+					name = "assets"
+					path = "testdata/assets.csv"
+				}
+				table {
+					name = "findings"
+					data = []
+					columns = ["host", "title"]
+				}
+				sql_query = <<-SQL
+					SELECT a.host, count(f.title) AS findings
+					FROM assets a LEFT JOIN findings f ON f.host = a.host
+					WHERE a.host = '10.0.0.1' GROUP BY a.host
+				SQL
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"host":     plugindata.String("10.0.0.1"),
+					"findings": plugindata.Number(0),
+				},
+			},
+		},
+		{
+			name: "columns_filter_keys",
+			args: `
+				table {
+					name = "owners"
+					data = [{team = "infra", lead = "Alice"}]
+					columns = ["team"]
+				}
+				sql_query = "SELECT * FROM owners"
+			`,
+			expected: plugindata.List{
+				plugindata.Map{
+					"team": plugindata.String("infra"),
+				},
+			},
+		},
+		{
+			name: "empty_table_without_columns",
+			args: `
+				table {
+					name = "findings"
+					data = []
+				}
+				sql_query = "SELECT 1"
+			`,
+			diags: diagtest.Asserts{{
+				diagtest.IsError,
+				diagtest.SummaryEquals("Failed to load the table"),
+				diagtest.DetailContains(`Table "findings"`, `set "columns" argument`),
+			}},
+		},
+		{
+			name: "columns_differ_in_case",
+			args: `
+				table {
+					name = "hosts"
+					data = [{Host = "web-1"}, {host = "web-2"}]
+				}
+				sql_query = "SELECT 1"
+			`,
+			diags: diagtest.Asserts{{
+				diagtest.IsError,
+				diagtest.SummaryEquals("Failed to load the table"),
+				diagtest.DetailContains(`Table "hosts"`, `columns "Host" and "host" differ only in case`),
+			}},
+		},
+		{
+			name: "invalid_query",
+			args: `
+				table {
+					name = "assets"
+					path = "testdata/assets.csv"
+				}
+				sql_query = "SELECT * FROM missing"
+			`,
+			diags: diagtest.Asserts{{
+				diagtest.IsError,
+				diagtest.SummaryEquals("Failed to query database"),
+				diagtest.DetailContains("no such table: missing"),
+			}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			source := makeSqliteTablesDataSource()
+			data, diags := source.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+				Args: plugintest.DecodeAndAssert(t, source.Args, tc.args, nil, diagtest.Asserts{}),
+			})
+			tc.diags.AssertMatch(t, diags, nil)
+			assert.Equal(t, tc.expected, data)
+		})
+	}
+}
@@ -9,7 +9,8 @@ func Plugin(version string) *plugin.Schema {
 		Name:    "blackstork/sqlite",
 		Version: version,
 		DataSources: plugin.DataSources{
-			"sqlite": makeSqliteDataSource(),
+			"sqlite":        makeSqliteDataSource(),
+			"sqlite_tables": makeSqliteTablesDataSource(),
 		},
 	}
 }
@@ -6,11 +6,13 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/base32"
+	"encoding/json"
 	"fmt"
 	"html/template"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/blackstork-io/fabric/pkg/diagnostics"
 	"github.com/blackstork-io/fabric/plugin"
@@ -22,6 +24,9 @@ import (
 //go:embed stixview.gohtml
 var stixViewTmplStr string
 
+// stixViewTypeURL is the type URL of the custom AST node payload produced by content.stixview.
+const stixViewTypeURL = "blackstork.io/stixview.StixView"
+
 var stixViewTmpl = template.Must(template.New("stixview").Parse(stixViewTmplStr))
 
 func makeStixViewContentProvider() *plugin.ContentProvider {
@@ -135,23 +140,60 @@ func renderStixView(ctx context.Context, params *plugin.ProvideContentParams) (*
 			Detail:   "Must provide either stix_url or gist_id or objects",
 		}}
 	}
-	buf := &bytes.Buffer{}
-	err = stixViewTmpl.Execute(buf, rctx)
+	payload, err := json.Marshal(rctx)
 	if err != nil {
 		return nil, diagnostics.Diag{{
 			Severity: hcl.DiagError,
-			Summary:  "Failed to render template",
+			Summary:  "Failed to encode stixview",
 			Detail:   err.Error(),
 		}}
 	}
-
 	return &plugin.ContentResult{
-		Content: plugin.NewElement(
-			ast.HTMLBlock(buf.Bytes()),
-		),
+		Content: plugin.NewElement(ast.CustomBlock(&anypb.Any{
+			TypeUrl: stixViewTypeURL,
+			Value:   payload,
+		})),
 	}, nil
 }
 
+func makeStixViewNodeRenderer() *plugin.NodeRenderer {
+	return &plugin.NodeRenderer{
+		Doc: "Renders the graphs produced by content.stixview",
+		Formats: []plugin.OutputFormat{
+			plugin.OutputFormatMD,
+			plugin.OutputFormatHTML,
+		},
+		RenderFunc: renderStixViewNode,
+	}
+}
+
+// renderStixViewNode renders the graph as html, the markdown keeps the html too.
+func renderStixViewNode(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+	var node struct {
+		Args    *stixViewArgs
+		UID     string
+		Objects []any
+	}
+	err := json.Unmarshal(params.Node.GetValue(), &node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to decode stixview",
+			Detail:   err.Error(),
+		}}
+	}
+	buf := &bytes.Buffer{}
+	err = stixViewTmpl.Execute(buf, node)
+	if err != nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to render template",
+			Detail:   err.Error(),
+		}}
+	}
+	return &plugin.RenderNodeResult{Content: buf.Bytes()}, nil
+}
+
 type renderContext struct {
 	Args    *stixViewArgs
 	UID     string
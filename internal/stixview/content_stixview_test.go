@@ -11,6 +11,7 @@ import (
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/plugindata"
 	"github.com/blackstork-io/fabric/plugin/plugintest"
+	"github.com/blackstork-io/fabric/print"
 	"github.com/blackstork-io/fabric/print/mdprint"
 )
 
@@ -27,6 +28,16 @@ func (s *StixViewTestSuite) SetupTest() {
 	s.schema = makeStixViewContentProvider()
 }
 
+// print prints the content to markdown, rendering the stixview nodes.
+func (s *StixViewTestSuite) print(content plugin.Content) string {
+	var buf strings.Builder
+	err := mdprint.New(print.WithNodeRenderers(plugin.NodeRenderers{
+		stixViewTypeURL: makeStixViewNodeRenderer(),
+	})).Print(context.Background(), &buf, content)
+	s.Require().NoError(err)
+	return buf.String()
+}
+
 func (s *StixViewTestSuite) TestSchema() {
 	s.Require().NotNil(s.schema)
 	s.Nil(s.schema.Config)
@@ -50,7 +61,7 @@ func (s *StixViewTestSuite) TestGistID() {
 		`<div data-stix-gist-id="123">`,
 		`</div>`,
 		``,
-	}, "\n"), mdprint.PrintString(res.Content))
+	}, "\n"), s.print(res.Content))
 }
 
 func (s *StixViewTestSuite) TestStixURL() {
@@ -70,7 +81,7 @@ func (s *StixViewTestSuite) TestStixURL() {
 		`<div data-stix-url="https://example.com/stix.json">`,
 		`</div>`,
 		``,
-	}, "\n"), mdprint.PrintString(res.Content))
+	}, "\n"), s.print(res.Content))
 }
 
 func (s *StixViewTestSuite) TestAllArgs() {
@@ -100,7 +111,7 @@ func (s *StixViewTestSuite) TestAllArgs() {
 		`<div data-stix-gist-id="123" data-show-sidebar="true" data-show-footer="true" data-show-tlp-as-tags="true" data-caption="test caption" data-show-marking-nodes="true" data-show-labels="true" data-show-idrefs="true" data-graph-width="400" data-graph-height="300">`,
 		`</div>`,
 		``,
-	}, "\n"), mdprint.PrintString(res.Content))
+	}, "\n"), s.print(res.Content))
 }
 
 func (s *StixViewTestSuite) TestDataCtx() {
@@ -117,8 +128,8 @@ func (s *StixViewTestSuite) TestDataCtx() {
 		DataContext: dataCtx,
 	})
 	s.Empty(diags)
-	s.Contains(mdprint.PrintString(res.Content), `<script src="https://unpkg.com/stixview/dist/stixview.bundle.js" type="text/javascript"></script>`)
-	s.Contains(mdprint.PrintString(res.Content), `<div id="graph-`)
-	s.Contains(mdprint.PrintString(res.Content), `window.stixview.init(`)
-	s.Contains(mdprint.PrintString(res.Content), `"objects": [{"key":"value"}]`)
+	s.Contains(s.print(res.Content), `<script src="https://unpkg.com/stixview/dist/stixview.bundle.js" type="text/javascript"></script>`)
+	s.Contains(s.print(res.Content), `<div id="graph-`)
+	s.Contains(s.print(res.Content), `window.stixview.init(`)
+	s.Contains(s.print(res.Content), `"objects": [{"key":"value"}]`)
 }
@@ -0,0 +1,521 @@
+package stixview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/dataspec"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// fetchTimeout limits the time of downloading the bundle from the url.
+const fetchTimeout = 30 * time.Second
+
+// tlpLevels orders TLP levels, both TLP 1.0 and TLP 2.0 names.
+var tlpLevels = map[string]int{
+	"white":        0,
+	"clear":        0,
+	"green":        1,
+	"amber":        2,
+	"amber+strict": 3,
+	"red":          4,
+}
+
+// tlpMarkings maps the IDs of the standard TLP marking definitions to their levels.
+var tlpMarkings = map[string]string{
+	// TLP 1.0, defined in STIX 2.1 specification
+	"marking-definition--613f2e26-407d-48c7-9eca-b8e91df99dc9": "white",
+	"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da": "green",
+	"marking-definition--f88d31f6-486f-44da-b317-01333bde0b82": "amber",
+	"marking-definition--5e57c739-391a-4eb3-b6be-7d15ca92d5ed": "red",
+	// TLP 2.0, defined in the extension of STIX 2.1
+	"marking-definition--94868c89-83c2-464b-929b-a1a8aa3c8487": "clear",
+	"marking-definition--bab4a63c-aed9-4cf5-a766-dfca5abac2bb": "green",
+	"marking-definition--55d920b0-5e8b-4f79-9ee9-91f868d9b421": "amber",
+	"marking-definition--939a9414-2ddd-4d32-a0cd-375ea402b003": "amber+strict",
+	"marking-definition--e828b379-4e03-4974-9ac4-e53a884c97c1": "red",
+}
+
+func makeStixDataSource(version string) *plugin.DataSource {
+	return &plugin.DataSource{
+		DataFunc: func(ctx context.Context, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+			return fetchStixData(ctx, version, params)
+		},
+		Args: &dataspec.RootSpec{
+			Attrs: []*dataspec.AttrSpec{
+				{
+					Name:       "path",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("path/to/bundle.json"),
+					Doc:        `A file path to a STIX bundle to read`,
+				},
+				{
+					Name:       "glob",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("path/to/bundles/*.json"),
+					Doc:        `A glob pattern to select STIX bundles to read. The objects of the bundles are merged`,
+				},
+				{
+					Name:       "url",
+					Type:       cty.String,
+					ExampleVal: cty.StringVal("https://example.com/bundle.json"),
+					Doc:        `A URL of a STIX bundle to fetch`,
+				},
+				{
+					Name:       "types",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("indicator")}),
+					Doc:        `Types of the objects to select. If not set, the objects of all types are selected`,
+				},
+				{
+					Name:       "labels",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("malicious-activity")}),
+					Doc:        `Labels of the objects to select: an object is selected if it has any of the labels`,
+				},
+				{
+					Name: "max_tlp",
+					Type: cty.String,
+					OneOf: []cty.Value{
+						cty.StringVal("clear"),
+						cty.StringVal("white"),
+						cty.StringVal("green"),
+						cty.StringVal("amber"),
+						cty.StringVal("amber+strict"),
+						cty.StringVal("red"),
+					},
+					ExampleVal: cty.StringVal("green"),
+					Doc: `The most restrictive TLP marking of the returned objects. The objects with more restrictive
+markings are dropped, including the related ones. The objects without TLP markings are treated as TLP:CLEAR`,
+				},
+				{
+					Name:         "depth",
+					Type:         cty.Number,
+					DefaultVal:   cty.NumberIntVal(0),
+					MinInclusive: cty.NumberIntVal(0),
+					Doc: `The number of relationship hops to follow from the selected objects. The objects found on
+the way are returned together with the relationships and sightings connecting them`,
+				},
+				{
+					Name:       "relationship_types",
+					Type:       cty.List(cty.String),
+					ExampleVal: cty.ListVal([]cty.Value{cty.StringVal("indicates"), cty.StringVal("attributed-to")}),
+					Doc:        `Types of the relationships to follow. If not set, all relationships and sightings are followed`,
+				},
+			},
+		},
+		Doc: `Loads STIX 2.1 bundles from a file, files or a URL, and returns their objects as a list.
+
+Exactly one of ` + "`path`" + `, ` + "`glob`" + ` or ` + "`url`" + ` arguments must be set.
+
+If ` + "`types`" + ` or ` + "`labels`" + ` are set, only the matching objects are selected. With ` + "`depth`" + `
+greater than zero, the objects related to the selected ones are added too. For example, to get the indicators
+with the malware they indicate and the threat actors the malware is attributed to:
+
+` + "```hcl" + `
+data stix "intel" {
+  path               = "bundle.json"
+  types              = ["indicator"]
+  depth              = 2
+  relationship_types = ["indicates", "attributed-to"]
+}
+` + "```" + `
+
+The relationships between the returned objects and the marking definitions they refer to are always
+included, so the result can be passed directly to ` + "`objects`" + ` argument of ` + "`stixview`" + ` content provider:
+
+` + "```hcl" + `
+content stixview {
+  objects = query_jq(".data.stix.intel")
+}
+` + "```" + `
+
+If a bundle has multiple versions of an object, the latest one by ` + "`modified`" + ` timestamp is used.`,
+	}
+}
+
+type stixFilter struct {
+	types             []string
+	labels            []string
+	maxTLP            int
+	depth             int
+	relationshipTypes []string
+}
+
+func fetchStixData(ctx context.Context, version string, params *plugin.RetrieveDataParams) (plugindata.Data, diagnostics.Diag) {
+	path := stringAttr(params.Args, "path")
+	glob := stringAttr(params.Args, "glob")
+	url := stringAttr(params.Args, "url")
+	set := 0
+	for _, val := range []string{path, glob, url} {
+		if val != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to parse provided arguments",
+			Detail:   "Exactly one of \"path\", \"glob\" or \"url\" must be provided",
+		}}
+	}
+
+	var (
+		objects plugindata.List
+		err     error
+	)
+	switch {
+	case path != "":
+		slog.DebugContext(ctx, "Reading a STIX bundle from a path", "path", path)
+		objects, err = readStixBundleFile(path)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the file",
+				Detail:   err.Error(),
+			}}
+		}
+	case glob != "":
+		slog.DebugContext(ctx, "Reading the STIX bundles that match the glob pattern", "glob", glob)
+		objects, err = readStixBundleFiles(ctx, glob)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the files",
+				Detail:   err.Error(),
+			}}
+		}
+	default:
+		slog.DebugContext(ctx, "Fetching a STIX bundle", "url", url)
+		objects, err = fetchStixBundle(ctx, version, url)
+		if err != nil {
+			return nil, diagnostics.Diag{{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to fetch the bundle",
+				Detail:   err.Error(),
+			}}
+		}
+	}
+	return filterStixObjects(objects, parseStixFilter(params.Args)), nil
+}
+
+func parseStixFilter(args *dataspec.Block) stixFilter {
+	filter := stixFilter{
+		types:             stringListAttr(args, "types"),
+		labels:            stringListAttr(args, "labels"),
+		maxTLP:            tlpLevels["red"],
+		relationshipTypes: stringListAttr(args, "relationship_types"),
+	}
+	if level := stringAttr(args, "max_tlp"); level != "" {
+		filter.maxTLP = tlpLevels[level]
+	}
+	n, _ := args.GetAttrVal("depth").AsBigFloat().Int64()
+	filter.depth = int(n)
+	return filter
+}
+
+func readStixBundleFile(path string) (plugindata.List, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseStixBundle(raw)
+}
+
+func readStixBundleFiles(ctx context.Context, pattern string) (plugindata.List, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var result plugindata.List
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		objects, err := readStixBundleFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		result = append(result, objects...)
+	}
+	return result, nil
+}
+
+func fetchStixBundle(ctx context.Context, version, url string) (plugindata.List, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("fabric-data-stix/%s", version))
+	req.Header.Set("Accept", "application/stix+json, application/json")
+	client := &http.Client{Timeout: fetchTimeout}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %s", res.Status)
+	}
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseStixBundle(raw)
+}
+
+func parseStixBundle(raw []byte) (plugindata.List, error) {
+	data, err := plugindata.UnmarshalJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	bundle, ok := data.(plugindata.Map)
+	if !ok || stixString(bundle, "type") != "bundle" {
+		return nil, errors.New("not a STIX bundle")
+	}
+	objects, _ := bundle["objects"].(plugindata.List)
+	result := make(plugindata.List, 0, len(objects))
+	for i, obj := range objects {
+		m, ok := obj.(plugindata.Map)
+		if !ok || stixString(m, "id") == "" || stixString(m, "type") == "" {
+			return nil, fmt.Errorf("object %d of the bundle is not a STIX object", i)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// stixEdge connects an object to another one through a relationship or a sighting.
+type stixEdge struct {
+	sro   string
+	other string
+}
+
+// filterStixObjects selects the objects matching the filter with the related objects.
+func filterStixObjects(objects plugindata.List, filter stixFilter) plugindata.List {
+	objects = latestStixObjects(objects)
+
+	byID := make(map[string]plugindata.Map, len(objects))
+	for _, obj := range objects {
+		m := obj.(plugindata.Map)
+		byID[stixString(m, "id")] = m
+	}
+	allowed := func(id string) bool {
+		obj, ok := byID[id]
+		return ok && stixTLP(obj, byID) <= filter.maxTLP
+	}
+
+	edges := map[string][]stixEdge{}
+	for id, obj := range byID {
+		if !allowed(id) || !followStixSRO(obj, filter.relationshipTypes) {
+			continue
+		}
+		refs := stixSRORefs(obj)
+		for i, from := range refs {
+			for j, to := range refs {
+				// sightings connect the sighted object to the others only
+				if i != j && (i == 0 || j == 0) {
+					edges[from] = append(edges[from], stixEdge{sro: id, other: to})
+				}
+			}
+		}
+	}
+
+	included := map[string]bool{}
+	var frontier []string
+	for _, obj := range objects {
+		m := obj.(plugindata.Map)
+		id := stixString(m, "id")
+		if allowed(id) && matchStixObject(m, filter) {
+			included[id] = true
+			frontier = append(frontier, id)
+		}
+	}
+	for range filter.depth {
+		var next []string
+		for _, id := range frontier {
+			for _, edge := range edges[id] {
+				if !allowed(edge.other) {
+					continue
+				}
+				included[edge.sro] = true
+				if !included[edge.other] {
+					included[edge.other] = true
+					next = append(next, edge.other)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	// the relationships between the included objects and the markings they refer to
+	for id, obj := range byID {
+		if included[id] || !allowed(id) {
+			continue
+		}
+		refs := stixSRORefs(obj)
+		if len(refs) > 1 && included[refs[0]] && slices.ContainsFunc(refs[1:], func(ref string) bool { return included[ref] }) {
+			included[id] = true
+		}
+	}
+	for id := range included {
+		for _, ref := range stixStringList(byID[id], "object_marking_refs") {
+			if _, ok := byID[ref]; ok {
+				included[ref] = true
+			}
+		}
+	}
+
+	result := plugindata.List{}
+	for _, obj := range objects {
+		if included[stixString(obj.(plugindata.Map), "id")] {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// latestStixObjects keeps the latest version of each object, at the position of the first one.
+func latestStixObjects(objects plugindata.List) plugindata.List {
+	index := map[string]int{}
+	result := make(plugindata.List, 0, len(objects))
+	for _, obj := range objects {
+		m := obj.(plugindata.Map)
+		id := stixString(m, "id")
+		i, ok := index[id]
+		if !ok {
+			index[id] = len(result)
+			result = append(result, m)
+			continue
+		}
+		if stixModified(m).After(stixModified(result[i].(plugindata.Map))) {
+			result[i] = m
+		}
+	}
+	return result
+}
+
+func matchStixObject(obj plugindata.Map, filter stixFilter) bool {
+	if len(filter.types) > 0 && !slices.Contains(filter.types, stixString(obj, "type")) {
+		return false
+	}
+	if len(filter.labels) > 0 {
+		labels := stixStringList(obj, "labels")
+		if !slices.ContainsFunc(filter.labels, func(label string) bool { return slices.Contains(labels, label) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func followStixSRO(obj plugindata.Map, relationshipTypes []string) bool {
+	switch stixString(obj, "type") {
+	case "relationship":
+		return len(relationshipTypes) == 0 || slices.Contains(relationshipTypes, stixString(obj, "relationship_type"))
+	case "sighting":
+		return len(relationshipTypes) == 0 || slices.Contains(relationshipTypes, "sighting")
+	default:
+		return false
+	}
+}
+
+// stixSRORefs returns the objects connected by a relationship or a sighting,
+// the source or the sighted object first.
+func stixSRORefs(obj plugindata.Map) []string {
+	switch stixString(obj, "type") {
+	case "relationship":
+		return []string{stixString(obj, "source_ref"), stixString(obj, "target_ref")}
+	case "sighting":
+		refs := []string{stixString(obj, "sighting_of_ref")}
+		refs = append(refs, stixStringList(obj, "where_sighted_refs")...)
+		return append(refs, stixStringList(obj, "observed_data_refs")...)
+	default:
+		return nil
+	}
+}
+
+// stixTLP returns the most restrictive TLP level of the object markings.
+func stixTLP(obj plugindata.Map, byID map[string]plugindata.Map) int {
+	level := 0
+	for _, ref := range stixStringList(obj, "object_marking_refs") {
+		name, ok := tlpMarkings[ref]
+		if !ok {
+			name = stixMarkingTLP(byID[ref])
+		}
+		if l, ok := tlpLevels[name]; ok && l > level {
+			level = l
+		}
+	}
+	return level
+}
+
+// stixMarkingTLP returns the TLP level of a custom marking definition, if it's a TLP marking.
+func stixMarkingTLP(marking plugindata.Map) string {
+	if marking == nil {
+		return ""
+	}
+	if stixString(marking, "definition_type") == "tlp" {
+		if def, ok := marking["definition"].(plugindata.Map); ok {
+			return strings.ToLower(stixString(def, "tlp"))
+		}
+	}
+	if name, ok := strings.CutPrefix(strings.ToLower(stixString(marking, "name")), "tlp:"); ok {
+		return name
+	}
+	return ""
+}
+
+func stixModified(obj plugindata.Map) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, stixString(obj, "modified"))
+	return t
+}
+
+func stixString(obj plugindata.Map, key string) string {
+	s, _ := obj[key].(plugindata.String)
+	return string(s)
+}
+
+func stixStringList(obj plugindata.Map, key string) []string {
+	list, _ := obj[key].(plugindata.List)
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(plugindata.String); ok {
+			result = append(result, string(s))
+		}
+	}
+	return result
+}
+
+func stringAttr(args *dataspec.Block, name string) string {
+	val := args.GetAttrVal(name)
+	if val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
+
+func stringListAttr(args *dataspec.Block, name string) []string {
+	val := args.GetAttrVal(name)
+	if val.IsNull() {
+		return nil
+	}
+	result := make([]string, 0, val.LengthInt())
+	for _, item := range val.AsValueSlice() {
+		result = append(result, item.AsString())
+	}
+	return result
+}
@@ -0,0 +1,206 @@
+package stixview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics/diagtest"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+	"github.com/blackstork-io/fabric/plugin/plugintest"
+)
+
+type StixDataSourceTestSuite struct {
+	suite.Suite
+	schema *plugin.DataSource
+}
+
+func TestStixDataSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(StixDataSourceTestSuite))
+}
+
+func (s *StixDataSourceTestSuite) SetupTest() {
+	s.schema = makeStixDataSource("1.2.3")
+}
+
+func (s *StixDataSourceTestSuite) TestSchema() {
+	s.Require().NotNil(s.schema)
+	s.Nil(s.schema.Config)
+	s.NotNil(s.schema.Args)
+	s.NotNil(s.schema.DataFunc)
+}
+
+func (s *StixDataSourceTestSuite) fetch(args string, asserts diagtest.Asserts) plugindata.Data {
+	data, diags := s.schema.DataFunc(context.Background(), &plugin.RetrieveDataParams{
+		Args: plugintest.DecodeAndAssert(s.T(), s.schema.Args, args, nil, diagtest.Asserts{}),
+	})
+	asserts.AssertMatch(s.T(), diags, nil)
+	return data
+}
+
+// fetchIDs returns the IDs of the returned objects.
+func (s *StixDataSourceTestSuite) fetchIDs(args string) []string {
+	data := s.fetch(args, diagtest.Asserts{})
+	list, ok := data.(plugindata.List)
+	s.Require().True(ok)
+	ids := make([]string, 0, len(list))
+	for _, obj := range list {
+		ids = append(ids, stixString(obj.(plugindata.Map), "id"))
+	}
+	return ids
+}
+
+func (s *StixDataSourceTestSuite) TestAllObjects() {
+	data := s.fetch(`path = "testdata/bundle.json"`, diagtest.Asserts{})
+	list := data.(plugindata.List)
+	s.Len(list, 15)
+	// the latest version of the object is kept at the position of the first one
+	indicator := list[4].(plugindata.Map)
+	s.Equal(plugindata.String("indicator--3"), indicator["id"])
+	s.Equal(plugindata.String("Dropper hash, revised"), indicator["name"])
+}
+
+func (s *StixDataSourceTestSuite) TestFilters() {
+	s.Equal([]string{
+		"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+		"marking-definition--00000000-0000-4000-8000-000000000001",
+		"indicator--1",
+		"indicator--2",
+		"indicator--3",
+		"relationship--5",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["indicator"]
+	`))
+	s.Equal([]string{
+		"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+		"marking-definition--00000000-0000-4000-8000-000000000001",
+		"indicator--1",
+		"indicator--2",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		labels = ["malicious-activity", "benign"]
+	`))
+	s.Equal([]string{
+		"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+		"indicator--1",
+		"indicator--3",
+		"relationship--5",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["indicator"]
+		max_tlp = "amber+strict"
+	`))
+	s.Equal([]string{
+		"indicator--3",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["indicator"]
+		max_tlp = "clear"
+	`))
+}
+
+func (s *StixDataSourceTestSuite) TestRelationships() {
+	s.Equal([]string{
+		"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+		"indicator--1",
+		"malware--1",
+		"threat-actor--1",
+		"relationship--1",
+		"relationship--2",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["indicator"]
+		labels = ["malicious-activity"]
+		max_tlp = "green"
+		depth = 2
+		relationship_types = ["indicates", "attributed-to"]
+	`))
+	s.Equal([]string{
+		"malware--1",
+		"threat-actor--1",
+		"attack-pattern--1",
+		"relationship--2",
+		"relationship--4",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["threat-actor"]
+		depth = 2
+		relationship_types = ["attributed-to", "uses"]
+	`))
+	s.Equal([]string{
+		"marking-definition--34098fce-860f-48ae-8e50-ebd3cc5e41da",
+		"indicator--1",
+		"identity--1",
+		"sighting--1",
+	}, s.fetchIDs(`
+		path = "testdata/bundle.json"
+		types = ["identity"]
+		depth = 1
+		relationship_types = ["sighting"]
+	`))
+}
+
+func (s *StixDataSourceTestSuite) TestGlob() {
+	ids := s.fetchIDs(`
+		glob = "testdata/*.json"
+		types = ["vulnerability", "malware"]
+	`)
+	s.Equal([]string{"malware--1", "vulnerability--1"}, ids)
+}
+
+func (s *StixDataSourceTestSuite) TestURL() {
+	bundle, err := os.ReadFile("testdata/extra.json")
+	s.Require().NoError(err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bundle.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.Equal("fabric-data-stix/1.2.3", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/stix+json")
+		w.Write(bundle)
+	}))
+	defer srv.Close()
+
+	s.Equal([]string{"vulnerability--1"}, s.fetchIDs(`url = "`+srv.URL+`/bundle.json"`))
+	s.fetch(`url = "`+srv.URL+`/missing.json"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to fetch the bundle"),
+		diagtest.DetailContains("404"),
+	}})
+}
+
+func (s *StixDataSourceTestSuite) TestErrors() {
+	s.fetch(``, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+		diagtest.DetailEquals("Exactly one of \"path\", \"glob\" or \"url\" must be provided"),
+	}})
+	s.fetch(`
+		path = "testdata/bundle.json"
+		url = "https://example.com/bundle.json"
+	`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to parse provided arguments"),
+	}})
+	s.fetch(`path = "testdata/invalid.txt"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+		diagtest.DetailEquals("not a STIX bundle"),
+	}})
+	s.fetch(`glob = "testdata/*"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the files"),
+		diagtest.DetailContains("invalid.txt", "not a STIX bundle"),
+	}})
+	s.fetch(`path = "testdata/missing.json"`, diagtest.Asserts{{
+		diagtest.IsError,
+		diagtest.SummaryEquals("Failed to read the file"),
+	}})
+}
@@ -8,8 +8,14 @@ func Plugin(version string) *plugin.Schema {
 	return &plugin.Schema{
 		Name:    "blackstork/stixview",
 		Version: version,
+		DataSources: plugin.DataSources{
+			"stix": makeStixDataSource(version),
+		},
 		ContentProviders: plugin.ContentProviders{
 			"stixview": makeStixViewContentProvider(),
 		},
+		NodeRenderers: plugin.NodeRenderers{
+			stixViewTypeURL: makeStixViewNodeRenderer(),
+		},
 	}
 }
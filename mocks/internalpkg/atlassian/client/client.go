@@ -23,6 +23,184 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// CreatePage provides a mock function with given fields: ctx, req
+func (_m *Client) CreatePage(ctx context.Context, req *client.CreatePageReq) (*client.Page, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePage")
+	}
+
+	var r0 *client.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *client.CreatePageReq) (*client.Page, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *client.CreatePageReq) *client.Page); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *client.CreatePageReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CreatePage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePage'
+type Client_CreatePage_Call struct {
+	*mock.Call
+}
+
+// CreatePage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *client.CreatePageReq
+func (_e *Client_Expecter) CreatePage(ctx interface{}, req interface{}) *Client_CreatePage_Call {
+	return &Client_CreatePage_Call{Call: _e.mock.On("CreatePage", ctx, req)}
+}
+
+func (_c *Client_CreatePage_Call) Run(run func(ctx context.Context, req *client.CreatePageReq)) *Client_CreatePage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*client.CreatePageReq))
+	})
+	return _c
+}
+
+func (_c *Client_CreatePage_Call) Return(_a0 *client.Page, _a1 error) *Client_CreatePage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CreatePage_Call) RunAndReturn(run func(context.Context, *client.CreatePageReq) (*client.Page, error)) *Client_CreatePage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPage provides a mock function with given fields: ctx, spaceKey, title
+func (_m *Client) FindPage(ctx context.Context, spaceKey string, title string) (*client.Page, error) {
+	ret := _m.Called(ctx, spaceKey, title)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 *client.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*client.Page, error)); ok {
+		return rf(ctx, spaceKey, title)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *client.Page); ok {
+		r0 = rf(ctx, spaceKey, title)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, spaceKey, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type Client_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spaceKey string
+//   - title string
+func (_e *Client_Expecter) FindPage(ctx interface{}, spaceKey interface{}, title interface{}) *Client_FindPage_Call {
+	return &Client_FindPage_Call{Call: _e.mock.On("FindPage", ctx, spaceKey, title)}
+}
+
+func (_c *Client_FindPage_Call) Run(run func(ctx context.Context, spaceKey string, title string)) *Client_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Client_FindPage_Call) Return(_a0 *client.Page, _a1 error) *Client_FindPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_FindPage_Call) RunAndReturn(run func(context.Context, string, string) (*client.Page, error)) *Client_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function with given fields: ctx, id
+func (_m *Client) GetPage(ctx context.Context, id string) (*client.Page, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 *client.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*client.Page, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *client.Page); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type Client_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Client_Expecter) GetPage(ctx interface{}, id interface{}) *Client_GetPage_Call {
+	return &Client_GetPage_Call{Call: _e.mock.On("GetPage", ctx, id)}
+}
+
+func (_c *Client_GetPage_Call) Run(run func(ctx context.Context, id string)) *Client_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_GetPage_Call) Return(_a0 *client.Page, _a1 error) *Client_GetPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetPage_Call) RunAndReturn(run func(context.Context, string) (*client.Page, error)) *Client_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchIssues provides a mock function with given fields: ctx, req
 func (_m *Client) SearchIssues(ctx context.Context, req *client.SearchIssuesReq) (*client.SearchIssuesRes, error) {
 	ret := _m.Called(ctx, req)
@@ -82,6 +260,114 @@ func (_c *Client_SearchIssues_Call) RunAndReturn(run func(context.Context, *clie
 	return _c
 }
 
+// UpdatePage provides a mock function with given fields: ctx, id, req
+func (_m *Client) UpdatePage(ctx context.Context, id string, req *client.UpdatePageReq) (*client.Page, error) {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePage")
+	}
+
+	var r0 *client.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *client.UpdatePageReq) (*client.Page, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *client.UpdatePageReq) *client.Page); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Page)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *client.UpdatePageReq) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_UpdatePage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePage'
+type Client_UpdatePage_Call struct {
+	*mock.Call
+}
+
+// UpdatePage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req *client.UpdatePageReq
+func (_e *Client_Expecter) UpdatePage(ctx interface{}, id interface{}, req interface{}) *Client_UpdatePage_Call {
+	return &Client_UpdatePage_Call{Call: _e.mock.On("UpdatePage", ctx, id, req)}
+}
+
+func (_c *Client_UpdatePage_Call) Run(run func(ctx context.Context, id string, req *client.UpdatePageReq)) *Client_UpdatePage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*client.UpdatePageReq))
+	})
+	return _c
+}
+
+func (_c *Client_UpdatePage_Call) Return(_a0 *client.Page, _a1 error) *Client_UpdatePage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_UpdatePage_Call) RunAndReturn(run func(context.Context, string, *client.UpdatePageReq) (*client.Page, error)) *Client_UpdatePage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadAttachment provides a mock function with given fields: ctx, pageID, att
+func (_m *Client) UploadAttachment(ctx context.Context, pageID string, att *client.Attachment) error {
+	ret := _m.Called(ctx, pageID, att)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *client.Attachment) error); ok {
+		r0 = rf(ctx, pageID, att)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_UploadAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadAttachment'
+type Client_UploadAttachment_Call struct {
+	*mock.Call
+}
+
+// UploadAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pageID string
+//   - att *client.Attachment
+func (_e *Client_Expecter) UploadAttachment(ctx interface{}, pageID interface{}, att interface{}) *Client_UploadAttachment_Call {
+	return &Client_UploadAttachment_Call{Call: _e.mock.On("UploadAttachment", ctx, pageID, att)}
+}
+
+func (_c *Client_UploadAttachment_Call) Run(run func(ctx context.Context, pageID string, att *client.Attachment)) *Client_UploadAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*client.Attachment))
+	})
+	return _c
+}
+
+func (_c *Client_UploadAttachment_Call) Return(_a0 error) *Client_UploadAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_UploadAttachment_Call) RunAndReturn(run func(context.Context, string, *client.Attachment) error) *Client_UploadAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
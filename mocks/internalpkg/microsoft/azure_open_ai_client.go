@@ -23,6 +23,64 @@ func (_m *AzureOpenAIClient) EXPECT() *AzureOpenAIClient_Expecter {
 	return &AzureOpenAIClient_Expecter{mock: &_m.Mock}
 }
 
+// GetChatCompletions provides a mock function with given fields: ctx, body, options
+func (_m *AzureOpenAIClient) GetChatCompletions(ctx context.Context, body azopenai.ChatCompletionsOptions, options *azopenai.GetChatCompletionsOptions) (azopenai.GetChatCompletionsResponse, error) {
+	ret := _m.Called(ctx, body, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatCompletions")
+	}
+
+	var r0 azopenai.GetChatCompletionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, azopenai.ChatCompletionsOptions, *azopenai.GetChatCompletionsOptions) (azopenai.GetChatCompletionsResponse, error)); ok {
+		return rf(ctx, body, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, azopenai.ChatCompletionsOptions, *azopenai.GetChatCompletionsOptions) azopenai.GetChatCompletionsResponse); ok {
+		r0 = rf(ctx, body, options)
+	} else {
+		r0 = ret.Get(0).(azopenai.GetChatCompletionsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, azopenai.ChatCompletionsOptions, *azopenai.GetChatCompletionsOptions) error); ok {
+		r1 = rf(ctx, body, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AzureOpenAIClient_GetChatCompletions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatCompletions'
+type AzureOpenAIClient_GetChatCompletions_Call struct {
+	*mock.Call
+}
+
+// GetChatCompletions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body azopenai.ChatCompletionsOptions
+//   - options *azopenai.GetChatCompletionsOptions
+func (_e *AzureOpenAIClient_Expecter) GetChatCompletions(ctx interface{}, body interface{}, options interface{}) *AzureOpenAIClient_GetChatCompletions_Call {
+	return &AzureOpenAIClient_GetChatCompletions_Call{Call: _e.mock.On("GetChatCompletions", ctx, body, options)}
+}
+
+func (_c *AzureOpenAIClient_GetChatCompletions_Call) Run(run func(ctx context.Context, body azopenai.ChatCompletionsOptions, options *azopenai.GetChatCompletionsOptions)) *AzureOpenAIClient_GetChatCompletions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(azopenai.ChatCompletionsOptions), args[2].(*azopenai.GetChatCompletionsOptions))
+	})
+	return _c
+}
+
+func (_c *AzureOpenAIClient_GetChatCompletions_Call) Return(_a0 azopenai.GetChatCompletionsResponse, _a1 error) *AzureOpenAIClient_GetChatCompletions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AzureOpenAIClient_GetChatCompletions_Call) RunAndReturn(run func(context.Context, azopenai.ChatCompletionsOptions, *azopenai.GetChatCompletionsOptions) (azopenai.GetChatCompletionsResponse, error)) *AzureOpenAIClient_GetChatCompletions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCompletions provides a mock function with given fields: ctx, body, options
 func (_m *AzureOpenAIClient) GetCompletions(ctx context.Context, body azopenai.CompletionsOptions, options *azopenai.GetCompletionsOptions) (azopenai.GetCompletionsResponse, error) {
 	ret := _m.Called(ctx, body, options)
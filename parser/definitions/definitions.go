@@ -17,16 +17,21 @@ const (
 	BlockKindGlobalConfig = "fabric"
 	BlockKindDynamic      = "dynamic"
 
-	PluginTypeRef    = "ref"
-	AttrRefBase      = "base"
-	AttrTitle        = "title"
-	AttrDependsOn    = "depends_on"
-	AttrLocalVar     = "local_var"
-	AttrRequiredVars = "required_vars"
-	AttrIsIncluded   = "is_included"
-	AttrDynamicItems = "items"
+	PluginTypeRef        = "ref"
+	AttrRefBase          = "base"
+	AttrTitle            = "title"
+	AttrDependsOn        = "depends_on"
+	AttrLocalVar         = "local_var"
+	AttrRequiredVars     = "required_vars"
+	AttrIsIncluded       = "is_included"
+	AttrDynamicItems     = "items"
+	AttrNumberedHeadings = "numbered_headings"
 )
 
+// SectionAnchorPrefix is the prefix of the anchor of a named section title,
+// followed by the section name.
+const SectionAnchorPrefix = "section."
+
 type FabricBlock interface {
 	GetHCLBlock() *hclsyntax.Block
 	CtyType() cty.Type
@@ -38,6 +38,7 @@ func (g *GlobalConfigDefinition) Parse(ctx context.Context) (cfg *GlobalConfig,
 	globalCfg.EnvVarsPattern, diag = g.parseEnvVarPattern(ctx)
 	diags.Extend(diag)
 	diags.Extend(gohcl.DecodeBody(g.block.Body, evalCtx, &globalCfg))
+	diags.Extend(g.validateAllowedCommands(globalCfg.AllowedCommands))
 
 	if diags.HasErrors() {
 		return
@@ -92,6 +93,21 @@ func (g *GlobalConfigDefinition) parseEnvVarPattern(ctx context.Context) (pat gl
 	return
 }
 
+// validateAllowedCommands checks that the patterns of the commands allowed to run are valid globs.
+func (g *GlobalConfigDefinition) validateAllowedCommands(patterns []string) (diags diagnostics.Diag) {
+	for _, pattern := range patterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  `Failed to parse "allowed_commands"`,
+				Detail:   fmt.Sprintf("Invalid pattern %q: %s", pattern, err),
+				Subject:  g.block.Body.Attributes["allowed_commands"].Expr.Range().Ptr(),
+			})
+		}
+	}
+	return
+}
+
 func DefineGlobalConfig(block *hclsyntax.Block) (config *GlobalConfigDefinition, diags diagnostics.Diag) {
 	return &GlobalConfigDefinition{
 		block: block,
@@ -99,10 +115,11 @@ func DefineGlobalConfig(block *hclsyntax.Block) (config *GlobalConfigDefinition,
 }
 
 type GlobalConfig struct {
-	CacheDir       string            `hcl:"cache_dir,optional"`
-	PluginRegistry *PluginRegistry   `hcl:"plugin_registry,block"`
-	PluginVersions map[string]string `hcl:"plugin_versions,optional"`
-	EnvVarsPattern glob.Glob
+	CacheDir        string            `hcl:"cache_dir,optional"`
+	PluginRegistry  *PluginRegistry   `hcl:"plugin_registry,block"`
+	PluginVersions  map[string]string `hcl:"plugin_versions,optional"`
+	AllowedCommands []string          `hcl:"allowed_commands,optional"`
+	EnvVarsPattern  glob.Glob
 }
 
 type PluginRegistry struct {
@@ -129,5 +146,8 @@ func (g *GlobalConfig) Merge(other *GlobalConfig) {
 	if other.EnvVarsPattern != DefaultEnvVarsPattern {
 		g.EnvVarsPattern = other.EnvVarsPattern
 	}
+	if other.AllowedCommands != nil {
+		g.AllowedCommands = other.AllowedCommands
+	}
 	g.PluginVersions = other.PluginVersions
 }
@@ -5,7 +5,9 @@ type ParsedDocument struct {
 	Meta         *MetaBlock
 	Vars         *ParsedVars
 	RequiredVars []string
-	Content      []*ParsedContent
-	Data         []*ParsedPlugin
-	Publish      []*ParsedPlugin
+	// NumberedHeadings enables hierarchical numbering of the headings
+	NumberedHeadings bool
+	Content          []*ParsedContent
+	Data             []*ParsedPlugin
+	Publish          []*ParsedPlugin
 }
@@ -132,5 +132,10 @@ func (db *DefinedBlocks) ParseDocument(ctx context.Context, d *definitions.Docum
 		diag := gohcl.DecodeExpression(requiredVarsAttr.Expr, nil, &doc.RequiredVars)
 		diags.Extend(diag)
 	}
+
+	if numberedAttr := d.Block.Body.Attributes[definitions.AttrNumberedHeadings]; numberedAttr != nil {
+		diag := gohcl.DecodeExpression(numberedAttr.Expr, nil, &doc.NumberedHeadings)
+		diags.Extend(diag)
+	}
 	return
 }
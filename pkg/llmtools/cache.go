@@ -0,0 +1,106 @@
+package llmtools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Cache stores the responses of the model in files named after the hash of the request,
+// so the same request produces the same response on re-renders.
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a cache storing the responses in the directory. The directory is created on write.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Key returns a hash of the request parts, for example, the model, the system prompt and the prompt.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		// the length prefix keeps the boundaries of the parts in the hash
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached response.
+func (c *Cache) Get(key string) (string, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read cached response: %w", err)
+	}
+	return string(data), true, nil
+}
+
+// Put stores the response.
+func (c *Cache) Put(key, response string) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// the response is written to a temporary file first, so concurrent renders never read a partial response
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+	_, err = tmp.WriteString(response)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".txt")
+}
+
+// Completion is the response of the model.
+type Completion struct {
+	Text string
+	// Truncated is set if the model stopped at the token limit, such responses aren't cached.
+	Truncated bool
+}
+
+// CompletionFunc sends the prompt to the model and returns the response with its finish status.
+type CompletionFunc func(ctx context.Context, prompt string) (Completion, error)
+
+// Cached wraps the function with the cache. The key of a request is the hash of keyParts and the prompt.
+// If the cache is nil, the responses are returned as is.
+func Cached(cache *Cache, complete CompletionFunc, keyParts ...string) CompleteFunc {
+	return func(ctx context.Context, prompt string) (string, error) {
+		if cache == nil {
+			res, err := complete(ctx, prompt)
+			return res.Text, err
+		}
+		key := Key(append(keyParts[:len(keyParts):len(keyParts)], prompt)...)
+		if response, found, err := cache.Get(key); err != nil || found {
+			return response, err
+		}
+		res, err := complete(ctx, prompt)
+		if err != nil {
+			return "", err
+		}
+		if res.Truncated {
+			return res.Text, nil
+		}
+		return res.Text, cache.Put(key, res.Text)
+	}
+}
@@ -0,0 +1,213 @@
+// Package llmtools fits large inputs into the context window of language models:
+// the data is split into chunks, every chunk is processed with the same prompt (map step)
+// and the partial results are combined into a single response (reduce step).
+package llmtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// CompleteFunc sends the prompt to the model and returns the response.
+type CompleteFunc func(ctx context.Context, prompt string) (string, error)
+
+// Options configure the processing of the prompt and the data.
+type Options struct {
+	// MaxInputTokens is the estimated number of tokens a single request can take.
+	// Zero disables chunking.
+	MaxInputTokens int
+	// ReducePrompt is the instruction to combine the results of the chunks.
+	// If empty, a default instruction referring to the original prompt is used.
+	ReducePrompt string
+}
+
+// charsPerToken is the average number of characters in a token of English text and JSON.
+const charsPerToken = 4
+
+// EstimateTokens returns an estimated number of tokens in the text.
+// The estimate errs on the side of more tokens for non-ASCII text.
+func EstimateTokens(text string) int {
+	runes := utf8.RuneCountInString(text)
+	nonASCII := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			nonASCII++
+		}
+	}
+	// multi-byte characters tend to be separate tokens
+	return (runes+charsPerToken-1)/charsPerToken + nonASCII/2
+}
+
+// Run sends the prompt with the data items to the model. If the request doesn't fit into
+// the token budget, the items are split into chunks, the prompt is run for every chunk and
+// the results are combined with the reduce prompt.
+//
+// items are the serialized data items, for example, JSON objects of a list. They are sent
+// after the prompt as a JSON array.
+func Run(ctx context.Context, complete CompleteFunc, prompt string, items []string, opts Options) (string, error) {
+	single := withData(prompt, items)
+	// without the data items there is nothing to split, the prompt is sent as is
+	if opts.MaxInputTokens <= 0 || len(items) == 0 || EstimateTokens(single) <= opts.MaxInputTokens {
+		return complete(ctx, single)
+	}
+	header := mapHeader(prompt, 999, 999)
+	budget := opts.MaxInputTokens - EstimateTokens(header)
+	if budget <= 0 {
+		return "", fmt.Errorf("the prompt doesn't fit into the limit of %d tokens", opts.MaxInputTokens)
+	}
+	chunks := SplitChunks(items, budget)
+	results := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		result, err := complete(ctx, mapHeader(prompt, i+1, len(chunks))+"["+strings.Join(chunk, ",")+"]")
+		if err != nil {
+			return "", fmt.Errorf("part %d of %d: %w", i+1, len(chunks), err)
+		}
+		results[i] = result
+	}
+	return reduce(ctx, complete, prompt, results, opts)
+}
+
+// SplitChunks groups the items into chunks fitting into the token budget, keeping the order.
+// Items exceeding the budget are split into several items.
+func SplitChunks(items []string, budget int) [][]string {
+	var chunks [][]string
+	var chunk []string
+	tokens := 0
+	for _, item := range items {
+		for _, part := range splitItem(item, budget) {
+			// the separator of the items takes a token
+			size := EstimateTokens(part) + 1
+			if len(chunk) > 0 && tokens+size > budget {
+				chunks = append(chunks, chunk)
+				chunk, tokens = nil, 0
+			}
+			chunk = append(chunk, part)
+			tokens += size
+		}
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// splitItem splits the text into parts fitting into the budget, preferably at line breaks.
+func splitItem(text string, budget int) []string {
+	if EstimateTokens(text)+1 <= budget {
+		return []string{text}
+	}
+	maxChars := max((budget-1)*charsPerToken/2, 1)
+	var parts []string
+	for text != "" {
+		n := 0
+		end := len(text)
+		for i := range text {
+			if n == maxChars {
+				end = i
+				break
+			}
+			n++
+		}
+		if end < len(text) {
+			if nl := strings.LastIndexByte(text[:end], '\n'); nl > 0 {
+				end = nl + 1
+			}
+		}
+		parts = append(parts, text[:end])
+		text = text[end:]
+	}
+	return parts
+}
+
+func reduce(ctx context.Context, complete CompleteFunc, prompt string, results []string, opts Options) (string, error) {
+	instruction := opts.ReducePrompt
+	if instruction == "" {
+		instruction = "The task below was run on the parts of the data separately. " +
+			"Combine the results of the parts into a single response to the task, " +
+			"as if the task was run on the whole data.\n\nTask: " + prompt
+	}
+	budget := opts.MaxInputTokens - EstimateTokens(instruction) - 2
+	if budget <= 0 {
+		return "", fmt.Errorf("the reduce prompt doesn't fit into the limit of %d tokens", opts.MaxInputTokens)
+	}
+	for {
+		groups := SplitChunks(numbered(results), budget)
+		if len(groups) == 1 {
+			return complete(ctx, instruction+"\n\n"+strings.Join(groups[0], "\n\n"))
+		}
+		if len(groups) >= len(results) {
+			return "", fmt.Errorf("the results of %d parts don't fit into the limit of %d tokens", len(results), opts.MaxInputTokens)
+		}
+		// the results are combined in groups until they fit into a single request
+		combined := make([]string, len(groups))
+		for i, group := range groups {
+			result, err := complete(ctx, instruction+"\n\n"+strings.Join(group, "\n\n"))
+			if err != nil {
+				return "", fmt.Errorf("combining results: %w", err)
+			}
+			combined[i] = result
+		}
+		results = combined
+	}
+}
+
+func numbered(results []string) []string {
+	out := make([]string, len(results))
+	for i, result := range results {
+		out[i] = fmt.Sprintf("Result of part %d:\n%s", i+1, result)
+	}
+	return out
+}
+
+func withData(prompt string, items []string) string {
+	if len(items) == 0 {
+		return prompt
+	}
+	return prompt + "\n\n[" + strings.Join(items, ",") + "]"
+}
+
+func mapHeader(prompt string, part, total int) string {
+	return fmt.Sprintf("%s\n\nThe data is split into %d parts, this is part %d:\n\n", prompt, total, part)
+}
+
+// MarshalItems serializes the data as JSON items: the elements of a list, or the value itself.
+func MarshalItems(data any) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	list, ok := data.([]any)
+	if !ok {
+		list = []any{data}
+	}
+	items := make([]string, len(list))
+	for i, item := range list {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = string(raw)
+	}
+	return items, nil
+}
+
+// DataItems serializes the value of `data` argument of a content block with MarshalItems.
+func DataItems(val cty.Value) ([]string, error) {
+	if val.IsNull() {
+		return nil, nil
+	}
+	data, err := plugindata.Encapsulated.FromCty(val)
+	if err != nil {
+		return nil, fmt.Errorf("data: %w", err)
+	}
+	if data == nil || *data == nil {
+		return nil, nil
+	}
+	return MarshalItems((*data).Any())
+}
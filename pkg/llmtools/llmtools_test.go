@@ -0,0 +1,171 @@
+package llmtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 3, EstimateTokens("hello, world"))
+	assert.Greater(t, EstimateTokens("привет"), EstimateTokens("privet"))
+}
+
+func TestSplitChunks(t *testing.T) {
+	items := []string{`{"id":1}`, `{"id":2}`, `{"id":3}`, `{"id":4}`}
+	// every item takes 2 tokens and a separator
+	assert.Equal(t, [][]string{
+		{`{"id":1}`, `{"id":2}`},
+		{`{"id":3}`, `{"id":4}`},
+	}, SplitChunks(items, 6))
+	assert.Equal(t, [][]string{items}, SplitChunks(items, 100))
+
+	long := strings.Repeat("line of text\n", 10)
+	chunks := SplitChunks([]string{long}, 10)
+	assert.Greater(t, len(chunks), 1)
+	var joined strings.Builder
+	for _, chunk := range chunks {
+		for _, part := range chunk {
+			assert.LessOrEqual(t, EstimateTokens(part)+1, 10)
+			assert.True(t, strings.HasSuffix(part, "\n"))
+			joined.WriteString(part)
+		}
+	}
+	assert.Equal(t, long, joined.String())
+}
+
+// recorder is a model echoing the number of the requests.
+type recorder struct {
+	prompts []string
+}
+
+func (r *recorder) complete(ctx context.Context, prompt string) (string, error) {
+	r.prompts = append(r.prompts, prompt)
+	return fmt.Sprintf("summary %d", len(r.prompts)), nil
+}
+
+func (r *recorder) completion(ctx context.Context, prompt string) (Completion, error) {
+	text, err := r.complete(ctx, prompt)
+	return Completion{Text: text}, err
+}
+
+func TestRunSingle(t *testing.T) {
+	var r recorder
+	res, err := Run(context.Background(), r.complete, "Summarize", []string{`1`, `2`}, Options{MaxInputTokens: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+	assert.Equal(t, []string{"Summarize\n\n[1,2]"}, r.prompts)
+}
+
+func TestRunMapReduce(t *testing.T) {
+	var r recorder
+	items := make([]string, 20)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"alert":"alert number %d"}`, i)
+	}
+	res, err := Run(context.Background(), r.complete, "Summarize", items, Options{
+		MaxInputTokens: 60,
+		ReducePrompt:   "Combine",
+	})
+	require.NoError(t, err)
+	require.Greater(t, len(r.prompts), 2)
+	last := r.prompts[len(r.prompts)-1]
+	assert.Equal(t, fmt.Sprintf("summary %d", len(r.prompts)), res)
+	assert.True(t, strings.HasPrefix(last, "Combine\n\nResult of part 1:\n"))
+	assert.Contains(t, r.prompts[0], "The data is split into")
+	for _, prompt := range r.prompts {
+		assert.LessOrEqual(t, EstimateTokens(prompt), 60)
+	}
+}
+
+func TestRunPromptTooLarge(t *testing.T) {
+	var r recorder
+	prompt := strings.Repeat("word ", 100)
+	_, err := Run(context.Background(), r.complete, prompt, nil, Options{MaxInputTokens: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prompt}, r.prompts)
+
+	_, err = Run(context.Background(), r.complete, prompt, []string{"1", "2"}, Options{MaxInputTokens: 10})
+	assert.EqualError(t, err, "the prompt doesn't fit into the limit of 10 tokens")
+}
+
+func TestCached(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	var r recorder
+	complete := Cached(cache, r.completion, "model")
+
+	res, err := complete(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+	res, err = complete(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+	assert.Len(t, r.prompts, 1)
+
+	res, err = Cached(cache, r.completion, "other model")(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 2", res)
+
+	failing := Cached(cache, func(ctx context.Context, prompt string) (Completion, error) {
+		return Completion{}, errors.New("failed")
+	}, "model")
+	res, err = failing(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+	_, err = failing(context.Background(), "new prompt")
+	assert.EqualError(t, err, "failed")
+}
+
+func TestCachedTruncated(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	var r recorder
+	complete := Cached(cache, func(ctx context.Context, prompt string) (Completion, error) {
+		res, err := r.completion(ctx, prompt)
+		res.Truncated = len(r.prompts) == 1
+		return res, err
+	}, "model")
+
+	res, err := complete(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+	res, err = complete(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 2", res)
+	res, err = complete(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 2", res)
+	assert.Len(t, r.prompts, 2)
+}
+
+func TestCachedNil(t *testing.T) {
+	var r recorder
+	res, err := Cached(nil, r.completion, "model")(context.Background(), "prompt")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", res)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("a", "b"), Key("a", "b"))
+	assert.NotEqual(t, Key("ab", ""), Key("a", "b"))
+}
+
+func TestMarshalItems(t *testing.T) {
+	items, err := MarshalItems([]any{map[string]any{"id": 1.0}, "two"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":1}`, `"two"`}, items)
+
+	items, err = MarshalItems(map[string]any{"id": 1.0})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":1}`}, items)
+
+	items, err = MarshalItems(nil)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
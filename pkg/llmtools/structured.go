@@ -0,0 +1,95 @@
+package llmtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// SchemaName is the name of the response schema sent to the APIs supporting structured output.
+const SchemaName = "response"
+
+// Message is a message of the conversation with the model.
+type Message struct {
+	Role    string
+	Content string
+}
+
+// ChatFunc sends the conversation to the model and returns the response.
+type ChatFunc func(ctx context.Context, messages []Message) (string, error)
+
+// ResponseSchema is the JSON schema the response of the model must match.
+type ResponseSchema struct {
+	// Raw is the JSON encoded schema, sent to the model.
+	Raw    json.RawMessage
+	schema *jsonschema.Schema
+}
+
+// CompileSchema encodes and compiles the JSON schema of the response.
+func CompileSchema(data plugindata.Data) (*ResponseSchema, error) {
+	raw, err := json.Marshal(data.Any())
+	if err != nil {
+		return nil, err
+	}
+	schema, err := jsonschema.CompileString(SchemaName+".json", string(raw))
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseSchema{
+		Raw:    raw,
+		schema: schema,
+	}, nil
+}
+
+// GenerateStructured sends the conversation to the model until the response matches the schema.
+// Invalid responses are sent back to the model with the validation errors, at most maxRetries times.
+func GenerateStructured(ctx context.Context, chat ChatFunc, messages []Message, schema *ResponseSchema, maxRetries int) (plugindata.Data, error) {
+	messages = append([]Message(nil), messages...)
+	var lastErr error
+	for range maxRetries + 1 {
+		text, err := chat(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ParseStructured(text, schema)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+		messages = append(messages,
+			Message{
+				Role:    "assistant",
+				Content: text,
+			},
+			Message{
+				Role:    "user",
+				Content: fmt.Sprintf("The response is invalid: %s\nRespond only with JSON matching the schema.", err),
+			},
+		)
+	}
+	return nil, fmt.Errorf("invalid response after %d attempts: %w", maxRetries+1, lastErr)
+}
+
+// ParseStructured parses the JSON response of the model and validates it against the schema.
+func ParseStructured(text string, schema *ResponseSchema) (plugindata.Data, error) {
+	text = strings.TrimSpace(text)
+	// models without structured output support tend to wrap JSON in a code block
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(text, "```")
+	}
+	var val any
+	if err := json.Unmarshal([]byte(text), &val); err != nil {
+		return nil, fmt.Errorf("response is not a valid JSON: %w", err)
+	}
+	if err := schema.schema.Validate(val); err != nil {
+		return nil, err
+	}
+	return plugindata.ParseAny(val)
+}
@@ -0,0 +1,38 @@
+package llmtools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func TestGenerateStructured(t *testing.T) {
+	schema, err := CompileSchema(plugindata.Map{
+		"type":     plugindata.String("object"),
+		"required": plugindata.List{plugindata.String("id")},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","required":["id"]}`, string(schema.Raw))
+
+	responses := []string{`{"name": "a"}`, "```json\n{\"id\": 1}\n```"}
+	var conversations [][]Message
+	data, err := GenerateStructured(context.Background(), func(ctx context.Context, messages []Message) (string, error) {
+		conversations = append(conversations, messages)
+		return responses[len(conversations)-1], nil
+	}, []Message{{Role: "user", Content: "Find the id"}}, schema, 1)
+	require.NoError(t, err)
+	assert.Equal(t, plugindata.Map{"id": plugindata.Number(1)}, data)
+	require.Len(t, conversations, 2)
+	assert.Len(t, conversations[0], 1)
+	assert.Equal(t, Message{Role: "assistant", Content: `{"name": "a"}`}, conversations[1][1])
+	assert.Contains(t, conversations[1][2].Content, "The response is invalid")
+
+	_, err = GenerateStructured(context.Background(), func(ctx context.Context, messages []Message) (string, error) {
+		return "not a json", nil
+	}, nil, schema, 0)
+	assert.ErrorContains(t, err, "invalid response after 1 attempts")
+}
@@ -0,0 +1,246 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression codecs.
+const (
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+	codecZstd         = 6
+)
+
+// Page types.
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// maxPageSize limits the memory allocated for a page declared in the file.
+const maxPageSize = 1 << 30
+
+var codecNames = map[int]string{
+	3: "LZO",
+	4: "BROTLI",
+	5: "LZ4",
+	7: "LZ4_RAW",
+}
+
+// columnData holds the decoded levels and values of a column chunk.
+type columnData struct {
+	col    *node
+	reps   []int32
+	defs   []int32
+	values []any
+	// count is the number of the levels, including the null values
+	count int
+	// pos and valuePos are the positions of the next level and the next value
+	pos      int
+	valuePos int
+}
+
+func (f *File) readColumnChunk(chunk columnChunk, col *node) (*columnData, error) {
+	start := chunk.dataPageOffset
+	if chunk.dictionaryOffset > 0 && chunk.dictionaryOffset < start {
+		start = chunk.dictionaryOffset
+	}
+	if start < 4 || chunk.compressedSize < 0 || chunk.compressedSize > maxPageSize || start+chunk.compressedSize > f.size {
+		return nil, errors.New("column chunk is out of the file bounds")
+	}
+	buf := make([]byte, chunk.compressedSize)
+	if _, err := f.r.ReadAt(buf, start); err != nil {
+		return nil, err
+	}
+	data := &columnData{col: col}
+	var dict []any
+	pos := 0
+	for int64(data.count) < chunk.numValues && pos < len(buf) {
+		header, n, err := decodeThriftStruct(buf[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid page header: %w", err)
+		}
+		pos += n
+		size := int(header.int(3))
+		if size < 0 || size > len(buf)-pos {
+			return nil, errors.New("page is out of the column chunk bounds")
+		}
+		page := buf[pos : pos+size]
+		pos += size
+		uncompressedSize := int(header.int(2))
+		switch header.int(1) {
+		case pageDictionary:
+			if page, err = decompress(chunk.codec, page, uncompressedSize); err != nil {
+				return nil, err
+			}
+			dph := header.strct(7)
+			if dict, err = decodePlain(page, col, int(dph.int(1))); err != nil {
+				return nil, fmt.Errorf("invalid dictionary page: %w", err)
+			}
+			for i, v := range dict {
+				dict[i] = col.convert(v)
+			}
+		case pageData:
+			if page, err = decompress(chunk.codec, page, uncompressedSize); err != nil {
+				return nil, err
+			}
+			if err := data.readDataPage(header.strct(5), page, dict); err != nil {
+				return nil, err
+			}
+		case pageDataV2:
+			if err := data.readDataPageV2(header.strct(8), page, chunk.codec, uncompressedSize, dict); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if int64(data.count) != chunk.numValues {
+		return nil, fmt.Errorf("column %q has %d values, expected %d", col.pathString(), data.count, chunk.numValues)
+	}
+	return data, nil
+}
+
+func (data *columnData) readDataPage(header thriftStruct, page []byte, dict []any) error {
+	if header == nil {
+		return errors.New("data page has no header")
+	}
+	count := int(header.int(1))
+	readLevels := func(maxLevel int, encoding int64) ([]int32, error) {
+		if maxLevel == 0 {
+			return nil, nil
+		}
+		if encoding != encodingRLE {
+			return nil, fmt.Errorf("unsupported level encoding %d", encoding)
+		}
+		if len(page) < 4 {
+			return nil, errTruncated
+		}
+		size := int(binary.LittleEndian.Uint32(page))
+		if size > len(page)-4 {
+			return nil, errTruncated
+		}
+		levels, err := decodeLevels(page[4:4+size], maxLevel, count)
+		page = page[4+size:]
+		return levels, err
+	}
+	reps, err := readLevels(data.col.maxRep, header.int(4))
+	if err != nil {
+		return fmt.Errorf("invalid repetition levels: %w", err)
+	}
+	defs, err := readLevels(data.col.maxDef, header.int(3))
+	if err != nil {
+		return fmt.Errorf("invalid definition levels: %w", err)
+	}
+	return data.addPage(count, reps, defs, page, int(header.int(2)), dict)
+}
+
+func (data *columnData) readDataPageV2(header thriftStruct, page []byte, codec, uncompressedSize int, dict []any) error {
+	if header == nil {
+		return errors.New("data page has no header")
+	}
+	count := int(header.int(1))
+	defSize := int(header.int(5))
+	repSize := int(header.int(6))
+	if defSize < 0 || repSize < 0 || defSize+repSize > len(page) {
+		return errTruncated
+	}
+	var reps, defs []int32
+	var err error
+	if data.col.maxRep > 0 {
+		if reps, err = decodeLevels(page[:repSize], data.col.maxRep, count); err != nil {
+			return fmt.Errorf("invalid repetition levels: %w", err)
+		}
+	}
+	if data.col.maxDef > 0 {
+		if defs, err = decodeLevels(page[repSize:repSize+defSize], data.col.maxDef, count); err != nil {
+			return fmt.Errorf("invalid definition levels: %w", err)
+		}
+	}
+	values := page[repSize+defSize:]
+	// the levels are never compressed
+	if header.bool(7, true) {
+		if values, err = decompress(codec, values, uncompressedSize-repSize-defSize); err != nil {
+			return err
+		}
+	}
+	return data.addPage(count, reps, defs, values, int(header.int(4)), dict)
+}
+
+func (data *columnData) addPage(count int, reps, defs []int32, page []byte, encoding int, dict []any) error {
+	if (reps != nil && len(reps) != count) || (defs != nil && len(defs) != count) {
+		return errors.New("the number of levels does not match the number of values")
+	}
+	defined := count
+	if defs != nil {
+		defined = 0
+		for _, d := range defs {
+			if int(d) == data.col.maxDef {
+				defined++
+			}
+		}
+	}
+	values, err := decodeValues(page, encoding, data.col, dict, defined)
+	if err != nil {
+		return fmt.Errorf("invalid values of column %q: %w", data.col.pathString(), err)
+	}
+	if len(values) != defined {
+		return fmt.Errorf("column %q has %d values, expected %d", data.col.pathString(), len(values), defined)
+	}
+	if encoding != encodingPlainDictionary && encoding != encodingRLEDictionary {
+		for i, v := range values {
+			values[i] = data.col.convert(v)
+		}
+	}
+	data.count += count
+	data.values = append(data.values, values...)
+	if data.col.maxRep > 0 {
+		data.reps = append(data.reps, reps...)
+	}
+	if data.col.maxDef > 0 {
+		data.defs = append(data.defs, defs...)
+	}
+	return nil
+}
+
+func decompress(codec int, page []byte, size int) ([]byte, error) {
+	if size < 0 || size > maxPageSize {
+		return nil, errors.New("invalid page size")
+	}
+	switch codec {
+	case codecUncompressed:
+		return page, nil
+	case codecSnappy:
+		return snappy.Decode(make([]byte, size), page)
+	case codecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		buf := bytes.NewBuffer(make([]byte, 0, size))
+		_, err = io.Copy(buf, io.LimitReader(r, maxPageSize))
+		return buf.Bytes(), err
+	case codecZstd:
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		return dec.DecodeAll(page, make([]byte, 0, size))
+	default:
+		name, ok := codecNames[codec]
+		if !ok {
+			name = fmt.Sprint(codec)
+		}
+		return nil, fmt.Errorf("unsupported compression codec %s", name)
+	}
+}
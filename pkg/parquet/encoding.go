@@ -0,0 +1,408 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Encodings of the values and the levels.
+const (
+	encodingPlain                = 0
+	encodingPlainDictionary      = 2
+	encodingRLE                  = 3
+	encodingDeltaBinaryPacked    = 5
+	encodingDeltaLengthByteArray = 6
+	encodingDeltaByteArray       = 7
+	encodingRLEDictionary        = 8
+	encodingByteStreamSplit      = 9
+)
+
+var errTruncated = errors.New("truncated page data")
+
+// decodeLevels decodes count levels encoded with RLE/bit-packing hybrid encoding.
+func decodeLevels(buf []byte, maxLevel, count int) ([]int32, error) {
+	levels := make([]int32, 0, count)
+	return levels, decodeHybrid(buf, bits.Len(uint(maxLevel)), count, func(v uint64) error {
+		if v > uint64(maxLevel) {
+			return fmt.Errorf("level %d is greater than the maximum %d", v, maxLevel)
+		}
+		levels = append(levels, int32(v))
+		return nil
+	})
+}
+
+// decodeHybrid decodes count values of RLE/bit-packing hybrid encoding.
+func decodeHybrid(buf []byte, bitWidth, count int, yield func(uint64) error) error {
+	if bitWidth > 64 {
+		return fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+	byteWidth := (bitWidth + 7) / 8
+	pos := 0
+	for count > 0 {
+		header, n := binary.Uvarint(buf[pos:])
+		if n <= 0 {
+			return errTruncated
+		}
+		pos += n
+		if header&1 == 0 {
+			// RLE run: the value is repeated
+			run := int(min(header>>1, uint64(count)))
+			if len(buf)-pos < byteWidth {
+				return errTruncated
+			}
+			var v uint64
+			for i := range byteWidth {
+				v |= uint64(buf[pos+i]) << (8 * i)
+			}
+			pos += byteWidth
+			for range run {
+				if err := yield(v); err != nil {
+					return err
+				}
+			}
+			count -= run
+			continue
+		}
+		// bit-packed groups of 8 values
+		groups := header >> 1
+		if groups > uint64(len(buf)) {
+			return errTruncated
+		}
+		size := int(groups) * bitWidth
+		if len(buf)-pos < size {
+			return errTruncated
+		}
+		values := int(groups) * 8
+		if err := unpackBits(buf[pos:pos+size], bitWidth, min(values, count), yield); err != nil {
+			return err
+		}
+		pos += size
+		count -= min(values, count)
+	}
+	return nil
+}
+
+// unpackBits yields count values packed from the least significant bit.
+func unpackBits(buf []byte, bitWidth, count int, yield func(uint64) error) error {
+	if bitWidth == 0 {
+		for range count {
+			if err := yield(0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	bit := 0
+	for range count {
+		var v uint64
+		for i := 0; i < bitWidth; {
+			idx := bit / 8
+			if idx >= len(buf) {
+				return errTruncated
+			}
+			offset := bit % 8
+			n := min(8-offset, bitWidth-i)
+			v |= uint64((buf[idx]>>offset)&(1<<n-1)) << i
+			i += n
+			bit += n
+		}
+		if err := yield(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodePlain decodes count values of the physical type.
+func decodePlain(buf []byte, col *node, count int) ([]any, error) {
+	values := make([]any, 0, count)
+	pos := 0
+	fixed := func(size int) ([]byte, error) {
+		if size < 0 || len(buf)-pos < size {
+			return nil, errTruncated
+		}
+		b := buf[pos : pos+size]
+		pos += size
+		return b, nil
+	}
+	for i := range count {
+		switch col.typ {
+		case typeBoolean:
+			if i/8 >= len(buf) {
+				return nil, errTruncated
+			}
+			values = append(values, buf[i/8]>>(i%8)&1 == 1)
+			continue
+		case typeInt32:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, int32(binary.LittleEndian.Uint32(b)))
+		case typeInt64:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, int64(binary.LittleEndian.Uint64(b)))
+		case typeInt96:
+			b, err := fixed(12)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, int96(b))
+		case typeFloat:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case typeDouble:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		case typeByteArray:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			if b, err = fixed(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+			values = append(values, b)
+		case typeFixedLenByteArray:
+			b, err := fixed(col.typeLength)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, b)
+		default:
+			return nil, fmt.Errorf("unknown physical type %d", col.typ)
+		}
+	}
+	return values, nil
+}
+
+// decodeDeltaBinaryPacked decodes the integers and returns the number of bytes read.
+func decodeDeltaBinaryPacked(buf []byte) ([]int64, int, error) {
+	pos := 0
+	uvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(buf[pos:])
+		if n <= 0 {
+			return 0, errTruncated
+		}
+		pos += n
+		return v, nil
+	}
+	varint := func() (int64, error) {
+		v, err := uvarint()
+		return int64(v>>1) ^ -int64(v&1), err
+	}
+	blockSize, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	miniblocks, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	first, err := varint()
+	if err != nil {
+		return nil, 0, err
+	}
+	if miniblocks == 0 || blockSize%128 != 0 || (blockSize/miniblocks)%32 != 0 {
+		return nil, 0, errors.New("invalid delta encoding header")
+	}
+	// every value takes at least a bit
+	if total > uint64(len(buf))*8+1 {
+		return nil, 0, errTruncated
+	}
+	values := make([]int64, 0, total)
+	values = append(values, first)
+	prev := first
+	perMiniblock := int(blockSize / miniblocks)
+	for uint64(len(values)) < total {
+		minDelta, err := varint()
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(buf)-pos < int(miniblocks) {
+			return nil, 0, errTruncated
+		}
+		widths := buf[pos : pos+int(miniblocks)]
+		pos += int(miniblocks)
+		for _, width := range widths {
+			if uint64(len(values)) >= total {
+				// the remaining miniblocks of the last block are not stored
+				break
+			}
+			size := perMiniblock * int(width) / 8
+			if width > 64 || len(buf)-pos < size {
+				return nil, 0, errTruncated
+			}
+			err := unpackBits(buf[pos:pos+size], int(width), perMiniblock, func(delta uint64) error {
+				if uint64(len(values)) < total {
+					prev += minDelta + int64(delta)
+					values = append(values, prev)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			pos += size
+		}
+	}
+	return values, pos, nil
+}
+
+// decodeDeltaLengthByteArray decodes the byte arrays and returns the number of bytes read.
+func decodeDeltaLengthByteArray(buf []byte) ([][]byte, int, error) {
+	lengths, pos, err := decodeDeltaBinaryPacked(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	values := make([][]byte, len(lengths))
+	for i, n := range lengths {
+		if n < 0 || int64(len(buf)-pos) < n {
+			return nil, 0, errTruncated
+		}
+		values[i] = buf[pos : pos+int(n)]
+		pos += int(n)
+	}
+	return values, pos, nil
+}
+
+func decodeDeltaByteArray(buf []byte) ([][]byte, error) {
+	prefixes, pos, err := decodeDeltaBinaryPacked(buf)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, _, err := decodeDeltaLengthByteArray(buf[pos:])
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) != len(suffixes) {
+		return nil, errors.New("invalid delta byte array encoding")
+	}
+	values := make([][]byte, len(suffixes))
+	var prev []byte
+	for i, suffix := range suffixes {
+		if prefixes[i] < 0 || prefixes[i] > int64(len(prev)) {
+			return nil, errors.New("invalid delta byte array prefix")
+		}
+		value := make([]byte, 0, int(prefixes[i])+len(suffix))
+		value = append(append(value, prev[:prefixes[i]]...), suffix...)
+		values[i] = value
+		prev = value
+	}
+	return values, nil
+}
+
+// decodeByteStreamSplit joins the bytes of the values stored in separate streams.
+func decodeByteStreamSplit(buf []byte, col *node, count int) ([]any, error) {
+	var width int
+	switch col.typ {
+	case typeInt32, typeFloat:
+		width = 4
+	case typeInt64, typeDouble:
+		width = 8
+	case typeFixedLenByteArray:
+		width = col.typeLength
+	default:
+		return nil, fmt.Errorf("byte stream split encoding is not supported for type %d", col.typ)
+	}
+	if len(buf) < width*count {
+		return nil, errTruncated
+	}
+	joined := make([]byte, width*count)
+	for i := range count {
+		for j := range width {
+			joined[i*width+j] = buf[j*count+i]
+		}
+	}
+	return decodePlain(joined, col, count)
+}
+
+// decodeValues decodes count values of the page.
+func decodeValues(buf []byte, encoding int, col *node, dict []any, count int) ([]any, error) {
+	switch encoding {
+	case encodingPlain:
+		return decodePlain(buf, col, count)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if dict == nil {
+			return nil, errors.New("the page refers to a missing dictionary")
+		}
+		if count == 0 {
+			return nil, nil
+		}
+		if len(buf) == 0 {
+			return nil, errTruncated
+		}
+		values := make([]any, 0, count)
+		err := decodeHybrid(buf[1:], int(buf[0]), count, func(idx uint64) error {
+			if idx >= uint64(len(dict)) {
+				return fmt.Errorf("dictionary index %d is out of range", idx)
+			}
+			values = append(values, dict[idx])
+			return nil
+		})
+		return values, err
+	case encodingRLE:
+		if col.typ != typeBoolean {
+			return nil, fmt.Errorf("RLE encoding is not supported for type %d", col.typ)
+		}
+		if len(buf) < 4 {
+			return nil, errTruncated
+		}
+		values := make([]any, 0, count)
+		err := decodeHybrid(buf[4:], 1, count, func(v uint64) error {
+			values = append(values, v == 1)
+			return nil
+		})
+		return values, err
+	case encodingDeltaBinaryPacked:
+		ints, _, err := decodeDeltaBinaryPacked(buf)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, len(ints))
+		for i, v := range ints {
+			if col.typ == typeInt32 {
+				values[i] = int32(v)
+			} else {
+				values[i] = v
+			}
+		}
+		return values, nil
+	case encodingDeltaLengthByteArray, encodingDeltaByteArray:
+		var arrays [][]byte
+		var err error
+		if encoding == encodingDeltaByteArray {
+			arrays, err = decodeDeltaByteArray(buf)
+		} else {
+			arrays, _, err = decodeDeltaLengthByteArray(buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, len(arrays))
+		for i, v := range arrays {
+			values[i] = v
+		}
+		return values, nil
+	case encodingByteStreamSplit:
+		return decodeByteStreamSplit(buf, col, count)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %d", encoding)
+	}
+}
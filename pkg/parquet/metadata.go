@@ -0,0 +1,255 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Physical types.
+const (
+	typeBoolean           = 0
+	typeInt32             = 1
+	typeInt64             = 2
+	typeInt96             = 3
+	typeFloat             = 4
+	typeDouble            = 5
+	typeByteArray         = 6
+	typeFixedLenByteArray = 7
+)
+
+// Field repetition types.
+const (
+	repetitionRequired = 0
+	repetitionOptional = 1
+	repetitionRepeated = 2
+)
+
+// Converted types, the legacy annotations of the types.
+const (
+	convertedUTF8            = 0
+	convertedMap             = 1
+	convertedMapKeyValue     = 2
+	convertedList            = 3
+	convertedEnum            = 4
+	convertedDecimal         = 5
+	convertedDate            = 6
+	convertedTimeMillis      = 7
+	convertedTimeMicros      = 8
+	convertedTimestampMillis = 9
+	convertedTimestampMicros = 10
+	convertedUint8           = 11
+	convertedUint64          = 14
+	convertedJSON            = 19
+)
+
+// Fields of LogicalType union.
+const (
+	logicalString    = 1
+	logicalMap       = 2
+	logicalList      = 3
+	logicalEnum      = 4
+	logicalDecimal   = 5
+	logicalDate      = 6
+	logicalTime      = 7
+	logicalTimestamp = 8
+	logicalInteger   = 10
+	logicalJSON      = 12
+	logicalUUID      = 14
+)
+
+// Time units, the fields of TimeUnit union.
+const (
+	unitMillis = 1
+	unitMicros = 2
+	unitNanos  = 3
+)
+
+type columnChunk struct {
+	typ              int
+	path             []string
+	codec            int
+	numValues        int64
+	compressedSize   int64
+	dataPageOffset   int64
+	dictionaryOffset int64
+}
+
+type rowGroup struct {
+	numRows int64
+	columns []columnChunk
+}
+
+type fileMetaData struct {
+	numRows   int64
+	createdBy string
+	schema    *node
+	leaves    []*node
+	rowGroups []rowGroup
+}
+
+// node is an element of the schema tree.
+type node struct {
+	name       string
+	typ        int
+	typeLength int
+	repetition int
+	converted  int
+	logical    thriftStruct
+	scale      int
+	precision  int
+	children   []*node
+	// maxDef and maxRep are the definition and repetition levels of the node
+	maxDef int
+	maxRep int
+	// path is the path from the root, excluding the root
+	path []*node
+	// leaf is the index of the column of the leaf nodes
+	leaf int
+}
+
+func (n *node) isLeaf() bool {
+	return n.children == nil
+}
+
+func (n *node) pathString() string {
+	names := make([]string, len(n.path))
+	for i, p := range n.path {
+		names[i] = p.name
+	}
+	return strings.Join(names, ".")
+}
+
+func parseFileMetaData(s thriftStruct) (*fileMetaData, error) {
+	meta := &fileMetaData{
+		numRows:   s.int(3),
+		createdBy: s.string(6),
+	}
+	elements := s.list(2)
+	if len(elements) == 0 {
+		return nil, errors.New("the file has no schema")
+	}
+	pos := 0
+	root, err := parseSchema(elements, &pos, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(elements) {
+		return nil, errors.New("invalid schema: unexpected elements after the root")
+	}
+	meta.schema = root
+	meta.leaves = collectLeaves(root, nil)
+
+	for _, rg := range s.list(4) {
+		rg, ok := rg.(thriftStruct)
+		if !ok {
+			return nil, errors.New("invalid row group metadata")
+		}
+		group := rowGroup{numRows: rg.int(3)}
+		for _, cc := range rg.list(1) {
+			cc, ok := cc.(thriftStruct)
+			if !ok {
+				return nil, errors.New("invalid column chunk metadata")
+			}
+			if cc.string(1) != "" {
+				return nil, errors.New("the columns stored in external files are not supported")
+			}
+			md := cc.strct(3)
+			if md == nil {
+				return nil, errors.New("column chunk has no metadata")
+			}
+			chunk := columnChunk{
+				typ:              int(md.int(1)),
+				codec:            int(md.int(4)),
+				numValues:        md.int(5),
+				compressedSize:   md.int(7),
+				dataPageOffset:   md.int(9),
+				dictionaryOffset: md.int(11),
+			}
+			for _, name := range md.list(3) {
+				name, _ := name.([]byte)
+				chunk.path = append(chunk.path, string(name))
+			}
+			group.columns = append(group.columns, chunk)
+		}
+		if len(group.columns) != len(meta.leaves) {
+			return nil, fmt.Errorf("row group has %d columns, the schema has %d", len(group.columns), len(meta.leaves))
+		}
+		meta.rowGroups = append(meta.rowGroups, group)
+	}
+	return meta, nil
+}
+
+// parseSchema builds the schema tree from the depth-first list of the schema elements.
+func parseSchema(elements []any, pos *int, parent *node) (*node, error) {
+	if *pos >= len(elements) {
+		return nil, errors.New("invalid schema: missing elements")
+	}
+	el, ok := elements[*pos].(thriftStruct)
+	if !ok {
+		return nil, errors.New("invalid schema element")
+	}
+	*pos++
+	n := &node{
+		name:       el.string(4),
+		typ:        int(el.int(1)),
+		typeLength: int(el.int(2)),
+		repetition: int(el.int(3)),
+		converted:  -1,
+		logical:    el.strct(10),
+		scale:      int(el.int(7)),
+		precision:  int(el.int(8)),
+	}
+	if el.has(6) {
+		n.converted = int(el.int(6))
+	}
+	if parent != nil {
+		n.maxDef, n.maxRep = parent.maxDef, parent.maxRep
+		n.path = append(append([]*node{}, parent.path...), n)
+		switch n.repetition {
+		case repetitionOptional:
+			n.maxDef++
+		case repetitionRepeated:
+			n.maxDef++
+			n.maxRep++
+		}
+	}
+	if !el.has(5) {
+		if parent == nil {
+			return nil, errors.New("invalid schema: the root is not a group")
+		}
+		return n, nil
+	}
+	numChildren := int(el.int(5))
+	if numChildren < 0 || numChildren > len(elements)-*pos {
+		return nil, errors.New("invalid schema: wrong number of children")
+	}
+	n.children = make([]*node, 0, numChildren)
+	for range numChildren {
+		child, err := parseSchema(elements, pos, n)
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, child)
+	}
+	return n, nil
+}
+
+func collectLeaves(n *node, leaves []*node) []*node {
+	if n.isLeaf() {
+		n.leaf = len(leaves)
+		return append(leaves, n)
+	}
+	for _, child := range n.children {
+		leaves = collectLeaves(child, leaves)
+	}
+	return leaves
+}
+
+func (n *node) isList() bool {
+	return n.converted == convertedList || n.logical.has(logicalList)
+}
+
+func (n *node) isMap() bool {
+	return n.converted == convertedMap || n.converted == convertedMapKeyValue || n.logical.has(logicalMap)
+}
@@ -0,0 +1,341 @@
+// Package parquet reads the rows of Apache Parquet files.
+//
+// The reader supports the flat and the nested schemas, including LIST and MAP annotated groups,
+// PLAIN, dictionary, RLE, delta and byte stream split encodings, uncompressed, Snappy, gzip and
+// zstd compressed pages. The rows are read one row group at a time, so the memory used is
+// limited by the size of the row groups, not the size of the file.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var magic = []byte("PAR1")
+
+// maxFooterSize limits the size of the file metadata.
+const maxFooterSize = 64 << 20
+
+// File is an opened Parquet file.
+type File struct {
+	r    io.ReaderAt
+	size int64
+	meta *fileMetaData
+}
+
+// Open reads the metadata of the Parquet file.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, errors.New("file is too small to be a Parquet file")
+	}
+	tail := make([]byte, 8)
+	if _, err := r.ReadAt(tail, size-8); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tail[4:], magic) {
+		return nil, errors.New("not a Parquet file")
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(tail))
+	if footerSize > maxFooterSize || footerSize > size-8-int64(len(magic)) {
+		return nil, errors.New("invalid Parquet file metadata size")
+	}
+	footer := make([]byte, footerSize)
+	if _, err := r.ReadAt(footer, size-8-footerSize); err != nil {
+		return nil, err
+	}
+	s, _, err := decodeThriftStruct(footer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Parquet file metadata: %w", err)
+	}
+	meta, err := parseFileMetaData(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Parquet file metadata: %w", err)
+	}
+	return &File{r: r, size: size, meta: meta}, nil
+}
+
+// NumRows returns the number of rows in the file.
+func (f *File) NumRows() int64 {
+	return f.meta.numRows
+}
+
+// Columns returns the names of the top-level columns.
+func (f *File) Columns() []string {
+	names := make([]string, len(f.meta.schema.children))
+	for i, child := range f.meta.schema.children {
+		names[i] = child.name
+	}
+	return names
+}
+
+// Rows is an iterator over the rows of the file.
+type Rows struct {
+	f       *File
+	fields  []*node
+	leaves  []*node
+	group   int
+	left    int64
+	columns []*columnData
+	row     map[string]any
+	err     error
+}
+
+// Rows returns an iterator over the rows with the columns given, or all columns if none are given.
+// Only the selected columns are read from the file.
+func (f *File) Rows(columns ...string) (*Rows, error) {
+	rows := &Rows{f: f}
+	if len(columns) == 0 {
+		rows.fields = f.meta.schema.children
+	}
+	for _, name := range columns {
+		found := false
+		for _, child := range f.meta.schema.children {
+			if child.name == name {
+				rows.fields = append(rows.fields, child)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column %q not found", name)
+		}
+	}
+	for _, field := range rows.fields {
+		rows.leaves = collectFieldLeaves(field, rows.leaves)
+	}
+	return rows, nil
+}
+
+func collectFieldLeaves(n *node, leaves []*node) []*node {
+	if n.isLeaf() {
+		return append(leaves, n)
+	}
+	for _, child := range n.children {
+		leaves = collectFieldLeaves(child, leaves)
+	}
+	return leaves
+}
+
+// Next advances to the next row, it returns false at the end of the file or on error.
+func (r *Rows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	for r.left == 0 {
+		if r.group >= len(r.f.meta.rowGroups) {
+			return false
+		}
+		if r.err = r.readRowGroup(r.f.meta.rowGroups[r.group]); r.err != nil {
+			return false
+		}
+		r.group++
+	}
+	r.left--
+	row := make(map[string]any, len(r.fields))
+	for _, data := range r.columns {
+		if r.err = data.assemble(row); r.err != nil {
+			return false
+		}
+	}
+	for _, field := range r.fields {
+		row[field.name] = finalizeField(row[field.name], field)
+	}
+	r.row = row
+	return true
+}
+
+// Row returns the current row: a map of the column names to bool, int64, uint64, float64, string,
+// time.Time, nil values, lists ([]any) and maps (map[string]any) of them.
+func (r *Rows) Row() map[string]any {
+	return r.row
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+func (r *Rows) readRowGroup(group rowGroup) error {
+	r.columns = r.columns[:0]
+	for _, leaf := range r.leaves {
+		data, err := r.f.readColumnChunk(group.columns[leaf.leaf], leaf)
+		if err != nil {
+			return err
+		}
+		r.columns = append(r.columns, data)
+	}
+	r.left = group.numRows
+	return nil
+}
+
+// assemble sets the values of the column in the current row, creating the groups and the lists
+// on the path to the leaf according to the definition and the repetition levels.
+func (data *columnData) assemble(row map[string]any) error {
+	col := data.col
+	// indexes of the elements of the repeated nodes on the path, by repetition level
+	indexes := make([]int, col.maxRep+1)
+	for first := true; data.pos < data.count; first = false {
+		rep, def := 0, col.maxDef
+		if data.reps != nil {
+			rep = int(data.reps[data.pos])
+		}
+		if data.defs != nil {
+			def = int(data.defs[data.pos])
+		}
+		if !first && rep == 0 {
+			// the next row starts
+			break
+		}
+		if first && rep != 0 {
+			return fmt.Errorf("column %q: invalid repetition level at the start of a row", col.pathString())
+		}
+		if !first {
+			indexes[rep]++
+			for i := rep + 1; i < len(indexes); i++ {
+				indexes[i] = 0
+			}
+		}
+		var value any
+		if def == col.maxDef {
+			if data.valuePos >= len(data.values) {
+				return fmt.Errorf("column %q: not enough values", col.pathString())
+			}
+			value = data.values[data.valuePos]
+			data.valuePos++
+		}
+		if err := setValue(row, col, def, indexes, value); err != nil {
+			return err
+		}
+		data.pos++
+	}
+	return nil
+}
+
+func setValue(container map[string]any, col *node, def int, indexes []int, value any) error {
+	for _, n := range col.path {
+		if def < n.maxDef {
+			// the node is not defined: it's null or an empty list
+			if _, ok := container[n.name]; !ok {
+				if n.repetition == repetitionRepeated {
+					container[n.name] = []any{}
+				} else {
+					container[n.name] = nil
+				}
+			}
+			return nil
+		}
+		if n.repetition == repetitionRepeated {
+			list, _ := container[n.name].([]any)
+			idx := indexes[n.maxRep]
+			if idx > len(list) {
+				return fmt.Errorf("column %q: invalid repetition levels", col.pathString())
+			}
+			if idx == len(list) {
+				var elem any
+				if !n.isLeaf() {
+					elem = map[string]any{}
+				}
+				list = append(list, elem)
+				container[n.name] = list
+			}
+			if n.isLeaf() {
+				list[idx] = value
+				return nil
+			}
+			m, ok := list[idx].(map[string]any)
+			if !ok {
+				return fmt.Errorf("column %q: invalid repetition levels", col.pathString())
+			}
+			container = m
+			continue
+		}
+		if n.isLeaf() {
+			container[n.name] = value
+			return nil
+		}
+		m, ok := container[n.name].(map[string]any)
+		if !ok {
+			m = map[string]any{}
+			container[n.name] = m
+		}
+		container = m
+	}
+	return nil
+}
+
+// finalizeField converts the assembled value of the field according to the LIST and MAP annotations.
+func finalizeField(v any, n *node) any {
+	if n.repetition != repetitionRepeated {
+		return finalizeElem(v, n)
+	}
+	list, _ := v.([]any)
+	for i, elem := range list {
+		list[i] = finalizeElem(elem, n)
+	}
+	return list
+}
+
+func finalizeElem(v any, n *node) any {
+	m, ok := v.(map[string]any)
+	if n.isLeaf() || !ok {
+		return v
+	}
+	if n.isList() && len(n.children) == 1 && n.children[0].repetition == repetitionRepeated {
+		return finalizeList(m, n)
+	}
+	if n.isMap() && len(n.children) == 1 && n.children[0].repetition == repetitionRepeated &&
+		len(n.children[0].children) > 0 {
+		return finalizeMap(m, n.children[0])
+	}
+	for _, child := range n.children {
+		if value, ok := m[child.name]; ok {
+			m[child.name] = finalizeField(value, child)
+		}
+	}
+	return m
+}
+
+func finalizeList(m map[string]any, n *node) []any {
+	rep := n.children[0]
+	items, _ := m[rep.name].([]any)
+	result := make([]any, len(items))
+	// the elements are wrapped in the groups with a single field, except the legacy 2-level lists
+	wrapped := !rep.isLeaf() && len(rep.children) == 1 && rep.name != "array" && rep.name != n.name+"_tuple"
+	for i, item := range items {
+		if !wrapped {
+			result[i] = finalizeElem(item, rep)
+			continue
+		}
+		elem, _ := item.(map[string]any)
+		result[i] = finalizeField(elem[rep.children[0].name], rep.children[0])
+	}
+	return result
+}
+
+func finalizeMap(m map[string]any, kv *node) map[string]any {
+	items, _ := m[kv.name].([]any)
+	result := make(map[string]any, len(items))
+	keyNode := kv.children[0]
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		var key string
+		switch k := finalizeField(entry[keyNode.name], keyNode).(type) {
+		case string:
+			key = k
+		case time.Time:
+			key = k.Format(time.RFC3339Nano)
+		default:
+			key = fmt.Sprint(k)
+		}
+		var value any
+		if len(kv.children) > 1 {
+			value = finalizeField(entry[kv.children[1].name], kv.children[1])
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package parquet
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The test files are also checked with the Apache Arrow reader. They are shared with the tests
+// of the parquet data source of the builtin plugin. flat.parquet has
+// two row groups: the first one with v1 pages, a dictionary and Snappy compression, the second
+// one with v2 pages, delta and RLE encodings and gzip compression.
+
+func readAll(t *testing.T, path string, columns ...string) []map[string]any {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	info, err := f.Stat()
+	require.NoError(t, err)
+	file, err := Open(f, info.Size())
+	require.NoError(t, err)
+	rows, err := file.Rows(columns...)
+	require.NoError(t, err)
+	var result []map[string]any
+	for rows.Next() {
+		result = append(result, rows.Row())
+	}
+	require.NoError(t, rows.Err())
+	return result
+}
+
+func date(day int) time.Time {
+	return time.Date(2024, time.January, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReadFlat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []map[string]any{
+		{
+			"id": int64(1), "name": "alice", "score": 9.5, "active": true,
+			"created": date(1), "day": date(1), "price": 19.99,
+		},
+		{
+			"id": int64(2), "name": "bob", "score": nil, "active": false,
+			"created": date(1).Add(time.Hour), "day": date(2), "price": -2.5,
+		},
+		{
+			"id": int64(3), "name": nil, "score": 7.25, "active": true,
+			"created": date(1).Add(2 * time.Hour), "day": date(3), "price": nil,
+		},
+		{
+			"id": int64(4), "name": "alice", "score": 8.0, "active": true,
+			"created": nil, "day": date(4), "price": 1.0,
+		},
+		{
+			"id": int64(5), "name": "carol", "score": nil, "active": false,
+			"created": date(2), "day": nil, "price": 0.0,
+		},
+	}, readAll(t, "testdata/flat.parquet"))
+}
+
+func TestReadColumns(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []map[string]any{
+		{"name": "alice", "id": int64(1)},
+		{"name": "bob", "id": int64(2)},
+		{"name": nil, "id": int64(3)},
+		{"name": "alice", "id": int64(4)},
+		{"name": "carol", "id": int64(5)},
+	}, readAll(t, "testdata/flat.parquet", "name", "id"))
+}
+
+func TestReadNested(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []map[string]any{
+		{
+			"id":      int64(1),
+			"tags":    []any{"a", "b", nil},
+			"address": map[string]any{"city": "berlin", "zip": "10115"},
+			"attrs":   map[string]any{"x": int64(1), "y": nil},
+		},
+		{
+			"id":      int64(2),
+			"tags":    []any{},
+			"address": nil,
+			"attrs":   nil,
+		},
+		{
+			"id":      int64(3),
+			"tags":    nil,
+			"address": map[string]any{"city": "paris", "zip": nil},
+			"attrs":   map[string]any{},
+		},
+	}, readAll(t, "testdata/nested.parquet"))
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("testdata/flat.parquet")
+	require.NoError(t, err)
+
+	file, err := Open(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), file.NumRows())
+	assert.Equal(t, []string{"id", "name", "score", "active", "created", "day", "price"}, file.Columns())
+
+	_, err = file.Rows("unknown")
+	assert.EqualError(t, err, `column "unknown" not found`)
+
+	_, err = Open(bytes.NewReader([]byte("id,name\n1,alice\n")), 16)
+	assert.EqualError(t, err, "not a Parquet file")
+
+	// the footer points outside of the file
+	truncated := content[len(content)-100:]
+	_, err = Open(bytes.NewReader(truncated), int64(len(truncated)))
+	assert.EqualError(t, err, "invalid Parquet file metadata size")
+}
+
+func TestDecodeHybrid(t *testing.T) {
+	t.Parallel()
+
+	// RLE run of 3 fives, then a bit-packed group of 8 values with bit width 3
+	buf := []byte{3 << 1, 5, 1<<1 | 1, 0x88, 0xc6, 0xfa}
+	var values []uint64
+	err := decodeHybrid(buf, 3, 11, func(v uint64) error {
+		values = append(values, v)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5, 5, 5, 0, 1, 2, 3, 4, 5, 6, 7}, values)
+
+	err = decodeHybrid(buf[:4], 3, 11, func(uint64) error { return nil })
+	assert.ErrorIs(t, err, errTruncated)
+}
+
+func TestDecodeDeltaBinaryPacked(t *testing.T) {
+	t.Parallel()
+
+	// block size 128, 4 miniblocks, 5 values, first value 7, min delta -2, bit width 2
+	buf := []byte{0x80, 0x01, 4, 5, 14, 3, 2, 0, 0, 0, 0xe4, 0, 0, 0, 0, 0, 0, 0}
+	values, n, err := decodeDeltaBinaryPacked(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 5, 4, 4, 5}, values)
+	assert.Equal(t, len(buf), n)
+}
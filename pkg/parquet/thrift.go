@@ -0,0 +1,256 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The metadata of Parquet files is serialized with Thrift compact protocol. The structs are
+// decoded into generic maps of field ids to values, the typed metadata is read from them.
+
+const (
+	thriftStop       = 0
+	thriftTrue       = 1
+	thriftFalse      = 2
+	thriftByte       = 3
+	thriftI16        = 4
+	thriftI32        = 5
+	thriftI64        = 6
+	thriftDouble     = 7
+	thriftBinary     = 8
+	thriftList       = 9
+	thriftSet        = 10
+	thriftMap        = 11
+	thriftStructType = 12
+	maxThriftDepth   = 64
+	maxThriftLength  = 1 << 28
+)
+
+var errThriftTruncated = errors.New("truncated thrift data")
+
+// thriftStruct holds the fields of a struct: bool, int64, float64, []byte, []any or thriftStruct values.
+type thriftStruct map[int16]any
+
+type thriftDecoder struct {
+	buf   []byte
+	pos   int
+	depth int
+}
+
+// decodeThriftStruct decodes a struct from the start of the buffer and returns the number of bytes read.
+func decodeThriftStruct(buf []byte) (thriftStruct, int, error) {
+	d := &thriftDecoder{buf: buf}
+	s, err := d.readStruct()
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, d.pos, nil
+}
+
+func (d *thriftDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, errThriftTruncated
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *thriftDecoder) readVarint() (int64, error) {
+	v, err := d.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	// zigzag encoding
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (d *thriftDecoder) readLength() (int, error) {
+	n, err := d.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > maxThriftLength || int(n) > len(d.buf)-d.pos {
+		return 0, errThriftTruncated
+	}
+	return int(n), nil
+}
+
+func (d *thriftDecoder) readStruct() (thriftStruct, error) {
+	if d.depth++; d.depth > maxThriftDepth {
+		return nil, errors.New("thrift data is nested too deep")
+	}
+	defer func() { d.depth-- }()
+	s := thriftStruct{}
+	var id int16
+	for {
+		header, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		typ := header & 0x0f
+		if typ == thriftStop {
+			return s, nil
+		}
+		if delta := header >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			v, err := d.readVarint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		var val any
+		switch typ {
+		case thriftTrue:
+			val = true
+		case thriftFalse:
+			val = false
+		default:
+			val, err = d.readValue(typ)
+			if err != nil {
+				return nil, err
+			}
+		}
+		s[id] = val
+	}
+}
+
+func (d *thriftDecoder) readValue(typ byte) (any, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// the booleans in the lists are bytes
+		b, err := d.readByte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := d.readByte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return d.readVarint()
+	case thriftDouble:
+		if len(d.buf)-d.pos < 8 {
+			return nil, errThriftTruncated
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[d.pos:]))
+		d.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := d.readLength()
+		if err != nil {
+			return nil, err
+		}
+		v := d.buf[d.pos : d.pos+n]
+		d.pos += n
+		return v, nil
+	case thriftList, thriftSet:
+		return d.readList()
+	case thriftMap:
+		return d.readMap()
+	case thriftStructType:
+		return d.readStruct()
+	default:
+		return nil, fmt.Errorf("unknown thrift type %d", typ)
+	}
+}
+
+func (d *thriftDecoder) readList() ([]any, error) {
+	header, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	size := int(header >> 4)
+	if size == 15 {
+		if size, err = d.readLength(); err != nil {
+			return nil, err
+		}
+	}
+	// every element takes at least a byte
+	if size > len(d.buf)-d.pos {
+		return nil, errThriftTruncated
+	}
+	list := make([]any, size)
+	for i := range list {
+		if list[i], err = d.readValue(header & 0x0f); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// readMap returns the keys and the values of the map as a flat list, Parquet metadata has no maps.
+func (d *thriftDecoder) readMap() ([]any, error) {
+	size, err := d.readLength()
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	types, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]any, 0, 2*size)
+	for range size {
+		key, err := d.readValue(types >> 4)
+		if err != nil {
+			return nil, err
+		}
+		val, err := d.readValue(types & 0x0f)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, key, val)
+	}
+	return list, nil
+}
+
+func (s thriftStruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s thriftStruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStruct) bool(id int16, def bool) bool {
+	v, ok := s[id].(bool)
+	if !ok {
+		return def
+	}
+	return v
+}
+
+func (s thriftStruct) string(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s thriftStruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+// union returns the id of the field set in the union, or -1.
+func (s thriftStruct) union() int16 {
+	for id := range s {
+		return id
+	}
+	return -1
+}
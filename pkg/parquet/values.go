@@ -0,0 +1,130 @@
+package parquet
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"time"
+	"unicode/utf8"
+)
+
+// int96 is the legacy timestamp: nanoseconds of the day followed by the Julian day.
+type int96 []byte
+
+const julianUnixEpoch = 2440588
+
+func (v int96) time() time.Time {
+	nanos := int64(binary.LittleEndian.Uint64(v[:8]))
+	days := int64(binary.LittleEndian.Uint32(v[8:]))
+	return time.Unix((days-julianUnixEpoch)*86400, nanos).UTC()
+}
+
+// convert converts the value of the physical type to Go value according to the annotations
+// of the column: bool, int64, uint64, float64, string or time.Time.
+func (n *node) convert(v any) any {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case int32:
+		return n.convertInt(int64(v), 32)
+	case int64:
+		return n.convertInt(v, 64)
+	case int96:
+		return v.time()
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	case []byte:
+		return n.convertBytes(v)
+	default:
+		return v
+	}
+}
+
+func (n *node) convertInt(v int64, size int) any {
+	switch {
+	case n.converted == convertedDate || n.logical.has(logicalDate):
+		return time.Unix(v*86400, 0).UTC()
+	case n.converted == convertedTimestampMillis:
+		return time.UnixMilli(v).UTC()
+	case n.converted == convertedTimestampMicros:
+		return time.UnixMicro(v).UTC()
+	case n.logical.has(logicalTimestamp):
+		return timeOf(v, n.logical.strct(logicalTimestamp).strct(2).union()).UTC()
+	case n.converted == convertedTimeMillis:
+		return timeOfDay(v, unitMillis)
+	case n.converted == convertedTimeMicros:
+		return timeOfDay(v, unitMicros)
+	case n.logical.has(logicalTime):
+		return timeOfDay(v, n.logical.strct(logicalTime).strct(2).union())
+	case n.converted == convertedDecimal || n.logical.has(logicalDecimal):
+		return n.decimal(big.NewInt(v))
+	case n.unsigned():
+		if size == 32 {
+			return uint64(uint32(v))
+		}
+		return uint64(v)
+	default:
+		return v
+	}
+}
+
+func (n *node) unsigned() bool {
+	if n.converted >= convertedUint8 && n.converted <= convertedUint64 {
+		return true
+	}
+	integer := n.logical.strct(logicalInteger)
+	return integer != nil && !integer.bool(2, true)
+}
+
+func timeOf(v int64, unit int16) time.Time {
+	switch unit {
+	case unitMillis:
+		return time.UnixMilli(v)
+	case unitNanos:
+		return time.Unix(0, v)
+	default:
+		return time.UnixMicro(v)
+	}
+}
+
+// timeOfDay formats the time of a day, for example, "13:45:00.5".
+func timeOfDay(v int64, unit int16) string {
+	t := timeOf(v, unit).UTC()
+	return t.Format("15:04:05.999999999")
+}
+
+func (n *node) decimal(unscaled *big.Int) float64 {
+	scale := n.scale
+	if d := n.logical.strct(logicalDecimal); d != nil {
+		scale = int(d.int(1))
+	}
+	f, _ := new(big.Float).SetInt(unscaled).Float64()
+	return f / math.Pow10(scale)
+}
+
+func (n *node) convertBytes(v []byte) any {
+	switch {
+	case n.converted == convertedUTF8 || n.converted == convertedEnum || n.converted == convertedJSON ||
+		n.logical.has(logicalString) || n.logical.has(logicalEnum) || n.logical.has(logicalJSON):
+		return string(v)
+	case n.converted == convertedDecimal || n.logical.has(logicalDecimal):
+		// big-endian two's complement
+		unscaled := new(big.Int).SetBytes(v)
+		if len(v) > 0 && v[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*len(v))))
+		}
+		return n.decimal(unscaled)
+	case n.logical.has(logicalUUID) && len(v) == 16:
+		s := hex.EncodeToString(v)
+		return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+	case utf8.Valid(v):
+		// many writers do not annotate the strings
+		return string(v)
+	default:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+}
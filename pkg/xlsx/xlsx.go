@@ -0,0 +1,468 @@
+// Package xlsx reads the cells of Office Open XML workbooks.
+//
+// The worksheets are streamed row by row, only the shared strings and the styles of the workbook
+// are kept in memory. The cells are typed: the numbers formatted as dates are converted to
+// time.Time values.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Workbook is an opened XLSX file.
+type Workbook struct {
+	zr            *zip.ReadCloser
+	files         map[string]*zip.File
+	sheets        []sheet
+	sharedStrings []string
+	// dateStyles are the indexes of the cell styles with date and time number formats
+	dateStyles map[int]bool
+	date1904   bool
+}
+
+type sheet struct {
+	name string
+	path string
+}
+
+// Open opens the workbook and reads its sheets, shared strings and styles.
+func Open(name string) (*Workbook, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, err
+	}
+	wb := &Workbook{
+		zr:         zr,
+		files:      make(map[string]*zip.File, len(zr.File)),
+		dateStyles: map[int]bool{},
+	}
+	for _, f := range zr.File {
+		wb.files[f.Name] = f
+	}
+	if err := wb.init(); err != nil {
+		zr.Close()
+		return nil, err
+	}
+	return wb, nil
+}
+
+// Close closes the file.
+func (wb *Workbook) Close() error {
+	return wb.zr.Close()
+}
+
+// Sheets returns the names of the sheets in the order of the workbook.
+func (wb *Workbook) Sheets() []string {
+	names := make([]string, len(wb.sheets))
+	for i, s := range wb.sheets {
+		names[i] = s.name
+	}
+	return names
+}
+
+func (wb *Workbook) init() error {
+	var workbook struct {
+		Pr struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := wb.decodeFile("xl/workbook.xml", &workbook); err != nil {
+		return err
+	}
+	wb.date1904 = workbook.Pr.Date1904 == "1" || workbook.Pr.Date1904 == "true"
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := wb.decodeFile("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return err
+	}
+	targets := map[string]string{}
+	for _, rel := range rels.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join("xl", rel.Target)
+		}
+	}
+	for _, s := range workbook.Sheets {
+		target, ok := targets[s.ID]
+		if !ok {
+			return fmt.Errorf("sheet %q has no worksheet", s.Name)
+		}
+		wb.sheets = append(wb.sheets, sheet{name: s.Name, path: target})
+	}
+	if err := wb.readSharedStrings(); err != nil {
+		return err
+	}
+	return wb.readStyles()
+}
+
+func (wb *Workbook) open(name string) (io.ReadCloser, error) {
+	f, ok := wb.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in the workbook", name)
+	}
+	return f.Open()
+}
+
+func (wb *Workbook) decodeFile(name string, v any) error {
+	r, err := wb.open(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := xml.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt *richText) String() string {
+	if len(rt.Runs) == 0 {
+		return rt.T
+	}
+	var sb strings.Builder
+	for _, run := range rt.Runs {
+		sb.WriteString(run.T)
+	}
+	return sb.String()
+}
+
+func (wb *Workbook) readSharedStrings() error {
+	if _, ok := wb.files["xl/sharedStrings.xml"]; !ok {
+		return nil
+	}
+	r, err := wb.open("xl/sharedStrings.xml")
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid shared strings: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "si" {
+			var si richText
+			if err := dec.DecodeElement(&si, &start); err != nil {
+				return fmt.Errorf("invalid shared strings: %w", err)
+			}
+			wb.sharedStrings = append(wb.sharedStrings, si.String())
+		}
+	}
+}
+
+func (wb *Workbook) readStyles() error {
+	if _, ok := wb.files["xl/styles.xml"]; !ok {
+		return nil
+	}
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := wb.decodeFile("xl/styles.xml", &styles); err != nil {
+		return err
+	}
+	custom := map[int]string{}
+	for _, f := range styles.NumFmts {
+		custom[f.ID] = f.Code
+	}
+	for i, xf := range styles.CellXfs {
+		if code, ok := custom[xf.NumFmtID]; ok {
+			wb.dateStyles[i] = isDateFormat(code)
+		} else {
+			wb.dateStyles[i] = isBuiltinDateFormat(xf.NumFmtID)
+		}
+	}
+	return nil
+}
+
+// isBuiltinDateFormat reports whether the built-in number format is a date or a time.
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+// isDateFormat reports whether the custom number format code has date or time parts.
+func isDateFormat(code string) bool {
+	// only the format of the positive numbers matters
+	section := code
+	if i := strings.IndexByte(code, ';'); i >= 0 {
+		section = code[:i]
+	}
+	for i := 0; i < len(section); i++ {
+		switch c := section[i]; c {
+		case '"':
+			// literal text
+			if j := strings.IndexByte(section[i+1:], '"'); j >= 0 {
+				i += j + 1
+			}
+		case '\\', '_', '*':
+			// escaped characters and paddings
+			i++
+		case '[':
+			j := strings.IndexByte(section[i:], ']')
+			if j < 0 {
+				return false
+			}
+			// elapsed time, for example, [h]:mm; other brackets are colors, conditions and locales
+			content := strings.ToLower(section[i+1 : i+j])
+			if content != "" && strings.Trim(content, "hms") == "" {
+				return true
+			}
+			i += j
+		case 'y', 'Y', 'm', 'M', 'd', 'D', 'h', 'H', 's', 'S':
+			return true
+		}
+	}
+	return false
+}
+
+// Rows is an iterator over the rows of a sheet.
+type Rows struct {
+	wb     *Workbook
+	r      io.ReadCloser
+	dec    *xml.Decoder
+	number int
+	values []any
+	err    error
+}
+
+// Rows returns an iterator over the rows of the sheet, or of the first sheet if the name is empty.
+func (wb *Workbook) Rows(name string) (*Rows, error) {
+	if len(wb.sheets) == 0 {
+		return nil, errors.New("the workbook has no sheets")
+	}
+	target := wb.sheets[0]
+	if name != "" {
+		found := false
+		for _, s := range wb.sheets {
+			if s.name == name {
+				target, found = s, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("sheet %q not found", name)
+		}
+	}
+	r, err := wb.open(target.path)
+	if err != nil {
+		return nil, err
+	}
+	return &Rows{wb: wb, r: r, dec: xml.NewDecoder(r)}, nil
+}
+
+type xmlRow struct {
+	R     int       `xml:"r,attr"`
+	Cells []xmlCell `xml:"c"`
+}
+
+type xmlCell struct {
+	R  string    `xml:"r,attr"`
+	S  int       `xml:"s,attr"`
+	T  string    `xml:"t,attr"`
+	V  *string   `xml:"v"`
+	IS *richText `xml:"is"`
+}
+
+// Next advances to the next row with cells, it returns false at the end of the sheet or on error.
+func (r *Rows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	for {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return false
+		} else if err != nil {
+			r.err = err
+			return false
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row xmlRow
+		if r.err = r.dec.DecodeElement(&row, &start); r.err != nil {
+			return false
+		}
+		if row.R > 0 {
+			r.number = row.R
+		} else {
+			r.number++
+		}
+		r.values = r.values[:0]
+		for _, cell := range row.Cells {
+			col := len(r.values)
+			if cell.R != "" {
+				if col, r.err = columnIndex(cell.R); r.err != nil {
+					return false
+				}
+			}
+			if col < len(r.values) {
+				r.err = fmt.Errorf("cell %s is out of order", cell.R)
+				return false
+			}
+			for len(r.values) < col {
+				r.values = append(r.values, nil)
+			}
+			value, err := r.wb.cellValue(cell)
+			if err != nil {
+				r.err = fmt.Errorf("cell %s: %w", cell.R, err)
+				return false
+			}
+			r.values = append(r.values, value)
+		}
+		return true
+	}
+}
+
+// Number returns the 1-based number of the current row.
+func (r *Rows) Number() int {
+	return r.number
+}
+
+// Values returns the values of the cells of the current row: string, float64, bool, time.Time or nil
+// for the empty cells. The slice is reused by the next call of Next.
+func (r *Rows) Values() []any {
+	return r.values
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close closes the sheet.
+func (r *Rows) Close() error {
+	return r.r.Close()
+}
+
+func (wb *Workbook) cellValue(cell xmlCell) (any, error) {
+	if cell.T == "inlineStr" {
+		if cell.IS == nil {
+			return nil, nil
+		}
+		return cell.IS.String(), nil
+	}
+	if cell.V == nil {
+		return nil, nil
+	}
+	v := *cell.V
+	switch cell.T {
+	case "s":
+		idx, err := strconv.Atoi(v)
+		if err != nil || idx < 0 || idx >= len(wb.sharedStrings) {
+			return nil, fmt.Errorf("invalid shared string index %q", v)
+		}
+		return wb.sharedStrings[idx], nil
+	case "str", "e":
+		// formula results and errors
+		return v, nil
+	case "b":
+		return v == "1" || v == "true", nil
+	case "d":
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q", v)
+	default:
+		if v == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", v)
+		}
+		if wb.dateStyles[cell.S] {
+			if t, ok := wb.serialTime(f); ok {
+				return t, nil
+			}
+		}
+		return f, nil
+	}
+}
+
+// maxSerial is the serial number of 9999-12-31, the last date supported by spreadsheets.
+const maxSerial = 2958465
+
+// serialTime converts the serial number of a date to time: the days since the epoch of the workbook,
+// the fraction is the time of the day.
+func (wb *Workbook) serialTime(serial float64) (time.Time, bool) {
+	if serial < 0 || serial > maxSerial {
+		return time.Time{}, false
+	}
+	// 1899-12-30 instead of 1900-01-01 accounts for the non-existent 1900-02-29 of the 1900 date system
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	if wb.date1904 {
+		epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	ms := math.Round(serial * 24 * 60 * 60 * 1000)
+	return epoch.Add(time.Duration(ms) * time.Millisecond), true
+}
+
+// columnIndex returns the 0-based index of the column of the cell reference, for example, 1 for "B12".
+func columnIndex(ref string) (int, error) {
+	col := 0
+	i := 0
+	for ; i < len(ref); i++ {
+		c := ref[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+		if col > 1<<14 {
+			return 0, fmt.Errorf("invalid cell reference %q", ref)
+		}
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// ColumnName returns the name of the column with the 0-based index, for example, "AB" for 27.
+func ColumnName(idx int) string {
+	var name []byte
+	for idx++; idx > 0; idx = (idx - 1) / 26 {
+		name = append([]byte{byte('A' + (idx-1)%26)}, name...)
+	}
+	return string(name)
+}
@@ -0,0 +1,109 @@
+package xlsx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	number int
+	values []any
+}
+
+func readSheet(t *testing.T, path, sheet string) []row {
+	t.Helper()
+	wb, err := Open(path)
+	require.NoError(t, err)
+	defer wb.Close()
+	rows, err := wb.Rows(sheet)
+	require.NoError(t, err)
+	defer rows.Close()
+	var result []row
+	for rows.Next() {
+		result = append(result, row{rows.Number(), append([]any{}, rows.Values()...)})
+	}
+	require.NoError(t, rows.Err())
+	return result
+}
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []row{
+		{1, []any{"Vendor risk report"}},
+		{2, []any{}},
+		{3, []any{"Vendor", "Score", "Reviewed", "Active", "Notes"}},
+		{4, []any{"Acme", 87.5, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), true, "renewal due", 0.25}},
+		{5, []any{"Globex", 42.0, time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC), false, nil}},
+		{6, []any{"Initech", nil, nil, true}},
+	}, readSheet(t, "testdata/report.xlsx", ""))
+
+	assert.Equal(t, []row{
+		{1, []any{"ID", "Severity", "Found at", "", "Severity"}},
+		{2, []any{"F-1", "high", time.Date(2024, time.January, 2, 13, 30, 0, 0, time.UTC), "x", "critical"}},
+		{3, []any{}},
+		{4, []any{nil, "low"}},
+	}, readSheet(t, "testdata/report.xlsx", "Findings"))
+}
+
+func TestDate1904(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []row{
+		{1, []any{"name", "since"}},
+		{2, []any{"legacy", time.Date(1924, time.January, 2, 12, 0, 0, 0, time.UTC)}},
+	}, readSheet(t, "testdata/legacy.xlsx", ""))
+}
+
+func TestSheets(t *testing.T) {
+	t.Parallel()
+
+	wb, err := Open("testdata/report.xlsx")
+	require.NoError(t, err)
+	defer wb.Close()
+	assert.Equal(t, []string{"Summary", "Findings"}, wb.Sheets())
+	_, err = wb.Rows("Missing")
+	assert.EqualError(t, err, `sheet "Missing" not found`)
+
+	_, err = Open("xlsx.go")
+	assert.Error(t, err)
+}
+
+func TestIsDateFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code string
+		date bool
+	}{
+		{"yyyy-mm-dd", true},
+		{"[$-409]mmmm d, yyyy", true},
+		{"[h]:mm:ss", true},
+		{"hh:mm AM/PM", true},
+		{"General", false},
+		{"0.00%", false},
+		{"#,##0.00 \"days\"", false},
+		{"[Red][<0]0.00;0.00", false},
+		{"0\\d", false},
+		{"#,##0;[Red]-#,##0;\"-\"", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.date, isDateFormat(tc.code), tc.code)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+
+	for idx, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, ColumnName(idx))
+		col, err := columnIndex(name + "12")
+		require.NoError(t, err)
+		assert.Equal(t, idx, col)
+	}
+	_, err := columnIndex("12")
+	assert.EqualError(t, err, `invalid cell reference "12"`)
+}
@@ -3,6 +3,9 @@ package ast
 import (
 	"bytes"
 	"regexp"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/anypb"
 
 	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
 )
@@ -280,6 +283,145 @@ func HTMLBlock(html []byte) *astv1.Node_HtmlBlock {
 	}
 }
 
+// FootnoteLink creates a reference to the footnote with the given index.
+func FootnoteLink(index int64) *astv1.Node_FootnoteLink {
+	return &astv1.Node_FootnoteLink{
+		FootnoteLink: &astv1.FootnoteLink{
+			Base:  &astv1.BaseNode{},
+			Index: index,
+		},
+	}
+}
+
+// Footnote creates a footnote definition, must be placed inside of the FootnoteList.
+func Footnote(index int64, children ...astv1.BlockContent) *astv1.Node_Footnote {
+	return &astv1.Node_Footnote{
+		Footnote: &astv1.Footnote{
+			Base: &astv1.BaseNode{
+				Children: astv1.Blocks.ExtendNodes(children, nil),
+			},
+			Ref:   []byte(strconv.FormatInt(index, 10)),
+			Index: index,
+		},
+	}
+}
+
+// FootnoteList creates a list of footnote definitions, usually placed at the end of the content.
+func FootnoteList(footnotes ...*astv1.Node_Footnote) *astv1.Node_FootnoteList {
+	children := make([]*astv1.Node, 0, len(footnotes))
+	for _, footnote := range footnotes {
+		children = footnote.ExtendNodes(children)
+	}
+	return &astv1.Node_FootnoteList{
+		FootnoteList: &astv1.FootnoteList{
+			Base: &astv1.BaseNode{
+				Children: children,
+			},
+			Count: int64(len(footnotes)),
+		},
+	}
+}
+
+// DefinitionList creates a definition list from terms and descriptions.
+func DefinitionList(items ...astv1.BlockContent) *astv1.Node_DefinitionList {
+	return &astv1.Node_DefinitionList{
+		DefinitionList: &astv1.DefinitionList{
+			Base: &astv1.BaseNode{
+				Children: astv1.Blocks.ExtendNodes(items, nil),
+			},
+		},
+	}
+}
+
+func DefinitionTerm(children ...astv1.InlineContent) *astv1.Node_DefinitionTerm {
+	return &astv1.Node_DefinitionTerm{
+		DefinitionTerm: &astv1.DefinitionTerm{
+			Base: &astv1.BaseNode{
+				Children: astv1.Inlines.ExtendNodes(children, nil),
+			},
+		},
+	}
+}
+
+func DefinitionDescription(children ...astv1.BlockContent) *astv1.Node_DefinitionDescription {
+	return &astv1.Node_DefinitionDescription{
+		DefinitionDescription: &astv1.DefinitionDescription{
+			Base: &astv1.BaseNode{
+				Children: astv1.Blocks.ExtendNodes(children, nil),
+			},
+			IsTight: true,
+		},
+	}
+}
+
+// Admonition creates a callout block, kind is one of "note", "tip", "important", "warning", "caution".
+// Title is optional.
+func Admonition(kind, title string, children ...astv1.BlockContent) *astv1.Node_Admonition {
+	return &astv1.Node_Admonition{
+		Admonition: &astv1.Admonition{
+			Base: &astv1.BaseNode{
+				Children: astv1.Blocks.ExtendNodes(children, nil),
+			},
+			Kind:  kind,
+			Title: title,
+		},
+	}
+}
+
+// Details creates a collapsible block with a summary, expanded by default if open is set.
+func Details(summary string, open bool, children ...astv1.BlockContent) *astv1.Node_Details {
+	return &astv1.Node_Details{
+		Details: &astv1.Details{
+			Base: &astv1.BaseNode{
+				Children: astv1.Blocks.ExtendNodes(children, nil),
+			},
+			Summary: summary,
+			Open:    open,
+		},
+	}
+}
+
+// MathBlock creates a display math block from TeX source.
+func MathBlock(tex string) *astv1.Node_MathBlock {
+	return &astv1.Node_MathBlock{
+		MathBlock: &astv1.MathBlock{
+			Base:  &astv1.BaseNode{},
+			Lines: bytes.Split([]byte(tex), []byte("\n")),
+		},
+	}
+}
+
+// MathInline creates an inline math expression from TeX source.
+func MathInline(tex string) *astv1.Node_MathInline {
+	return &astv1.Node_MathInline{
+		MathInline: &astv1.MathInline{
+			Base:  &astv1.BaseNode{},
+			Value: []byte(tex),
+		},
+	}
+}
+
+// CustomBlock creates a block node with a plugin-defined payload.
+// Printers render it using node renderers registered for the payload type URL.
+func CustomBlock(data *anypb.Any) *astv1.Node_Custom {
+	return &astv1.Node_Custom{
+		Custom: &astv1.CustomNode{
+			Data: data,
+		},
+	}
+}
+
+// CustomInline creates an inline node with a plugin-defined payload.
+// Printers render it using node renderers registered for the payload type URL.
+func CustomInline(data *anypb.Any) *astv1.Node_Custom {
+	return &astv1.Node_Custom{
+		Custom: &astv1.CustomNode{
+			IsInline: true,
+			Data:     data,
+		},
+	}
+}
+
 func Paragraph(children ...astv1.InlineContent) *astv1.Node_Paragraph {
 	return &astv1.Node_Paragraph{
 		Paragraph: &astv1.Paragraph{
@@ -51,3 +51,12 @@ func (m *FabricContentNode) Kind() ast.NodeKind {
 }
 
 var _ ast.Node = &FabricContentNode{}
+
+// Children returns the children of the node, so they can be moved while iterating.
+func Children(n ast.Node) []ast.Node {
+	res := make([]ast.Node, 0, n.ChildCount())
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		res = append(res, c)
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package nodes
+
+import (
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	AdmonitionKind = ast.NewNodeKind("FabricAdmonition")
+	MathBlockKind  = ast.NewNodeKind("FabricMathBlock")
+	MathInlineKind = ast.NewNodeKind("FabricMathInline")
+	DetailsKind    = ast.NewNodeKind("FabricDetails")
+)
+
+// Admonition is a callout block (note, tip, warning, etc.) containing other blocks.
+type Admonition struct {
+	ast.BaseBlock
+	// AdmonitionKind is the kind of the callout, ie "note", "tip", "important", "warning", "caution"
+	AdmonitionKind string
+	// Title is an optional title, printers default to the capitalized kind
+	Title string
+}
+
+func NewAdmonition(kind, title string) *Admonition {
+	return &Admonition{
+		AdmonitionKind: kind,
+		Title:          title,
+	}
+}
+
+var _ ast.Node = &Admonition{}
+
+// Kind implements ast.Node.
+func (n *Admonition) Kind() ast.NodeKind {
+	return AdmonitionKind
+}
+
+// Dump implements ast.Node.
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"kind":  n.AdmonitionKind,
+		"title": n.Title,
+	}, nil)
+}
+
+// MathBlock is a display math block holding TeX source in its lines.
+type MathBlock struct {
+	ast.BaseBlock
+}
+
+func NewMathBlock() *MathBlock {
+	return &MathBlock{}
+}
+
+var _ ast.Node = &MathBlock{}
+
+// Kind implements ast.Node.
+func (n *MathBlock) Kind() ast.NodeKind {
+	return MathBlockKind
+}
+
+// IsRaw implements ast.Node.
+func (n *MathBlock) IsRaw() bool {
+	return true
+}
+
+// Dump implements ast.Node.
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// MathInline is an inline math expression holding TeX source.
+type MathInline struct {
+	ast.BaseInline
+	Value []byte
+}
+
+func NewMathInline(value []byte) *MathInline {
+	return &MathInline{
+		Value: value,
+	}
+}
+
+var _ ast.Node = &MathInline{}
+
+// Kind implements ast.Node.
+func (n *MathInline) Kind() ast.NodeKind {
+	return MathInlineKind
+}
+
+// Dump implements ast.Node.
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"value": string(n.Value),
+	}, nil)
+}
+
+// Details is a collapsible block with a summary containing other blocks.
+type Details struct {
+	ast.BaseBlock
+	// Summary is shown when the block is collapsed
+	Summary string
+	// Open expands the block by default
+	Open bool
+}
+
+func NewDetails(summary string, open bool) *Details {
+	return &Details{
+		Summary: summary,
+		Open:    open,
+	}
+}
+
+var _ ast.Node = &Details{}
+
+// Kind implements ast.Node.
+func (n *Details) Kind() ast.NodeKind {
+	return DetailsKind
+}
+
+// Dump implements ast.Node.
+func (n *Details) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"summary": n.Summary,
+		"open":    strconv.FormatBool(n.Open),
+	}, nil)
+}
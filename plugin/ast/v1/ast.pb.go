@@ -368,6 +368,17 @@ type Node struct {
 	//	*Node_TableCell
 	//	*Node_TaskCheckbox
 	//	*Node_Strikethrough
+	//	*Node_FootnoteList
+	//	*Node_Footnote
+	//	*Node_DefinitionList
+	//	*Node_DefinitionTerm
+	//	*Node_DefinitionDescription
+	//	*Node_Admonition
+	//	*Node_MathBlock
+	//	*Node_FootnoteLink
+	//	*Node_FootnoteBacklink
+	//	*Node_MathInline
+	//	*Node_Details
 	//	*Node_ContentNode
 	//	*Node_Custom
 	Kind          isNode_Kind `protobuf_oneof:"kind"`
@@ -619,6 +630,105 @@ func (x *Node) GetStrikethrough() *Strikethrough {
 	return nil
 }
 
+func (x *Node) GetFootnoteList() *FootnoteList {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_FootnoteList); ok {
+			return x.FootnoteList
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFootnote() *Footnote {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_Footnote); ok {
+			return x.Footnote
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetDefinitionList() *DefinitionList {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_DefinitionList); ok {
+			return x.DefinitionList
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetDefinitionTerm() *DefinitionTerm {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_DefinitionTerm); ok {
+			return x.DefinitionTerm
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetDefinitionDescription() *DefinitionDescription {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_DefinitionDescription); ok {
+			return x.DefinitionDescription
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetAdmonition() *Admonition {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_Admonition); ok {
+			return x.Admonition
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetMathBlock() *MathBlock {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_MathBlock); ok {
+			return x.MathBlock
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFootnoteLink() *FootnoteLink {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_FootnoteLink); ok {
+			return x.FootnoteLink
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFootnoteBacklink() *FootnoteBacklink {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_FootnoteBacklink); ok {
+			return x.FootnoteBacklink
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetMathInline() *MathInline {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_MathInline); ok {
+			return x.MathInline
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetDetails() *Details {
+	if x != nil {
+		if x, ok := x.Kind.(*Node_Details); ok {
+			return x.Details
+		}
+	}
+	return nil
+}
+
 func (x *Node) GetContentNode() *FabricContentNode {
 	if x != nil {
 		if x, ok := x.Kind.(*Node_ContentNode); ok {
@@ -738,6 +848,54 @@ type Node_Strikethrough struct {
 	Strikethrough *Strikethrough `protobuf:"bytes,26,opt,name=strikethrough,proto3,oneof"`
 }
 
+type Node_FootnoteList struct {
+	// Extended markdown
+	// blocks
+	FootnoteList *FootnoteList `protobuf:"bytes,27,opt,name=footnote_list,json=footnoteList,proto3,oneof"`
+}
+
+type Node_Footnote struct {
+	Footnote *Footnote `protobuf:"bytes,28,opt,name=footnote,proto3,oneof"`
+}
+
+type Node_DefinitionList struct {
+	DefinitionList *DefinitionList `protobuf:"bytes,29,opt,name=definition_list,json=definitionList,proto3,oneof"`
+}
+
+type Node_DefinitionTerm struct {
+	DefinitionTerm *DefinitionTerm `protobuf:"bytes,30,opt,name=definition_term,json=definitionTerm,proto3,oneof"`
+}
+
+type Node_DefinitionDescription struct {
+	DefinitionDescription *DefinitionDescription `protobuf:"bytes,31,opt,name=definition_description,json=definitionDescription,proto3,oneof"`
+}
+
+type Node_Admonition struct {
+	Admonition *Admonition `protobuf:"bytes,32,opt,name=admonition,proto3,oneof"`
+}
+
+type Node_MathBlock struct {
+	MathBlock *MathBlock `protobuf:"bytes,33,opt,name=math_block,json=mathBlock,proto3,oneof"`
+}
+
+type Node_FootnoteLink struct {
+	// inline
+	FootnoteLink *FootnoteLink `protobuf:"bytes,34,opt,name=footnote_link,json=footnoteLink,proto3,oneof"`
+}
+
+type Node_FootnoteBacklink struct {
+	FootnoteBacklink *FootnoteBacklink `protobuf:"bytes,35,opt,name=footnote_backlink,json=footnoteBacklink,proto3,oneof"`
+}
+
+type Node_MathInline struct {
+	MathInline *MathInline `protobuf:"bytes,36,opt,name=math_inline,json=mathInline,proto3,oneof"`
+}
+
+type Node_Details struct {
+	// blocks
+	Details *Details `protobuf:"bytes,37,opt,name=details,proto3,oneof"`
+}
+
 type Node_ContentNode struct {
 	// Root of the plugin-rendered data
 	ContentNode *FabricContentNode `protobuf:"bytes,254,opt,name=content_node,json=contentNode,proto3,oneof"`
@@ -794,6 +952,28 @@ func (*Node_TaskCheckbox) isNode_Kind() {}
 
 func (*Node_Strikethrough) isNode_Kind() {}
 
+func (*Node_FootnoteList) isNode_Kind() {}
+
+func (*Node_Footnote) isNode_Kind() {}
+
+func (*Node_DefinitionList) isNode_Kind() {}
+
+func (*Node_DefinitionTerm) isNode_Kind() {}
+
+func (*Node_DefinitionDescription) isNode_Kind() {}
+
+func (*Node_Admonition) isNode_Kind() {}
+
+func (*Node_MathBlock) isNode_Kind() {}
+
+func (*Node_FootnoteLink) isNode_Kind() {}
+
+func (*Node_FootnoteBacklink) isNode_Kind() {}
+
+func (*Node_MathInline) isNode_Kind() {}
+
+func (*Node_Details) isNode_Kind() {}
+
 func (*Node_ContentNode) isNode_Kind() {}
 
 func (*Node_Custom) isNode_Kind() {}
@@ -2058,30 +2238,28 @@ func (x *Strikethrough) GetBase() *BaseNode {
 	return nil
 }
 
-type CustomNode struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Indicates that this block is an inline element
-	IsInline           bool       `protobuf:"varint,1,opt,name=is_inline,json=isInline,proto3" json:"is_inline,omitempty"`
-	Data               *anypb.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	BlankPreviousLines bool       `protobuf:"varint,3,opt,name=blank_previous_lines,json=blankPreviousLines,proto3" json:"blank_previous_lines,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type FootnoteList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CustomNode) Reset() {
-	*x = CustomNode{}
+func (x *FootnoteList) Reset() {
+	*x = FootnoteList{}
 	mi := &file_ast_v1_ast_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CustomNode) String() string {
+func (x *FootnoteList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CustomNode) ProtoMessage() {}
+func (*FootnoteList) ProtoMessage() {}
 
-func (x *CustomNode) ProtoReflect() protoreflect.Message {
+func (x *FootnoteList) ProtoReflect() protoreflect.Message {
 	mi := &file_ast_v1_ast_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2093,57 +2271,48 @@ func (x *CustomNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CustomNode.ProtoReflect.Descriptor instead.
-func (*CustomNode) Descriptor() ([]byte, []int) {
+// Deprecated: Use FootnoteList.ProtoReflect.Descriptor instead.
+func (*FootnoteList) Descriptor() ([]byte, []int) {
 	return file_ast_v1_ast_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *CustomNode) GetIsInline() bool {
-	if x != nil {
-		return x.IsInline
-	}
-	return false
-}
-
-func (x *CustomNode) GetData() *anypb.Any {
+func (x *FootnoteList) GetBase() *BaseNode {
 	if x != nil {
-		return x.Data
+		return x.Base
 	}
 	return nil
 }
 
-func (x *CustomNode) GetBlankPreviousLines() bool {
+func (x *FootnoteList) GetCount() int64 {
 	if x != nil {
-		return x.BlankPreviousLines
+		return x.Count
 	}
-	return false
+	return 0
 }
 
-type Metadata struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// ie "blackstork/builtin"
-	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
-	// ie "title"
-	Plugin        string `protobuf:"bytes,2,opt,name=plugin,proto3" json:"plugin,omitempty"`
-	Version       string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+type Footnote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Ref           []byte                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Index         int64                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Metadata) Reset() {
-	*x = Metadata{}
+func (x *Footnote) Reset() {
+	*x = Footnote{}
 	mi := &file_ast_v1_ast_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Metadata) String() string {
+func (x *Footnote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Metadata) ProtoMessage() {}
+func (*Footnote) ProtoMessage() {}
 
-func (x *Metadata) ProtoReflect() protoreflect.Message {
+func (x *Footnote) ProtoReflect() protoreflect.Message {
 	mi := &file_ast_v1_ast_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2155,55 +2324,56 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
-func (*Metadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use Footnote.ProtoReflect.Descriptor instead.
+func (*Footnote) Descriptor() ([]byte, []int) {
 	return file_ast_v1_ast_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *Metadata) GetProvider() string {
+func (x *Footnote) GetBase() *BaseNode {
 	if x != nil {
-		return x.Provider
+		return x.Base
 	}
-	return ""
+	return nil
 }
 
-func (x *Metadata) GetPlugin() string {
+func (x *Footnote) GetRef() []byte {
 	if x != nil {
-		return x.Plugin
+		return x.Ref
 	}
-	return ""
+	return nil
 }
 
-func (x *Metadata) GetVersion() string {
+func (x *Footnote) GetIndex() int64 {
 	if x != nil {
-		return x.Version
+		return x.Index
 	}
-	return ""
+	return 0
 }
 
-// Root of the plugin-rendered data
-type FabricContentNode struct {
+type FootnoteLink struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Root          *BaseNode              `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"` // direct content, no document node
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Index         int64                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	RefCount      int64                  `protobuf:"varint,3,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"`
+	RefIndex      int64                  `protobuf:"varint,4,opt,name=ref_index,json=refIndex,proto3" json:"ref_index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FabricContentNode) Reset() {
-	*x = FabricContentNode{}
+func (x *FootnoteLink) Reset() {
+	*x = FootnoteLink{}
 	mi := &file_ast_v1_ast_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FabricContentNode) String() string {
+func (x *FootnoteLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FabricContentNode) ProtoMessage() {}
+func (*FootnoteLink) ProtoMessage() {}
 
-func (x *FabricContentNode) ProtoReflect() protoreflect.Message {
+func (x *FootnoteLink) ProtoReflect() protoreflect.Message {
 	mi := &file_ast_v1_ast_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2215,56 +2385,690 @@ func (x *FabricContentNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FabricContentNode.ProtoReflect.Descriptor instead.
-func (*FabricContentNode) Descriptor() ([]byte, []int) {
+// Deprecated: Use FootnoteLink.ProtoReflect.Descriptor instead.
+func (*FootnoteLink) Descriptor() ([]byte, []int) {
 	return file_ast_v1_ast_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *FabricContentNode) GetMetadata() *Metadata {
+func (x *FootnoteLink) GetBase() *BaseNode {
 	if x != nil {
-		return x.Metadata
+		return x.Base
 	}
 	return nil
 }
 
-func (x *FabricContentNode) GetRoot() *BaseNode {
+func (x *FootnoteLink) GetIndex() int64 {
 	if x != nil {
-		return x.Root
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FootnoteLink) GetRefCount() int64 {
+	if x != nil {
+		return x.RefCount
+	}
+	return 0
+}
+
+func (x *FootnoteLink) GetRefIndex() int64 {
+	if x != nil {
+		return x.RefIndex
+	}
+	return 0
+}
+
+type FootnoteBacklink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Index         int64                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	RefCount      int64                  `protobuf:"varint,3,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"`
+	RefIndex      int64                  `protobuf:"varint,4,opt,name=ref_index,json=refIndex,proto3" json:"ref_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FootnoteBacklink) Reset() {
+	*x = FootnoteBacklink{}
+	mi := &file_ast_v1_ast_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FootnoteBacklink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FootnoteBacklink) ProtoMessage() {}
+
+func (x *FootnoteBacklink) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FootnoteBacklink.ProtoReflect.Descriptor instead.
+func (*FootnoteBacklink) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FootnoteBacklink) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
 	}
 	return nil
 }
 
-var File_ast_v1_ast_proto protoreflect.FileDescriptor
+func (x *FootnoteBacklink) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
 
-var file_ast_v1_ast_proto_rawDesc = string([]byte{
-	0x0a, 0x10, 0x61, 0x73, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x06, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x54, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
-	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12,
-	0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73,
-	0x74, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x08,
-	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c,
-	0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x74,
-	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
-	0x65, 0x6e, 0x12, 0x31, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x5f, 0x70,
-	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x12, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f,
-	0x75, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x8d, 0x0a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
-	0x12, 0x2e, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x32, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65,
-	0x78, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70,
-	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x50, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x48, 0x00, 0x52, 0x09, 0x70, 0x61,
+func (x *FootnoteBacklink) GetRefCount() int64 {
+	if x != nil {
+		return x.RefCount
+	}
+	return 0
+}
+
+func (x *FootnoteBacklink) GetRefIndex() int64 {
+	if x != nil {
+		return x.RefIndex
+	}
+	return 0
+}
+
+type DefinitionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DefinitionList) Reset() {
+	*x = DefinitionList{}
+	mi := &file_ast_v1_ast_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefinitionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefinitionList) ProtoMessage() {}
+
+func (x *DefinitionList) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefinitionList.ProtoReflect.Descriptor instead.
+func (*DefinitionList) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DefinitionList) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DefinitionList) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type DefinitionTerm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DefinitionTerm) Reset() {
+	*x = DefinitionTerm{}
+	mi := &file_ast_v1_ast_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefinitionTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefinitionTerm) ProtoMessage() {}
+
+func (x *DefinitionTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefinitionTerm.ProtoReflect.Descriptor instead.
+func (*DefinitionTerm) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DefinitionTerm) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type DefinitionDescription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	IsTight       bool                   `protobuf:"varint,2,opt,name=is_tight,json=isTight,proto3" json:"is_tight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DefinitionDescription) Reset() {
+	*x = DefinitionDescription{}
+	mi := &file_ast_v1_ast_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefinitionDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefinitionDescription) ProtoMessage() {}
+
+func (x *DefinitionDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefinitionDescription.ProtoReflect.Descriptor instead.
+func (*DefinitionDescription) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DefinitionDescription) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DefinitionDescription) GetIsTight() bool {
+	if x != nil {
+		return x.IsTight
+	}
+	return false
+}
+
+type Admonition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// ie "note", "tip", "important", "warning", "caution"
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Optional title, defaults to the capitalized kind
+	Title         string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Admonition) Reset() {
+	*x = Admonition{}
+	mi := &file_ast_v1_ast_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Admonition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Admonition) ProtoMessage() {}
+
+func (x *Admonition) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Admonition.ProtoReflect.Descriptor instead.
+func (*Admonition) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Admonition) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Admonition) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Admonition) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type MathBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Lines         [][]byte               `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MathBlock) Reset() {
+	*x = MathBlock{}
+	mi := &file_ast_v1_ast_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MathBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MathBlock) ProtoMessage() {}
+
+func (x *MathBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MathBlock.ProtoReflect.Descriptor instead.
+func (*MathBlock) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MathBlock) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *MathBlock) GetLines() [][]byte {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type MathInline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MathInline) Reset() {
+	*x = MathInline{}
+	mi := &file_ast_v1_ast_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MathInline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MathInline) ProtoMessage() {}
+
+func (x *MathInline) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MathInline.ProtoReflect.Descriptor instead.
+func (*MathInline) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MathInline) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *MathInline) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Details struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseNode              `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Summary shown when the block is collapsed
+	Summary string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Whether the block is expanded by default
+	Open          bool `protobuf:"varint,3,opt,name=open,proto3" json:"open,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Details) Reset() {
+	*x = Details{}
+	mi := &file_ast_v1_ast_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Details) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Details) ProtoMessage() {}
+
+func (x *Details) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Details.ProtoReflect.Descriptor instead.
+func (*Details) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Details) GetBase() *BaseNode {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Details) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Details) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+type CustomNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Indicates that this block is an inline element
+	IsInline           bool       `protobuf:"varint,1,opt,name=is_inline,json=isInline,proto3" json:"is_inline,omitempty"`
+	Data               *anypb.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	BlankPreviousLines bool       `protobuf:"varint,3,opt,name=blank_previous_lines,json=blankPreviousLines,proto3" json:"blank_previous_lines,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CustomNode) Reset() {
+	*x = CustomNode{}
+	mi := &file_ast_v1_ast_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomNode) ProtoMessage() {}
+
+func (x *CustomNode) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomNode.ProtoReflect.Descriptor instead.
+func (*CustomNode) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CustomNode) GetIsInline() bool {
+	if x != nil {
+		return x.IsInline
+	}
+	return false
+}
+
+func (x *CustomNode) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CustomNode) GetBlankPreviousLines() bool {
+	if x != nil {
+		return x.BlankPreviousLines
+	}
+	return false
+}
+
+type Metadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ie "blackstork/builtin"
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// ie "title"
+	Plugin        string `protobuf:"bytes,2,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Version       string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_ast_v1_ast_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Metadata) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Metadata) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *Metadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Root of the plugin-rendered data
+type FabricContentNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Root          *BaseNode              `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"` // direct content, no document node
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FabricContentNode) Reset() {
+	*x = FabricContentNode{}
+	mi := &file_ast_v1_ast_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FabricContentNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FabricContentNode) ProtoMessage() {}
+
+func (x *FabricContentNode) ProtoReflect() protoreflect.Message {
+	mi := &file_ast_v1_ast_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FabricContentNode.ProtoReflect.Descriptor instead.
+func (*FabricContentNode) Descriptor() ([]byte, []int) {
+	return file_ast_v1_ast_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FabricContentNode) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FabricContentNode) GetRoot() *BaseNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+var File_ast_v1_ast_proto protoreflect.FileDescriptor
+
+var file_ast_v1_ast_proto_rawDesc = string([]byte{
+	0x0a, 0x10, 0x61, 0x73, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x54, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73,
+	0x74, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x08,
+	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x73, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x12, 0x31, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x5f, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0xac, 0x0f, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x2e, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x32, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65,
+	0x78, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x48, 0x00, 0x52, 0x09, 0x70, 0x61,
 	0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x2b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x69,
 	0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
 	0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07, 0x68, 0x65, 0x61,
@@ -2329,204 +3133,308 @@ var file_ast_v1_ast_proto_rawDesc = string([]byte{
 	0x68, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
 	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x48, 0x00,
 	0x52, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x12,
-	0x3f, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18,
-	0xfe, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x46, 0x61, 0x62, 0x72, 0x69, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x12, 0x2d, 0x0a, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x18, 0xff, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f,
-	0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x42,
-	0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x30, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x09, 0x54, 0x65, 0x78,
-	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
-	0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x09,
-	0x50, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x3b, 0x0a, 0x0d, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c,
+	0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x08,
+	0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x41, 0x0a, 0x0f,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x41, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x65,
+	0x72, 0x6d, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x72, 0x6d,
+	0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x65,
+	0x72, 0x6d, 0x12, 0x56, 0x0a, 0x16, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x15, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x0a, 0x61, 0x64,
+	0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x32, 0x0a, 0x0a, 0x6d, 0x61, 0x74, 0x68, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x74, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x61, 0x74, 0x68, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x0d, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65,
+	0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x73,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e,
+	0x6b, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x47, 0x0a, 0x11, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x62, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61,
+	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x42, 0x61,
+	0x63, 0x6b, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x10, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f,
+	0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x35, 0x0a, 0x0b, 0x6d, 0x61,
+	0x74, 0x68, 0x5f, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x6c,
+	0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x68, 0x49, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x12, 0x2b, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x25, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x3f,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0xfe,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x61, 0x62, 0x72, 0x69, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x48, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0x2d, 0x0a, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x18, 0xff, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x42, 0x06,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x30, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73,
+	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x31, 0x0a, 0x09, 0x50,
+	0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x45,
+	0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22,
-	0x45, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x35, 0x0a, 0x0d, 0x54, 0x68, 0x65, 0x6d, 0x61, 0x74,
-	0x69, 0x63, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42,
-	0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x47, 0x0a,
-	0x09, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x0f, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x64,
+	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x35, 0x0a, 0x0d, 0x54, 0x68, 0x65, 0x6d, 0x61, 0x74, 0x69,
+	0x63, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x47, 0x0a, 0x09,
 	0x43, 0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
 	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
-	0x20, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x52, 0x04, 0x69, 0x6e, 0x66,
-	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73,
-	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x75, 0x0a, 0x04, 0x4c,
-	0x69, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72,
-	0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65,
-	0x72, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x54, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x22, 0x48, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x24,
-	0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
-	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04,
-	0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x95, 0x01, 0x0a,
-	0x09, 0x48, 0x54, 0x4d, 0x4c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
-	0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15,
-	0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x4d, 0x4c, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65,
-	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x6c, 0x69, 0x6e,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65,
-	0x4c, 0x69, 0x6e, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x24, 0x0a,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x6f, 0x0a, 0x0f, 0x46, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x43,
+	0x6f, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x20,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x71,
+	0x75, 0x6f, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x75, 0x0a, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72,
+	0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x74, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x54, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x22, 0x48, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x24, 0x0a,
 	0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73,
 	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62,
-	0x61, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a,
-	0x0f, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6e, 0x65,
-	0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x69,
-	0x6e, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
-	0x68, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x10, 0x0a,
-	0x03, 0x72, 0x61, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22,
-	0x6a, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x09,
+	0x48, 0x54, 0x4d, 0x4c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
 	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x30, 0x0a, 0x08, 0x43,
-	0x6f, 0x64, 0x65, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42,
-	0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x46, 0x0a,
-	0x08, 0x45, 0x6d, 0x70, 0x68, 0x61, 0x73, 0x69, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b, 0x4f, 0x72,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73,
-	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x8c,
-	0x01, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x6f, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62,
-	0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e,
-	0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73,
-	0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x14, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x4c, 0x69, 0x6e,
-	0x6b, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4b, 0x0a,
-	0x07, 0x52, 0x61, 0x77, 0x48, 0x54, 0x4d, 0x4c, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x4d, 0x4c, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x4c,
+	0x69, 0x6e, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x24, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x73, 0x6f, 0x66, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x69, 0x6e,
+	0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x68,
+	0x61, 0x72, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12, 0x10, 0x0a, 0x03,
+	0x72, 0x61, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0x6a,
+	0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x30, 0x0a, 0x08, 0x43, 0x6f,
+	0x64, 0x65, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x08,
+	0x45, 0x6d, 0x70, 0x68, 0x61, 0x73, 0x69, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x05, 0x54, 0x61,
-	0x62, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b, 0x4f, 0x72, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x8c, 0x01,
+	0x0a, 0x08, 0x41, 0x75, 0x74, 0x6f, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x4c, 0x69, 0x6e, 0x6b,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4b, 0x0a, 0x07,
+	0x52, 0x61, 0x77, 0x48, 0x54, 0x4d, 0x4c, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x05, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x6c, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x61,
+	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x84, 0x01, 0x0a, 0x08, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x24, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61,
+	0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x66, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43,
+	0x65, 0x6c, 0x6c, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x6c, 0x69,
-	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e,
-	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x22, 0x84, 0x01, 0x0a, 0x08, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x24, 0x0a,
-	0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73,
-	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62,
-	0x61, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a,
-	0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73,
-	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69,
-	0x73, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x66, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x43, 0x65, 0x6c, 0x6c, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x61, 0x6c, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x61,
+	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x53,
+	0x0a, 0x0c, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x12, 0x24,
+	0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
+	0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x22, 0x35, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x74, 0x68, 0x72,
+	0x6f, 0x75, 0x67, 0x68, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x0c, 0x46, 0x6f,
+	0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x58, 0x0a, 0x08, 0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f,
+	0x74, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x84, 0x01, 0x0a, 0x0c, 0x46, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x72, 0x65, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
+	0x66, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x66, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x46, 0x6f, 0x6f, 0x74,
+	0x6e, 0x6f, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x24, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x66,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x66, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0x4e, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65,
-	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x61, 0x6c,
-	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
-	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x22,
-	0x53, 0x0a, 0x0c, 0x54, 0x61, 0x73, 0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x62, 0x6f, 0x78, 0x12,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x22, 0x36, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x65, 0x72, 0x6d, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x15, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f,
+	0x74, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x54,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0x5c, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x22, 0x47, 0x0a, 0x09, 0x4d, 0x61, 0x74, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
 	0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
 	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63,
-	0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x65, 0x64, 0x22, 0x35, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x74, 0x68,
-	0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73,
-	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x85, 0x01, 0x0a, 0x0a,
-	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73,
-	0x5f, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69,
-	0x73, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69,
-	0x6f, 0x75, 0x73, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x12, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4c, 0x69,
-	0x6e, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
-	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x75,
-	0x67, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x67, 0x0a,
-	0x11, 0x46, 0x61, 0x62, 0x72, 0x69, 0x63, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x0a, 0x4d,
+	0x61, 0x74, 0x68, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5d, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x12, 0x24, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
 	0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65,
-	0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x2a, 0xd1, 0x01, 0x0a, 0x0d, 0x48, 0x54, 0x4d, 0x4c, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x48, 0x54, 0x4d, 0x4c,
-	0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d,
-	0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x31, 0x10, 0x01,
+	0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x6f, 0x70, 0x65, 0x6e, 0x22, 0x85, 0x01, 0x0a, 0x0a, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4e,
+	0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x14, 0x62, 0x6c,
+	0x61, 0x6e, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6c, 0x69, 0x6e,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x50,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x08,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x67, 0x0a, 0x11, 0x46, 0x61, 0x62, 0x72, 0x69, 0x63,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6f,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x61, 0x73, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x2a,
+	0xd1, 0x01, 0x0a, 0x0d, 0x48, 0x54, 0x4d, 0x4c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x31, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d,
+	0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x32, 0x10, 0x02,
 	0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x32, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f,
-	0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x33, 0x10, 0x03, 0x12, 0x15,
+	0x59, 0x50, 0x45, 0x5f, 0x33, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f,
+	0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x34, 0x10, 0x04, 0x12, 0x15,
 	0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x34, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c,
-	0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x35, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11,
+	0x45, 0x5f, 0x35, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c,
+	0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x36, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11,
 	0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x36, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x54, 0x4d, 0x4c, 0x5f, 0x42, 0x4c, 0x4f, 0x43,
-	0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x37, 0x10, 0x07, 0x2a, 0x60, 0x0a, 0x0c, 0x41, 0x75,
-	0x74, 0x6f, 0x4c, 0x69, 0x6e, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55,
-	0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55,
-	0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4d, 0x41,
-	0x49, 0x4c, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e,
-	0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x52, 0x4c, 0x10, 0x02, 0x2a, 0x96, 0x01, 0x0a,
-	0x0d, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1e,
-	0x0a, 0x1a, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17,
-	0x0a, 0x13, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54,
-	0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x45, 0x4c, 0x4c, 0x5f,
-	0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x49, 0x47, 0x48, 0x54, 0x10,
-	0x02, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d,
-	0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13,
-	0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4e,
-	0x4f, 0x4e, 0x45, 0x10, 0x04, 0x42, 0x84, 0x01, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x73,
-	0x74, 0x2e, 0x76, 0x31, 0x42, 0x08, 0x41, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
-	0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6c, 0x61,
-	0x63, 0x6b, 0x73, 0x74, 0x6f, 0x72, 0x6b, 0x2d, 0x69, 0x6f, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69,
-	0x63, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x61, 0x73, 0x74, 0x2f, 0x76, 0x31, 0x3b,
-	0x61, 0x73, 0x74, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x58, 0x58, 0xaa, 0x02, 0x06, 0x41, 0x73,
-	0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x06, 0x41, 0x73, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x12,
-	0x41, 0x73, 0x74, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0xea, 0x02, 0x07, 0x41, 0x73, 0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x37, 0x10, 0x07, 0x2a, 0x60, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x6f, 0x4c, 0x69, 0x6e, 0x6b, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e, 0x4b,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e, 0x4b,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x16, 0x0a,
+	0x12, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x52, 0x4c, 0x10, 0x02, 0x2a, 0x96, 0x01, 0x0a, 0x0d, 0x43, 0x65, 0x6c, 0x6c, 0x41, 0x6c,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x45, 0x4c, 0x4c, 0x5f,
+	0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x45, 0x4c, 0x4c, 0x5f,
+	0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x01,
+	0x12, 0x18, 0x0a, 0x14, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45,
+	0x4e, 0x54, 0x5f, 0x52, 0x49, 0x47, 0x48, 0x54, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x45,
+	0x4c, 0x4c, 0x5f, 0x41, 0x4c, 0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x4e,
+	0x54, 0x45, 0x52, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x41, 0x4c,
+	0x49, 0x47, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x04, 0x42, 0x84,
+	0x01, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x73, 0x74, 0x2e, 0x76, 0x31, 0x42, 0x08, 0x41,
+	0x73, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x73, 0x74, 0x6f, 0x72, 0x6b,
+	0x2d, 0x69, 0x6f, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2f, 0x61, 0x73, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x73, 0x74, 0x76, 0x31, 0xa2, 0x02,
+	0x03, 0x41, 0x58, 0x58, 0xaa, 0x02, 0x06, 0x41, 0x73, 0x74, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x06,
+	0x41, 0x73, 0x74, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x12, 0x41, 0x73, 0x74, 0x5c, 0x56, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x41, 0x73,
+	0x74, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -2542,41 +3450,52 @@ func file_ast_v1_ast_proto_rawDescGZIP() []byte {
 }
 
 var file_ast_v1_ast_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_ast_v1_ast_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_ast_v1_ast_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_ast_v1_ast_proto_goTypes = []any{
-	(HTMLBlockType)(0),        // 0: ast.v1.HTMLBlockType
-	(AutoLinkType)(0),         // 1: ast.v1.AutoLinkType
-	(CellAlignment)(0),        // 2: ast.v1.CellAlignment
-	(*Attribute)(nil),         // 3: ast.v1.Attribute
-	(*BaseNode)(nil),          // 4: ast.v1.BaseNode
-	(*Node)(nil),              // 5: ast.v1.Node
-	(*Document)(nil),          // 6: ast.v1.Document
-	(*TextBlock)(nil),         // 7: ast.v1.TextBlock
-	(*Paragraph)(nil),         // 8: ast.v1.Paragraph
-	(*Heading)(nil),           // 9: ast.v1.Heading
-	(*ThematicBreak)(nil),     // 10: ast.v1.ThematicBreak
-	(*CodeBlock)(nil),         // 11: ast.v1.CodeBlock
-	(*FencedCodeBlock)(nil),   // 12: ast.v1.FencedCodeBlock
-	(*Blockquote)(nil),        // 13: ast.v1.Blockquote
-	(*List)(nil),              // 14: ast.v1.List
-	(*ListItem)(nil),          // 15: ast.v1.ListItem
-	(*HTMLBlock)(nil),         // 16: ast.v1.HTMLBlock
-	(*Text)(nil),              // 17: ast.v1.Text
-	(*String)(nil),            // 18: ast.v1.String
-	(*CodeSpan)(nil),          // 19: ast.v1.CodeSpan
-	(*Emphasis)(nil),          // 20: ast.v1.Emphasis
-	(*LinkOrImage)(nil),       // 21: ast.v1.LinkOrImage
-	(*AutoLink)(nil),          // 22: ast.v1.AutoLink
-	(*RawHTML)(nil),           // 23: ast.v1.RawHTML
-	(*Table)(nil),             // 24: ast.v1.Table
-	(*TableRow)(nil),          // 25: ast.v1.TableRow
-	(*TableCell)(nil),         // 26: ast.v1.TableCell
-	(*TaskCheckbox)(nil),      // 27: ast.v1.TaskCheckbox
-	(*Strikethrough)(nil),     // 28: ast.v1.Strikethrough
-	(*CustomNode)(nil),        // 29: ast.v1.CustomNode
-	(*Metadata)(nil),          // 30: ast.v1.Metadata
-	(*FabricContentNode)(nil), // 31: ast.v1.FabricContentNode
-	(*anypb.Any)(nil),         // 32: google.protobuf.Any
+	(HTMLBlockType)(0),            // 0: ast.v1.HTMLBlockType
+	(AutoLinkType)(0),             // 1: ast.v1.AutoLinkType
+	(CellAlignment)(0),            // 2: ast.v1.CellAlignment
+	(*Attribute)(nil),             // 3: ast.v1.Attribute
+	(*BaseNode)(nil),              // 4: ast.v1.BaseNode
+	(*Node)(nil),                  // 5: ast.v1.Node
+	(*Document)(nil),              // 6: ast.v1.Document
+	(*TextBlock)(nil),             // 7: ast.v1.TextBlock
+	(*Paragraph)(nil),             // 8: ast.v1.Paragraph
+	(*Heading)(nil),               // 9: ast.v1.Heading
+	(*ThematicBreak)(nil),         // 10: ast.v1.ThematicBreak
+	(*CodeBlock)(nil),             // 11: ast.v1.CodeBlock
+	(*FencedCodeBlock)(nil),       // 12: ast.v1.FencedCodeBlock
+	(*Blockquote)(nil),            // 13: ast.v1.Blockquote
+	(*List)(nil),                  // 14: ast.v1.List
+	(*ListItem)(nil),              // 15: ast.v1.ListItem
+	(*HTMLBlock)(nil),             // 16: ast.v1.HTMLBlock
+	(*Text)(nil),                  // 17: ast.v1.Text
+	(*String)(nil),                // 18: ast.v1.String
+	(*CodeSpan)(nil),              // 19: ast.v1.CodeSpan
+	(*Emphasis)(nil),              // 20: ast.v1.Emphasis
+	(*LinkOrImage)(nil),           // 21: ast.v1.LinkOrImage
+	(*AutoLink)(nil),              // 22: ast.v1.AutoLink
+	(*RawHTML)(nil),               // 23: ast.v1.RawHTML
+	(*Table)(nil),                 // 24: ast.v1.Table
+	(*TableRow)(nil),              // 25: ast.v1.TableRow
+	(*TableCell)(nil),             // 26: ast.v1.TableCell
+	(*TaskCheckbox)(nil),          // 27: ast.v1.TaskCheckbox
+	(*Strikethrough)(nil),         // 28: ast.v1.Strikethrough
+	(*FootnoteList)(nil),          // 29: ast.v1.FootnoteList
+	(*Footnote)(nil),              // 30: ast.v1.Footnote
+	(*FootnoteLink)(nil),          // 31: ast.v1.FootnoteLink
+	(*FootnoteBacklink)(nil),      // 32: ast.v1.FootnoteBacklink
+	(*DefinitionList)(nil),        // 33: ast.v1.DefinitionList
+	(*DefinitionTerm)(nil),        // 34: ast.v1.DefinitionTerm
+	(*DefinitionDescription)(nil), // 35: ast.v1.DefinitionDescription
+	(*Admonition)(nil),            // 36: ast.v1.Admonition
+	(*MathBlock)(nil),             // 37: ast.v1.MathBlock
+	(*MathInline)(nil),            // 38: ast.v1.MathInline
+	(*Details)(nil),               // 39: ast.v1.Details
+	(*CustomNode)(nil),            // 40: ast.v1.CustomNode
+	(*Metadata)(nil),              // 41: ast.v1.Metadata
+	(*FabricContentNode)(nil),     // 42: ast.v1.FabricContentNode
+	(*anypb.Any)(nil),             // 43: google.protobuf.Any
 }
 var file_ast_v1_ast_proto_depIdxs = []int32{
 	5,  // 0: ast.v1.BaseNode.children:type_name -> ast.v1.Node
@@ -2604,45 +3523,67 @@ var file_ast_v1_ast_proto_depIdxs = []int32{
 	26, // 22: ast.v1.Node.table_cell:type_name -> ast.v1.TableCell
 	27, // 23: ast.v1.Node.task_checkbox:type_name -> ast.v1.TaskCheckbox
 	28, // 24: ast.v1.Node.strikethrough:type_name -> ast.v1.Strikethrough
-	31, // 25: ast.v1.Node.content_node:type_name -> ast.v1.FabricContentNode
-	29, // 26: ast.v1.Node.custom:type_name -> ast.v1.CustomNode
-	4,  // 27: ast.v1.Document.base:type_name -> ast.v1.BaseNode
-	4,  // 28: ast.v1.TextBlock.base:type_name -> ast.v1.BaseNode
-	4,  // 29: ast.v1.Paragraph.base:type_name -> ast.v1.BaseNode
-	4,  // 30: ast.v1.Heading.base:type_name -> ast.v1.BaseNode
-	4,  // 31: ast.v1.ThematicBreak.base:type_name -> ast.v1.BaseNode
-	4,  // 32: ast.v1.CodeBlock.base:type_name -> ast.v1.BaseNode
-	4,  // 33: ast.v1.FencedCodeBlock.base:type_name -> ast.v1.BaseNode
-	17, // 34: ast.v1.FencedCodeBlock.info:type_name -> ast.v1.Text
-	4,  // 35: ast.v1.Blockquote.base:type_name -> ast.v1.BaseNode
-	4,  // 36: ast.v1.List.base:type_name -> ast.v1.BaseNode
-	4,  // 37: ast.v1.ListItem.base:type_name -> ast.v1.BaseNode
-	4,  // 38: ast.v1.HTMLBlock.base:type_name -> ast.v1.BaseNode
-	0,  // 39: ast.v1.HTMLBlock.type:type_name -> ast.v1.HTMLBlockType
-	4,  // 40: ast.v1.Text.base:type_name -> ast.v1.BaseNode
-	4,  // 41: ast.v1.String.base:type_name -> ast.v1.BaseNode
-	4,  // 42: ast.v1.CodeSpan.base:type_name -> ast.v1.BaseNode
-	4,  // 43: ast.v1.Emphasis.base:type_name -> ast.v1.BaseNode
-	4,  // 44: ast.v1.LinkOrImage.base:type_name -> ast.v1.BaseNode
-	4,  // 45: ast.v1.AutoLink.base:type_name -> ast.v1.BaseNode
-	1,  // 46: ast.v1.AutoLink.type:type_name -> ast.v1.AutoLinkType
-	4,  // 47: ast.v1.RawHTML.base:type_name -> ast.v1.BaseNode
-	4,  // 48: ast.v1.Table.base:type_name -> ast.v1.BaseNode
-	2,  // 49: ast.v1.Table.alignments:type_name -> ast.v1.CellAlignment
-	4,  // 50: ast.v1.TableRow.base:type_name -> ast.v1.BaseNode
-	2,  // 51: ast.v1.TableRow.alignments:type_name -> ast.v1.CellAlignment
-	4,  // 52: ast.v1.TableCell.base:type_name -> ast.v1.BaseNode
-	2,  // 53: ast.v1.TableCell.alignment:type_name -> ast.v1.CellAlignment
-	4,  // 54: ast.v1.TaskCheckbox.base:type_name -> ast.v1.BaseNode
-	4,  // 55: ast.v1.Strikethrough.base:type_name -> ast.v1.BaseNode
-	32, // 56: ast.v1.CustomNode.data:type_name -> google.protobuf.Any
-	30, // 57: ast.v1.FabricContentNode.metadata:type_name -> ast.v1.Metadata
-	4,  // 58: ast.v1.FabricContentNode.root:type_name -> ast.v1.BaseNode
-	59, // [59:59] is the sub-list for method output_type
-	59, // [59:59] is the sub-list for method input_type
-	59, // [59:59] is the sub-list for extension type_name
-	59, // [59:59] is the sub-list for extension extendee
-	0,  // [0:59] is the sub-list for field type_name
+	29, // 25: ast.v1.Node.footnote_list:type_name -> ast.v1.FootnoteList
+	30, // 26: ast.v1.Node.footnote:type_name -> ast.v1.Footnote
+	33, // 27: ast.v1.Node.definition_list:type_name -> ast.v1.DefinitionList
+	34, // 28: ast.v1.Node.definition_term:type_name -> ast.v1.DefinitionTerm
+	35, // 29: ast.v1.Node.definition_description:type_name -> ast.v1.DefinitionDescription
+	36, // 30: ast.v1.Node.admonition:type_name -> ast.v1.Admonition
+	37, // 31: ast.v1.Node.math_block:type_name -> ast.v1.MathBlock
+	31, // 32: ast.v1.Node.footnote_link:type_name -> ast.v1.FootnoteLink
+	32, // 33: ast.v1.Node.footnote_backlink:type_name -> ast.v1.FootnoteBacklink
+	38, // 34: ast.v1.Node.math_inline:type_name -> ast.v1.MathInline
+	39, // 35: ast.v1.Node.details:type_name -> ast.v1.Details
+	42, // 36: ast.v1.Node.content_node:type_name -> ast.v1.FabricContentNode
+	40, // 37: ast.v1.Node.custom:type_name -> ast.v1.CustomNode
+	4,  // 38: ast.v1.Document.base:type_name -> ast.v1.BaseNode
+	4,  // 39: ast.v1.TextBlock.base:type_name -> ast.v1.BaseNode
+	4,  // 40: ast.v1.Paragraph.base:type_name -> ast.v1.BaseNode
+	4,  // 41: ast.v1.Heading.base:type_name -> ast.v1.BaseNode
+	4,  // 42: ast.v1.ThematicBreak.base:type_name -> ast.v1.BaseNode
+	4,  // 43: ast.v1.CodeBlock.base:type_name -> ast.v1.BaseNode
+	4,  // 44: ast.v1.FencedCodeBlock.base:type_name -> ast.v1.BaseNode
+	17, // 45: ast.v1.FencedCodeBlock.info:type_name -> ast.v1.Text
+	4,  // 46: ast.v1.Blockquote.base:type_name -> ast.v1.BaseNode
+	4,  // 47: ast.v1.List.base:type_name -> ast.v1.BaseNode
+	4,  // 48: ast.v1.ListItem.base:type_name -> ast.v1.BaseNode
+	4,  // 49: ast.v1.HTMLBlock.base:type_name -> ast.v1.BaseNode
+	0,  // 50: ast.v1.HTMLBlock.type:type_name -> ast.v1.HTMLBlockType
+	4,  // 51: ast.v1.Text.base:type_name -> ast.v1.BaseNode
+	4,  // 52: ast.v1.String.base:type_name -> ast.v1.BaseNode
+	4,  // 53: ast.v1.CodeSpan.base:type_name -> ast.v1.BaseNode
+	4,  // 54: ast.v1.Emphasis.base:type_name -> ast.v1.BaseNode
+	4,  // 55: ast.v1.LinkOrImage.base:type_name -> ast.v1.BaseNode
+	4,  // 56: ast.v1.AutoLink.base:type_name -> ast.v1.BaseNode
+	1,  // 57: ast.v1.AutoLink.type:type_name -> ast.v1.AutoLinkType
+	4,  // 58: ast.v1.RawHTML.base:type_name -> ast.v1.BaseNode
+	4,  // 59: ast.v1.Table.base:type_name -> ast.v1.BaseNode
+	2,  // 60: ast.v1.Table.alignments:type_name -> ast.v1.CellAlignment
+	4,  // 61: ast.v1.TableRow.base:type_name -> ast.v1.BaseNode
+	2,  // 62: ast.v1.TableRow.alignments:type_name -> ast.v1.CellAlignment
+	4,  // 63: ast.v1.TableCell.base:type_name -> ast.v1.BaseNode
+	2,  // 64: ast.v1.TableCell.alignment:type_name -> ast.v1.CellAlignment
+	4,  // 65: ast.v1.TaskCheckbox.base:type_name -> ast.v1.BaseNode
+	4,  // 66: ast.v1.Strikethrough.base:type_name -> ast.v1.BaseNode
+	4,  // 67: ast.v1.FootnoteList.base:type_name -> ast.v1.BaseNode
+	4,  // 68: ast.v1.Footnote.base:type_name -> ast.v1.BaseNode
+	4,  // 69: ast.v1.FootnoteLink.base:type_name -> ast.v1.BaseNode
+	4,  // 70: ast.v1.FootnoteBacklink.base:type_name -> ast.v1.BaseNode
+	4,  // 71: ast.v1.DefinitionList.base:type_name -> ast.v1.BaseNode
+	4,  // 72: ast.v1.DefinitionTerm.base:type_name -> ast.v1.BaseNode
+	4,  // 73: ast.v1.DefinitionDescription.base:type_name -> ast.v1.BaseNode
+	4,  // 74: ast.v1.Admonition.base:type_name -> ast.v1.BaseNode
+	4,  // 75: ast.v1.MathBlock.base:type_name -> ast.v1.BaseNode
+	4,  // 76: ast.v1.MathInline.base:type_name -> ast.v1.BaseNode
+	4,  // 77: ast.v1.Details.base:type_name -> ast.v1.BaseNode
+	43, // 78: ast.v1.CustomNode.data:type_name -> google.protobuf.Any
+	41, // 79: ast.v1.FabricContentNode.metadata:type_name -> ast.v1.Metadata
+	4,  // 80: ast.v1.FabricContentNode.root:type_name -> ast.v1.BaseNode
+	81, // [81:81] is the sub-list for method output_type
+	81, // [81:81] is the sub-list for method input_type
+	81, // [81:81] is the sub-list for extension type_name
+	81, // [81:81] is the sub-list for extension extendee
+	0,  // [0:81] is the sub-list for field type_name
 }
 
 func init() { file_ast_v1_ast_proto_init() }
@@ -2678,6 +3619,17 @@ func file_ast_v1_ast_proto_init() {
 		(*Node_TableCell)(nil),
 		(*Node_TaskCheckbox)(nil),
 		(*Node_Strikethrough)(nil),
+		(*Node_FootnoteList)(nil),
+		(*Node_Footnote)(nil),
+		(*Node_DefinitionList)(nil),
+		(*Node_DefinitionTerm)(nil),
+		(*Node_DefinitionDescription)(nil),
+		(*Node_Admonition)(nil),
+		(*Node_MathBlock)(nil),
+		(*Node_FootnoteLink)(nil),
+		(*Node_FootnoteBacklink)(nil),
+		(*Node_MathInline)(nil),
+		(*Node_Details)(nil),
 		(*Node_ContentNode)(nil),
 		(*Node_Custom)(nil),
 	}
@@ -2687,7 +3639,7 @@ func file_ast_v1_ast_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ast_v1_ast_proto_rawDesc), len(file_ast_v1_ast_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   29,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -226,6 +226,54 @@ func (d *decoder) decodeNode(node *Node) (res ast.Node) {
 		base = val.TaskCheckbox.GetBase()
 		res = east.NewTaskCheckBox(val.TaskCheckbox.GetIsChecked())
 
+	case *Node_FootnoteList:
+		base = val.FootnoteList.GetBase()
+		tRes := east.NewFootnoteList()
+		tRes.Count = int(val.FootnoteList.GetCount())
+		res = tRes
+	case *Node_Footnote:
+		base = val.Footnote.GetBase()
+		tRes := east.NewFootnote(val.Footnote.GetRef())
+		tRes.Index = int(val.Footnote.GetIndex())
+		res = tRes
+	case *Node_FootnoteLink:
+		base = val.FootnoteLink.GetBase()
+		tRes := east.NewFootnoteLink(int(val.FootnoteLink.GetIndex()))
+		tRes.RefCount = int(val.FootnoteLink.GetRefCount())
+		tRes.RefIndex = int(val.FootnoteLink.GetRefIndex())
+		res = tRes
+	case *Node_FootnoteBacklink:
+		base = val.FootnoteBacklink.GetBase()
+		tRes := east.NewFootnoteBacklink(int(val.FootnoteBacklink.GetIndex()))
+		tRes.RefCount = int(val.FootnoteBacklink.GetRefCount())
+		tRes.RefIndex = int(val.FootnoteBacklink.GetRefIndex())
+		res = tRes
+	case *Node_DefinitionList:
+		base = val.DefinitionList.GetBase()
+		res = east.NewDefinitionList(int(val.DefinitionList.GetOffset()), nil)
+	case *Node_DefinitionTerm:
+		base = val.DefinitionTerm.GetBase()
+		res = east.NewDefinitionTerm()
+	case *Node_DefinitionDescription:
+		base = val.DefinitionDescription.GetBase()
+		tRes := east.NewDefinitionDescription()
+		tRes.IsTight = val.DefinitionDescription.GetIsTight()
+		res = tRes
+	case *Node_Admonition:
+		base = val.Admonition.GetBase()
+		res = nodes.NewAdmonition(val.Admonition.GetKind(), val.Admonition.GetTitle())
+	case *Node_Details:
+		base = val.Details.GetBase()
+		res = nodes.NewDetails(val.Details.GetSummary(), val.Details.GetOpen())
+	case *Node_MathBlock:
+		base = val.MathBlock.GetBase()
+		mathBlock := nodes.NewMathBlock()
+		mathBlock.SetLines(d.source.AppendMultiple(val.MathBlock.GetLines()))
+		res = mathBlock
+	case *Node_MathInline:
+		base = val.MathInline.GetBase()
+		res = nodes.NewMathInline(val.MathInline.GetValue())
+
 	case *Node_ContentNode:
 		res = &nodes.FabricContentNode{
 			Meta: DecodeMetadata(val.ContentNode.GetMetadata()),
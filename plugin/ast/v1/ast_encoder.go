@@ -304,6 +304,90 @@ func (e *encoder) encodeNode(node ast.Node) *Node {
 				IsChecked: n.IsChecked,
 			},
 		}
+	// Extended markdown
+	case *east.FootnoteList:
+		kind = &Node_FootnoteList{
+			FootnoteList: &FootnoteList{
+				Base:  e.encodeBaseBlock(&n.BaseBlock),
+				Count: int64(n.Count),
+			},
+		}
+	case *east.Footnote:
+		kind = &Node_Footnote{
+			Footnote: &Footnote{
+				Base:  e.encodeBaseBlock(&n.BaseBlock),
+				Ref:   n.Ref,
+				Index: int64(n.Index),
+			},
+		}
+	case *east.FootnoteLink:
+		kind = &Node_FootnoteLink{
+			FootnoteLink: &FootnoteLink{
+				Base:     e.encodeBaseNode(&n.BaseNode),
+				Index:    int64(n.Index),
+				RefCount: int64(n.RefCount),
+				RefIndex: int64(n.RefIndex),
+			},
+		}
+	case *east.FootnoteBacklink:
+		kind = &Node_FootnoteBacklink{
+			FootnoteBacklink: &FootnoteBacklink{
+				Base:     e.encodeBaseNode(&n.BaseNode),
+				Index:    int64(n.Index),
+				RefCount: int64(n.RefCount),
+				RefIndex: int64(n.RefIndex),
+			},
+		}
+	case *east.DefinitionList:
+		kind = &Node_DefinitionList{
+			DefinitionList: &DefinitionList{
+				Base:   e.encodeBaseBlock(&n.BaseBlock),
+				Offset: int64(n.Offset),
+			},
+		}
+	case *east.DefinitionTerm:
+		kind = &Node_DefinitionTerm{
+			DefinitionTerm: &DefinitionTerm{
+				Base: e.encodeBaseBlock(&n.BaseBlock),
+			},
+		}
+	case *east.DefinitionDescription:
+		kind = &Node_DefinitionDescription{
+			DefinitionDescription: &DefinitionDescription{
+				Base:    e.encodeBaseBlock(&n.BaseBlock),
+				IsTight: n.IsTight,
+			},
+		}
+	case *nodes.Admonition:
+		kind = &Node_Admonition{
+			Admonition: &Admonition{
+				Base:  e.encodeBaseBlock(&n.BaseBlock),
+				Kind:  n.AdmonitionKind,
+				Title: n.Title,
+			},
+		}
+	case *nodes.Details:
+		kind = &Node_Details{
+			Details: &Details{
+				Base:    e.encodeBaseBlock(&n.BaseBlock),
+				Summary: n.Summary,
+				Open:    n.Open,
+			},
+		}
+	case *nodes.MathBlock:
+		kind = &Node_MathBlock{
+			MathBlock: &MathBlock{
+				Base:  e.encodeBaseBlock(&n.BaseBlock),
+				Lines: e.encodeSegments(n.Lines()),
+			},
+		}
+	case *nodes.MathInline:
+		kind = &Node_MathInline{
+			MathInline: &MathInline{
+				Base:  e.encodeBaseNode(&n.BaseNode),
+				Value: n.Value,
+			},
+		}
 	case *nodes.FabricContentNode:
 		kind = &Node_ContentNode{
 			ContentNode: &FabricContentNode{
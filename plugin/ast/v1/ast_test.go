@@ -7,6 +7,7 @@ import (
 	"github.com/blackstork-io/goldmark-markdown/pkg/mdexamples"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 
 	astv1 "github.com/blackstork-io/fabric/plugin/ast/v1"
@@ -14,14 +15,17 @@ import (
 
 func roundtrip(t *testing.T, source []byte) {
 	t.Helper()
-	md := goldmark.New(
+	roundtripWith(t, goldmark.New(
 		goldmark.WithExtensions(
 			extension.Table,
 			extension.Strikethrough,
 			extension.TaskList,
 		),
-	)
+	), source)
+}
 
+func roundtripWith(t *testing.T, md goldmark.Markdown, source []byte) {
+	t.Helper()
 	tree := md.Parser().Parse(text.NewReader(source))
 
 	// roundtrip
@@ -77,6 +81,37 @@ func TestDocumentsRoundtrip(t *testing.T) {
 	}
 }
 
+func TestExtendedRoundtrip(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.Footnote,
+			extension.DefinitionList,
+		),
+		goldmark.WithParserOptions(
+			parser.WithAttribute(),
+		),
+	)
+	roundtripWith(t, md, []byte(`# Heading {#custom-id .title}
+
+Text with a footnote[^1] and another one[^note].
+
+Term
+:   Definition of the term
+
+Second term
+:   First definition
+
+:   Second definition
+    with continuation
+
+[^1]: The footnote.
+
+[^note]: The other footnote.
+
+    With a second paragraph.
+`))
+}
+
 func TestFuzzCase(t *testing.T) {
 	t.Skip("Expected to fail: bugs in goldmark")
 	roundtrip(t, []byte("* 0\n-|\n\t0"))
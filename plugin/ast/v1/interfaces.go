@@ -190,6 +190,30 @@ func (n *Node_Heading) ExtendNodes(nodes []*Node) []*Node {
 	return append(nodes, &Node{Kind: n})
 }
 
+// SetID sets the id of the heading, used as an anchor in rendered documents.
+func (n *Node_Heading) SetID(id string) *Node_Heading {
+	return n.SetAttribute("id", id)
+}
+
+// SetAttribute sets an attribute of the heading, replacing the existing one with the same name.
+func (n *Node_Heading) SetAttribute(name, value string) *Node_Heading {
+	base := n.Heading.GetBase()
+	if base == nil {
+		base = &BaseNode{}
+		n.Heading.Base = base
+	}
+	base.Attributes = slices.DeleteFunc(base.Attributes, func(attr *Attribute) bool {
+		return string(attr.GetName()) == name
+	})
+	base.Attributes = append(base.Attributes, &Attribute{
+		Name: []byte(name),
+		Value: &Attribute_Str{
+			Str: value,
+		},
+	})
+	return n
+}
+
 func (n *Node_CodeBlock) isBlock() {}
 
 func (n *Node_CodeBlock) ExtendNodes(nodes []*Node) []*Node {
@@ -317,3 +341,60 @@ func NewContent(nodes ...BlockContent) *FabricContentNode {
 		},
 	}
 }
+
+// Custom node is both inline and block content, depending on the IsInline flag.
+func (n *Node_Custom) isInline() {}
+func (n *Node_Custom) isBlock()  {}
+func (n *Node_Custom) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_FootnoteList) isBlock() {}
+func (n *Node_FootnoteList) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_Footnote) isBlock() {}
+func (n *Node_Footnote) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_DefinitionList) isBlock() {}
+func (n *Node_DefinitionList) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_DefinitionTerm) isBlock() {}
+func (n *Node_DefinitionTerm) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_DefinitionDescription) isBlock() {}
+func (n *Node_DefinitionDescription) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_Admonition) isBlock() {}
+func (n *Node_Admonition) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_Details) isBlock() {}
+func (n *Node_Details) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_MathBlock) isBlock() {}
+func (n *Node_MathBlock) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_FootnoteLink) isInline() {}
+func (n *Node_FootnoteLink) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
+
+func (n *Node_MathInline) isInline() {}
+func (n *Node_MathInline) ExtendNodes(nodes []*Node) []*Node {
+	return append(nodes, &Node{Kind: n})
+}
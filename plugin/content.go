@@ -10,6 +10,7 @@ import (
 	markdown "github.com/blackstork-io/goldmark-markdown"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/text"
 	"google.golang.org/protobuf/proto"
 
@@ -286,6 +287,10 @@ func NewElementFromMarkdownAndAST(source []byte, ast *astv1.FabricContentNode, m
 	}
 }
 
+// ReferenceLinkTitle is the title of the links to the document titles produced by `ref`
+// template function of the builtin plugin. The links are resolved after the document is rendered.
+const ReferenceLinkTitle = "fabric:ref"
+
 var BaseMarkdownOptions = goldmark.WithExtensions(
 	extension.Table,
 	extension.Strikethrough,
@@ -310,6 +315,18 @@ func (c *ContentElement) AsMarkdownSrc() []byte {
 					nodes.ContentNodeKind,
 					nodes.CustomBlockKind,
 					nodes.CustomInlineKind,
+					// extended nodes are lowered by the printers, here only their content is kept
+					nodes.AdmonitionKind,
+					nodes.DetailsKind,
+					nodes.MathBlockKind,
+					nodes.MathInlineKind,
+					east.KindFootnoteList,
+					east.KindFootnote,
+					east.KindFootnoteLink,
+					east.KindFootnoteBacklink,
+					east.KindDefinitionList,
+					east.KindDefinitionTerm,
+					east.KindDefinitionDescription,
 				),
 			),
 		),
@@ -356,11 +373,63 @@ func (c *ContentElement) AsNode() (*astsrc.ASTSource, *nodes.FabricContentNode)
 			Parser().Parse(text.NewReader(c.mdString))
 		c.node = nodes.ToFabricContentNode(node)
 		c.node.Meta = c.meta
+		// segments of the parsed nodes point to the markdown string
+		c.source = astsrc.ASTSource(bytes.Clone(c.mdString))
 	}
 
 	return &c.source, c.node
 }
 
+func (c *ContentElement) clone() *ContentElement {
+	clone := &ContentElement{
+		meta:     c.Meta(),
+		id:       c.id,
+		mdString: c.mdString,
+	}
+	if c.IsAst() {
+		clone.serializedNode = proto.Clone(c.AsSerializedNode()).(*astv1.FabricContentNode)
+	}
+	return clone
+}
+
+// CloneContent returns a deep copy of the content tree.
+// The copy can be modified without affecting the original, for example by the printers.
+func CloneContent(content Content) Content {
+	switch content := content.(type) {
+	case *ContentSection:
+		content.mtx.RLock()
+		defer content.mtx.RUnlock()
+		clone := &ContentSection{
+			idStore:  content.idStore,
+			id:       content.id,
+			meta:     content.meta,
+			Children: make([]Content, len(content.Children)),
+		}
+		for i, child := range content.Children {
+			clone.Children[i] = CloneContent(child)
+		}
+		return clone
+	case *ContentElement:
+		return content.clone()
+	case *ContentEmpty:
+		return &ContentEmpty{
+			id:   content.id,
+			meta: content.meta,
+		}
+	}
+	return content
+}
+
+// InvalidateCache drops the representations of the element derived from its AST.
+// Must be called after the AST returned by [ContentElement.AsNode] was modified in place.
+func (c *ContentElement) InvalidateCache() {
+	if c.node == nil {
+		return
+	}
+	c.mdString = nil
+	c.serializedNode = nil
+}
+
 func (c *ContentElement) ID() uint32 {
 	return c.id
 }
@@ -55,8 +55,17 @@ type ContentProvider struct {
 	Args            *dataspec.RootSpec
 	Config          *dataspec.RootSpec
 	InvocationOrder InvocationOrder
+	// DocumentArg is the name of the argument holding a name of another document template.
+	// The engine renders the referenced documents in advance and passes their content
+	// in the data context under IncludedDocumentsKey. Only static string values are supported.
+	// The field is not transferred to external plugins.
+	DocumentArg string
 }
 
+// IncludedDocumentsKey is the key of the content of the documents referenced by
+// ContentProvider.DocumentArg in the data context.
+const IncludedDocumentsKey = "documents"
+
 func (cg *ContentProvider) Validate() diagnostics.Diag {
 	var diags diagnostics.Diag
 	if cg.ContentFunc == nil {
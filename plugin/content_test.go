@@ -0,0 +1,21 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuin/goldmark/ast"
+
+	"github.com/blackstork-io/fabric/plugin"
+)
+
+func TestContentElementAsNodeFromMarkdown(t *testing.T) {
+	el := plugin.NewElementFromMarkdown("Hello **world**")
+	src, node := el.AsNode()
+	require.NotNil(t, node)
+	para, ok := node.FirstChild().(*ast.Paragraph)
+	require.True(t, ok)
+	// the segments of the parsed nodes must resolve against the returned source
+	assert.Equal(t, "Hello world", string(para.Text(src.AsBytes())))
+}
@@ -103,7 +103,7 @@ func (q *JqQuery) parse() (code *gojq.Code, diags diagnostics.Diag) {
 		return
 	}
 
-	code, err = gojq.Compile(jqQuery)
+	code, err = gojq.Compile(jqQuery, jqFunctions...)
 	if err != nil {
 		diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
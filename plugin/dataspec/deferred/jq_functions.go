@@ -0,0 +1,56 @@
+package deferred
+
+import (
+	"fmt"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+// jqFunctions are the functions available in the queries in addition to jq builtins.
+var jqFunctions = []gojq.CompilerOption{
+	// diff(previous; current; key) and diff(previous; current; key; fields) compare two lists
+	// of objects, see plugindata.Diff
+	gojq.WithFunction("diff", 3, 4, jqDiff),
+}
+
+func jqDiff(_ any, args []any) any {
+	var lists [2]plugindata.List
+	for i, name := range []string{"previous", "current"} {
+		data, err := plugindata.ParseAny(args[i])
+		if err != nil {
+			return fmt.Errorf("diff: %s: %w", name, err)
+		}
+		switch data := data.(type) {
+		case plugindata.List:
+			lists[i] = data
+		case nil:
+		default:
+			return fmt.Errorf("diff: %s must be an array", name)
+		}
+	}
+	key, ok := args[2].(string)
+	if !ok {
+		return fmt.Errorf("diff: key must be a string")
+	}
+	opts := plugindata.DiffOptions{Key: key}
+	if len(args) > 3 {
+		fields, ok := args[3].([]any)
+		if !ok {
+			return fmt.Errorf("diff: fields must be an array of strings")
+		}
+		for _, field := range fields {
+			field, ok := field.(string)
+			if !ok {
+				return fmt.Errorf("diff: fields must be an array of strings")
+			}
+			opts.Fields = append(opts.Fields, field)
+		}
+	}
+	res, err := plugindata.Diff(lists[0], lists[1], opts)
+	if err != nil {
+		return fmt.Errorf("diff: %w", err)
+	}
+	return res.AsPluginData().Any()
+}
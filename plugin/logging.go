@@ -19,6 +19,7 @@ func WithLogging(plugin *Schema, logger *slog.Logger) *Schema {
 	plugin.ContentProviders = makeContentProvidersLogging(plugin.Name, plugin.ContentProviders, logger)
 	plugin.DataSources = makeDataSourcesLogging(plugin.Name, plugin.DataSources, logger)
 	plugin.Publishers = makePublishersLogging(plugin.Name, plugin.Publishers, logger)
+	plugin.NodeRenderers = makeNodeRenderersLogging(plugin.Name, plugin.NodeRenderers, logger)
 	return plugin
 }
 
@@ -49,6 +50,31 @@ func makePublishersLogging(plugin string, publishers Publishers, logger *slog.Lo
 	return result
 }
 
+func makeNodeRenderersLogging(plugin string, renderers NodeRenderers, logger *slog.Logger) NodeRenderers {
+	if renderers == nil {
+		return nil
+	}
+	result := make(NodeRenderers)
+	for typeURL, renderer := range renderers {
+		renderer.RenderFunc = makeNodeRendererLogging(plugin, typeURL, *renderer, logger)
+		result[typeURL] = renderer
+	}
+	return result
+}
+
+func makeNodeRendererLogging(plugin, typeURL string, renderer NodeRenderer, logger *slog.Logger) RenderNodeFunc {
+	next := renderer.RenderFunc
+	return func(ctx context.Context, params *RenderNodeParams) (*RenderNodeResult, diagnostics.Diag) {
+		logger.DebugContext(ctx, "Executing node renderer", "params", slog.GroupValue(
+			slog.String("plugin", plugin),
+			slog.String("type_url", typeURL),
+			slog.String("format", params.Format.String()),
+			slog.Bool("inline", params.Inline),
+		))
+		return next(ctx, params)
+	}
+}
+
 func makePublisherLogging(plugin, name string, publisher Publisher, logger *slog.Logger) PublishFunc {
 	next := publisher.PublishFunc
 	return func(ctx context.Context, params *PublishParams) diagnostics.Diag {
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+)
+
+// NodeRenderers maps the type URL of the custom AST node payload
+// (see [anypb.Any.TypeUrl]) to the renderer responsible for it.
+type NodeRenderers map[string]*NodeRenderer
+
+func (nr NodeRenderers) Validate() diagnostics.Diag {
+	var diags diagnostics.Diag
+	for typeURL, renderer := range nr {
+		if renderer == nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Incomplete NodeRenderer schema",
+				Detail:   "NodeRenderer for '" + typeURL + "' not loaded",
+			})
+		} else {
+			diags = append(diags, renderer.Validate()...)
+		}
+	}
+	return diags
+}
+
+// Lookup returns the renderer for the node and the format it should be rendered in.
+// If the renderer doesn't support the requested format, markdown is used as a fallback.
+func (nr NodeRenderers) Lookup(node *anypb.Any, format OutputFormat) (*NodeRenderer, OutputFormat, bool) {
+	renderer, ok := nr[node.GetTypeUrl()]
+	if !ok || renderer == nil {
+		return nil, OutputFormatUnspecified, false
+	}
+	if slices.Contains(renderer.Formats, format) {
+		return renderer, format, true
+	}
+	if slices.Contains(renderer.Formats, OutputFormatMD) {
+		return renderer, OutputFormatMD, true
+	}
+	return nil, OutputFormatUnspecified, false
+}
+
+// RenderNodeFunc renders a custom AST node.
+type RenderNodeFunc func(ctx context.Context, params *RenderNodeParams) (*RenderNodeResult, diagnostics.Diag)
+
+type RenderNodeParams struct {
+	// Node is the payload of the custom node.
+	Node *anypb.Any
+	// Inline is true if the node is used in inline context (inside of a paragraph, heading, etc.).
+	Inline bool
+	// Format is the format to render the node in.
+	Format OutputFormat
+}
+
+type RenderNodeResult struct {
+	// Content holds the rendered node: raw HTML for the html format,
+	// markdown source for the md and pdf formats.
+	Content []byte
+}
+
+// NodeRenderer renders custom AST nodes produced by the plugin's content providers.
+type NodeRenderer struct {
+	Doc string
+	// Formats lists supported output formats. If the md format is supported,
+	// it is used as a fallback for all other formats.
+	Formats    []OutputFormat
+	RenderFunc RenderNodeFunc
+}
+
+func (nr *NodeRenderer) Validate() diagnostics.Diag {
+	var diags diagnostics.Diag
+	if nr.RenderFunc == nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Incomplete NodeRenderer schema",
+			Detail:   "NodeRenderer function not loaded",
+		})
+	}
+	if len(nr.Formats) == 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Incomplete NodeRenderer schema",
+			Detail:   "No output formats defined",
+		})
+	}
+	return diags
+}
+
+func (nr *NodeRenderer) Execute(ctx context.Context, params *RenderNodeParams) (_ *RenderNodeResult, diags diagnostics.Diag) {
+	if nr == nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Missing NodeRenderer schema",
+		}}
+	}
+	if nr.RenderFunc == nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Incomplete NodeRenderer schema",
+			Detail:   "NodeRenderer function not loaded",
+		}}
+	}
+	return nr.RenderFunc(ctx, params)
+}
@@ -64,6 +64,12 @@ func (p *grpcPlugin) GRPCClient(ctx context.Context, broker *goplugin.GRPCBroker
 		}
 		pub.PublishFunc = p.clientPublishFunc(name, client)
 	}
+	for typeURL, nr := range schema.NodeRenderers {
+		if nr == nil {
+			return nil, fmt.Errorf("nil node renderer")
+		}
+		nr.RenderFunc = p.clientRenderNodeFunc(typeURL, client)
+	}
 	return schema, nil
 }
 
@@ -172,3 +178,31 @@ func (p *grpcPlugin) clientPublishFunc(name string, client PluginServiceClient)
 		return
 	}
 }
+
+func (p *grpcPlugin) clientRenderNodeFunc(typeURL string, client PluginServiceClient) plugin.RenderNodeFunc {
+	return func(ctx context.Context, params *plugin.RenderNodeParams) (result *plugin.RenderNodeResult, diags diagnostics.Diag) {
+		p.logger.DebugContext(ctx, "Calling node renderer", "type_url", typeURL)
+		defer func(start time.Time) {
+			p.logger.DebugContext(ctx, "Called node renderer", "type_url", typeURL, "took", time.Since(start))
+		}(time.Now())
+		if params == nil {
+			diags.Add("Node renderer error", "Nil params")
+			return
+		}
+		res, err := client.RenderNode(ctx, &RenderNodeRequest{
+			Node:   params.Node,
+			Inline: params.Inline,
+			Format: encodeOutputFormat(params.Format),
+		}, p.callOptions()...)
+		if diags.AppendErr(err, "Failed to render node") {
+			return
+		}
+		diags.Extend(decodeDiagnosticList(res.GetDiagnostics()))
+		if diags.HasErrors() {
+			return
+		}
+		return &plugin.RenderNodeResult{
+			Content: res.GetContent(),
+		}, diags
+	}
+}
@@ -23,6 +23,7 @@ const (
 	PluginService_RetrieveData_FullMethodName   = "/pluginapi.v1.PluginService/RetrieveData"
 	PluginService_ProvideContent_FullMethodName = "/pluginapi.v1.PluginService/ProvideContent"
 	PluginService_Publish_FullMethodName        = "/pluginapi.v1.PluginService/Publish"
+	PluginService_RenderNode_FullMethodName     = "/pluginapi.v1.PluginService/RenderNode"
 )
 
 // PluginServiceClient is the client API for PluginService service.
@@ -33,6 +34,7 @@ type PluginServiceClient interface {
 	RetrieveData(ctx context.Context, in *RetrieveDataRequest, opts ...grpc.CallOption) (*RetrieveDataResponse, error)
 	ProvideContent(ctx context.Context, in *ProvideContentRequest, opts ...grpc.CallOption) (*ProvideContentResponse, error)
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	RenderNode(ctx context.Context, in *RenderNodeRequest, opts ...grpc.CallOption) (*RenderNodeResponse, error)
 }
 
 type pluginServiceClient struct {
@@ -83,6 +85,16 @@ func (c *pluginServiceClient) Publish(ctx context.Context, in *PublishRequest, o
 	return out, nil
 }
 
+func (c *pluginServiceClient) RenderNode(ctx context.Context, in *RenderNodeRequest, opts ...grpc.CallOption) (*RenderNodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderNodeResponse)
+	err := c.cc.Invoke(ctx, PluginService_RenderNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginServiceServer is the server API for PluginService service.
 // All implementations must embed UnimplementedPluginServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type PluginServiceServer interface {
 	RetrieveData(context.Context, *RetrieveDataRequest) (*RetrieveDataResponse, error)
 	ProvideContent(context.Context, *ProvideContentRequest) (*ProvideContentResponse, error)
 	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	RenderNode(context.Context, *RenderNodeRequest) (*RenderNodeResponse, error)
 	mustEmbedUnimplementedPluginServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedPluginServiceServer) ProvideContent(context.Context, *Provide
 func (UnimplementedPluginServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
 }
+func (UnimplementedPluginServiceServer) RenderNode(context.Context, *RenderNodeRequest) (*RenderNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderNode not implemented")
+}
 func (UnimplementedPluginServiceServer) mustEmbedUnimplementedPluginServiceServer() {}
 func (UnimplementedPluginServiceServer) testEmbeddedByValue()                       {}
 
@@ -206,6 +222,24 @@ func _PluginService_Publish_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginService_RenderNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).RenderNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_RenderNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).RenderNode(ctx, req.(*RenderNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginService_ServiceDesc is the grpc.ServiceDesc for PluginService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +263,10 @@ var PluginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Publish",
 			Handler:    _PluginService_Publish_Handler,
 		},
+		{
+			MethodName: "RenderNode",
+			Handler:    _PluginService_RenderNode_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pluginapi/v1/plugin.proto",
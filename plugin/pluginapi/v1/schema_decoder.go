@@ -21,12 +21,14 @@ func decodeSchema(src *Schema) (*plugin.Schema, error) {
 	if err != nil {
 		return nil, err
 	}
+	nodeRenderers := decodeNodeRendererSchemaMap(src.GetNodeRenderers())
 	return &plugin.Schema{
 		Name:             src.GetName(),
 		Version:          src.GetVersion(),
 		DataSources:      dataSources,
 		ContentProviders: contentProviders,
 		Publishers:       publishers,
+		NodeRenderers:    nodeRenderers,
 		Doc:              src.GetDoc(),
 		Tags:             src.GetTags(),
 	}, nil
@@ -131,6 +133,27 @@ func decodePublisherSchema(src *PublisherSchema) (*plugin.Publisher, error) {
 	}, nil
 }
 
+func decodeNodeRendererSchemaMap(src map[string]*NodeRendererSchema) plugin.NodeRenderers {
+	if len(src) == 0 {
+		return nil
+	}
+	dst := make(plugin.NodeRenderers, len(src))
+	for k, v := range src {
+		dst[k] = decodeNodeRendererSchema(v)
+	}
+	return dst
+}
+
+func decodeNodeRendererSchema(src *NodeRendererSchema) *plugin.NodeRenderer {
+	if src == nil {
+		return nil
+	}
+	return &plugin.NodeRenderer{
+		Doc:     src.GetDoc(),
+		Formats: decodeOutputFormats(src.GetFormats()),
+	}
+}
+
 func decodeOutputFormats(src []OutputFormat) []plugin.OutputFormat {
 	dst := make([]plugin.OutputFormat, len(src))
 	for i, v := range src {
@@ -18,6 +18,7 @@ func encodeSchema(src *plugin.Schema) (*Schema, diagnostics.Diag) {
 		DataSources:      utils.MapMapDiags(&diags, src.DataSources, encodeDataSourceSchema),
 		ContentProviders: utils.MapMapDiags(&diags, src.ContentProviders, encodeContentProviderSchema),
 		Publishers:       utils.MapMapDiags(&diags, src.Publishers, encodePublisherShema),
+		NodeRenderers:    utils.MapMapDiags(&diags, src.NodeRenderers, encodeNodeRendererSchema),
 		Doc:              src.Doc,
 		Tags:             src.Tags,
 	}, diags
@@ -109,3 +110,13 @@ func encodePublisherShema(src *plugin.Publisher) (_ *PublisherSchema, diags diag
 	}
 	return schema, diags
 }
+
+func encodeNodeRendererSchema(src *plugin.NodeRenderer) (_ *NodeRendererSchema, diags diagnostics.Diag) {
+	if src == nil {
+		return nil, nil
+	}
+	return &NodeRendererSchema{
+		Doc:     src.Doc,
+		Formats: utils.FnMap(src.Formats, encodeOutputFormat),
+	}, nil
+}
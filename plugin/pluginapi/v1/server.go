@@ -141,3 +141,31 @@ func (srv *grpcServer) Publish(ctx context.Context, req *PublishRequest) (*Publi
 		Diagnostics: encodeDiagnosticList(diags),
 	}, nil
 }
+
+func (srv *grpcServer) RenderNode(ctx context.Context, req *RenderNodeRequest) (*RenderNodeResponse, error) {
+	slog.DebugContext(ctx, "RenderNode")
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "RenderNode done", "panic", r)
+			panic(r)
+		} else {
+			slog.DebugContext(ctx, "RenderNode done")
+		}
+	}()
+	node := req.GetNode()
+	if node == nil {
+		return nil, status.Error(codes.InvalidArgument, "node is required")
+	}
+	result, diags := srv.schema.RenderNode(ctx, node.GetTypeUrl(), &plugin.RenderNodeParams{
+		Node:   node,
+		Inline: req.GetInline(),
+		Format: decodeOutputFormat(req.GetFormat()),
+	})
+	res := &RenderNodeResponse{
+		Diagnostics: encodeDiagnosticList(diags),
+	}
+	if result != nil {
+		res.Content = result.Content
+	}
+	return res, nil
+}
@@ -0,0 +1,244 @@
+package plugindata
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiffOptions configure the comparison of two lists of objects.
+type DiffOptions struct {
+	// Key is the field identifying the objects in both lists. Nested fields are separated by dots.
+	Key string
+	// Fields are the compared fields. If empty, all top-level fields of the objects are compared.
+	Fields []string
+}
+
+// FieldChange is a changed value of a field.
+type FieldChange struct {
+	Field    string
+	Previous Data
+	Current  Data
+}
+
+// ChangedItem is an object present in both lists with different values of the compared fields.
+type ChangedItem struct {
+	Key      string
+	Previous Data
+	Current  Data
+	Changes  []FieldChange
+}
+
+// DiffResult is the difference between the previous and the current lists.
+type DiffResult struct {
+	// Added are the objects of the current list missing from the previous one.
+	Added List
+	// Removed are the objects of the previous list missing from the current one.
+	Removed List
+	// Changed are the objects present in both lists with different fields.
+	Changed []ChangedItem
+	// Unchanged are the objects of the current list equal to the previous ones.
+	Unchanged List
+}
+
+// Diff compares two lists of objects matched by the key field.
+// The objects keep the order of the lists they are taken from.
+func Diff(previous, current List, opts DiffOptions) (*DiffResult, error) {
+	if opts.Key == "" {
+		return nil, fmt.Errorf("key field is required")
+	}
+	prevKeys, prevIndex, err := indexDiffRows(previous, opts.Key)
+	if err != nil {
+		return nil, fmt.Errorf("previous list: %w", err)
+	}
+	currKeys, currIndex, err := indexDiffRows(current, opts.Key)
+	if err != nil {
+		return nil, fmt.Errorf("current list: %w", err)
+	}
+	res := &DiffResult{
+		Added:     List{},
+		Removed:   List{},
+		Changed:   []ChangedItem{},
+		Unchanged: List{},
+	}
+	for i, row := range current {
+		prev, found := prevIndex[currKeys[i]]
+		if !found {
+			res.Added = append(res.Added, row)
+			continue
+		}
+		changes := diffFields(prev, row, opts.Fields)
+		if len(changes) == 0 {
+			res.Unchanged = append(res.Unchanged, row)
+			continue
+		}
+		res.Changed = append(res.Changed, ChangedItem{
+			Key:      currKeys[i],
+			Previous: prev,
+			Current:  row,
+			Changes:  changes,
+		})
+	}
+	for i, row := range previous {
+		if _, found := currIndex[prevKeys[i]]; !found {
+			res.Removed = append(res.Removed, row)
+		}
+	}
+	return res, nil
+}
+
+// AsPluginData returns the result as a map with `added`, `removed`, `changed`, `unchanged`
+// lists and `summary` map with the number of objects in each list.
+func (r *DiffResult) AsPluginData() Data {
+	changed := make(List, len(r.Changed))
+	for i, item := range r.Changed {
+		changes := make(List, len(item.Changes))
+		for j, change := range item.Changes {
+			changes[j] = Map{
+				"field":    String(change.Field),
+				"previous": change.Previous,
+				"current":  change.Current,
+			}
+		}
+		changed[i] = Map{
+			"key":      String(item.Key),
+			"previous": item.Previous,
+			"current":  item.Current,
+			"changes":  changes,
+		}
+	}
+	return Map{
+		"added":     r.Added,
+		"removed":   r.Removed,
+		"changed":   changed,
+		"unchanged": r.Unchanged,
+		"summary": Map{
+			"added":     Number(len(r.Added)),
+			"removed":   Number(len(r.Removed)),
+			"changed":   Number(len(r.Changed)),
+			"unchanged": Number(len(r.Unchanged)),
+		},
+	}
+}
+
+// LookupField returns the value of the field of the object. Nested fields are separated by dots.
+func LookupField(row Data, path string) (Data, bool) {
+	cur := row
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(Map)
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func indexDiffRows(rows List, key string) ([]string, map[string]Data, error) {
+	keys := make([]string, len(rows))
+	index := make(map[string]Data, len(rows))
+	for i, row := range rows {
+		val, found := LookupField(row, key)
+		if !found || val == nil {
+			return nil, nil, fmt.Errorf("item %d: key field %q is missing", i+1, key)
+		}
+		k, err := diffKey(val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("item %d: key field %q: %w", i+1, key, err)
+		}
+		if _, found := index[k]; found {
+			return nil, nil, fmt.Errorf("item %d: duplicate key %q", i+1, k)
+		}
+		keys[i] = k
+		index[k] = row
+	}
+	return keys, index, nil
+}
+
+func diffKey(val Data) (string, error) {
+	switch val := val.(type) {
+	case String:
+		return string(val), nil
+	case Number:
+		return strconv.FormatFloat(float64(val), 'f', -1, 64), nil
+	case Bool:
+		return strconv.FormatBool(bool(val)), nil
+	case Time:
+		return time.Time(val).Format(time.RFC3339Nano), nil
+	}
+	return "", fmt.Errorf("key must be a string, number, bool or time")
+}
+
+func diffFields(prev, curr Data, fields []string) []FieldChange {
+	if len(fields) == 0 {
+		fields = objectFields(prev, curr)
+	}
+	var changes []FieldChange
+	for _, field := range fields {
+		prevVal, _ := LookupField(prev, field)
+		currVal, _ := LookupField(curr, field)
+		if !equalData(prevVal, currVal) {
+			changes = append(changes, FieldChange{
+				Field:    field,
+				Previous: prevVal,
+				Current:  currVal,
+			})
+		}
+	}
+	return changes
+}
+
+// objectFields returns the sorted top-level fields of the objects.
+func objectFields(rows ...Data) []string {
+	var fields []string
+	for _, row := range rows {
+		m, ok := row.(Map)
+		if !ok {
+			continue
+		}
+		for k := range m {
+			if !slices.Contains(fields, k) {
+				fields = append(fields, k)
+			}
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+func equalData(a, b Data) bool {
+	switch a := a.(type) {
+	case Map:
+		b, ok := b.(Map)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			bv, found := b[k]
+			if !found || !equalData(v, bv) {
+				return false
+			}
+		}
+		return true
+	case List:
+		b, ok := b.(List)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalData(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case Time:
+		b, ok := b.(Time)
+		return ok && time.Time(a).Equal(time.Time(b))
+	}
+	return a == b
+}
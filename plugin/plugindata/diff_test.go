@@ -0,0 +1,87 @@
+package plugindata_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin/plugindata"
+)
+
+func TestDiff(t *testing.T) {
+	previous := plugindata.List{
+		plugindata.Map{"id": plugindata.Number(1), "status": plugindata.String("open"), "score": plugindata.Number(5)},
+		plugindata.Map{"id": plugindata.Number(2), "status": plugindata.String("open"), "score": plugindata.Number(7)},
+		plugindata.Map{"id": plugindata.Number(3), "status": plugindata.String("open"), "score": plugindata.Number(9)},
+	}
+	current := plugindata.List{
+		plugindata.Map{"id": plugindata.Number(4), "status": plugindata.String("open"), "score": plugindata.Number(1)},
+		plugindata.Map{"id": plugindata.Number(3), "status": plugindata.String("closed"), "score": plugindata.Number(8)},
+		plugindata.Map{"id": plugindata.Number(2), "status": plugindata.String("open"), "score": plugindata.Number(7)},
+	}
+
+	res, err := plugindata.Diff(previous, current, plugindata.DiffOptions{Key: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, plugindata.List{current[0]}, res.Added)
+	assert.Equal(t, plugindata.List{previous[0]}, res.Removed)
+	assert.Equal(t, plugindata.List{current[2]}, res.Unchanged)
+	assert.Equal(t, []plugindata.ChangedItem{{
+		Key:      "3",
+		Previous: previous[2],
+		Current:  current[1],
+		Changes: []plugindata.FieldChange{
+			{Field: "score", Previous: plugindata.Number(9), Current: plugindata.Number(8)},
+			{Field: "status", Previous: plugindata.String("open"), Current: plugindata.String("closed")},
+		},
+	}}, res.Changed)
+
+	res, err = plugindata.Diff(previous, current, plugindata.DiffOptions{Key: "id", Fields: []string{"status"}})
+	require.NoError(t, err)
+	require.Len(t, res.Changed, 1)
+	assert.Equal(t, []plugindata.FieldChange{
+		{Field: "status", Previous: plugindata.String("open"), Current: plugindata.String("closed")},
+	}, res.Changed[0].Changes)
+
+	assert.Equal(t, plugindata.Map{
+		"added":     plugindata.Number(1),
+		"removed":   plugindata.Number(1),
+		"changed":   plugindata.Number(1),
+		"unchanged": plugindata.Number(1),
+	}, res.AsPluginData().(plugindata.Map)["summary"])
+}
+
+func TestDiffNestedKey(t *testing.T) {
+	row := func(host, severity string) plugindata.Data {
+		return plugindata.Map{
+			"asset":    plugindata.Map{"host": plugindata.String(host)},
+			"severity": plugindata.String(severity),
+		}
+	}
+	res, err := plugindata.Diff(
+		plugindata.List{row("a", "low"), row("b", "high")},
+		plugindata.List{row("b", "high"), row("a", "high")},
+		plugindata.DiffOptions{Key: "asset.host"},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, res.Added)
+	assert.Empty(t, res.Removed)
+	require.Len(t, res.Changed, 1)
+	assert.Equal(t, "a", res.Changed[0].Key)
+	assert.Equal(t, plugindata.List{row("b", "high")}, res.Unchanged)
+}
+
+func TestDiffErrors(t *testing.T) {
+	_, err := plugindata.Diff(
+		plugindata.List{plugindata.Map{"id": plugindata.String("a")}, plugindata.Map{"id": plugindata.String("a")}},
+		nil,
+		plugindata.DiffOptions{Key: "id"},
+	)
+	assert.EqualError(t, err, `previous list: item 2: duplicate key "a"`)
+
+	_, err = plugindata.Diff(nil, plugindata.List{plugindata.Map{}}, plugindata.DiffOptions{Key: "id"})
+	assert.EqualError(t, err, `current list: item 1: key field "id" is missing`)
+
+	_, err = plugindata.Diff(nil, plugindata.List{plugindata.Map{"id": plugindata.List{}}}, plugindata.DiffOptions{Key: "id"})
+	assert.EqualError(t, err, `current list: item 1: key field "id": key must be a string, number, bool or time`)
+}
@@ -36,6 +36,11 @@ type loadedPublisher struct {
 	*plugin.Publisher
 }
 
+type loadedNodeRenderer struct {
+	plugin *plugin.Schema
+	*plugin.NodeRenderer
+}
+
 type loader struct {
 	logger       *slog.Logger
 	tracer       trace.Tracer
@@ -45,6 +50,7 @@ type loader struct {
 	dataMap      map[string]loadedDataSource
 	contentMap   map[string]loadedContentProvider
 	publisherMap map[string]loadedPublisher
+	rendererMap  map[string]loadedNodeRenderer
 }
 
 func makeLoader(
@@ -62,6 +68,7 @@ func makeLoader(
 		dataMap:      make(map[string]loadedDataSource),
 		contentMap:   make(map[string]loadedContentProvider),
 		publisherMap: make(map[string]loadedPublisher),
+		rendererMap:  make(map[string]loadedNodeRenderer),
 	}
 }
 
@@ -145,6 +152,7 @@ func (l *loader) registerContentProvider(ctx context.Context, name string, schem
 				return schema.ProvideContent(ctx, name, params)
 			},
 			InvocationOrder: cp.InvocationOrder,
+			DocumentArg:     cp.DocumentArg,
 		},
 	}
 	return nil
@@ -166,6 +174,28 @@ func (l *loader) registerPublisher(ctx context.Context, name string, schema *plu
 	return nil
 }
 
+func (l *loader) registerNodeRenderer(ctx context.Context, typeURL string, schema *plugin.Schema, nr *plugin.NodeRenderer) diagnostics.Diag {
+	l.logger.DebugContext(ctx, "Registering node renderer", "type_url", typeURL, "plugin", schema.Name, "version", schema.Version)
+	if found, has := l.rendererMap[typeURL]; has {
+		return diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Duplicate node renderer",
+			Detail:   fmt.Sprintf("Node renderer for %s provided by plugin %s@%s and %s@%s", typeURL, schema.Name, schema.Version, found.plugin.Name, found.plugin.Version),
+		}}
+	}
+	l.rendererMap[typeURL] = loadedNodeRenderer{
+		plugin: schema,
+		NodeRenderer: &plugin.NodeRenderer{
+			Doc:     nr.Doc,
+			Formats: nr.Formats,
+			RenderFunc: func(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+				return schema.RenderNode(ctx, typeURL, params)
+			},
+		},
+	}
+	return nil
+}
+
 func (l *loader) registerPlugin(ctx context.Context, schema *plugin.Schema, closefn func() error) diagnostics.Diag {
 	l.logger.DebugContext(ctx, "Registering a plugin", "name", schema.Name, "version", schema.Version)
 	if diags := schema.Validate(); diags.HasErrors() {
@@ -210,6 +240,11 @@ func (l *loader) registerPlugin(ctx context.Context, schema *plugin.Schema, clos
 			return diags
 		}
 	}
+	for typeURL, renderer := range schema.NodeRenderers {
+		if diags := l.registerNodeRenderer(ctx, typeURL, schema, renderer); diags.HasErrors() {
+			return diags
+		}
+	}
 	return nil
 }
 
@@ -18,6 +18,7 @@ type Runner struct {
 	dataMap      map[string]loadedDataSource
 	contentMap   map[string]loadedContentProvider
 	publisherMap map[string]loadedPublisher
+	rendererMap  map[string]loadedNodeRenderer
 }
 
 func Load(
@@ -46,6 +47,7 @@ func Load(
 		dataMap:      loader.dataMap,
 		contentMap:   loader.contentMap,
 		publisherMap: loader.publisherMap,
+		rendererMap:  loader.rendererMap,
 	}, nil
 }
 
@@ -89,6 +91,15 @@ func (m *Runner) Publisher(name string) (*plugin.Publisher, bool) {
 	return publisher.Publisher, true
 }
 
+// NodeRenderers returns renderers for custom AST nodes provided by all loaded plugins.
+func (m *Runner) NodeRenderers() plugin.NodeRenderers {
+	renderers := make(plugin.NodeRenderers, len(m.rendererMap))
+	for typeURL, renderer := range m.rendererMap {
+		renderers[typeURL] = renderer.NodeRenderer
+	}
+	return renderers
+}
+
 func (m *Runner) Close() diagnostics.Diag {
 	var diags diagnostics.Diag
 	for _, p := range m.pluginMap {
@@ -20,6 +20,7 @@ type Schema struct {
 	DataSources      DataSources
 	ContentProviders ContentProviders
 	Publishers       Publishers
+	NodeRenderers    NodeRenderers
 }
 
 func (p *Schema) Validate() diagnostics.Diag {
@@ -40,11 +41,14 @@ func (p *Schema) Validate() diagnostics.Diag {
 	if p.Publishers != nil {
 		diags = append(diags, p.Publishers.Validate()...)
 	}
-	if p.DataSources == nil && p.ContentProviders == nil && p.Publishers == nil {
+	if p.NodeRenderers != nil {
+		diags = append(diags, p.NodeRenderers.Validate()...)
+	}
+	if p.DataSources == nil && p.ContentProviders == nil && p.Publishers == nil && p.NodeRenderers == nil {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Incomplete PluginSchema",
-			Detail:   "No data sources, content providers or publishers defined",
+			Detail:   "No data sources, content providers, publishers or node renderers defined",
 		})
 	}
 	return diags
@@ -144,3 +148,36 @@ func (p *Schema) Publish(ctx context.Context, name string, params *PublishParams
 	}
 	return publisher.Execute(ctx, params)
 }
+
+func (p *Schema) RenderNode(ctx context.Context, typeURL string, params *RenderNodeParams) (_ *RenderNodeResult, diags diagnostics.Diag) {
+	if p == nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "No schema",
+			Detail:   "No schema defined",
+		}}
+	}
+	if p.NodeRenderers == nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "No node renderers",
+			Detail:   "No node renderers defined in schema",
+		}}
+	}
+	renderer, ok := p.NodeRenderers[typeURL]
+	if !ok || renderer == nil {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Node renderer not found",
+			Detail:   fmt.Sprintf("Node renderer for '%s' not found in schema", typeURL),
+		}}
+	}
+	if !slices.Contains(renderer.Formats, params.Format) {
+		return nil, diagnostics.Diag{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid format",
+			Detail:   fmt.Sprintf("Node renderer for '%s' does not support format '%s'", typeURL, params.Format),
+		}}
+	}
+	return renderer.Execute(ctx, params)
+}
@@ -16,6 +16,7 @@ func WithTracing(plugin *Schema, tracer trace.Tracer) *Schema {
 	plugin.ContentProviders = makeContentProvidersTracing(plugin.Name, plugin.ContentProviders, tracer)
 	plugin.DataSources = makeDataSourcesTracing(plugin.Name, plugin.DataSources, tracer)
 	plugin.Publishers = makePublishersTracing(plugin.Name, plugin.Publishers, tracer)
+	plugin.NodeRenderers = makeNodeRenderersTracing(plugin.Name, plugin.NodeRenderers, tracer)
 	return plugin
 }
 
@@ -99,3 +100,34 @@ func makePublisherTracing(plugin, name string, publisher *Publisher, tracer trac
 		return next(ctx, params)
 	}
 }
+
+func makeNodeRenderersTracing(plugin string, renderers NodeRenderers, tracer trace.Tracer) NodeRenderers {
+	if renderers == nil {
+		return nil
+	}
+	result := make(NodeRenderers)
+	for typeURL, renderer := range renderers {
+		renderer.RenderFunc = makeNodeRendererTracing(plugin, typeURL, renderer, tracer)
+		result[typeURL] = renderer
+	}
+	return result
+}
+
+func makeNodeRendererTracing(plugin, typeURL string, renderer *NodeRenderer, tracer trace.Tracer) RenderNodeFunc {
+	next := renderer.RenderFunc
+	return func(ctx context.Context, params *RenderNodeParams) (_ *RenderNodeResult, diags diagnostics.Diag) {
+		ctx, span := tracer.Start(ctx, "NodeRenderer.Execute", trace.WithAttributes(
+			attribute.String("plugin", plugin),
+			attribute.String("type_url", typeURL),
+			attribute.String("format", params.Format.String()),
+		))
+		defer func() {
+			if diags.HasErrors() {
+				span.RecordError(diags)
+				span.SetStatus(codes.Error, diags.Error())
+			}
+			span.End()
+		}()
+		return next(ctx, params)
+	}
+}
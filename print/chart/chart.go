@@ -0,0 +1,114 @@
+// Package chart renders simple bar, line, pie and stacked area charts
+// as SVG and PNG images.
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Type is the type of the chart.
+type Type string
+
+const (
+	TypeBar         Type = "bar"
+	TypeLine        Type = "line"
+	TypePie         Type = "pie"
+	TypeStackedArea Type = "stacked_area"
+)
+
+// Types lists the supported chart types.
+var Types = []Type{TypeBar, TypeLine, TypePie, TypeStackedArea}
+
+const (
+	DefaultWidth  = 640
+	DefaultHeight = 360
+)
+
+// Chart describes the chart to render.
+type Chart struct {
+	Type   Type   `json:"type"`
+	Title  string `json:"title,omitempty"`
+	XLabel string `json:"x_label,omitempty"`
+	YLabel string `json:"y_label,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	// Categories are the values of the x axis, or the slices of the pie chart.
+	Categories []string  `json:"categories"`
+	Series     []*Series `json:"series"`
+}
+
+// Series is a named set of values, one per category. Missing values are nil.
+// Pie charts use only the first series.
+type Series struct {
+	Name   string     `json:"name,omitempty"`
+	Values []*float64 `json:"values"`
+}
+
+// Value returns the value for the category index, missing values are NaN.
+func (s *Series) Value(i int) float64 {
+	if i >= len(s.Values) || s.Values[i] == nil {
+		return math.NaN()
+	}
+	return *s.Values[i]
+}
+
+// Validate checks that the chart can be rendered.
+func (c *Chart) Validate() error {
+	switch c.Type {
+	case TypeBar, TypeLine, TypePie, TypeStackedArea:
+	default:
+		return fmt.Errorf("unsupported chart type %q", c.Type)
+	}
+	if len(c.Categories) == 0 || len(c.Series) == 0 {
+		return errors.New("chart has no data")
+	}
+	if c.Width < 0 || c.Height < 0 {
+		return errors.New("chart size must be positive")
+	}
+	return nil
+}
+
+func (c *Chart) size() (w, h float64) {
+	w, h = DefaultWidth, DefaultHeight
+	if c.Width > 0 {
+		w = float64(c.Width)
+	}
+	if c.Height > 0 {
+		h = float64(c.Height)
+	}
+	return w, h
+}
+
+// Table returns the chart data as a table: the category column followed by a column per series.
+func (c *Chart) Table() (header []string, rows [][]string) {
+	header = []string{c.XLabel}
+	if header[0] == "" {
+		header[0] = "Category"
+	}
+	for _, s := range c.Series {
+		name := s.Name
+		if name == "" {
+			name = c.YLabel
+		}
+		if name == "" {
+			name = "Value"
+		}
+		header = append(header, name)
+	}
+	for i, category := range c.Categories {
+		row := []string{category}
+		for _, s := range c.Series {
+			v := s.Value(i)
+			if math.IsNaN(v) {
+				row = append(row, "")
+			} else {
+				row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
@@ -0,0 +1,100 @@
+package chart_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/print/chart"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func testChart(typ chart.Type) *chart.Chart {
+	return &chart.Chart{
+		Type:       typ,
+		Title:      "Alerts <per day>",
+		XLabel:     "Day",
+		YLabel:     "Alerts",
+		Categories: []string{"Mon", "Tue", "Wed", "Thu"},
+		Series: []*chart.Series{
+			{Name: "high", Values: []*float64{ptr(3), ptr(5), nil, ptr(2)}},
+			{Name: "low", Values: []*float64{ptr(12), ptr(7), ptr(9), ptr(15000)}},
+		},
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	for _, typ := range chart.Types {
+		t.Run(string(typ), func(t *testing.T) {
+			svg, err := chart.RenderSVG(testChart(typ))
+			require.NoError(t, err)
+			assert.NotContains(t, string(svg), "\n\n")
+			assert.Contains(t, string(svg), "Alerts &lt;per day&gt;")
+			// the output is well-formed xml
+			dec := xml.NewDecoder(bytes.NewReader(svg))
+			for {
+				_, err := dec.Token()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRenderSVGContent(t *testing.T) {
+	svg, err := chart.RenderSVG(testChart(chart.TypeBar))
+	require.NoError(t, err)
+	// legend and compact tick labels
+	assert.Contains(t, string(svg), ">high</text>")
+	assert.Contains(t, string(svg), ">15k</text>")
+	// 7 bars, missing value is skipped, plus background and 2 legend swatches
+	assert.Equal(t, 10, strings.Count(string(svg), "<rect "))
+
+	svg, err = chart.RenderSVG(testChart(chart.TypePie))
+	require.NoError(t, err)
+	// slices of the first series, legend lists the categories
+	assert.Equal(t, 3, strings.Count(string(svg), "<polygon "))
+	assert.Contains(t, string(svg), ">Thu</text>")
+	assert.Contains(t, string(svg), ">50%</text>")
+}
+
+func TestRenderPNG(t *testing.T) {
+	c := testChart(chart.TypeStackedArea)
+	c.Width = 300
+	c.Height = 200
+	data, err := chart.RenderPNG(c)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 600, img.Bounds().Dx())
+	assert.Equal(t, 400, img.Bounds().Dy())
+}
+
+func TestValidate(t *testing.T) {
+	_, err := chart.RenderSVG(&chart.Chart{Type: "radar", Categories: []string{"a"}, Series: []*chart.Series{{}}})
+	assert.ErrorContains(t, err, `unsupported chart type "radar"`)
+	_, err = chart.RenderPNG(&chart.Chart{Type: chart.TypeLine})
+	assert.ErrorContains(t, err, "chart has no data")
+}
+
+func TestTable(t *testing.T) {
+	header, rows := testChart(chart.TypeLine).Table()
+	assert.Equal(t, []string{"Day", "high", "low"}, header)
+	assert.Equal(t, [][]string{
+		{"Mon", "3", "12"},
+		{"Tue", "5", "7"},
+		{"Wed", "", "9"},
+		{"Thu", "2", "15000"},
+	}, rows)
+}
@@ -0,0 +1,376 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+const (
+	fontSize      = 12.0
+	titleFontSize = 16.0
+	margin        = 12.0
+	legendSwatch  = 10.0
+	legendGap     = 16.0
+	legendRow     = 18.0
+	tickLength    = 4.0
+	maxTicks      = 6
+)
+
+const (
+	axisColor  = "#666666"
+	gridColor  = "#e5e5e5"
+	textColor  = "#1f1f1f"
+	labelColor = "#555555"
+)
+
+// palette is the colors of the series (or the pie slices), repeated if there are more.
+var palette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2",
+	"#59a14f", "#edc948", "#b07aa1", "#ff9da7",
+}
+
+func seriesColor(i int) string {
+	return palette[i%len(palette)]
+}
+
+// fontFace is used to measure the text.
+var fontFace = sync.OnceValues(func() (font.Face, error) {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+})
+
+type point struct {
+	X, Y float64
+}
+
+type anchor string
+
+const (
+	anchorStart  anchor = "start"
+	anchorMiddle anchor = "middle"
+	anchorEnd    anchor = "end"
+)
+
+type textStyle struct {
+	Anchor anchor
+	Size   float64
+	Bold   bool
+	Color  string
+}
+
+// canvas is implemented by the SVG and the raster backends.
+type canvas interface {
+	rect(x, y, w, h float64, fill string)
+	polyline(pts []point, stroke string, width float64)
+	polygon(pts []point, fill string, opacity float64)
+	circle(x, y, r float64, fill string)
+	// text draws the text with the baseline at y.
+	text(s string, x, y float64, style textStyle)
+}
+
+func measure(face font.Face, s string, size float64) float64 {
+	return float64(font.MeasureString(face, s)) / 64 * size / fontSize
+}
+
+// drawChart lays out the chart and draws it on the canvas.
+func drawChart(c *Chart, cv canvas) error {
+	face, err := fontFace()
+	if err != nil {
+		return err
+	}
+	w, h := c.size()
+	cv.rect(0, 0, w, h, "#ffffff")
+	top := margin
+	if c.Title != "" {
+		cv.text(c.Title, w/2, top+titleFontSize, textStyle{Anchor: anchorMiddle, Size: titleFontSize, Bold: true, Color: textColor})
+		top += titleFontSize + margin
+	}
+	names, showLegend := legendNames(c)
+	bottom := h - margin
+	if showLegend {
+		rows := legendRows(face, names, w-2*margin)
+		for i, row := range rows {
+			y := bottom - float64(len(rows)-i-1)*legendRow
+			x := (w - row.width) / 2
+			for _, item := range row.items {
+				cv.rect(x, y-legendSwatch, legendSwatch, legendSwatch, seriesColor(item))
+				cv.text(names[item], x+legendSwatch+4, y, textStyle{Anchor: anchorStart, Size: fontSize, Color: textColor})
+				x += legendSwatch + 4 + measure(face, names[item], fontSize) + legendGap
+			}
+		}
+		bottom -= float64(len(rows))*legendRow + margin/2
+	}
+	area := box{X: margin, Y: top, W: w - 2*margin, H: bottom - top}
+	if area.W <= 0 || area.H <= 0 {
+		return nil
+	}
+	if c.Type == TypePie {
+		drawPie(c, cv, area)
+		return nil
+	}
+	drawXY(c, cv, face, area)
+	return nil
+}
+
+type box struct {
+	X, Y, W, H float64
+}
+
+// legendNames returns the legend entries: the categories of a pie chart or the names of the series.
+func legendNames(c *Chart) ([]string, bool) {
+	if c.Type == TypePie {
+		return c.Categories, true
+	}
+	names := make([]string, len(c.Series))
+	for i, s := range c.Series {
+		names[i] = s.Name
+	}
+	return names, len(names) > 1 || (len(names) == 1 && names[0] != "")
+}
+
+type legendLine struct {
+	items []int
+	width float64
+}
+
+func legendRows(face font.Face, names []string, maxWidth float64) []legendLine {
+	var rows []legendLine
+	var cur legendLine
+	for i, name := range names {
+		itemW := legendSwatch + 4 + measure(face, name, fontSize)
+		if len(cur.items) > 0 && cur.width+legendGap+itemW > maxWidth {
+			rows = append(rows, cur)
+			cur = legendLine{}
+		}
+		if len(cur.items) > 0 {
+			cur.width += legendGap
+		}
+		cur.items = append(cur.items, i)
+		cur.width += itemW
+	}
+	if len(cur.items) > 0 {
+		rows = append(rows, cur)
+	}
+	return rows
+}
+
+func drawPie(c *Chart, cv canvas, area box) {
+	s := c.Series[0]
+	var total float64
+	for i := range c.Categories {
+		if v := s.Value(i); v > 0 {
+			total += v
+		}
+	}
+	if total == 0 {
+		return
+	}
+	cx, cy := area.X+area.W/2, area.Y+area.H/2
+	r := math.Min(area.W, area.H) / 2
+	angle := -math.Pi / 2
+	for i := range c.Categories {
+		v := s.Value(i)
+		if !(v > 0) {
+			continue
+		}
+		sweep := 2 * math.Pi * v / total
+		pts := []point{{cx, cy}}
+		segments := max(2, int(math.Ceil(sweep/(math.Pi/90))))
+		for j := 0; j <= segments; j++ {
+			a := angle + sweep*float64(j)/float64(segments)
+			pts = append(pts, point{cx + r*math.Cos(a), cy + r*math.Sin(a)})
+		}
+		cv.polygon(pts, seriesColor(i), 1)
+		if share := v / total; share >= 0.05 {
+			mid := angle + sweep/2
+			label := strconv.FormatFloat(math.Round(share*1000)/10, 'f', -1, 64) + "%"
+			cv.text(label, cx+r*0.65*math.Cos(mid), cy+r*0.65*math.Sin(mid)+fontSize/3, textStyle{
+				Anchor: anchorMiddle, Size: fontSize, Bold: true, Color: "#ffffff",
+			})
+		}
+		angle += sweep
+	}
+}
+
+func drawXY(c *Chart, cv canvas, face font.Face, area box) {
+	lo, hi := valueRange(c)
+	ticks := niceTicks(lo, hi)
+	lo, hi = ticks[0], ticks[len(ticks)-1]
+	tickLabels := make([]string, len(ticks))
+	labelW := 0.0
+	for i, t := range ticks {
+		tickLabels[i] = formatTick(t)
+		labelW = math.Max(labelW, measure(face, tickLabels[i], fontSize))
+	}
+	if c.YLabel != "" {
+		cv.text(c.YLabel, area.X, area.Y+fontSize, textStyle{Anchor: anchorStart, Size: fontSize, Color: labelColor})
+		area.Y += fontSize + margin
+		area.H -= fontSize + margin
+	}
+	// space for the category labels and the x axis label below the plot
+	below := fontSize + tickLength + 4
+	if c.XLabel != "" {
+		below += fontSize + margin/2
+	}
+	plot := box{X: area.X + labelW + tickLength + 4, Y: area.Y, W: area.W - labelW - tickLength - 4, H: area.H - below}
+	if plot.W <= 0 || plot.H <= 0 {
+		return
+	}
+	y := func(v float64) float64 {
+		return plot.Y + plot.H - (v-lo)/(hi-lo)*plot.H
+	}
+	for i, t := range ticks {
+		ty := y(t)
+		cv.polyline([]point{{plot.X, ty}, {plot.X + plot.W, ty}}, gridColor, 1)
+		cv.text(tickLabels[i], plot.X-tickLength-2, ty+fontSize/3, textStyle{Anchor: anchorEnd, Size: fontSize, Color: labelColor})
+	}
+	n := len(c.Categories)
+	band := plot.W / float64(n)
+	// line and area charts place the points at the band centers as well, to share the label placement
+	x := func(i int) float64 {
+		return plot.X + band*(float64(i)+0.5)
+	}
+	// skip the category labels if they don't fit
+	step := 1
+	for _, category := range c.Categories {
+		for measure(face, category, fontSize)+8 > band*float64(step) && step < n {
+			step++
+		}
+	}
+	for i := 0; i < n; i += step {
+		cv.text(c.Categories[i], x(i), plot.Y+plot.H+tickLength+fontSize+2, textStyle{Anchor: anchorMiddle, Size: fontSize, Color: labelColor})
+	}
+	if c.XLabel != "" {
+		cv.text(c.XLabel, plot.X+plot.W/2, area.Y+area.H, textStyle{Anchor: anchorMiddle, Size: fontSize, Color: labelColor})
+	}
+	switch c.Type {
+	case TypeBar:
+		groupW := band * 0.8
+		barW := groupW / float64(len(c.Series))
+		for si, s := range c.Series {
+			for i := range c.Categories {
+				v := s.Value(i)
+				if math.IsNaN(v) {
+					continue
+				}
+				y0, y1 := y(math.Max(lo, math.Min(0, hi))), y(v)
+				cv.rect(x(i)-groupW/2+float64(si)*barW, math.Min(y0, y1), barW, math.Abs(y1-y0), seriesColor(si))
+			}
+		}
+	case TypeLine:
+		for si, s := range c.Series {
+			var run []point
+			flush := func() {
+				if len(run) > 1 {
+					cv.polyline(run, seriesColor(si), 2)
+				}
+				run = nil
+			}
+			for i := range c.Categories {
+				v := s.Value(i)
+				if math.IsNaN(v) {
+					flush()
+					continue
+				}
+				run = append(run, point{x(i), y(v)})
+				cv.circle(x(i), y(v), 3, seriesColor(si))
+			}
+			flush()
+		}
+	case TypeStackedArea:
+		base := make([]float64, n)
+		for si, s := range c.Series {
+			upper := make([]point, n)
+			lower := make([]point, n)
+			for i := range c.Categories {
+				lower[i] = point{x(i), y(base[i])}
+				if v := s.Value(i); !math.IsNaN(v) {
+					base[i] += v
+				}
+				upper[i] = point{x(i), y(base[i])}
+			}
+			pts := append([]point{}, upper...)
+			for i := n - 1; i >= 0; i-- {
+				pts = append(pts, lower[i])
+			}
+			cv.polygon(pts, seriesColor(si), 0.6)
+			cv.polyline(upper, seriesColor(si), 1.5)
+		}
+	}
+	cv.polyline([]point{{plot.X, plot.Y}, {plot.X, plot.Y + plot.H}, {plot.X + plot.W, plot.Y + plot.H}}, axisColor, 1)
+}
+
+// valueRange returns the range of the values to plot, always including zero.
+func valueRange(c *Chart) (lo, hi float64) {
+	if c.Type == TypeStackedArea {
+		for i := range c.Categories {
+			var pos, neg float64
+			for _, s := range c.Series {
+				if v := s.Value(i); v > 0 {
+					pos += v
+				} else if v < 0 {
+					neg += v
+				}
+			}
+			lo, hi = math.Min(lo, neg), math.Max(hi, pos)
+		}
+		return lo, hi
+	}
+	for _, s := range c.Series {
+		for i := range c.Categories {
+			if v := s.Value(i); !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	return lo, hi
+}
+
+// niceTicks returns round tick values covering the range.
+func niceTicks(lo, hi float64) []float64 {
+	if hi-lo == 0 {
+		hi = lo + 1
+	}
+	raw := (hi - lo) / (maxTicks - 1)
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := mag * 10
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if mag*m >= raw {
+			step = mag * m
+			break
+		}
+	}
+	start := math.Floor(lo/step) * step
+	end := math.Ceil(hi/step) * step
+	var ticks []float64
+	for v := start; v <= end+step/2; v += step {
+		// avoid the accumulated float errors in the labels
+		ticks = append(ticks, math.Round(v/step)*step)
+	}
+	return ticks
+}
+
+func formatTick(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1e9:
+		return strconv.FormatFloat(v/1e9, 'f', -1, 64) + "B"
+	case abs >= 1e6:
+		return strconv.FormatFloat(v/1e6, 'f', -1, 64) + "M"
+	case abs >= 1e4:
+		return strconv.FormatFloat(v/1e3, 'f', -1, 64) + "k"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,84 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/blackstork-io/fabric/print/raster"
+)
+
+// RenderPNG renders the chart as a PNG image, for the formats that can't embed SVG.
+func RenderPNG(c *Chart) ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	w, h := c.size()
+	cv := rasterCanvas{raster.New(w, h)}
+	defer cv.Close()
+	if err := drawChart(c, cv); err != nil {
+		return nil, err
+	}
+	return cv.PNG()
+}
+
+// rasterCanvas adapts raster.Canvas to the canvas interface.
+type rasterCanvas struct {
+	*raster.Canvas
+}
+
+func (cv rasterCanvas) rect(x, y, w, h float64, fill string) {
+	cv.Fill([]raster.Point{{X: x, Y: y}, {X: x + w, Y: y}, {X: x + w, Y: y + h}, {X: x, Y: y + h}}, parseColor(fill, 1))
+}
+
+func (cv rasterCanvas) polyline(pts []point, stroke string, width float64) {
+	col := parseColor(stroke, 1)
+	for i := 1; i < len(pts); i++ {
+		cv.Line(raster.Point(pts[i-1]), raster.Point(pts[i]), width, col)
+	}
+}
+
+func (cv rasterCanvas) polygon(pts []point, fill string, opacity float64) {
+	cv.Fill(rasterPoints(pts), parseColor(fill, opacity))
+}
+
+func (cv rasterCanvas) circle(x, y, r float64, fill string) {
+	const segments = 16
+	pts := make([]raster.Point, segments)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / segments
+		pts[i] = raster.Point{X: x + r*math.Cos(a), Y: y + r*math.Sin(a)}
+	}
+	cv.Fill(pts, parseColor(fill, 1))
+}
+
+func rasterPoints(pts []point) []raster.Point {
+	res := make([]raster.Point, len(pts))
+	for i, p := range pts {
+		res[i] = raster.Point(p)
+	}
+	return res
+}
+
+func (cv rasterCanvas) text(s string, x, y float64, style textStyle) {
+	anchor := raster.AnchorStart
+	switch style.Anchor {
+	case anchorMiddle:
+		anchor = raster.AnchorMiddle
+	case anchorEnd:
+		anchor = raster.AnchorEnd
+	}
+	cv.Text(s, x, y, style.Size, style.Bold, anchor, parseColor(style.Color, 1))
+}
+
+// parseColor parses the "#rrggbb" color, opacity is premultiplied.
+func parseColor(s string, opacity float64) color.Color {
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil || len(s) != 7 {
+		return color.Black
+	}
+	scale := func(c uint64) uint8 {
+		return uint8(math.Round(float64(c&0xff) * opacity))
+	}
+	return color.RGBA{scale(v >> 16), scale(v >> 8), scale(v), uint8(math.Round(255 * opacity))}
+}
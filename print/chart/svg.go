@@ -0,0 +1,79 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+)
+
+// RenderSVG renders the chart as a standalone SVG image.
+// The output contains no blank lines, so it can be embedded in markdown as an HTML block.
+func RenderSVG(c *Chart) ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	w, h := c.size()
+	cv := &svgCanvas{}
+	fmt.Fprintf(
+		&cv.buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Go, Helvetica, Arial, sans-serif" font-size="%.0f">`+"\n",
+		math.Ceil(w), math.Ceil(h), math.Ceil(w), math.Ceil(h), fontSize,
+	)
+	if c.Title != "" {
+		fmt.Fprintf(&cv.buf, "<title>%s</title>\n", html.EscapeString(c.Title))
+	}
+	if err := drawChart(c, cv); err != nil {
+		return nil, err
+	}
+	cv.buf.WriteString("</svg>")
+	return cv.buf.Bytes(), nil
+}
+
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+func (cv *svgCanvas) rect(x, y, w, h float64, fill string) {
+	fmt.Fprintf(&cv.buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, w, h, fill)
+}
+
+func (cv *svgCanvas) writePoints(pts []point) {
+	for i, p := range pts {
+		if i > 0 {
+			cv.buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&cv.buf, "%.1f,%.1f", p.X, p.Y)
+	}
+}
+
+func (cv *svgCanvas) polyline(pts []point, stroke string, width float64) {
+	fmt.Fprintf(&cv.buf, `<polyline fill="none" stroke="%s" stroke-width="%g" stroke-linejoin="round" points="`, stroke, width)
+	cv.writePoints(pts)
+	cv.buf.WriteString("\"/>\n")
+}
+
+func (cv *svgCanvas) polygon(pts []point, fill string, opacity float64) {
+	fmt.Fprintf(&cv.buf, `<polygon fill="%s"`, fill)
+	if opacity < 1 {
+		fmt.Fprintf(&cv.buf, ` fill-opacity="%g"`, opacity)
+	}
+	cv.buf.WriteString(` points="`)
+	cv.writePoints(pts)
+	cv.buf.WriteString("\"/>\n")
+}
+
+func (cv *svgCanvas) circle(x, y, r float64, fill string) {
+	fmt.Fprintf(&cv.buf, `<circle cx="%.1f" cy="%.1f" r="%g" fill="%s"/>`+"\n", x, y, r, fill)
+}
+
+func (cv *svgCanvas) text(s string, x, y float64, style textStyle) {
+	fmt.Fprintf(&cv.buf, `<text x="%.1f" y="%.1f" text-anchor="%s" fill="%s"`, x, y, style.Anchor, style.Color)
+	if style.Size != fontSize {
+		fmt.Fprintf(&cv.buf, ` font-size="%g"`, style.Size)
+	}
+	if style.Bold {
+		cv.buf.WriteString(` font-weight="bold"`)
+	}
+	fmt.Fprintf(&cv.buf, ">%s</text>\n", html.EscapeString(s))
+}
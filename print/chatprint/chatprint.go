@@ -0,0 +1,199 @@
+// Package chatprint contains the helpers shared by the chat message printers.
+//
+// The chat formats support only a small subset of markdown, so the printers
+// render the content tree to markdown, parse it again and walk the resulting AST.
+package chatprint
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+// Parse prints the content as markdown and parses it into the goldmark AST.
+// Frontmatter is removed, custom and extended nodes are lowered to markdown.
+func Parse(ctx context.Context, el plugin.Content, opts ...print.Option) (ast.Node, []byte, error) {
+	el, err := print.RenderCustomNodes(ctx, el, print.MakeOptions(opts...).NodeRenderers, plugin.OutputFormatMD)
+	if err != nil {
+		return nil, nil, err
+	}
+	removeFrontmatter(el)
+	err = print.LowerExtendedNodes(el, plugin.OutputFormatMD)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := mdprint.New(opts...).Print(ctx, buf, el); err != nil {
+		return nil, nil, err
+	}
+	source := buf.Bytes()
+	md := goldmark.New(plugin.BaseMarkdownOptions)
+	return md.Parser().Parse(text.NewReader(source)), source, nil
+}
+
+func removeFrontmatter(el plugin.Content) {
+	section, ok := el.(*plugin.ContentSection)
+	if !ok {
+		return
+	}
+	for i, child := range section.Children {
+		meta := child.Meta()
+		if meta != nil && meta.Plugin == "blackstork/builtin" && meta.Provider == "frontmatter" {
+			section.Children = append(section.Children[:i], section.Children[i+1:]...)
+			return
+		}
+	}
+}
+
+// PlainText returns the text of the node without markup.
+func PlainText(n ast.Node, source []byte) string {
+	var buf strings.Builder
+	_ = ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			buf.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(n.Value)
+		case *ast.AutoLink:
+			buf.Write(n.URL(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(buf.String())
+}
+
+// CodeText returns the content of the code block.
+func CodeText(n ast.Node, source []byte) string {
+	var buf strings.Builder
+	for i := range n.Lines().Len() {
+		seg := n.Lines().At(i)
+		buf.Write(seg.Value(source))
+	}
+	return buf.String()
+}
+
+// Table is the text of the table cells.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// ReadTable extracts the cells of the table, cell content is rendered with the cell func.
+func ReadTable(n *east.Table, cell func(*east.TableCell) string) Table {
+	var t Table
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for c := row.FirstChild(); c != nil; c = c.NextSibling() {
+			if tc, ok := c.(*east.TableCell); ok {
+				cells = append(cells, cell(tc))
+			}
+		}
+		if _, ok := row.(*east.TableHeader); ok {
+			t.Header = cells
+		} else {
+			t.Rows = append(t.Rows, cells)
+		}
+	}
+	return t
+}
+
+// Columns returns the number of columns in the table.
+func (t Table) Columns() int {
+	cols := len(t.Header)
+	for _, row := range t.Rows {
+		cols = max(cols, len(row))
+	}
+	return cols
+}
+
+// Format renders the table as aligned plain text, to be shown in monospace font.
+func (t Table) Format() string {
+	widths := make([]int, t.Columns())
+	rows := append([][]string{t.Header}, t.Rows...)
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	var buf strings.Builder
+	writeRow := func(row []string) {
+		for i, w := range widths {
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			if i > 0 {
+				buf.WriteString(" | ")
+			}
+			buf.WriteString(cell)
+			if i < len(widths)-1 {
+				buf.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(cell)))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	writeRow(t.Header)
+	for i, w := range widths {
+		if i > 0 {
+			buf.WriteString("-+-")
+		}
+		buf.WriteString(strings.Repeat("-", w))
+	}
+	buf.WriteByte('\n')
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+	return buf.String()
+}
+
+// Split splits the text into chunks of at most limit runes.
+// The text is split at the last paragraph, line or word boundary that fits.
+func Split(s string, limit int) []string {
+	var chunks []string
+	for utf8.RuneCountInString(s) > limit {
+		// byte offset of the limit
+		end := 0
+		for i := 0; i < limit; i++ {
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+		}
+		cut := end
+		for _, sep := range []string{"\n\n", "\n", " "} {
+			if i := strings.LastIndex(s[:end], sep); i > 0 {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimRight(s[:cut], " \n"))
+		s = strings.TrimLeft(s[cut:], " \n")
+	}
+	if s != "" || len(chunks) == 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// Truncate shortens the text to at most limit runes, replacing the tail with an ellipsis.
+func Truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
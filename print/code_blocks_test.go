@@ -0,0 +1,40 @@
+package print_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+func codeContent() plugin.Content {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("```go\nfunc main() {}\n```"), nil)
+	section.Add(plugin.NewElementFromMarkdown("```mermaid\ngraph LR\n  A[Start] --> B[End]\n```"), nil)
+	section.Add(plugin.NewElementFromMarkdown("```mermaid\nsequenceDiagram\n  A->>B: Hi\n```"), nil)
+	return section
+}
+
+func TestCodeHighlightingHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := htmlprint.New().Print(context.Background(), buf, codeContent())
+	require.NoError(t, err)
+	html := buf.String()
+	assert.Contains(t, html, `<span style="color:#000;font-weight:bold">func</span>`)
+	assert.Contains(t, html, `<figure class="diagram">`+"\n"+`<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, html, `<tspan x="`)
+	assert.Contains(t, html, "sequenceDiagram")
+}
+
+func TestDiagramsMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := mdprint.New().Print(context.Background(), buf, codeContent())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "```mermaid\ngraph LR\n  A[Start] --> B[End]\n```")
+}
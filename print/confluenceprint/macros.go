@@ -0,0 +1,357 @@
+package confluenceprint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log/slog"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/blackstork-io/fabric/print/diagram"
+)
+
+// kindPanel is the kind of the blockquote with GitHub alert marker (`> [!NOTE]`).
+var kindPanel = ast.NewNodeKind("ConfluencePanel")
+
+type panel struct {
+	ast.BaseBlock
+	Macro string
+	Title string
+}
+
+// Kind implements ast.Node.
+func (n *panel) Kind() ast.NodeKind {
+	return kindPanel
+}
+
+// Dump implements ast.Node.
+func (n *panel) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Macro": n.Macro,
+		"Title": n.Title,
+	}, nil)
+}
+
+var alertRe = regexp.MustCompile(`^\[!(\w+)\]\s*$`)
+
+// panelMacros maps the alert kinds to the Confluence panel macros.
+var panelMacros = map[string]string{
+	"note":      "info",
+	"tip":       "tip",
+	"important": "note",
+	"warning":   "warning",
+	"caution":   "warning",
+}
+
+// alertTransformer replaces blockquotes with alert markers with panel nodes.
+type alertTransformer struct{}
+
+// Transform implements parser.ASTTransformer.
+func (alertTransformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if quote, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, quote)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, quote := range quotes {
+		para, ok := quote.FirstChild().(*ast.Paragraph)
+		if !ok || para.Lines().Len() == 0 {
+			continue
+		}
+		first := para.Lines().At(0)
+		match := alertRe.FindSubmatch(first.Value(source))
+		if match == nil {
+			continue
+		}
+		macro, ok := panelMacros[strings.ToLower(string(match[1]))]
+		if !ok {
+			macro = "info"
+		}
+		p := &panel{Macro: macro}
+		// drop the marker, it can be split into several text nodes
+		for c := para.FirstChild(); c != nil; c = para.FirstChild() {
+			txt, ok := c.(*ast.Text)
+			if !ok || txt.Segment.Start >= first.Stop {
+				break
+			}
+			para.RemoveChild(para, c)
+		}
+		if para.Lines().Len() > 1 {
+			second := para.Lines().At(1)
+			line := bytes.TrimSpace(second.Value(source))
+			strong, ok := para.FirstChild().(*ast.Emphasis)
+			if ok && strong.Level == 2 && bytes.HasPrefix(line, []byte("**")) && bytes.HasSuffix(line, []byte("**")) {
+				p.Title = string(strong.Text(source))
+				para.RemoveChild(para, strong)
+				// the line break after the title is kept in an empty text node
+				if txt, ok := para.FirstChild().(*ast.Text); ok && txt.Segment.Start < second.Stop && len(bytes.TrimSpace(txt.Segment.Value(source))) == 0 {
+					para.RemoveChild(para, txt)
+				}
+			}
+		}
+		if para.ChildCount() == 0 {
+			quote.RemoveChild(quote, para)
+		}
+		for c := quote.FirstChild(); c != nil; c = quote.FirstChild() {
+			p.AppendChild(p, c)
+		}
+		quote.Parent().ReplaceChild(quote.Parent(), quote, p)
+	}
+}
+
+// nodeRenderer renders the nodes that have Confluence macro counterparts
+// and collects the files to attach to the page.
+type nodeRenderer struct {
+	attachments []Attachment
+	diagrams    int
+	baseDir     string
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *nodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindBlockquote, r.renderBlockquote)
+	reg.Register(kindPanel, r.renderPanel)
+	reg.Register(ast.KindImage, r.renderImage)
+	reg.Register(east.KindTaskCheckBox, r.renderTaskCheckBox)
+}
+
+func (r *nodeRenderer) renderCodeBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	var lang string
+	if fenced, ok := n.(*ast.FencedCodeBlock); ok {
+		lang = string(fenced.Language(source))
+	}
+	var content bytes.Buffer
+	for i := range n.Lines().Len() {
+		seg := n.Lines().At(i)
+		content.Write(seg.Value(source))
+	}
+	if diagram.Supported(lang) {
+		filename, err := r.renderDiagram(lang, content.Bytes())
+		if err == nil {
+			_, _ = fmt.Fprintf(w, "<p><ac:image><ri:attachment ri:filename=\"%s\" /></ac:image></p>\n", html.EscapeString(filename))
+			return ast.WalkSkipChildren, nil
+		}
+		slog.Warn("Failed to render diagram, leaving it as a code block", "error", err)
+	}
+	_, _ = w.WriteString(`<ac:structured-macro ac:name="code" ac:schema-version="1">`)
+	if lang != "" {
+		_, _ = fmt.Fprintf(w, `<ac:parameter ac:name="language">%s</ac:parameter>`, html.EscapeString(lang))
+	}
+	_, _ = w.WriteString(`<ac:plain-text-body><![CDATA[`)
+	// CDATA can't contain its terminator, split it between two sections
+	_, _ = w.WriteString(strings.ReplaceAll(content.String(), "]]>", "]]]]><![CDATA[>"))
+	_, _ = w.WriteString("]]></ac:plain-text-body></ac:structured-macro>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *nodeRenderer) renderDiagram(lang string, src []byte) (string, error) {
+	g, err := diagram.Parse(lang, src)
+	if err != nil {
+		return "", err
+	}
+	data, err := diagram.RenderPNG(g)
+	if err != nil {
+		return "", err
+	}
+	r.diagrams++
+	filename := fmt.Sprintf("diagram-%d.png", r.diagrams)
+	r.attachments = append(r.attachments, Attachment{
+		Filename:    filename,
+		ContentType: "image/png",
+		Data:        data,
+	})
+	return filename, nil
+}
+
+func (r *nodeRenderer) renderBlockquote(w util.BufWriter, _ []byte, _ ast.Node, entering bool) (ast.WalkStatus, error) {
+	writePanel(w, "info", "", entering)
+	return ast.WalkContinue, nil
+}
+
+func (r *nodeRenderer) renderPanel(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	p := n.(*panel)
+	writePanel(w, p.Macro, p.Title, entering)
+	return ast.WalkContinue, nil
+}
+
+func writePanel(w util.BufWriter, macro, title string, entering bool) {
+	if !entering {
+		_, _ = w.WriteString("</ac:rich-text-body></ac:structured-macro>\n")
+		return
+	}
+	_, _ = fmt.Fprintf(w, `<ac:structured-macro ac:name="%s" ac:schema-version="1">`, macro)
+	if title != "" {
+		_, _ = fmt.Fprintf(w, `<ac:parameter ac:name="title">%s</ac:parameter>`, html.EscapeString(title))
+	}
+	_, _ = w.WriteString("<ac:rich-text-body>\n")
+}
+
+func (r *nodeRenderer) renderImage(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	img := n.(*ast.Image)
+	_, _ = w.WriteString("<ac:image")
+	if alt := img.Text(source); len(alt) > 0 {
+		_, _ = fmt.Fprintf(w, ` ac:alt="%s"`, html.EscapeString(string(alt)))
+	}
+	if len(img.Title) > 0 {
+		_, _ = fmt.Fprintf(w, ` ac:title="%s"`, html.EscapeString(string(img.Title)))
+	}
+	_, _ = w.WriteString(">")
+	dest := string(img.Destination)
+	if filename, ok := r.attachImage(dest); ok {
+		_, _ = fmt.Fprintf(w, `<ri:attachment ri:filename="%s" />`, html.EscapeString(filename))
+	} else {
+		_, _ = fmt.Fprintf(w, `<ri:url ri:value="%s" />`, html.EscapeString(dest))
+	}
+	_, _ = w.WriteString("</ac:image>")
+	return ast.WalkSkipChildren, nil
+}
+
+// attachImage adds embedded images and local images from the base directory to the
+// attachments. Returns false if the image must be referenced by URL.
+func (r *nodeRenderer) attachImage(dest string) (string, bool) {
+	if strings.HasPrefix(dest, "data:") {
+		contentType, data, ok := decodeDataURI(dest)
+		if !ok || !strings.HasPrefix(contentType, "image/") {
+			return "", false
+		}
+		return r.attach("", contentType, data), true
+	}
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "", false
+	}
+	path, ok := r.localImagePath(u.Path)
+	if !ok {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		slog.Warn("Failed to read the image, referencing it by URL", "path", u.Path, "error", err)
+		return "", false
+	}
+	return r.attach(filepath.Base(path), mime.TypeByExtension(filepath.Ext(path)), data), true
+}
+
+// localImagePath resolves the image path against the base directory. Returns false for
+// the files of non-image types and the files outside of the base directory.
+func (r *nodeRenderer) localImagePath(name string) (string, bool) {
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return "", false
+	}
+	if !strings.HasPrefix(mime.TypeByExtension(filepath.Ext(name)), "image/") {
+		return "", false
+	}
+	baseDir := r.baseDir
+	if baseDir == "" {
+		baseDir = "."
+	}
+	base, err := filepath.EvalSymlinks(baseDir)
+	if err != nil {
+		return "", false
+	}
+	base, err = filepath.Abs(base)
+	if err != nil {
+		return "", false
+	}
+	// symlinks must not lead outside of the base directory
+	path, err := filepath.EvalSymlinks(filepath.Join(base, name))
+	if err != nil {
+		return "", false
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	return path, true
+}
+
+// attach adds the file to the attachments, the files with the same name and content are
+// attached once. Unnamed and conflicting files are named after the hash of the content.
+func (r *nodeRenderer) attach(filename, contentType string, data []byte) string {
+	sum := sha256.Sum256(data)
+	hashName := "image-" + hex.EncodeToString(sum[:6])
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		hashName += exts[0]
+	}
+	if filename == "" {
+		filename = hashName
+	}
+	for _, a := range r.attachments {
+		if a.Filename != filename {
+			continue
+		}
+		if bytes.Equal(a.Data, data) {
+			return filename
+		}
+		filename = hashName
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	r.attachments = append(r.attachments, Attachment{
+		Filename:    filename,
+		ContentType: contentType,
+		Data:        data,
+	})
+	return filename
+}
+
+// decodeDataURI decodes base64 encoded data URIs: `data:image/png;base64,...`.
+func decodeDataURI(uri string) (contentType string, data []byte, ok bool) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return "", nil, false
+	}
+	contentType, ok = strings.CutSuffix(header, ";base64")
+	if !ok {
+		return "", nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, false
+	}
+	return contentType, data, true
+}
+
+func (r *nodeRenderer) renderTaskCheckBox(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	if n.(*east.TaskCheckBox).IsChecked {
+		_, _ = w.WriteString("☑ ")
+	} else {
+		_, _ = w.WriteString("☐ ")
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,140 @@
+package confluenceprint
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+// Attachment is a file referenced by the page, it must be uploaded to the page
+// to be displayed.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Printer prints content in Confluence storage format (XHTML with Confluence macros).
+type Printer struct {
+	md      mdprint.Printer
+	opts    print.Options
+	toc     bool
+	baseDir string
+}
+
+// New creates a new Confluence storage format printer.
+func New(opts ...print.Option) Printer {
+	return Printer{
+		md:   mdprint.New(opts...),
+		opts: print.MakeOptions(opts...),
+	}
+}
+
+// WithTOC returns the printer that adds the table of contents macro at the top of the page.
+func (p Printer) WithTOC(toc bool) Printer {
+	p.toc = toc
+	return p
+}
+
+// WithBaseDir returns the printer that attaches the local images from the directory.
+// Relative image paths are resolved against it, images outside of it are referenced by URL.
+// Defaults to the working directory.
+func (p Printer) WithBaseDir(dir string) Printer {
+	p.baseDir = dir
+	return p
+}
+
+// Print is a helper function to print Confluence storage format content to a writer.
+func Print(w io.Writer, el plugin.Content) error {
+	p := New()
+	return p.Print(context.Background(), w, el)
+}
+
+// Print implements print.Printer. Attachments referenced by the page are discarded,
+// use [Printer.PrintPage] to get them.
+func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) error {
+	_, err := p.PrintPage(ctx, w, el)
+	return err
+}
+
+// PrintPage prints the content and returns the files (local images and rendered diagrams)
+// that should be attached to the page.
+func (p Printer) PrintPage(ctx context.Context, w io.Writer, el plugin.Content) ([]Attachment, error) {
+	el, err := print.RenderCustomNodes(ctx, el, p.opts.NodeRenderers, plugin.OutputFormatHTML)
+	if err != nil {
+		return nil, err
+	}
+	removeFrontmatter(el)
+	// extended nodes are lowered to markdown, alerts are rendered as panel macros
+	err = print.LowerExtendedNodes(el, plugin.OutputFormatMD)
+	if err != nil {
+		return nil, err
+	}
+	trusted, err := print.NewTrustedHTML()
+	if err != nil {
+		return nil, err
+	}
+	err = trusted.ReplaceWithPlaceholders(el)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := p.md.Print(ctx, buf, el); err != nil {
+		return nil, err
+	}
+	nr := &nodeRenderer{baseDir: p.baseDir}
+	md := goldmark.New(
+		plugin.BaseMarkdownOptions,
+		goldmark.WithExtensions(
+			extension.Footnote,
+			extension.DefinitionList,
+		),
+		goldmark.WithParserOptions(
+			parser.WithAttribute(),
+			parser.WithASTTransformers(
+				util.Prioritized(alertTransformer{}, 100),
+			),
+		),
+		goldmark.WithRendererOptions(
+			html.WithXHTML(),
+			renderer.WithNodeRenderers(
+				util.Prioritized(nr, 100),
+				trusted.Renderer(html.WithXHTML()),
+			),
+		),
+	)
+	if p.toc {
+		if _, err := io.WriteString(w, `<ac:structured-macro ac:name="toc" ac:schema-version="1" />`+"\n"); err != nil {
+			return nil, err
+		}
+	}
+	if err := md.Convert(buf.Bytes(), w); err != nil {
+		return nil, err
+	}
+	return nr.attachments, nil
+}
+
+func removeFrontmatter(el plugin.Content) {
+	section, ok := el.(*plugin.ContentSection)
+	if !ok {
+		return
+	}
+	for i, child := range section.Children {
+		meta := child.Meta()
+		if meta != nil && meta.Plugin == "blackstork/builtin" && meta.Provider == "frontmatter" {
+			section.Children = append(section.Children[:i], section.Children[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package confluenceprint_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/print/confluenceprint"
+)
+
+func TestConfluenceStorageFormat(t *testing.T) {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("## Findings"), nil)
+	section.Add(plugin.NewElementFromMarkdown("| Host | Risk |\n|---|---|\n| web-1 | high |"), nil)
+	section.Add(plugin.NewElementFromMarkdown("```go\nx := []int{1}[0]]]>\n```"), nil)
+	section.Add(plugin.NewElementFromMarkdown("```mermaid\ngraph LR\n  A --> B\n```"), nil)
+	section.Add(plugin.NewElementFromMarkdown("> Quoted text"), nil)
+	section.Add(plugin.NewElementFromMarkdown("> [!TIP]\n> **Remember**\n> Rotate the keys"), nil)
+	section.Add(plugin.NewElement(ast.Admonition("caution", "", ast.Paragraph(ast.Text("Be careful")))), nil)
+	section.Add(plugin.NewElementFromMarkdown("![logo](data:image/png;base64,iVBORw0KGgo=) ![remote](https://example.com/a.png)"), nil)
+
+	buf := &bytes.Buffer{}
+	attachments, err := confluenceprint.New().WithTOC(true).PrintPage(context.Background(), buf, section)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, `<ac:structured-macro ac:name="toc" ac:schema-version="1" />`)
+	assert.Contains(t, out, "<h2>Findings</h2>")
+	assert.Contains(t, out, "<td>web-1</td>")
+	assert.Contains(t, out, `<ac:structured-macro ac:name="code" ac:schema-version="1">`+
+		`<ac:parameter ac:name="language">go</ac:parameter>`+
+		"<ac:plain-text-body><![CDATA[x := []int{1}[0]]]]]><![CDATA[>\n]]></ac:plain-text-body></ac:structured-macro>")
+	assert.Contains(t, out, `<p><ac:image><ri:attachment ri:filename="diagram-1.png" /></ac:image></p>`)
+	assert.Contains(t, out, `<ac:structured-macro ac:name="info" ac:schema-version="1"><ac:rich-text-body>`+"\n<p>Quoted text</p>")
+	assert.Contains(t, out, `<ac:structured-macro ac:name="tip" ac:schema-version="1">`+
+		`<ac:parameter ac:name="title">Remember</ac:parameter><ac:rich-text-body>`+"\n<p>Rotate the keys</p>")
+	assert.Contains(t, out, `<ac:structured-macro ac:name="warning" ac:schema-version="1"><ac:rich-text-body>`+"\n<p>Be careful</p>")
+	assert.NotContains(t, out, "[!")
+	assert.Contains(t, out, `<ac:image ac:alt="remote"><ri:url ri:value="https://example.com/a.png" /></ac:image>`)
+
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "diagram-1.png", attachments[0].Filename)
+	assert.Equal(t, "image/png", attachments[1].ContentType)
+	assert.Contains(t, out, `<ac:image ac:alt="logo"><ri:attachment ri:filename="`+attachments[1].Filename+`" /></ac:image>`)
+}
+
+func TestConfluenceLocalImages(t *testing.T) {
+	dir := t.TempDir()
+	baseDir := filepath.Join(dir, "report")
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "img"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "img", "logo.png"), []byte("png"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "notes.txt"), []byte("secret"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "outside.png"), []byte("outside"), 0o600))
+	absPath := filepath.ToSlash(filepath.Join(baseDir, "img", "logo.png"))
+
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("![logo](img/logo.png)"), nil)
+	section.Add(plugin.NewElementFromMarkdown("![notes](notes.txt)"), nil)
+	section.Add(plugin.NewElementFromMarkdown("![outside](../outside.png)"), nil)
+	section.Add(plugin.NewElementFromMarkdown("![absolute]("+absPath+")"), nil)
+	section.Add(plugin.NewElementFromMarkdown("![text](data:text/plain;base64,c2VjcmV0)"), nil)
+
+	buf := &bytes.Buffer{}
+	attachments, err := confluenceprint.New().WithBaseDir(baseDir).PrintPage(context.Background(), buf, section)
+	require.NoError(t, err)
+	out := buf.String()
+
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "logo.png", attachments[0].Filename)
+	assert.Equal(t, "image/png", attachments[0].ContentType)
+	assert.Equal(t, []byte("png"), attachments[0].Data)
+	assert.Contains(t, out, `<ac:image ac:alt="logo"><ri:attachment ri:filename="logo.png" /></ac:image>`)
+	assert.Contains(t, out, `<ac:image ac:alt="notes"><ri:url ri:value="notes.txt" /></ac:image>`)
+	assert.Contains(t, out, `<ac:image ac:alt="outside"><ri:url ri:value="../outside.png" /></ac:image>`)
+	assert.Contains(t, out, `<ac:image ac:alt="absolute"><ri:url ri:value="`+absPath+`" /></ac:image>`)
+	assert.Contains(t, out, `<ac:image ac:alt="text"><ri:url ri:value="data:text/plain;base64,c2VjcmV0" /></ac:image>`)
+}
+
+func TestConfluenceRawHTMLOmitted(t *testing.T) {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("<script>alert(1)</script>\n\nText <b>bold</b>"), nil)
+
+	buf := &bytes.Buffer{}
+	_, err := confluenceprint.New().PrintPage(context.Background(), buf, section)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.NotContains(t, buf.String(), "<b>")
+	assert.Contains(t, buf.String(), "Text")
+}
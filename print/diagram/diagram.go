@@ -0,0 +1,90 @@
+// Package diagram renders simple graph diagrams described in graphviz dot
+// or mermaid flowchart syntax, without relying on external tools.
+package diagram
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupported is returned for the diagram languages (or mermaid diagram types)
+// that can't be rendered.
+var ErrUnsupported = errors.New("unsupported diagram")
+
+// Direction is the direction in which the graph ranks are laid out.
+type Direction int
+
+const (
+	TopBottom Direction = iota
+	BottomTop
+	LeftRight
+	RightLeft
+)
+
+// Shape is the shape of the node.
+type Shape int
+
+const (
+	ShapeBox Shape = iota
+	ShapeRound
+	ShapeEllipse
+	ShapeDiamond
+)
+
+type Node struct {
+	ID string
+	// Label is the text of the node, lines are separated with "\n"
+	Label string
+	Shape Shape
+}
+
+type Edge struct {
+	From     string
+	To       string
+	Label    string
+	Directed bool
+	Dashed   bool
+}
+
+// Graph is the language-independent representation of a diagram.
+type Graph struct {
+	Direction Direction
+	Nodes     []*Node
+	Edges     []*Edge
+}
+
+// node returns the node with the given id, adding it to the graph if needed.
+func (g *Graph) node(id string) *Node {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	n := &Node{
+		ID:    id,
+		Label: id,
+	}
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+// Supported reports whether diagrams in the language of the fenced code block can be rendered.
+func Supported(lang string) bool {
+	switch strings.ToLower(lang) {
+	case "dot", "graphviz", "gv", "mermaid":
+		return true
+	}
+	return false
+}
+
+// Parse parses the diagram source in the given language.
+func Parse(lang string, src []byte) (*Graph, error) {
+	switch strings.ToLower(lang) {
+	case "dot", "graphviz", "gv":
+		return parseDOT(src)
+	case "mermaid":
+		return parseMermaid(src)
+	}
+	return nil, fmt.Errorf("%w: language %q", ErrUnsupported, lang)
+}
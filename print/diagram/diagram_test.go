@@ -0,0 +1,96 @@
+package diagram_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+
+	"github.com/blackstork-io/fabric/print/diagram"
+)
+
+func TestParseMermaid(t *testing.T) {
+	g, err := diagram.Parse("mermaid", []byte(`flowchart LR
+    %% initial access
+    A[Phishing email] -->|opens| B(Macro)
+    B --> C{Persistence?} & D((C2))
+    C -- yes --> E[Scheduled task]
+    C -.-> A;
+`))
+	require.NoError(t, err)
+	assert.Equal(t, diagram.LeftRight, g.Direction)
+	assert.Equal(t, []*diagram.Node{
+		{ID: "A", Label: "Phishing email", Shape: diagram.ShapeBox},
+		{ID: "B", Label: "Macro", Shape: diagram.ShapeRound},
+		{ID: "C", Label: "Persistence?", Shape: diagram.ShapeDiamond},
+		{ID: "D", Label: "C2", Shape: diagram.ShapeEllipse},
+		{ID: "E", Label: "Scheduled task", Shape: diagram.ShapeBox},
+	}, g.Nodes)
+	assert.Equal(t, []*diagram.Edge{
+		{From: "A", To: "B", Label: "opens", Directed: true},
+		{From: "B", To: "C", Directed: true},
+		{From: "B", To: "D", Directed: true},
+		{From: "C", To: "E", Label: "yes", Directed: true},
+		{From: "C", To: "A", Directed: true, Dashed: true},
+	}, g.Edges)
+}
+
+func TestParseMermaidUnsupported(t *testing.T) {
+	_, err := diagram.Parse("mermaid", []byte("sequenceDiagram\n    Alice->>Bob: Hello"))
+	assert.ErrorIs(t, err, diagram.ErrUnsupported)
+}
+
+func TestParseDOT(t *testing.T) {
+	g, err := diagram.Parse("dot", []byte(`digraph G {
+    rankdir=LR;
+    a [label="Initial\naccess", shape=box];
+    a -> b [label="drops", style=dashed];
+    b -> c;
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, diagram.LeftRight, g.Direction)
+	assert.Equal(t, []*diagram.Node{
+		{ID: "a", Label: "Initial\naccess", Shape: diagram.ShapeBox},
+		{ID: "b", Label: "b", Shape: diagram.ShapeEllipse},
+		{ID: "c", Label: "c", Shape: diagram.ShapeEllipse},
+	}, g.Nodes)
+	assert.Equal(t, []*diagram.Edge{
+		{From: "a", To: "b", Label: "drops", Directed: true, Dashed: true},
+		{From: "b", To: "c", Directed: true},
+	}, g.Edges)
+}
+
+func TestRender(t *testing.T) {
+	g, err := diagram.Parse("mermaid", []byte("graph TD\n  A[Start] --> B[Loop] --> B\n  B --> A"))
+	require.NoError(t, err)
+
+	svg, err := diagram.RenderSVG(g)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(svg, []byte("<svg ")))
+	assert.Contains(t, string(svg), ">Start</tspan>")
+	assert.NotContains(t, string(svg), "\n\n")
+
+	data, err := diagram.RenderPNG(g)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Positive(t, img.Bounds().Dx())
+	assert.Positive(t, img.Bounds().Dy())
+}
+
+func TestExtensionRaster(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(&diagram.Extension{Raster: true}))
+	src := []byte("```dot\ndigraph { a -> b }\n```\n\n```dot\nnot a graph\n```\n")
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	img, ok := doc.FirstChild().FirstChild().(*ast.Image)
+	require.True(t, ok)
+	assert.True(t, bytes.HasPrefix(img.Destination, []byte("data:image/png;base64,")))
+	// invalid diagrams are kept as code blocks
+	assert.Equal(t, ast.KindFencedCodeBlock, doc.LastChild().Kind())
+}
@@ -0,0 +1,75 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awalterschulze/gographviz"
+)
+
+func parseDOT(src []byte) (*Graph, error) {
+	dot, err := gographviz.Read(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dot graph: %w", err)
+	}
+	g := &Graph{}
+	switch strings.ToUpper(dotString(dot.Attrs["rankdir"])) {
+	case "LR":
+		g.Direction = LeftRight
+	case "RL":
+		g.Direction = RightLeft
+	case "BT":
+		g.Direction = BottomTop
+	}
+	for _, dn := range dot.Nodes.Nodes {
+		n := g.node(dotString(dn.Name))
+		if label, ok := dn.Attrs["label"]; ok {
+			n.Label = dotString(label)
+		}
+		n.Shape = dotShape(dotString(dn.Attrs["shape"]))
+	}
+	for _, de := range dot.Edges.Edges {
+		g.Edges = append(g.Edges, &Edge{
+			From:     g.node(dotString(de.Src)).ID,
+			To:       g.node(dotString(de.Dst)).ID,
+			Label:    dotString(de.Attrs["label"]),
+			Directed: dot.Directed,
+			Dashed:   strings.Contains(dotString(de.Attrs["style"]), "dashed"),
+		})
+	}
+	return g, nil
+}
+
+func dotShape(shape string) Shape {
+	switch strings.ToLower(shape) {
+	case "box", "rect", "rectangle", "square", "record", "plaintext", "plain", "none", "note", "tab", "folder":
+		return ShapeBox
+	case "mrecord":
+		return ShapeRound
+	case "diamond":
+		return ShapeDiamond
+	}
+	return ShapeEllipse
+}
+
+// dotString unquotes the dot ID and replaces the line break escapes.
+func dotString(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'l', 'r':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,122 @@
+package diagram
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindDiagram is the kind of the rendered diagram node.
+var KindDiagram = ast.NewNodeKind("Diagram")
+
+// Block is a rendered SVG diagram.
+type Block struct {
+	ast.BaseBlock
+	SVG []byte
+}
+
+// Kind implements ast.Node.
+func (n *Block) Kind() ast.NodeKind {
+	return KindDiagram
+}
+
+// Dump implements ast.Node.
+func (n *Block) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// Extension is a goldmark extension that renders fenced code blocks with supported
+// diagram languages. Diagrams that fail to render are left as code blocks.
+type Extension struct {
+	// Raster replaces diagrams with PNG images instead of inline SVG,
+	// for the renderers that can't output raw HTML.
+	Raster bool
+}
+
+// Extend implements goldmark.Extender.
+func (e *Extension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&transformer{raster: e.Raster}, 100),
+	))
+	if !e.Raster {
+		m.Renderer().AddOptions(renderer.WithNodeRenderers(
+			util.Prioritized(htmlRenderer{}, 100),
+		))
+	}
+}
+
+type transformer struct {
+	raster bool
+}
+
+// Transform implements parser.ASTTransformer.
+func (t *transformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	var blocks []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if code, ok := n.(*ast.FencedCodeBlock); ok && entering && Supported(string(code.Language(source))) {
+			blocks = append(blocks, code)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, code := range blocks {
+		repl, err := t.render(code, source)
+		if err != nil {
+			if !errors.Is(err, ErrUnsupported) {
+				slog.Warn("Failed to render diagram, leaving it as a code block", "error", err)
+			}
+			continue
+		}
+		code.Parent().ReplaceChild(code.Parent(), code, repl)
+	}
+}
+
+func (t *transformer) render(code *ast.FencedCodeBlock, source []byte) (ast.Node, error) {
+	var content bytes.Buffer
+	for i := range code.Lines().Len() {
+		seg := code.Lines().At(i)
+		content.Write(seg.Value(source))
+	}
+	g, err := Parse(string(code.Language(source)), content.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if !t.raster {
+		svg, err := RenderSVG(g)
+		if err != nil {
+			return nil, err
+		}
+		return &Block{SVG: svg}, nil
+	}
+	img, err := RenderPNG(g)
+	if err != nil {
+		return nil, err
+	}
+	image := ast.NewImage(ast.NewLink())
+	image.Destination = []byte("data:image/png;base64," + base64.StdEncoding.EncodeToString(img))
+	para := ast.NewParagraph()
+	para.AppendChild(para, image)
+	return para, nil
+}
+
+type htmlRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (htmlRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindDiagram, func(w util.BufWriter, _ []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString("<figure class=\"diagram\">\n")
+			_, _ = w.Write(n.(*Block).SVG)
+			_, _ = w.WriteString("\n</figure>\n")
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
@@ -0,0 +1,380 @@
+package diagram
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+const (
+	fontSize   = 14.0
+	lineHeight = 18.0
+	nodePadX   = 14.0
+	nodePadY   = 9.0
+	nodeSep    = 30.0
+	rankSep    = 50.0
+	margin     = 10.0
+	minNodeW   = 40.0
+	arrowSize  = 8.0
+	labelPad   = 3.0
+)
+
+// fontFace is used to measure (and draw in raster images) the text.
+var fontFace = sync.OnceValues(func() (font.Face, error) {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+})
+
+type point struct {
+	X, Y float64
+}
+
+type placedNode struct {
+	*Node
+	// center of the node
+	X, Y float64
+	W, H float64
+}
+
+type placedEdge struct {
+	*Edge
+	Points []point
+	// center of the label
+	LabelPos point
+	LabelW   float64
+	LabelH   float64
+}
+
+type layout struct {
+	W, H  float64
+	Nodes []*placedNode
+	Edges []*placedEdge
+}
+
+func textLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func measure(face font.Face, s string) (w, h float64) {
+	lines := textLines(s)
+	for _, line := range lines {
+		w = math.Max(w, float64(font.MeasureString(face, line))/64)
+	}
+	return w, float64(len(lines)) * lineHeight
+}
+
+// doLayout places the nodes in ranks (layered graph drawing) and routes edges as straight lines.
+func doLayout(g *Graph) (*layout, error) {
+	face, err := fontFace()
+	if err != nil {
+		return nil, err
+	}
+	res := &layout{}
+	index := make(map[string]int, len(g.Nodes))
+	for i, n := range g.Nodes {
+		index[n.ID] = i
+		w, h := measure(face, n.Label)
+		pn := &placedNode{
+			Node: n,
+			W:    math.Max(minNodeW, w+2*nodePadX),
+			H:    math.Max(lineHeight, h) + 2*nodePadY,
+		}
+		switch n.Shape {
+		case ShapeEllipse:
+			pn.W *= math.Sqrt2
+			pn.H *= math.Sqrt2
+		case ShapeDiamond:
+			pn.W *= 1.6
+			pn.H *= 1.6
+		}
+		res.Nodes = append(res.Nodes, pn)
+	}
+	horizontal := g.Direction == LeftRight || g.Direction == RightLeft
+	// size of the node along the rank axis and along the order axis
+	rankSize := func(n *placedNode) float64 {
+		if horizontal {
+			return n.W
+		}
+		return n.H
+	}
+	orderSize := func(n *placedNode) float64 {
+		if horizontal {
+			return n.H
+		}
+		return n.W
+	}
+
+	ranks := assignRanks(g, index)
+	layers := orderLayers(g, index, ranks)
+
+	// rank axis coordinates
+	pos := 0.0
+	for _, layer := range layers {
+		size := 0.0
+		for _, i := range layer {
+			size = math.Max(size, rankSize(res.Nodes[i]))
+		}
+		for _, i := range layer {
+			if horizontal {
+				res.Nodes[i].X = pos + size/2
+			} else {
+				res.Nodes[i].Y = pos + size/2
+			}
+		}
+		pos += size + rankSep
+	}
+	rankExtent := math.Max(0, pos-rankSep)
+	// order axis coordinates, layers are centered
+	orderExtent := 0.0
+	layerSizes := make([]float64, len(layers))
+	for li, layer := range layers {
+		for i, ni := range layer {
+			if i > 0 {
+				layerSizes[li] += nodeSep
+			}
+			layerSizes[li] += orderSize(res.Nodes[ni])
+		}
+		orderExtent = math.Max(orderExtent, layerSizes[li])
+	}
+	for li, layer := range layers {
+		pos := (orderExtent - layerSizes[li]) / 2
+		for _, ni := range layer {
+			n := res.Nodes[ni]
+			if horizontal {
+				n.Y = pos + n.H/2
+			} else {
+				n.X = pos + n.W/2
+			}
+			pos += orderSize(n) + nodeSep
+		}
+	}
+	if horizontal {
+		res.W, res.H = rankExtent, orderExtent
+	} else {
+		res.W, res.H = orderExtent, rankExtent
+	}
+	for _, n := range res.Nodes {
+		switch g.Direction {
+		case BottomTop:
+			n.Y = res.H - n.Y
+		case RightLeft:
+			n.X = res.W - n.X
+		}
+		n.X += margin
+		n.Y += margin
+	}
+	res.W += 2 * margin
+	res.H += 2 * margin
+
+	for _, e := range g.Edges {
+		from, to := res.Nodes[index[e.From]], res.Nodes[index[e.To]]
+		pe := &placedEdge{Edge: e}
+		if from == to {
+			// self loop on the right side of the node
+			x, y := from.X+from.W/2, from.Y
+			pe.Points = []point{
+				{x, y - from.H/4},
+				{x + 20, y - from.H/4},
+				{x + 20, y + from.H/4},
+				{x, y + from.H/4},
+			}
+			res.W = math.Max(res.W, x+20+margin)
+		} else {
+			pe.Points = []point{
+				clip(from, point{to.X, to.Y}),
+				clip(to, point{from.X, from.Y}),
+			}
+		}
+		if e.Label != "" {
+			w, h := measure(face, e.Label)
+			pe.LabelW, pe.LabelH = w+2*labelPad, h+2*labelPad
+			a, b := pe.Points[len(pe.Points)/2-1], pe.Points[len(pe.Points)/2]
+			pe.LabelPos = point{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+			if from == to {
+				pe.LabelPos.X += pe.LabelW / 2
+				res.W = math.Max(res.W, pe.LabelPos.X+pe.LabelW/2+margin)
+			}
+		}
+		res.Edges = append(res.Edges, pe)
+	}
+	return res, nil
+}
+
+// assignRanks assigns the rank to every node using the longest path from the sources,
+// back edges found with the depth-first search are reversed to break cycles.
+func assignRanks(g *Graph, index map[string]int) []int {
+	n := len(g.Nodes)
+	succ := make([][]int, n)
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	out := make([][]int, n)
+	for _, e := range g.Edges {
+		from, to := index[e.From], index[e.To]
+		if from != to {
+			out[from] = append(out[from], to)
+		}
+	}
+	state := make([]int, n)
+	var visit func(v int)
+	visit = func(v int) {
+		state[v] = inProgress
+		for _, w := range out[v] {
+			switch state[w] {
+			case unvisited:
+				succ[v] = append(succ[v], w)
+				visit(w)
+			case inProgress:
+				// back edge, reversed
+				succ[w] = append(succ[w], v)
+			default:
+				succ[v] = append(succ[v], w)
+			}
+		}
+		state[v] = done
+	}
+	for v := range n {
+		if state[v] == unvisited {
+			visit(v)
+		}
+	}
+	// longest path layering in topological order
+	indegree := make([]int, n)
+	for v := range n {
+		for _, w := range succ[v] {
+			indegree[w]++
+		}
+	}
+	ranks := make([]int, n)
+	queue := make([]int, 0, n)
+	for v := range n {
+		if indegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range succ[v] {
+			ranks[w] = max(ranks[w], ranks[v]+1)
+			indegree[w]--
+			if indegree[w] == 0 {
+				queue = append(queue, w)
+			}
+		}
+	}
+	return ranks
+}
+
+// orderLayers groups nodes into layers and orders them with a few barycenter sweeps
+// to reduce the number of edge crossings.
+func orderLayers(g *Graph, index map[string]int, ranks []int) [][]int {
+	layerCount := 0
+	for _, r := range ranks {
+		layerCount = max(layerCount, r+1)
+	}
+	layers := make([][]int, layerCount)
+	for v, r := range ranks {
+		layers[r] = append(layers[r], v)
+	}
+	neighbors := make([][]int, len(g.Nodes))
+	for _, e := range g.Edges {
+		from, to := index[e.From], index[e.To]
+		if from != to {
+			neighbors[from] = append(neighbors[from], to)
+			neighbors[to] = append(neighbors[to], from)
+		}
+	}
+	position := make([]float64, len(g.Nodes))
+	updatePositions := func(layer []int) {
+		for i, v := range layer {
+			position[v] = float64(i)
+		}
+	}
+	for _, layer := range layers {
+		updatePositions(layer)
+	}
+	sweep := func(li, adjacent int) {
+		layer := layers[li]
+		bary := make(map[int]float64, len(layer))
+		for _, v := range layer {
+			sum, cnt := 0.0, 0
+			for _, w := range neighbors[v] {
+				if ranks[w] == adjacent {
+					sum += position[w]
+					cnt++
+				}
+			}
+			if cnt == 0 {
+				bary[v] = position[v]
+			} else {
+				bary[v] = sum / float64(cnt)
+			}
+		}
+		sort.SliceStable(layer, func(i, j int) bool {
+			return bary[layer[i]] < bary[layer[j]]
+		})
+		updatePositions(layer)
+	}
+	for range 4 {
+		for li := 1; li < layerCount; li++ {
+			sweep(li, li-1)
+		}
+		for li := layerCount - 2; li >= 0; li-- {
+			sweep(li, li+1)
+		}
+	}
+	return layers
+}
+
+// clip returns the point where the line from the node center to p crosses the node boundary.
+func clip(n *placedNode, p point) point {
+	dx, dy := p.X-n.X, p.Y-n.Y
+	if dx == 0 && dy == 0 {
+		return point{n.X, n.Y}
+	}
+	hw, hh := n.W/2, n.H/2
+	var t float64
+	switch n.Shape {
+	case ShapeEllipse:
+		t = 1 / math.Sqrt((dx*dx)/(hw*hw)+(dy*dy)/(hh*hh))
+	case ShapeDiamond:
+		t = 1 / (math.Abs(dx)/hw + math.Abs(dy)/hh)
+	default:
+		t = math.Min(hw/math.Abs(dx), hh/math.Abs(dy))
+	}
+	return point{n.X + dx*t, n.Y + dy*t}
+}
+
+// arrowHead returns the triangle of the arrow pointing to the end of the line.
+func arrowHead(from, to point) [3]point {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return [3]point{to, to, to}
+	}
+	ux, uy := dx/l, dy/l
+	bx, by := to.X-ux*arrowSize, to.Y-uy*arrowSize
+	return [3]point{
+		to,
+		{bx - uy*arrowSize/2, by + ux*arrowSize/2},
+		{bx + uy*arrowSize/2, by - ux*arrowSize/2},
+	}
+}
@@ -0,0 +1,186 @@
+package diagram
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	mermaidHeader   = regexp.MustCompile(`^(?:graph|flowchart)(?:\s+(TB|TD|BT|LR|RL))?\s*;?$`)
+	mermaidNodeID   = regexp.MustCompile(`^\s*([\w.]+)`)
+	mermaidLinkText = regexp.MustCompile(`^\s*(<?)(--|==|-\.)\s*([^\s\-=.>|][^\-=]*?)\s*(-{2,}|={2,}|\.+-)(>|o|x)?`)
+	mermaidLink     = regexp.MustCompile(`^\s*(<?)(-{2,}|={2,}|-\.+-)(>|o|x)?\s*(?:\|([^|]*)\|)?`)
+	mermaidBreak    = regexp.MustCompile(`(?i)<br\s*/?>`)
+	// node shapes, longer openings go first
+	mermaidShapes = []struct {
+		open, close string
+		shape       Shape
+	}{
+		{"(((", ")))", ShapeEllipse},
+		{"((", "))", ShapeEllipse},
+		{"([", "])", ShapeRound},
+		{"[[", "]]", ShapeBox},
+		{"[(", ")]", ShapeRound},
+		{"{{", "}}", ShapeBox},
+		{"[/", "/]", ShapeBox},
+		{"[\\", "\\]", ShapeBox},
+		{"[/", "\\]", ShapeBox},
+		{"[\\", "/]", ShapeBox},
+		{"[", "]", ShapeBox},
+		{"(", ")", ShapeRound},
+		{"{", "}", ShapeDiamond},
+		{">", "]", ShapeBox},
+	}
+)
+
+// ignored statements, subgraphs are flattened
+var mermaidIgnored = []string{
+	"subgraph", "end", "direction", "classDef", "class ", "style ", "linkStyle", "click ",
+}
+
+// parseMermaid parses the subset of the mermaid flowchart syntax:
+// node definitions with shapes and chains of links (with optional labels).
+func parseMermaid(src []byte) (*Graph, error) {
+	g := &Graph{}
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	header := false
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		for _, stmt := range strings.Split(scanner.Text(), ";") {
+			stmt = strings.TrimSpace(stmt)
+			if stmt == "" || strings.HasPrefix(stmt, "%%") {
+				continue
+			}
+			if !header {
+				m := mermaidHeader.FindStringSubmatch(stmt)
+				if m == nil {
+					return nil, fmt.Errorf("%w: only mermaid flowcharts are supported", ErrUnsupported)
+				}
+				switch m[1] {
+				case "BT":
+					g.Direction = BottomTop
+				case "LR":
+					g.Direction = LeftRight
+				case "RL":
+					g.Direction = RightLeft
+				}
+				header = true
+				continue
+			}
+			if isMermaidIgnored(stmt) {
+				continue
+			}
+			if err := g.parseMermaidStatement(stmt); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, fmt.Errorf("%w: empty mermaid diagram", ErrUnsupported)
+	}
+	return g, nil
+}
+
+func isMermaidIgnored(stmt string) bool {
+	for _, prefix := range mermaidIgnored {
+		if stmt == strings.TrimSpace(prefix) || strings.HasPrefix(stmt, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Graph) parseMermaidStatement(stmt string) error {
+	rest := stmt
+	prev, rest, err := g.parseMermaidNodes(rest)
+	if err != nil {
+		return err
+	}
+	for strings.TrimSpace(rest) != "" {
+		edge := &Edge{}
+		if m := mermaidLinkText.FindStringSubmatch(rest); m != nil {
+			edge.Label = m[3]
+			edge.Directed = m[5] == ">"
+			edge.Dashed = strings.Contains(m[2]+m[4], ".")
+			rest = rest[len(m[0]):]
+		} else if m := mermaidLink.FindStringSubmatch(rest); m != nil {
+			edge.Label = strings.TrimSpace(m[4])
+			edge.Directed = m[3] == ">"
+			edge.Dashed = strings.Contains(m[2], ".")
+			rest = rest[len(m[0]):]
+		} else {
+			return fmt.Errorf("unexpected %q", strings.TrimSpace(rest))
+		}
+		edge.Label = mermaidText(edge.Label)
+		var next []*Node
+		next, rest, err = g.parseMermaidNodes(rest)
+		if err != nil {
+			return err
+		}
+		for _, from := range prev {
+			for _, to := range next {
+				e := *edge
+				e.From, e.To = from.ID, to.ID
+				g.Edges = append(g.Edges, &e)
+			}
+		}
+		prev = next
+	}
+	return nil
+}
+
+// parseMermaidNodes parses the `&` separated list of nodes.
+func (g *Graph) parseMermaidNodes(s string) (nodes []*Node, rest string, err error) {
+	rest = s
+	for {
+		var n *Node
+		n, rest, err = g.parseMermaidNode(rest)
+		if err != nil {
+			return nil, "", err
+		}
+		nodes = append(nodes, n)
+		trimmed := strings.TrimSpace(rest)
+		if !strings.HasPrefix(trimmed, "&") {
+			return nodes, rest, nil
+		}
+		rest = trimmed[1:]
+	}
+}
+
+func (g *Graph) parseMermaidNode(s string) (*Node, string, error) {
+	m := mermaidNodeID.FindStringSubmatch(s)
+	if m == nil {
+		return nil, "", fmt.Errorf("expected node id, got %q", strings.TrimSpace(s))
+	}
+	n := g.node(m[1])
+	rest := s[len(m[0]):]
+	for _, shape := range mermaidShapes {
+		if !strings.HasPrefix(rest, shape.open) {
+			continue
+		}
+		end := strings.Index(rest[len(shape.open):], shape.close)
+		if end == -1 {
+			continue
+		}
+		n.Label = mermaidText(rest[len(shape.open) : len(shape.open)+end])
+		n.Shape = shape.shape
+		rest = rest[len(shape.open)+end+len(shape.close):]
+		break
+	}
+	return n, rest, nil
+}
+
+func mermaidText(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	return mermaidBreak.ReplaceAllString(s, "\n")
+}
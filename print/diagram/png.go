@@ -0,0 +1,116 @@
+package diagram
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/blackstork-io/fabric/print/raster"
+)
+
+var (
+	strokeRGBA = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	fillRGBA   = color.RGBA{0xee, 0xf3, 0xfb, 0xff}
+	textRGBA   = color.RGBA{0x1f, 0x1f, 0x1f, 0xff}
+)
+
+// RenderPNG renders the graph as a PNG image, for the formats that can't embed SVG.
+func RenderPNG(g *Graph) ([]byte, error) {
+	l, err := doLayout(g)
+	if err != nil {
+		return nil, err
+	}
+	c := raster.New(l.W, l.H)
+	defer c.Close()
+	for _, e := range l.Edges {
+		for i := 1; i < len(e.Points); i++ {
+			drawLine(c, e.Points[i-1], e.Points[i], e.Dashed)
+		}
+		if e.Directed {
+			head := arrowHead(e.Points[len(e.Points)-2], e.Points[len(e.Points)-1])
+			c.Fill(rasterPoints(head[:]), strokeRGBA)
+		}
+	}
+	for _, n := range l.Nodes {
+		c.Fill(rasterPoints(nodeOutline(n, 0)), strokeRGBA)
+		c.Fill(rasterPoints(nodeOutline(n, 1)), fillRGBA)
+		drawText(c, n.Label, n.X, n.Y)
+	}
+	for _, e := range l.Edges {
+		if e.Label == "" {
+			continue
+		}
+		x0, y0 := e.LabelPos.X-e.LabelW/2, e.LabelPos.Y-e.LabelH/2
+		c.Fill([]raster.Point{{X: x0, Y: y0}, {X: x0 + e.LabelW, Y: y0}, {X: x0 + e.LabelW, Y: y0 + e.LabelH}, {X: x0, Y: y0 + e.LabelH}}, color.White)
+		drawText(c, e.Label, e.LabelPos.X, e.LabelPos.Y)
+	}
+	return c.PNG()
+}
+
+// nodeOutline returns the polygon of the node shape, shrunk by inset.
+func nodeOutline(n *placedNode, inset float64) []point {
+	hw, hh := n.W/2-inset, n.H/2-inset
+	switch n.Shape {
+	case ShapeEllipse:
+		const segments = 48
+		res := make([]point, segments)
+		for i := range res {
+			a := 2 * math.Pi * float64(i) / segments
+			res[i] = point{n.X + hw*math.Cos(a), n.Y + hh*math.Sin(a)}
+		}
+		return res
+	case ShapeDiamond:
+		return []point{{n.X, n.Y - hh}, {n.X + hw, n.Y}, {n.X, n.Y + hh}, {n.X - hw, n.Y}}
+	case ShapeRound:
+		r := math.Max(0, math.Min(hh, 12-inset))
+		const segments = 8
+		var res []point
+		corners := []point{{n.X + hw - r, n.Y - hh + r}, {n.X + hw - r, n.Y + hh - r}, {n.X - hw + r, n.Y + hh - r}, {n.X - hw + r, n.Y - hh + r}}
+		for ci, center := range corners {
+			for i := 0; i <= segments; i++ {
+				a := -math.Pi/2 + float64(ci)*math.Pi/2 + math.Pi/2*float64(i)/segments
+				res = append(res, point{center.X + r*math.Cos(a), center.Y + r*math.Sin(a)})
+			}
+		}
+		return res
+	}
+	return []point{{n.X - hw, n.Y - hh}, {n.X + hw, n.Y - hh}, {n.X + hw, n.Y + hh}, {n.X - hw, n.Y + hh}}
+}
+
+// drawLine draws the line, dashed lines are split in segments.
+func drawLine(c *raster.Canvas, a, b point, dashed bool) {
+	const width = 1.0
+	l := math.Hypot(b.X-a.X, b.Y-a.Y)
+	if l == 0 {
+		return
+	}
+	if !dashed {
+		c.Line(raster.Point(a), raster.Point(b), width, strokeRGBA)
+		return
+	}
+	ux, uy := (b.X-a.X)/l, (b.Y-a.Y)/l
+	for pos := 0.0; pos < l; pos += 9 {
+		end := math.Min(pos+5, l)
+		c.Line(
+			raster.Point{X: a.X + ux*pos, Y: a.Y + uy*pos},
+			raster.Point{X: a.X + ux*end, Y: a.Y + uy*end},
+			width, strokeRGBA,
+		)
+	}
+}
+
+// drawText draws the (multiline) text centered at x, y.
+func drawText(c *raster.Canvas, text string, x, y float64) {
+	lines := textLines(text)
+	top := y - float64(len(lines))*lineHeight/2 + lineHeight*0.75
+	for i, line := range lines {
+		c.Text(line, x, top+float64(i)*lineHeight, fontSize, false, raster.AnchorMiddle, textRGBA)
+	}
+}
+
+func rasterPoints(pts []point) []raster.Point {
+	res := make([]raster.Point, len(pts))
+	for i, p := range pts {
+		res[i] = raster.Point(p)
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+)
+
+const (
+	strokeColor = "#333333"
+	fillColor   = "#eef3fb"
+	textColor   = "#1f1f1f"
+)
+
+// RenderSVG renders the graph as a standalone SVG image.
+// The output contains no blank lines, so it can be embedded in markdown as an HTML block.
+func RenderSVG(g *Graph) ([]byte, error) {
+	l, err := doLayout(g)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(
+		&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Go, Helvetica, Arial, sans-serif" font-size="%.0f">`+"\n",
+		math.Ceil(l.W), math.Ceil(l.H), math.Ceil(l.W), math.Ceil(l.H), fontSize,
+	)
+	for _, e := range l.Edges {
+		dash := ""
+		if e.Dashed {
+			dash = ` stroke-dasharray="5,4"`
+		}
+		buf.WriteString(`<polyline fill="none" stroke="` + strokeColor + `"` + dash + ` points="`)
+		for i, p := range e.Points {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			fmt.Fprintf(&buf, "%.1f,%.1f", p.X, p.Y)
+		}
+		buf.WriteString("\"/>\n")
+		if e.Directed {
+			head := arrowHead(e.Points[len(e.Points)-2], e.Points[len(e.Points)-1])
+			fmt.Fprintf(
+				&buf, `<polygon fill="%s" points="%.1f,%.1f %.1f,%.1f %.1f,%.1f"/>`+"\n",
+				strokeColor, head[0].X, head[0].Y, head[1].X, head[1].Y, head[2].X, head[2].Y,
+			)
+		}
+	}
+	for _, n := range l.Nodes {
+		switch n.Shape {
+		case ShapeEllipse:
+			fmt.Fprintf(
+				&buf, `<ellipse cx="%.1f" cy="%.1f" rx="%.1f" ry="%.1f" fill="%s" stroke="%s"/>`+"\n",
+				n.X, n.Y, n.W/2, n.H/2, fillColor, strokeColor,
+			)
+		case ShapeDiamond:
+			fmt.Fprintf(
+				&buf, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s" stroke="%s"/>`+"\n",
+				n.X, n.Y-n.H/2, n.X+n.W/2, n.Y, n.X, n.Y+n.H/2, n.X-n.W/2, n.Y, fillColor, strokeColor,
+			)
+		default:
+			radius := 0.0
+			if n.Shape == ShapeRound {
+				radius = math.Min(n.H/2, 12)
+			}
+			fmt.Fprintf(
+				&buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%.1f" fill="%s" stroke="%s"/>`+"\n",
+				n.X-n.W/2, n.Y-n.H/2, n.W, n.H, radius, fillColor, strokeColor,
+			)
+		}
+		writeSVGText(&buf, n.Label, n.X, n.Y)
+	}
+	for _, e := range l.Edges {
+		if e.Label == "" {
+			continue
+		}
+		fmt.Fprintf(
+			&buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#ffffff"/>`+"\n",
+			e.LabelPos.X-e.LabelW/2, e.LabelPos.Y-e.LabelH/2, e.LabelW, e.LabelH,
+		)
+		writeSVGText(&buf, e.Label, e.LabelPos.X, e.LabelPos.Y)
+	}
+	buf.WriteString("</svg>")
+	return buf.Bytes(), nil
+}
+
+// writeSVGText writes the (multiline) text centered at x, y.
+func writeSVGText(buf *bytes.Buffer, text string, x, y float64) {
+	lines := textLines(text)
+	if len(lines) == 0 {
+		return
+	}
+	// baseline of the first line
+	top := y - float64(len(lines))*lineHeight/2 + lineHeight*0.75
+	fmt.Fprintf(buf, `<text text-anchor="middle" fill="%s">`, textColor)
+	for i, line := range lines {
+		fmt.Fprintf(buf, `<tspan x="%.1f" y="%.1f">%s</tspan>`, x, top+float64(i)*lineHeight, html.EscapeString(line))
+	}
+	buf.WriteString("</text>\n")
+}
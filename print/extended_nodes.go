@@ -0,0 +1,483 @@
+package print
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	markdown "github.com/blackstork-io/goldmark-markdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+)
+
+// LowerExtendedNodes replaces extended markdown nodes (footnotes, definition lists, admonitions,
+// details, math and heading attributes) with nodes supported by the printer of the given format.
+//
+//   - md: extended nodes are replaced with their markdown syntax (footnotes, definition lists,
+//     GitHub-style alerts, `<details>` tags and `$` delimited math), heading attributes are dropped
+//   - html: admonitions, details and math are replaced with html, heading attributes are moved
+//     into `{#id}` suffix, the rest is the same as md and is expected to be parsed with
+//     the corresponding goldmark extensions
+//   - pdf: extended nodes are replaced with the basic markdown nodes
+func LowerExtendedNodes(el plugin.Content, format plugin.OutputFormat) error {
+	return ReplaceNodesInContent(el, func(src *astsrc.ASTSource, n ast.Node) (ast.Node, error) {
+		l := lowering{
+			src:    src,
+			format: format,
+		}
+		return l.replace(n)
+	})
+}
+
+type lowering struct {
+	src    *astsrc.ASTSource
+	format plugin.OutputFormat
+}
+
+func (l lowering) replace(n ast.Node) (ast.Node, error) {
+	switch l.format {
+	case plugin.OutputFormatPDF:
+		return l.replacePDF(n)
+	case plugin.OutputFormatHTML:
+		switch n := n.(type) {
+		case *nodes.Admonition:
+			return l.admonitionHTML(n)
+		case *nodes.Details:
+			return l.detailsHTML(n)
+		case *nodes.MathBlock:
+			var buf bytes.Buffer
+			buf.WriteString(`<div class="math display">\[`)
+			for _, line := range l.mathLines(n) {
+				buf.WriteString(html.EscapeString(string(line)))
+				buf.WriteByte('\n')
+			}
+			buf.WriteString(`\]</div>`)
+			return newTrustedHTML(buf.Bytes(), false), nil
+		case *nodes.MathInline:
+			return newTrustedHTML(fmt.Appendf(nil, `<span class="math inline">%s</span>`, html.EscapeString(`\(`+string(n.Value)+`\)`)), true), nil
+		}
+	}
+	return l.replaceMD(n)
+}
+
+func (l lowering) replaceMD(n ast.Node) (ast.Node, error) {
+	switch n := n.(type) {
+	case *ast.Heading:
+		if l.format == plugin.OutputFormatHTML {
+			l.headingAttributes(n)
+		} else {
+			// GitHub and most markdown viewers don't support the attribute syntax
+			n.RemoveAttributes()
+		}
+		return n, nil
+	case *east.FootnoteLink:
+		return l.rawHTML(fmt.Appendf(nil, "[^%d]", n.Index)), nil
+	case *east.FootnoteBacklink:
+		return nil, nil
+	case *east.FootnoteList:
+		var buf bytes.Buffer
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			footnote, ok := c.(*east.Footnote)
+			if !ok {
+				continue
+			}
+			content, err := l.renderChildren(footnote)
+			if err != nil {
+				return nil, err
+			}
+			if buf.Len() > 0 {
+				buf.WriteByte('\n')
+			}
+			fmt.Fprintf(&buf, "[^%d]: ", footnote.Index)
+			writeIndented(&buf, content, "    ")
+		}
+		return l.htmlBlock(n, buf.Bytes()), nil
+	case *east.DefinitionList:
+		var buf bytes.Buffer
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			content, err := l.renderChildren(c)
+			if err != nil {
+				return nil, err
+			}
+			switch c.(type) {
+			case *east.DefinitionTerm:
+				if buf.Len() > 0 {
+					buf.WriteByte('\n')
+				}
+				buf.Write(content)
+				buf.WriteByte('\n')
+			case *east.DefinitionDescription:
+				buf.WriteString(":   ")
+				writeIndented(&buf, content, "    ")
+			}
+		}
+		return l.htmlBlock(n, buf.Bytes()), nil
+	case *nodes.Admonition:
+		quote := ast.NewBlockquote()
+		marker := ast.NewRawTextSegment(l.src.AppendString("[!" + strings.ToUpper(n.AdmonitionKind) + "]"))
+		para := ast.NewParagraph()
+		para.AppendChild(para, marker)
+		if n.Title != "" {
+			marker.SetSoftLineBreak(true)
+			para.AppendChild(para, l.strong(n.Title))
+		}
+		quote.AppendChild(quote, para)
+		moveChildren(quote, n)
+		quote.SetBlankPreviousLines(true)
+		return quote, nil
+	case *nodes.Details:
+		// GitHub and most markdown viewers support the html tags
+		return l.detailsHTML(n)
+	case *nodes.MathBlock:
+		var buf bytes.Buffer
+		buf.WriteString("$$\n")
+		for _, line := range l.mathLines(n) {
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		buf.WriteString("$$")
+		return l.htmlBlock(n, buf.Bytes()), nil
+	case *nodes.MathInline:
+		return l.rawHTML(fmt.Appendf(nil, "$%s$", n.Value)), nil
+	}
+	return n, nil
+}
+
+func (l lowering) replacePDF(n ast.Node) (ast.Node, error) {
+	switch n := n.(type) {
+	case *ast.Heading:
+		n.RemoveAttributes()
+		return n, nil
+	case *east.FootnoteLink:
+		return ast.NewRawTextSegment(l.src.Appendf("[%d]", n.Index)), nil
+	case *east.FootnoteBacklink:
+		return nil, nil
+	case *east.FootnoteList:
+		parent := n.Parent()
+		hr := ast.NewThematicBreak()
+		hr.SetBlankPreviousLines(true)
+		parent.InsertBefore(parent, n, hr)
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			footnote, ok := c.(*east.Footnote)
+			if !ok {
+				continue
+			}
+			if err := l.lowerChildren(footnote); err != nil {
+				return nil, err
+			}
+			marker := ast.NewRawTextSegment(l.src.Appendf("[%d] ", footnote.Index))
+			if para, ok := footnote.FirstChild().(*ast.Paragraph); ok {
+				para.InsertBefore(para, para.FirstChild(), marker)
+			} else {
+				para := ast.NewParagraph()
+				para.AppendChild(para, marker)
+				footnote.InsertBefore(footnote, footnote.FirstChild(), para)
+			}
+			moveChildrenBefore(parent, n, footnote)
+		}
+		return nil, nil
+	case *east.DefinitionList:
+		parent := n.Parent()
+		if err := l.lowerChildren(n); err != nil {
+			return nil, err
+		}
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			switch c.(type) {
+			case *east.DefinitionTerm:
+				para := ast.NewParagraph()
+				strong := ast.NewEmphasis(2)
+				moveChildren(strong, c)
+				para.AppendChild(para, strong)
+				para.SetBlankPreviousLines(true)
+				parent.InsertBefore(parent, n, para)
+			case *east.DefinitionDescription:
+				quote := ast.NewBlockquote()
+				moveChildren(quote, c)
+				quote.SetBlankPreviousLines(true)
+				parent.InsertBefore(parent, n, quote)
+			}
+		}
+		return nil, nil
+	case *nodes.Admonition:
+		quote := ast.NewBlockquote()
+		para := ast.NewParagraph()
+		// the pdf printer colors the paragraphs wrapped in a colored span
+		para.AppendChild(para, l.rawHTML(fmt.Appendf(nil, `<span style="color: %s">`, admonitionColor(n))))
+		para.AppendChild(para, l.strong(admonitionTitle(n)))
+		para.AppendChild(para, l.rawHTML([]byte("</span>")))
+		quote.AppendChild(quote, para)
+		moveChildren(quote, n)
+		quote.SetBlankPreviousLines(true)
+		return quote, nil
+	case *nodes.Details:
+		// no way to collapse the content, the summary is followed by the expanded content
+		quote := ast.NewBlockquote()
+		para := ast.NewParagraph()
+		para.AppendChild(para, l.strong(n.Summary))
+		quote.AppendChild(quote, para)
+		moveChildren(quote, n)
+		quote.SetBlankPreviousLines(true)
+		return quote, nil
+	case *nodes.MathBlock:
+		code := ast.NewCodeBlock()
+		code.SetLines(l.src.AppendMultiple(l.mathLines(n)))
+		code.SetBlankPreviousLines(true)
+		return code, nil
+	case *nodes.MathInline:
+		code := ast.NewCodeSpan()
+		code.AppendChild(code, ast.NewRawTextSegment(l.src.Append(n.Value)))
+		return code, nil
+	}
+	return n, nil
+}
+
+func (l lowering) admonitionHTML(n *nodes.Admonition) (ast.Node, error) {
+	if err := l.lowerChildren(n); err != nil {
+		return nil, err
+	}
+	parent := n.Parent()
+	parent.InsertBefore(parent, n, newTrustedHTML(fmt.Appendf(
+		nil, "<div class=\"admonition %s\">\n<p class=\"admonition-title\">%s</p>",
+		html.EscapeString(strings.ToLower(n.AdmonitionKind)), html.EscapeString(admonitionTitle(n)),
+	), false))
+	moveChildrenBefore(parent, n, n)
+	return newTrustedHTML([]byte("</div>"), false), nil
+}
+
+// detailsHTML replaces the details block with the opening and closing tags around its content,
+// so the content stays markdown.
+func (l lowering) detailsHTML(n *nodes.Details) (ast.Node, error) {
+	if err := l.lowerChildren(n); err != nil {
+		return nil, err
+	}
+	parent := n.Parent()
+	tag := "<details>"
+	if n.Open {
+		tag = "<details open>"
+	}
+	parent.InsertBefore(parent, n, l.blockHTML(n, fmt.Appendf(nil, "%s\n<summary>%s</summary>", tag, html.EscapeString(n.Summary))))
+	first := true
+	for _, c := range nodes.Children(n) {
+		if first {
+			// blank line terminates the html block
+			c.SetBlankPreviousLines(true)
+			first = false
+		}
+		parent.InsertBefore(parent, n, c)
+	}
+	return l.blockHTML(n, []byte("</details>")), nil
+}
+
+// headingAttributes moves heading attributes into the `{#id .class key="value"}` suffix,
+// parsed back by the html printer.
+func (l lowering) headingAttributes(n *ast.Heading) {
+	attrs := n.Attributes()
+	if len(attrs) == 0 {
+		return
+	}
+	var parts []string
+	for _, attr := range attrs {
+		var value string
+		switch v := attr.Value.(type) {
+		case []byte:
+			value = string(v)
+		case string:
+			value = v
+		default:
+			value = fmt.Sprint(v)
+		}
+		switch name := string(attr.Name); name {
+		case "id":
+			parts = append(parts, "#"+value)
+		case "class":
+			for _, class := range strings.Fields(value) {
+				parts = append(parts, "."+class)
+			}
+		default:
+			parts = append(parts, name+"="+strconv.Quote(value))
+		}
+	}
+	n.RemoveAttributes()
+	n.AppendChild(n, l.rawHTML([]byte(" {"+strings.Join(parts, " ")+"}")))
+}
+
+// lowerChildren lowers the extended nodes among the children of n.
+func (l lowering) lowerChildren(n ast.Node) error {
+	c := n.FirstChild()
+	for c != nil {
+		repl, err := ReplaceNodes(c, l.replace)
+		if err != nil {
+			return err
+		}
+		next := c.NextSibling()
+		switch repl {
+		case c:
+		case nil:
+			n.RemoveChild(n, c)
+		default:
+			n.ReplaceChild(n, c, repl)
+		}
+		c = next
+	}
+	return nil
+}
+
+// renderChildren lowers and renders the children of n to markdown.
+func (l lowering) renderChildren(n ast.Node) ([]byte, error) {
+	if err := l.lowerChildren(n); err != nil {
+		return nil, err
+	}
+	var container ast.Node = ast.NewDocument()
+	if n.Type() == ast.TypeBlock && n.FirstChild() != nil && n.FirstChild().Type() == ast.TypeInline {
+		para := ast.NewParagraph()
+		container.AppendChild(container, para)
+		container = para
+	}
+	moveChildren(container, n)
+	for container.Parent() != nil {
+		container = container.Parent()
+	}
+	var buf bytes.Buffer
+	err := goldmark.New(
+		plugin.BaseMarkdownOptions,
+		goldmark.WithExtensions(
+			markdown.NewRenderer(
+				markdown.WithIgnoredNodes(
+					nodes.CustomBlockKind,
+					nodes.CustomInlineKind,
+				),
+			),
+		),
+	).Renderer().Render(&buf, l.src.AsBytes(), container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %q node: %w", n.Kind(), err)
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+func (l lowering) mathLines(n *nodes.MathBlock) [][]byte {
+	lines := make([][]byte, 0, n.Lines().Len())
+	for i := range n.Lines().Len() {
+		seg := n.Lines().At(i)
+		lines = append(lines, bytes.TrimRight(seg.Value(l.src.AsBytes()), "\n"))
+	}
+	return lines
+}
+
+// blockHTML returns the block of html: the trusted html for the html format,
+// the html block for the markdown.
+func (l lowering) blockHTML(n ast.Node, content []byte) ast.Node {
+	if l.format == plugin.OutputFormatHTML {
+		return newTrustedHTML(content, false)
+	}
+	return l.htmlBlock(n, content)
+}
+
+func (l lowering) htmlBlock(n ast.Node, content []byte) ast.Node {
+	block := ast.NewHTMLBlock(ast.HTMLBlockType7)
+	block.Lines().Append(l.src.Append(bytes.TrimRight(content, "\n")))
+	block.SetBlankPreviousLines(true)
+	if next := n.NextSibling(); next != nil && next.Type() == ast.TypeBlock {
+		next.SetBlankPreviousLines(true)
+	}
+	return block
+}
+
+func (l lowering) rawHTML(content []byte) ast.Node {
+	raw := ast.NewRawHTML()
+	raw.Segments.Append(l.src.Append(content))
+	return raw
+}
+
+func (l lowering) strong(text string) ast.Node {
+	strong := ast.NewEmphasis(2)
+	strong.AppendChild(strong, ast.NewTextSegment(l.src.AppendString(text)))
+	return strong
+}
+
+func admonitionTitle(n *nodes.Admonition) string {
+	if n.Title != "" {
+		return n.Title
+	}
+	kind := strings.ToLower(n.AdmonitionKind)
+	if kind == "" {
+		return ""
+	}
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+// admonitionColors are the colors of the admonition titles by kind.
+var admonitionColors = map[string]string{
+	"note":      "#0969da",
+	"tip":       "#1a7f37",
+	"important": "#8250df",
+	"warning":   "#9a6700",
+	"caution":   "#d1242f",
+}
+
+// ExtendedNodesCSS returns the stylesheet of the html produced for admonitions and details.
+func ExtendedNodesCSS() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf,
+		".admonition { margin: 1em 0; padding: 0.5em 1em; border-left: 4px solid %[1]s; background: #f6f8fa; }\n"+
+			".admonition > :last-child { margin-bottom: 0; }\n"+
+			".admonition-title { margin-top: 0; font-weight: bold; color: %[1]s; }\n",
+		admonitionColors["note"],
+	)
+	kinds := make([]string, 0, len(admonitionColors))
+	for kind := range admonitionColors {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		if kind == "note" {
+			continue
+		}
+		fmt.Fprintf(&buf, ".admonition.%[1]s { border-color: %[2]s; }\n", kind, admonitionColors[kind])
+		fmt.Fprintf(&buf, ".admonition.%[1]s .admonition-title { color: %[2]s; }\n", kind, admonitionColors[kind])
+	}
+	buf.WriteString("details { margin: 1em 0; padding: 0.5em 1em; border: 1px solid #d0d7de; border-radius: 6px; }\n")
+	buf.WriteString("details > summary { cursor: pointer; font-weight: bold; }\n")
+	return buf.String()
+}
+
+func admonitionColor(n *nodes.Admonition) string {
+	if color, ok := admonitionColors[strings.ToLower(n.AdmonitionKind)]; ok {
+		return color
+	}
+	return admonitionColors["note"]
+}
+
+// writeIndented writes content, indenting all lines except the first one.
+func writeIndented(buf *bytes.Buffer, content []byte, indent string) {
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		if i > 0 {
+			buf.WriteByte('\n')
+			if len(line) > 0 {
+				buf.WriteString(indent)
+			}
+		}
+		buf.Write(line)
+	}
+	buf.WriteByte('\n')
+}
+
+func moveChildren(dst, src ast.Node) {
+	for _, c := range nodes.Children(src) {
+		dst.AppendChild(dst, c)
+	}
+}
+
+func moveChildrenBefore(parent, before, src ast.Node) {
+	for _, c := range nodes.Children(src) {
+		parent.InsertBefore(parent, before, c)
+	}
+}
@@ -0,0 +1,96 @@
+package print_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+func extendedContent() plugin.Content {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElement(
+		ast.Header(2, ast.Text("Summary")).SetID("summary"),
+		ast.Paragraph(ast.Text("Energy"), ast.FootnoteLink(1), ast.Text(" is "), ast.MathInline("E=mc^2")),
+		ast.Admonition("warning", "", ast.Paragraph(ast.Text("Be careful"))),
+		ast.Details("Raw logs", false, ast.Paragraph(ast.Text("Log line"))),
+		ast.DefinitionList(
+			ast.DefinitionTerm(ast.Text("IOC")),
+			ast.DefinitionDescription(ast.Paragraph(ast.Text("Indicator of compromise"))),
+		),
+		ast.MathBlock("a^2 + b^2 = c^2"),
+		ast.FootnoteList(
+			ast.Footnote(1, ast.Paragraph(ast.Text("Einstein, 1905"))),
+		),
+	), nil)
+	return section
+}
+
+func TestExtendedNodesMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := mdprint.New().Print(context.Background(), buf, extendedContent())
+	require.NoError(t, err)
+	assert.Equal(t, `## Summary
+Energy[^1] is $E=mc^2$
+
+> [!WARNING]
+> 
+> Be careful
+
+<details>
+<summary>Raw logs</summary>
+
+Log line
+
+</details>
+
+IOC
+:   Indicator of compromise
+
+$$
+a^2 + b^2 = c^2
+$$
+
+[^1]: Einstein, 1905
+`, buf.String())
+}
+
+func TestExtendedNodesHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := htmlprint.New().Print(context.Background(), buf, extendedContent())
+	require.NoError(t, err)
+	html := buf.String()
+	assert.Contains(t, html, `<h2 id="summary">Summary</h2>`)
+	assert.Contains(t, html, `<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup>`)
+	assert.Contains(t, html, `<span class="math inline">\(E=mc^2\)</span>`)
+	assert.Contains(t, html, `<div class="admonition warning">`)
+	assert.Contains(t, html, `<p class="admonition-title">Warning</p>`)
+	assert.Contains(t, html, "<details>\n<summary>Raw logs</summary>")
+	assert.Contains(t, html, "<p>Log line</p>\n</details>")
+	assert.Contains(t, html, "<dt>IOC</dt>\n<dd>Indicator of compromise</dd>")
+	assert.Contains(t, html, `<div class="math display">\[a^2 + b^2 = c^2`)
+	assert.Contains(t, html, `<li id="fn:1">`)
+	assert.Contains(t, html, `.admonition.warning { border-color: #9a6700; }`)
+	assert.Contains(t, html, `details > summary { cursor: pointer; font-weight: bold; }`)
+}
+
+func TestMathBlankLines(t *testing.T) {
+	content := plugin.NewElement(ast.MathBlock("a = 1\n\nb = 2"))
+
+	buf := &bytes.Buffer{}
+	err := mdprint.New().Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.Equal(t, "$$\na = 1\n\nb = 2\n$$\n", buf.String())
+
+	buf.Reset()
+	err = htmlprint.New().Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "<div class=\"math display\">\\[a = 1\n\nb = 2\n\\]</div>")
+}
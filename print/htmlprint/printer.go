@@ -11,11 +11,16 @@ import (
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
 	"gopkg.in/yaml.v3"
 
 	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/diagram"
 	"github.com/blackstork-io/fabric/print/mdprint"
 )
 
@@ -36,20 +41,26 @@ type Data struct {
 	Title       string
 	Description string
 	Content     template.HTML
-	CSS         template.CSS
-	JS          template.JS
-	JSSources   []template.URL
-	CSSSources  []template.URL
+	// BaseCSS is the stylesheet of the html produced by the printer
+	BaseCSS    template.CSS
+	CSS        template.CSS
+	JS         template.JS
+	JSSources  []template.URL
+	CSSSources []template.URL
 }
 
 // Printer is the interface for printing html content.
 type Printer struct {
-	md mdprint.Printer
+	md   mdprint.Printer
+	opts print.Options
 }
 
 // New creates a new html printer.
-func New() Printer {
-	return Printer{mdprint.New()}
+func New(opts ...print.Option) Printer {
+	return Printer{
+		md:   mdprint.New(opts...),
+		opts: print.MakeOptions(opts...),
+	}
 }
 
 // PrintString is a helper function to print html content to a string.
@@ -69,7 +80,8 @@ func Print(w io.Writer, el plugin.Content) error {
 
 func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err error) {
 	data := Data{
-		Title: "Untitled",
+		Title:   "Untitled",
+		BaseCSS: template.CSS(print.ExtendedNodesCSS()),
 	}
 	if title, ok := p.firstTitle(el); ok {
 		data.Title = title
@@ -79,18 +91,47 @@ func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err
 		return err
 	}
 
+	el, err = print.RenderCustomNodes(ctx, el, p.opts.NodeRenderers, plugin.OutputFormatHTML)
+	if err != nil {
+		return err
+	}
+	err = print.LowerExtendedNodes(el, plugin.OutputFormatHTML)
+	if err != nil {
+		return err
+	}
+	// only the html of the node renderers and the printer is trusted, raw html of the content is omitted
+	trusted, err := print.NewTrustedHTML()
+	if err != nil {
+		return err
+	}
+	err = trusted.ReplaceWithPlaceholders(el)
+	if err != nil {
+		return err
+	}
 	buf := bytes.NewBuffer(nil)
 	if err := p.md.Print(ctx, buf, el); err != nil {
 		return err
 	}
 	md := goldmark.New(
 		plugin.BaseMarkdownOptions,
+		goldmark.WithExtensions(
+			extension.Footnote,
+			extension.DefinitionList,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(print.CodeHighlightStyle),
+			),
+			&diagram.Extension{},
+		),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
+			parser.WithAttribute(),
 		),
 		goldmark.WithRendererOptions(
 			html.WithHardWraps(),
 			html.WithXHTML(),
+			renderer.WithNodeRenderers(
+				trusted.Renderer(html.WithXHTML()),
+			),
 		),
 	)
 	buff := bytes.NewBuffer(nil)
@@ -18,11 +18,15 @@ import (
 )
 
 // Printer is the interface for printing markdown content.
-type Printer struct{}
+type Printer struct {
+	opts print.Options
+}
 
 // New creates a new markdown printer.
-func New() Printer {
-	return Printer{}
+func New(opts ...print.Option) Printer {
+	return Printer{
+		opts: print.MakeOptions(opts...),
+	}
 }
 
 // PrintString is a helper function to print markdown content to a string.
@@ -41,6 +45,14 @@ func Print(w io.Writer, el plugin.Content) error {
 }
 
 func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err error) {
+	el, err = print.RenderCustomNodes(ctx, el, p.opts.NodeRenderers, plugin.OutputFormatMD)
+	if err != nil {
+		return err
+	}
+	err = print.LowerExtendedNodes(el, plugin.OutputFormatMD)
+	if err != nil {
+		return err
+	}
 	return p.printContent(w, el)
 }
 
@@ -66,14 +78,14 @@ func (p Printer) printContentElement(w io.Writer, source *astsrc.ASTSource, node
 			slog.Info("OtherBlock found in AST, replacing with message segment")
 			p := ast.NewCodeBlock()
 			p.AppendChild(p, ast.NewRawTextSegment(
-				source.Appendf("<node of type %q is not supported by the pdf renderer>", nT.Data.GetTypeUrl()),
+				source.Appendf("<node of type %q is not supported by the markdown renderer>", nT.Data.GetTypeUrl()),
 			))
 			return p, nil
 		case *nodes.CustomInline:
 			slog.Info("OtherInline found in AST, replacing with message segment")
 			p := ast.NewCodeSpan()
 			p.AppendChild(p, ast.NewRawTextSegment(
-				source.Appendf("<node of type %q is not supported by the pdf renderer>", nT.Data.GetTypeUrl()),
+				source.Appendf("<node of type %q is not supported by the markdown renderer>", nT.Data.GetTypeUrl()),
 			))
 			return p, nil
 		}
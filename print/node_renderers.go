@@ -0,0 +1,186 @@
+package print
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+)
+
+// Options are the options shared by the printers.
+type Options struct {
+	NodeRenderers plugin.NodeRenderers
+}
+
+// Option configures the printer.
+type Option func(*Options)
+
+// WithNodeRenderers sets the renderers used for custom AST nodes.
+func WithNodeRenderers(renderers plugin.NodeRenderers) Option {
+	return func(o *Options) {
+		o.NodeRenderers = renderers
+	}
+}
+
+// MakeOptions applies the options.
+func MakeOptions(opts ...Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// RenderCustomNodes returns a copy of the content with the custom AST nodes replaced with their
+// representation in the given format, produced by the plugin-provided node renderers.
+// The html is kept in the trusted html nodes (see [TrustedHTMLBlock]). The markdown is kept
+// byte-exact for the md format, for the other formats (the pdf one and the md fallback)
+// it is parsed and inserted in place of the node.
+// Nodes without a suitable renderer are left as is.
+func RenderCustomNodes(ctx context.Context, el plugin.Content, renderers plugin.NodeRenderers, format plugin.OutputFormat) (plugin.Content, error) {
+	return renderCustomNodes(ctx, el, renderers, format, false)
+}
+
+// PrerenderCustomNodes is like [RenderCustomNodes], but the html is kept in the custom nodes
+// of [TrustedHTMLTypeURL] type, so the content can be serialized and passed to the plugins.
+func PrerenderCustomNodes(ctx context.Context, el plugin.Content, renderers plugin.NodeRenderers, format plugin.OutputFormat) (plugin.Content, error) {
+	return renderCustomNodes(ctx, el, renderers, format, true)
+}
+
+func renderCustomNodes(
+	ctx context.Context,
+	el plugin.Content,
+	renderers plugin.NodeRenderers,
+	format plugin.OutputFormat,
+	prerender bool,
+) (plugin.Content, error) {
+	el = plugin.CloneContent(el)
+	err := ReplaceNodesInContent(el, func(src *astsrc.ASTSource, n ast.Node) (ast.Node, error) {
+		var params plugin.RenderNodeParams
+		switch n := n.(type) {
+		case *nodes.CustomBlock:
+			params.Node = n.Data
+		case *nodes.CustomInline:
+			params.Node = n.Data
+			params.Inline = true
+		default:
+			return n, nil
+		}
+		if params.Node.GetTypeUrl() == TrustedHTMLTypeURL {
+			if prerender {
+				return n, nil
+			}
+			return newTrustedHTML(params.Node.GetValue(), params.Inline), nil
+		}
+		params.Format = format
+		content, contentFormat, err := renderCustomNode(ctx, renderers, &params)
+		if err != nil || content == nil {
+			return n, err
+		}
+		if contentFormat == plugin.OutputFormatHTML {
+			if prerender {
+				return prerenderedHTML(content, params.Inline), nil
+			}
+			return newTrustedHTML(content, params.Inline), nil
+		}
+		if format == plugin.OutputFormatMD {
+			return verbatimMarkdown(src, n, content), nil
+		}
+		insertMarkdown(src, n, content)
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return el, nil
+}
+
+// prerenderedHTML returns the custom node holding the html rendered in advance.
+func prerenderedHTML(content []byte, inline bool) ast.Node {
+	data := &anypb.Any{
+		TypeUrl: TrustedHTMLTypeURL,
+		Value:   content,
+	}
+	if inline {
+		return &nodes.CustomInline{Data: data}
+	}
+	return &nodes.CustomBlock{Data: data}
+}
+
+func renderCustomNode(ctx context.Context, renderers plugin.NodeRenderers, params *plugin.RenderNodeParams) ([]byte, plugin.OutputFormat, error) {
+	renderer, format, ok := renderers.Lookup(params.Node, params.Format)
+	if !ok {
+		return nil, format, nil
+	}
+	params.Format = format
+	res, diags := renderer.Execute(ctx, params)
+	if diags.HasErrors() {
+		return nil, format, fmt.Errorf("failed to render node of type %q: %w", params.Node.GetTypeUrl(), diags)
+	}
+	if res == nil {
+		return nil, format, nil
+	}
+	return res.Content, format, nil
+}
+
+// verbatimMarkdown returns the node holding the markdown as is. The markdown printer writes
+// the lines of the html nodes unchanged, so the output of the node renderer is kept byte-exact.
+func verbatimMarkdown(src *astsrc.ASTSource, n ast.Node, content []byte) ast.Node {
+	if n.Type() == ast.TypeInline {
+		raw := ast.NewRawHTML()
+		raw.Segments.Append(src.Append(content))
+		return raw
+	}
+	block := ast.NewHTMLBlock(ast.HTMLBlockType7)
+	block.SetLines(src.AppendMultiple(bytes.Split(bytes.TrimRight(content, "\n"), []byte("\n"))))
+	// separate rendered content from the surrounding blocks
+	block.SetBlankPreviousLines(true)
+	if next := n.NextSibling(); next != nil && next.Type() == ast.TypeBlock {
+		next.SetBlankPreviousLines(true)
+	}
+	return block
+}
+
+// insertMarkdown parses the markdown produced by the node renderer and inserts the nodes before n.
+// Inline nodes are replaced with the content of the first paragraph.
+func insertMarkdown(src *astsrc.ASTSource, n ast.Node, content []byte) {
+	var container ast.Node = parseMarkdown(src, content)
+	if n.Type() == ast.TypeInline {
+		container = container.FirstChild()
+		if container == nil {
+			return
+		}
+	}
+	parent := n.Parent()
+	for _, c := range nodes.Children(container) {
+		if c.Type() == ast.TypeBlock {
+			// separate rendered content from the surrounding blocks
+			c.SetBlankPreviousLines(true)
+		}
+		parent.InsertBefore(parent, n, c)
+	}
+	if next := n.NextSibling(); n.Type() == ast.TypeBlock && next != nil && next.Type() == ast.TypeBlock {
+		next.SetBlankPreviousLines(true)
+	}
+}
+
+// parseMarkdown appends the markdown to the source and parses it,
+// the segments of the parsed nodes point to the source.
+func parseMarkdown(src *astsrc.ASTSource, content []byte) ast.Node {
+	if len(*src) > 0 && (*src)[len(*src)-1] != '\n' {
+		src.AppendString("\n")
+	}
+	start := len(*src)
+	src.Append(content)
+	reader := text.NewReader(src.AsBytes())
+	reader.Advance(start)
+	return goldmark.New(plugin.BaseMarkdownOptions).Parser().Parse(reader)
+}
@@ -0,0 +1,154 @@
+package print_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/pkg/diagnostics"
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/htmlprint"
+	"github.com/blackstork-io/fabric/print/mdprint"
+)
+
+const testTypeURL = "blackstork.io/test.Widget"
+
+func testRenderers(formats ...plugin.OutputFormat) plugin.NodeRenderers {
+	return plugin.NodeRenderers{
+		testTypeURL: {
+			Formats: formats,
+			RenderFunc: func(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+				var content string
+				switch {
+				case params.Format == plugin.OutputFormatHTML:
+					content = `<div class="widget">` + string(params.Node.GetValue()) + `</div>`
+				case params.Inline:
+					content = "`" + string(params.Node.GetValue()) + "`"
+				default:
+					content = "**" + string(params.Node.GetValue()) + "**"
+				}
+				return &plugin.RenderNodeResult{Content: []byte(content)}, nil
+			},
+		},
+	}
+}
+
+func testContent() plugin.Content {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElement(
+		ast.Paragraph(ast.Text("before")),
+		ast.CustomBlock(&anypb.Any{TypeUrl: testTypeURL, Value: []byte("block")}),
+		ast.Paragraph(ast.Text("inline "), ast.CustomInline(&anypb.Any{TypeUrl: testTypeURL, Value: []byte("value")})),
+	), nil)
+	return section
+}
+
+func TestRenderCustomNodesMarkdown(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := mdprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatMD))).
+		Print(context.Background(), buf, testContent())
+	require.NoError(t, err)
+	assert.Equal(t, "before\n\n**block**\n\ninline `value`\n", buf.String())
+}
+
+func TestRenderCustomNodesHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := htmlprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatMD, plugin.OutputFormatHTML))).
+		Print(context.Background(), buf, testContent())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `<div class="widget">block</div>`)
+	assert.Contains(t, buf.String(), `inline <div class="widget">value</div>`)
+}
+
+func TestRenderCustomNodesMarkdownFallback(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := htmlprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatMD))).
+		Print(context.Background(), buf, testContent())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `<strong>block</strong>`)
+	assert.Contains(t, buf.String(), `inline <code>value</code>`)
+}
+
+func TestRenderCustomNodesNoRenderer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := mdprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatHTML))).
+		Print(context.Background(), buf, testContent())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "is not supported by the markdown renderer")
+}
+
+func TestRenderCustomNodesKeepsContent(t *testing.T) {
+	content := testContent()
+	before := mdprint.PrintString(content)
+	buf := &bytes.Buffer{}
+	err := htmlprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatMD, plugin.OutputFormatHTML))).
+		Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.Equal(t, before, mdprint.PrintString(content))
+}
+
+func TestRenderCustomNodesBlankLines(t *testing.T) {
+	renderers := plugin.NodeRenderers{
+		testTypeURL: {
+			Formats: []plugin.OutputFormat{plugin.OutputFormatMD, plugin.OutputFormatHTML},
+			RenderFunc: func(ctx context.Context, params *plugin.RenderNodeParams) (*plugin.RenderNodeResult, diagnostics.Diag) {
+				if params.Format == plugin.OutputFormatHTML {
+					return &plugin.RenderNodeResult{Content: []byte("<pre>a\n\nb</pre>")}, nil
+				}
+				return &plugin.RenderNodeResult{Content: []byte("```\na\n\nb\n```")}, nil
+			},
+		},
+	}
+	content := plugin.NewElement(ast.CustomBlock(&anypb.Any{TypeUrl: testTypeURL}))
+
+	buf := &bytes.Buffer{}
+	err := mdprint.New(print.WithNodeRenderers(renderers)).Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.Equal(t, "```\na\n\nb\n```\n", buf.String())
+
+	buf.Reset()
+	err = htmlprint.New(print.WithNodeRenderers(renderers)).Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "<pre>a\n\nb</pre>")
+}
+
+func TestRawHTMLOmitted(t *testing.T) {
+	content := plugin.NewElementFromMarkdown(
+		"<script>alert(1)</script>\n\ntext <fabric-html id=\"0-0\"/>\n",
+	)
+	buf := &bytes.Buffer{}
+	err := htmlprint.New(print.WithNodeRenderers(testRenderers(plugin.OutputFormatHTML))).
+		Print(context.Background(), buf, content)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.NotContains(t, buf.String(), "<fabric-html")
+	assert.Contains(t, buf.String(), "<!-- raw HTML omitted -->")
+}
+
+func TestStripTrustedHTML(t *testing.T) {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElement(
+		ast.CustomBlock(&anypb.Any{TypeUrl: print.TrustedHTMLTypeURL, Value: []byte("<script>alert(1)</script>")}),
+		ast.Paragraph(ast.Text("text "), ast.CustomInline(&anypb.Any{TypeUrl: print.TrustedHTMLTypeURL, Value: []byte("<b>x</b>")})),
+	), nil)
+	stripped, err := print.StripTrustedHTML(section)
+	require.NoError(t, err)
+	assert.True(t, stripped)
+
+	buf := &bytes.Buffer{}
+	err = htmlprint.New().Print(context.Background(), buf, section)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.NotContains(t, buf.String(), "<b>")
+	assert.Contains(t, buf.String(), "text")
+
+	stripped, err = print.StripTrustedHTML(testContent())
+	require.NoError(t, err)
+	assert.False(t, stripped)
+}
@@ -0,0 +1,116 @@
+package pdfprint
+
+import (
+	"regexp"
+	"strconv"
+
+	pdf "github.com/stephenafamo/goldmark-pdf"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// colorSpanRe matches the opening tag of the colored text, see content.table cell styles
+// and admonition titles.
+var colorSpanRe = regexp.MustCompile(`^<span style="color: ?#([0-9a-fA-F]{6});?">$`)
+
+// coloredTextRenderer renders the table cells and paragraphs with the default pdf renderer,
+// setting the text color if the content starts with a colored span.
+// The default renderer skips the raw html, the tags are dropped.
+type coloredTextRenderer struct {
+	cell      pdf.NodeRendererFunc
+	paragraph pdf.NodeRendererFunc
+}
+
+func newColoredTextRenderer() util.PrioritizedValue {
+	funcs := rendererFuncs{}
+	config := pdf.DefaultConfig()
+	config.AddDefaultNodeRenderers()
+	for _, v := range config.NodeRenderers {
+		if nr, ok := v.Value.(pdf.NodeRenderer); ok {
+			nr.RegisterFuncs(funcs)
+		}
+	}
+	return util.Prioritized(coloredTextRenderer{
+		cell:      funcs[east.KindTableCell],
+		paragraph: funcs[ast.KindParagraph],
+	}, 100)
+}
+
+func (r coloredTextRenderer) RegisterFuncs(reg pdf.NodeRendererFuncRegisterer) {
+	if r.cell != nil {
+		reg.Register(east.KindTableCell, r.renderCell)
+	}
+	if r.paragraph != nil {
+		reg.Register(ast.KindParagraph, r.renderParagraph)
+	}
+}
+
+// renderCell colors the cell, the default renderer writes the whole cell text on entering.
+func (r coloredTextRenderer) renderCell(w *pdf.Writer, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return r.cell(w, source, n, entering)
+	}
+	rgb, ok := textColor(n, source)
+	if !ok {
+		return r.cell(w, source, n, entering)
+	}
+	orig := w.Pdf
+	w.Pdf = coloredPDF{PDF: orig, rgb: rgb}
+	defer func() {
+		w.Pdf = orig
+	}()
+	return r.cell(w, source, n, entering)
+}
+
+// renderParagraph colors the paragraph, the children are rendered between entering and leaving.
+func (r coloredTextRenderer) renderParagraph(w *pdf.Writer, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		if rgb, ok := textColor(n, source); ok {
+			w.Pdf = coloredPDF{PDF: w.Pdf, rgb: rgb}
+		}
+		return r.paragraph(w, source, n, entering)
+	}
+	if colored, ok := w.Pdf.(coloredPDF); ok {
+		w.Pdf = colored.PDF
+	}
+	return r.paragraph(w, source, n, entering)
+}
+
+func textColor(n ast.Node, source []byte) (rgb [3]uint8, ok bool) {
+	raw, isRaw := n.FirstChild().(*ast.RawHTML)
+	if !isRaw {
+		return rgb, false
+	}
+	var tag []byte
+	for i := 0; i < raw.Segments.Len(); i++ {
+		segment := raw.Segments.At(i)
+		tag = append(tag, segment.Value(source)...)
+	}
+	m := colorSpanRe.FindSubmatch(tag)
+	if m == nil {
+		return rgb, false
+	}
+	v, err := strconv.ParseUint(string(m[1]), 16, 32)
+	if err != nil {
+		return rgb, false
+	}
+	return [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+}
+
+// coloredPDF overrides the text color set by the default renderer.
+type coloredPDF struct {
+	pdf.PDF
+	rgb [3]uint8
+}
+
+func (p coloredPDF) SetTextColor(_, _, _ uint8) {
+	p.PDF.SetTextColor(p.rgb[0], p.rgb[1], p.rgb[2])
+}
+
+// rendererFuncs collects the functions of the default renderer.
+type rendererFuncs map[ast.NodeKind]pdf.NodeRendererFunc
+
+func (f rendererFuncs) Register(kind ast.NodeKind, fn pdf.NodeRendererFunc) {
+	f[kind] = fn
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 
+	"github.com/alecthomas/chroma/v2/styles"
 	pdf "github.com/stephenafamo/goldmark-pdf"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
@@ -16,17 +17,22 @@ import (
 	"github.com/blackstork-io/fabric/plugin"
 	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
 	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/diagram"
 	"github.com/blackstork-io/fabric/print/mdprint"
 )
 
 // Printer is the interface for printing pdf content.
 type Printer struct {
-	md mdprint.Printer
+	md   mdprint.Printer
+	opts print.Options
 }
 
 // New creates a new pdf printer.
-func New() Printer {
-	return Printer{mdprint.New()}
+func New(opts ...print.Option) Printer {
+	return Printer{
+		md:   mdprint.New(opts...),
+		opts: print.MakeOptions(opts...),
+	}
 }
 
 // Print is a helper function to print pdf content to a writer.
@@ -36,7 +42,12 @@ func Print(w io.Writer, el plugin.Content) error {
 }
 
 func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err error) {
+	el = plugin.CloneContent(el)
 	p.removeFrontmatter(el)
+	err = print.LowerExtendedNodes(el, plugin.OutputFormatPDF)
+	if err != nil {
+		return err
+	}
 	err = print.ReplaceNodesInContent(el, func(src *astsrc.ASTSource, n ast.Node) (repl ast.Node, err error) {
 		switch n := n.(type) {
 		case *ast.HTMLBlock:
@@ -59,6 +70,11 @@ func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err
 	if err != nil {
 		return fmt.Errorf("replacement failed: %w", err)
 	}
+	// rendered after the html replacement, pdf renderers produce markdown
+	el, err = print.RenderCustomNodes(ctx, el, p.opts.NodeRenderers, plugin.OutputFormatPDF)
+	if err != nil {
+		return err
+	}
 
 	buf := &bytes.Buffer{}
 	if err := p.md.Print(ctx, buf, el); err != nil {
@@ -67,6 +83,11 @@ func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err
 
 	md := goldmark.New(
 		plugin.BaseMarkdownOptions,
+		goldmark.WithExtensions(
+			&diagram.Extension{
+				Raster: true,
+			},
+		),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
 		),
@@ -81,6 +102,8 @@ func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) (err
 				pdf.WithHeadingFont(pdf.GetTextFont("Open Sans", pdf.FontRoboto)),
 				pdf.WithBodyFont(pdf.GetTextFont("Open Sans", pdf.FontRoboto)),
 				pdf.WithCodeFont(pdf.GetCodeFont("Open Sans", pdf.FontRoboto)),
+				pdf.WithCodeBlockTheme(styles.Get(print.CodeHighlightStyle)),
+				pdf.WithNodeRenderers(newColoredTextRenderer()),
 			),
 		),
 	)
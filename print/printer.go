@@ -12,6 +12,9 @@ import (
 	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
 )
 
+// CodeHighlightStyle is the chroma style used to highlight code blocks in html and pdf.
+const CodeHighlightStyle = "github"
+
 // Printer is the interface for printing content.
 type Printer interface {
 	Print(ctx context.Context, w io.Writer, el plugin.Content) error
@@ -89,6 +92,7 @@ func ReplaceNodesInContent(el plugin.Content, replacer func(src *astsrc.ASTSourc
 		_, err := ReplaceNodes(node, func(n ast.Node) (repl ast.Node, err error) {
 			return replacer(src, n)
 		})
+		el.InvalidateCache()
 		return err
 	}
 	return nil
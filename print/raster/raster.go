@@ -0,0 +1,165 @@
+// Package raster draws the vector images (diagrams and charts) as PNG, for the formats
+// that can't embed SVG.
+package raster
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// Scale is the resolution multiplier of the images, keeps them crisp when printed.
+const Scale = 2
+
+// Point is a point in the image coordinates, before scaling.
+type Point struct {
+	X, Y float64
+}
+
+// Anchor is the horizontal alignment of the text relative to its position.
+type Anchor int
+
+const (
+	AnchorStart Anchor = iota
+	AnchorMiddle
+	AnchorEnd
+)
+
+type faceKey struct {
+	size float64
+	bold bool
+}
+
+// Canvas is a white image of the given size. The coordinates are scaled by Scale.
+type Canvas struct {
+	img   *image.RGBA
+	faces map[faceKey]font.Face
+	// err is the first error of loading the fonts
+	err error
+}
+
+// New returns a canvas of w by h size, must be closed after use.
+func New(w, h float64) *Canvas {
+	c := &Canvas{
+		img:   image.NewRGBA(image.Rect(0, 0, int(math.Ceil(w*Scale)), int(math.Ceil(h*Scale)))),
+		faces: map[faceKey]font.Face{},
+	}
+	draw.Draw(c.img, c.img.Bounds(), image.White, image.Point{}, draw.Src)
+	return c
+}
+
+// Close releases the font faces.
+func (c *Canvas) Close() {
+	for _, f := range c.faces {
+		f.Close()
+	}
+}
+
+// PNG encodes the image. It fails if any of the fonts couldn't be loaded.
+func (c *Canvas) PNG() ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Canvas) face(size float64, bold bool) font.Face {
+	key := faceKey{size, bold}
+	if f, ok := c.faces[key]; ok {
+		return f
+	}
+	ttf := goregular.TTF
+	if bold {
+		ttf = gobold.TTF
+	}
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		c.err = err
+		return nil
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size * Scale,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		c.err = err
+		return nil
+	}
+	c.faces[key] = face
+	return face
+}
+
+// Fill fills the polygon.
+func (c *Canvas) Fill(poly []Point, col color.Color) {
+	// rasterize only the bounding box of the polygon
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range poly {
+		minX, minY = math.Min(minX, p.X*Scale), math.Min(minY, p.Y*Scale)
+		maxX, maxY = math.Max(maxX, p.X*Scale), math.Max(maxY, p.Y*Scale)
+	}
+	r := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(c.img.Bounds())
+	if r.Empty() {
+		return
+	}
+	z := vector.NewRasterizer(r.Dx(), r.Dy())
+	for i, p := range poly {
+		x, y := float32(p.X*Scale-float64(r.Min.X)), float32(p.Y*Scale-float64(r.Min.Y))
+		if i == 0 {
+			z.MoveTo(x, y)
+		} else {
+			z.LineTo(x, y)
+		}
+	}
+	z.ClosePath()
+	z.Draw(c.img, r, image.NewUniform(col), image.Point{})
+}
+
+// Line draws the segment as a thin polygon.
+func (c *Canvas) Line(a, b Point, width float64, col color.Color) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	nx, ny := -dy/l*width/2, dx/l*width/2
+	c.Fill([]Point{{a.X + nx, a.Y + ny}, {b.X + nx, b.Y + ny}, {b.X - nx, b.Y - ny}, {a.X - nx, a.Y - ny}}, col)
+}
+
+// Text draws the text with the baseline at y.
+func (c *Canvas) Text(s string, x, y, size float64, bold bool, anchor Anchor, col color.Color) {
+	face := c.face(size, bold)
+	if face == nil {
+		return
+	}
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: face,
+	}
+	w := float64(d.MeasureString(s)) / 64
+	px := x * Scale
+	switch anchor {
+	case AnchorMiddle:
+		px -= w / 2
+	case AnchorEnd:
+		px -= w
+	}
+	d.Dot = fixed.P(int(math.Round(px)), int(math.Round(y*Scale)))
+	d.DrawString(s)
+}
@@ -0,0 +1,378 @@
+package slackprint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/chatprint"
+)
+
+// Limits of the Slack Block Kit messages.
+const (
+	MaxBlocks        = 50
+	MaxMessageLength = 40000
+	maxSectionLength = 3000
+	maxHeaderLength  = 150
+	maxFields        = 10
+	maxFieldLength   = 2000
+	maxAltTextLength = 2000
+	maxFallbackText  = 150
+)
+
+// Message is a Slack message with Block Kit layout.
+type Message struct {
+	Text      string   `json:"text"`
+	Blocks    []*Block `json:"blocks"`
+	Channel   string   `json:"channel,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	IconEmoji string   `json:"icon_emoji,omitempty"`
+}
+
+// Block is a Block Kit layout block.
+type Block struct {
+	Type     string  `json:"type"`
+	Text     *Text   `json:"text,omitempty"`
+	Fields   []*Text `json:"fields,omitempty"`
+	ImageURL string  `json:"image_url,omitempty"`
+	AltText  string  `json:"alt_text,omitempty"`
+}
+
+// Text is a Block Kit text object.
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (b *Block) length() int {
+	n := utf8.RuneCountInString(b.AltText)
+	if b.Text != nil {
+		n += utf8.RuneCountInString(b.Text.Text)
+	}
+	for _, f := range b.Fields {
+		n += utf8.RuneCountInString(f.Text)
+	}
+	return n
+}
+
+// Printer prints content as Slack Block Kit messages.
+type Printer struct {
+	opts             []print.Option
+	maxBlocks        int
+	maxMessageLength int
+}
+
+// New creates a new Slack printer.
+func New(opts ...print.Option) Printer {
+	return Printer{
+		opts:             opts,
+		maxBlocks:        MaxBlocks,
+		maxMessageLength: MaxMessageLength,
+	}
+}
+
+// WithLimits returns the printer that splits the content into messages of at most
+// blocks blocks and length characters.
+func (p Printer) WithLimits(blocks, length int) Printer {
+	p.maxBlocks = min(blocks, MaxBlocks)
+	p.maxMessageLength = min(length, MaxMessageLength)
+	return p
+}
+
+// Print is a helper function to print Slack messages to a writer.
+func Print(w io.Writer, el plugin.Content) error {
+	p := New()
+	return p.Print(context.Background(), w, el)
+}
+
+// Print implements print.Printer, it writes the JSON array of messages.
+func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) error {
+	msgs, err := p.PrintMessages(ctx, el)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(msgs)
+}
+
+// PrintMessages converts the content into Slack messages, long content is split into several messages.
+func (p Printer) PrintMessages(ctx context.Context, el plugin.Content) ([]*Message, error) {
+	doc, source, err := chatprint.Parse(ctx, el, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &renderer{source: source}
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		r.block(n)
+	}
+	var msgs []*Message
+	var length int
+	for _, b := range r.blocks {
+		l := b.length()
+		if len(msgs) == 0 || len(msgs[len(msgs)-1].Blocks) >= p.maxBlocks || length+l > p.maxMessageLength {
+			msgs = append(msgs, &Message{})
+			length = 0
+		}
+		msg := msgs[len(msgs)-1]
+		msg.Blocks = append(msg.Blocks, b)
+		length += l
+	}
+	for _, msg := range msgs {
+		msg.Text = fallbackText(msg)
+	}
+	return msgs, nil
+}
+
+// fallbackText is shown in notifications and clients that can't display blocks.
+func fallbackText(msg *Message) string {
+	for _, b := range msg.Blocks {
+		switch {
+		case b.Text != nil:
+			return chatprint.Truncate(b.Text.Text, maxFallbackText)
+		case len(b.Fields) > 0:
+			return chatprint.Truncate(b.Fields[0].Text, maxFallbackText)
+		case b.AltText != "":
+			return chatprint.Truncate(b.AltText, maxFallbackText)
+		}
+	}
+	return ""
+}
+
+type renderer struct {
+	source []byte
+	blocks []*Block
+}
+
+func (r *renderer) section(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, chunk := range chatprint.Split(text, maxSectionLength) {
+		r.blocks = append(r.blocks, &Block{
+			Type: "section",
+			Text: &Text{Type: "mrkdwn", Text: chunk},
+		})
+	}
+}
+
+func (r *renderer) code(code string) {
+	// the fence is counted in the section length
+	for _, chunk := range chatprint.Split(strings.TrimRight(code, "\n"), maxSectionLength-8) {
+		r.section("```\n" + chunk + "\n```")
+	}
+}
+
+func (r *renderer) block(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Heading:
+		r.blocks = append(r.blocks, &Block{
+			Type: "header",
+			Text: &Text{
+				Type: "plain_text",
+				Text: chatprint.Truncate(chatprint.PlainText(n, r.source), maxHeaderLength),
+			},
+		})
+	case *ast.Paragraph, *ast.TextBlock:
+		if img, ok := singleImage(n); ok && isURL(img.Destination) {
+			alt := chatprint.PlainText(img, r.source)
+			if alt == "" {
+				alt = "image"
+			}
+			r.blocks = append(r.blocks, &Block{
+				Type:     "image",
+				ImageURL: string(img.Destination),
+				AltText:  chatprint.Truncate(alt, maxAltTextLength),
+			})
+			return
+		}
+		r.section(r.inlines(n))
+	case *ast.List:
+		var buf strings.Builder
+		r.list(&buf, n, 0)
+		r.section(buf.String())
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		r.code(chatprint.CodeText(n, r.source))
+	case *ast.Blockquote:
+		var buf strings.Builder
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString(r.blockText(c))
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		for i, line := range lines {
+			lines[i] = "> " + line
+		}
+		r.section(strings.Join(lines, "\n"))
+	case *ast.ThematicBreak:
+		r.blocks = append(r.blocks, &Block{Type: "divider"})
+	case *east.Table:
+		r.table(n)
+	case *ast.HTMLBlock:
+		// raw html can't be displayed in Slack
+	default:
+		r.section(r.blockText(n))
+	}
+}
+
+// blockText renders the block as mrkdwn text, for nesting inside other blocks.
+func (r *renderer) blockText(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.List:
+		var buf strings.Builder
+		r.list(&buf, n, 0)
+		return buf.String()
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		return "```\n" + strings.TrimRight(chatprint.CodeText(n, r.source), "\n") + "\n```"
+	case *ast.Heading:
+		return "*" + r.inlines(n) + "*"
+	case *ast.HTMLBlock, *ast.ThematicBreak:
+		return ""
+	case *east.Table:
+		return "```\n" + r.readTable(n).Format() + "```"
+	}
+	if n.Type() == ast.TypeInline || n.HasChildren() && n.FirstChild().Type() == ast.TypeInline {
+		return r.inlines(n)
+	}
+	var parts []string
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if text := r.blockText(c); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func (r *renderer) list(buf *strings.Builder, n *ast.List, depth int) {
+	num := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		buf.WriteString(strings.Repeat("    ", depth))
+		if n.IsOrdered() {
+			buf.WriteString(strconv.Itoa(num) + ". ")
+			num++
+		} else {
+			buf.WriteString("• ")
+		}
+		first := true
+		for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+			if nested, ok := c.(*ast.List); ok {
+				r.list(buf, nested, depth+1)
+				continue
+			}
+			if !first {
+				buf.WriteString(strings.Repeat("    ", depth+1))
+			}
+			buf.WriteString(r.blockText(c))
+			buf.WriteString("\n")
+			first = false
+		}
+		if first {
+			buf.WriteString("\n")
+		}
+	}
+}
+
+func (r *renderer) readTable(n *east.Table) chatprint.Table {
+	return chatprint.ReadTable(n, func(c *east.TableCell) string {
+		return r.inlines(c)
+	})
+}
+
+// table renders two column tables as section fields, other tables as preformatted text.
+func (r *renderer) table(n *east.Table) {
+	t := r.readTable(n)
+	if t.Columns() == 2 && (len(t.Rows)+1)*2 <= maxFields {
+		block := &Block{Type: "section"}
+		for _, h := range t.Header {
+			block.Fields = append(block.Fields, &Text{Type: "mrkdwn", Text: "*" + h + "*"})
+		}
+		for _, row := range t.Rows {
+			for i := range 2 {
+				cell := " "
+				if i < len(row) && row[i] != "" {
+					cell = chatprint.Truncate(row[i], maxFieldLength)
+				}
+				block.Fields = append(block.Fields, &Text{Type: "mrkdwn", Text: cell})
+			}
+		}
+		r.blocks = append(r.blocks, block)
+		return
+	}
+	r.code(t.Format())
+}
+
+// inlines renders the inline children of the node as mrkdwn.
+func (r *renderer) inlines(n ast.Node) string {
+	var buf strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		r.inline(&buf, c)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (r *renderer) inline(buf *strings.Builder, n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Text:
+		buf.WriteString(escaper.Replace(string(n.Segment.Value(r.source))))
+		if n.SoftLineBreak() || n.HardLineBreak() {
+			buf.WriteString("\n")
+		}
+	case *ast.String:
+		buf.WriteString(escaper.Replace(string(n.Value)))
+	case *ast.Emphasis:
+		mark := "_"
+		if n.Level == 2 {
+			mark = "*"
+		}
+		buf.WriteString(mark + r.inlines(n) + mark)
+	case *east.Strikethrough:
+		buf.WriteString("~" + r.inlines(n) + "~")
+	case *ast.CodeSpan:
+		buf.WriteString("`" + chatprint.PlainText(n, r.source) + "`")
+	case *ast.Link:
+		fmt.Fprintf(buf, "<%s|%s>", n.Destination, r.inlines(n))
+	case *ast.Image:
+		fmt.Fprintf(buf, "<%s|%s>", n.Destination, escaper.Replace(chatprint.PlainText(n, r.source)))
+	case *ast.AutoLink:
+		fmt.Fprintf(buf, "<%s>", n.URL(r.source))
+	case *east.TaskCheckBox:
+		if n.IsChecked {
+			buf.WriteString("☑ ")
+		} else {
+			buf.WriteString("☐ ")
+		}
+	case *ast.RawHTML:
+		// raw html can't be displayed in Slack
+	default:
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			r.inline(buf, c)
+		}
+	}
+}
+
+// singleImage returns the image if it's the only content of the paragraph.
+func singleImage(n ast.Node) (*ast.Image, bool) {
+	if n.ChildCount() != 1 {
+		return nil, false
+	}
+	img, ok := n.FirstChild().(*ast.Image)
+	return img, ok
+}
+
+func isURL(dest []byte) bool {
+	return strings.HasPrefix(string(dest), "https://") || strings.HasPrefix(string(dest), "http://")
+}
@@ -0,0 +1,68 @@
+package slackprint_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print/slackprint"
+)
+
+func chatContent() plugin.Content {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("# Daily summary"), nil)
+	section.Add(plugin.NewElementFromMarkdown("New **alerts** for web-1 & db-2, see [the dashboard](https://example.com/d)"), nil)
+	section.Add(plugin.NewElementFromMarkdown("* first\n* second\n  1. nested"), nil)
+	section.Add(plugin.NewElementFromMarkdown("| Host | Risk |\n|---|---|\n| web-1 | high |"), nil)
+	section.Add(plugin.NewElementFromMarkdown("| Host | Risk | Owner |\n|---|---|---|\n| web-1 | high | ops |"), nil)
+	section.Add(plugin.NewElementFromMarkdown("---"), nil)
+	section.Add(plugin.NewElementFromMarkdown("> Stay safe"), nil)
+	return section
+}
+
+func TestSlackBlocks(t *testing.T) {
+	msgs, err := slackprint.New().PrintMessages(context.Background(), chatContent())
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	data, err := json.Marshal(msgs[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"text": "Daily summary",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Daily summary"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "New *alerts* for web-1 &amp; db-2, see <https://example.com/d|the dashboard>"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "• first\n• second\n    1. nested"}},
+			{"type": "section", "fields": [
+				{"type": "mrkdwn", "text": "*Host*"},
+				{"type": "mrkdwn", "text": "*Risk*"},
+				{"type": "mrkdwn", "text": "web-1"},
+				{"type": "mrkdwn", "text": "high"}
+			]},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "`+"```"+`\nHost  | Risk | Owner\n------+------+------\nweb-1 | high | ops\n`+"```"+`"}},
+			{"type": "divider"},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "> Stay safe"}}
+		]
+	}`, string(data))
+}
+
+func TestSlackSplit(t *testing.T) {
+	section := plugin.NewSection(0)
+	for range 5 {
+		section.Add(plugin.NewElementFromMarkdown(strings.Repeat("word ", 1000)), nil)
+	}
+	msgs, err := slackprint.New().WithLimits(3, 12000).PrintMessages(context.Background(), section)
+	require.NoError(t, err)
+	// each paragraph is split into two sections
+	require.Len(t, msgs, 4)
+	assert.Len(t, msgs[0].Blocks, 3)
+	for _, msg := range msgs {
+		for _, b := range msg.Blocks {
+			assert.LessOrEqual(t, len(b.Text.Text), 3000)
+		}
+	}
+}
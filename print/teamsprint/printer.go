@@ -0,0 +1,402 @@
+package teamsprint
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print"
+	"github.com/blackstork-io/fabric/print/chatprint"
+)
+
+const (
+	// MaxMessageSize is the size limit of the Teams message payload in bytes.
+	MaxMessageSize = 28000
+	// maxTextLength keeps the text blocks readable, longer texts are split.
+	maxTextLength = 5000
+
+	cardContentType = "application/vnd.microsoft.card.adaptive"
+	cardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	cardVersion     = "1.4"
+)
+
+// Message is a Teams message with a single Adaptive Card attachment.
+type Message struct {
+	Type        string        `json:"type"`
+	Attachments []*Attachment `json:"attachments"`
+}
+
+// Attachment is a message attachment.
+type Attachment struct {
+	ContentType string  `json:"contentType"`
+	ContentURL  *string `json:"contentUrl"`
+	Content     *Card   `json:"content"`
+}
+
+// Card is an Adaptive Card.
+type Card struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []*Element       `json:"body"`
+	MSTeams *MSTeamsSettings `json:"msteams,omitempty"`
+}
+
+// MSTeamsSettings are the Teams specific card settings.
+type MSTeamsSettings struct {
+	Width string `json:"width,omitempty"`
+}
+
+// Element is an Adaptive Card element.
+type Element struct {
+	Type      string     `json:"type"`
+	Text      string     `json:"text,omitempty"`
+	Size      string     `json:"size,omitempty"`
+	Weight    string     `json:"weight,omitempty"`
+	FontType  string     `json:"fontType,omitempty"`
+	Wrap      bool       `json:"wrap,omitempty"`
+	Separator bool       `json:"separator,omitempty"`
+	Style     string     `json:"style,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	AltText   string     `json:"altText,omitempty"`
+	Facts     []*Fact    `json:"facts,omitempty"`
+	Items     []*Element `json:"items,omitempty"`
+}
+
+// Fact is an item of the FactSet element.
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Printer prints content as Teams messages with Adaptive Cards.
+type Printer struct {
+	opts           []print.Option
+	maxMessageSize int
+}
+
+// New creates a new Teams printer.
+func New(opts ...print.Option) Printer {
+	return Printer{
+		opts:           opts,
+		maxMessageSize: MaxMessageSize,
+	}
+}
+
+// WithMaxMessageSize returns the printer that splits the content into messages
+// of at most size bytes.
+func (p Printer) WithMaxMessageSize(size int) Printer {
+	p.maxMessageSize = min(size, MaxMessageSize)
+	return p
+}
+
+// Print is a helper function to print Teams messages to a writer.
+func Print(w io.Writer, el plugin.Content) error {
+	p := New()
+	return p.Print(context.Background(), w, el)
+}
+
+// Print implements print.Printer, it writes the JSON array of messages.
+func (p Printer) Print(ctx context.Context, w io.Writer, el plugin.Content) error {
+	msgs, err := p.PrintMessages(ctx, el)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(msgs)
+}
+
+// PrintMessages converts the content into Teams messages, long content is split into several messages.
+func (p Printer) PrintMessages(ctx context.Context, el plugin.Content) ([]*Message, error) {
+	doc, source, err := chatprint.Parse(ctx, el, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &renderer{source: source}
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		r.block(n)
+	}
+	envelope, err := json.Marshal(newMessage(nil))
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*Message
+	var body []*Element
+	size := len(envelope)
+	for _, el := range r.elements {
+		data, err := json.Marshal(el)
+		if err != nil {
+			return nil, err
+		}
+		// +1 for the separating comma
+		if len(body) > 0 && size+len(data)+1 > p.maxMessageSize {
+			msgs = append(msgs, newMessage(body))
+			body = nil
+			size = len(envelope)
+		}
+		body = append(body, el)
+		size += len(data) + 1
+	}
+	if len(body) > 0 {
+		msgs = append(msgs, newMessage(body))
+	}
+	return msgs, nil
+}
+
+func newMessage(body []*Element) *Message {
+	return &Message{
+		Type: "message",
+		Attachments: []*Attachment{{
+			ContentType: cardContentType,
+			Content: &Card{
+				Schema:  cardSchema,
+				Type:    "AdaptiveCard",
+				Version: cardVersion,
+				Body:    body,
+				MSTeams: &MSTeamsSettings{Width: "Full"},
+			},
+		}},
+	}
+}
+
+type renderer struct {
+	source   []byte
+	elements []*Element
+	// separator is set by a thematic break, the next element is separated with a line
+	separator bool
+}
+
+func (r *renderer) add(el *Element) {
+	el.Separator = el.Separator || r.separator
+	r.separator = false
+	r.elements = append(r.elements, el)
+}
+
+func (r *renderer) text(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, chunk := range chatprint.Split(text, maxTextLength) {
+		r.add(&Element{Type: "TextBlock", Text: chunk, Wrap: true})
+	}
+}
+
+func (r *renderer) code(code string) {
+	for _, chunk := range chatprint.Split(strings.TrimRight(code, "\n"), maxTextLength) {
+		r.add(&Element{Type: "TextBlock", Text: chunk, FontType: "Monospace", Wrap: true})
+	}
+}
+
+var headingSizes = map[int]string{
+	1: "ExtraLarge",
+	2: "Large",
+	3: "Medium",
+}
+
+func (r *renderer) block(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Heading:
+		r.add(&Element{
+			Type:   "TextBlock",
+			Text:   chatprint.PlainText(n, r.source),
+			Size:   headingSizes[n.Level],
+			Weight: "Bolder",
+			Wrap:   true,
+		})
+	case *ast.Paragraph, *ast.TextBlock:
+		if img, ok := singleImage(n); ok && isImageURL(img.Destination) {
+			r.add(&Element{
+				Type:    "Image",
+				URL:     string(img.Destination),
+				AltText: chatprint.PlainText(img, r.source),
+			})
+			return
+		}
+		r.text(r.inlines(n))
+	case *ast.List:
+		var buf strings.Builder
+		r.list(&buf, n, 0)
+		r.text(buf.String())
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		r.code(chatprint.CodeText(n, r.source))
+	case *ast.Blockquote:
+		quote := &renderer{source: r.source}
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			quote.block(c)
+		}
+		if len(quote.elements) > 0 {
+			r.add(&Element{Type: "Container", Style: "emphasis", Items: quote.elements})
+		}
+	case *ast.ThematicBreak:
+		r.separator = true
+	case *east.Table:
+		r.table(n)
+	case *ast.HTMLBlock:
+		// raw html can't be displayed in Teams
+	default:
+		r.text(r.blockText(n))
+	}
+}
+
+// blockText renders the block as markdown text supported by Teams, for nesting inside lists.
+func (r *renderer) blockText(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.List:
+		var buf strings.Builder
+		r.list(&buf, n, 0)
+		return buf.String()
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		return strings.TrimRight(chatprint.CodeText(n, r.source), "\n")
+	case *ast.Heading:
+		return "**" + r.inlines(n) + "**"
+	case *ast.HTMLBlock, *ast.ThematicBreak:
+		return ""
+	}
+	if n.Type() == ast.TypeInline || n.HasChildren() && n.FirstChild().Type() == ast.TypeInline {
+		return r.inlines(n)
+	}
+	var parts []string
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if text := r.blockText(c); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func (r *renderer) list(buf *strings.Builder, n *ast.List, depth int) {
+	num := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		buf.WriteString(strings.Repeat("    ", depth))
+		if n.IsOrdered() {
+			buf.WriteString(strconv.Itoa(num) + ". ")
+			num++
+		} else {
+			buf.WriteString("- ")
+		}
+		first := true
+		for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+			if nested, ok := c.(*ast.List); ok {
+				r.list(buf, nested, depth+1)
+				continue
+			}
+			if !first {
+				buf.WriteString(strings.Repeat("    ", depth+1))
+			}
+			buf.WriteString(r.blockText(c))
+			buf.WriteString("\n")
+			first = false
+		}
+		if first {
+			buf.WriteString("\n")
+		}
+	}
+}
+
+// table renders two column tables as fact sets, other tables as preformatted text.
+func (r *renderer) table(n *east.Table) {
+	t := chatprint.ReadTable(n, func(c *east.TableCell) string {
+		return r.inlines(c)
+	})
+	if t.Columns() == 2 {
+		set := &Element{Type: "FactSet"}
+		for _, row := range t.Rows {
+			fact := &Fact{}
+			if len(row) > 0 {
+				fact.Title = row[0]
+			}
+			if len(row) > 1 {
+				fact.Value = row[1]
+			}
+			set.Facts = append(set.Facts, fact)
+		}
+		if len(t.Header) == 2 {
+			r.add(&Element{
+				Type:   "TextBlock",
+				Text:   t.Header[0] + " / " + t.Header[1],
+				Weight: "Bolder",
+				Wrap:   true,
+			})
+		}
+		r.add(set)
+		return
+	}
+	r.code(t.Format())
+}
+
+// inlines renders the inline children of the node as markdown supported by Teams.
+func (r *renderer) inlines(n ast.Node) string {
+	var buf strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		r.inline(&buf, c)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+func (r *renderer) inline(buf *strings.Builder, n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Text:
+		buf.Write(n.Segment.Value(r.source))
+		if n.SoftLineBreak() || n.HardLineBreak() {
+			buf.WriteString("\n")
+		}
+	case *ast.String:
+		buf.Write(n.Value)
+	case *ast.Emphasis:
+		mark := "_"
+		if n.Level == 2 {
+			mark = "**"
+		}
+		buf.WriteString(mark + r.inlines(n) + mark)
+	case *ast.CodeSpan:
+		// Teams has no inline code, it's kept as is
+		buf.WriteString("`" + chatprint.PlainText(n, r.source) + "`")
+	case *ast.Link:
+		buf.WriteString("[" + r.inlines(n) + "](" + string(n.Destination) + ")")
+	case *ast.Image:
+		buf.WriteString("[" + chatprint.PlainText(n, r.source) + "](" + string(n.Destination) + ")")
+	case *ast.AutoLink:
+		url := string(n.URL(r.source))
+		buf.WriteString("[" + url + "](" + url + ")")
+	case *east.TaskCheckBox:
+		if n.IsChecked {
+			buf.WriteString("☑ ")
+		} else {
+			buf.WriteString("☐ ")
+		}
+	case *ast.RawHTML:
+		// raw html can't be displayed in Teams
+	default:
+		// strikethrough and other unsupported markup is reduced to the text
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			r.inline(buf, c)
+		}
+	}
+}
+
+// singleImage returns the image if it's the only content of the paragraph.
+func singleImage(n ast.Node) (*ast.Image, bool) {
+	if n.ChildCount() != 1 {
+		return nil, false
+	}
+	img, ok := n.FirstChild().(*ast.Image)
+	return img, ok
+}
+
+// isImageURL reports if the image can be displayed by the card, data URIs are supported too.
+func isImageURL(dest []byte) bool {
+	for _, prefix := range []string{"https://", "http://", "data:image/"} {
+		if strings.HasPrefix(string(dest), prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package teamsprint_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/print/teamsprint"
+)
+
+func chatContent() plugin.Content {
+	section := plugin.NewSection(0)
+	section.Add(plugin.NewElementFromMarkdown("# Daily summary"), nil)
+	section.Add(plugin.NewElementFromMarkdown("New **alerts** for web-1 & db-2, see [the dashboard](https://example.com/d)"), nil)
+	section.Add(plugin.NewElementFromMarkdown("* first\n* second\n  1. nested"), nil)
+	section.Add(plugin.NewElementFromMarkdown("| Host | Risk |\n|---|---|\n| web-1 | high |"), nil)
+	section.Add(plugin.NewElementFromMarkdown("| Host | Risk | Owner |\n|---|---|---|\n| web-1 | high | ops |"), nil)
+	section.Add(plugin.NewElementFromMarkdown("---"), nil)
+	section.Add(plugin.NewElementFromMarkdown("> Stay safe"), nil)
+	return section
+}
+
+func TestTeamsCard(t *testing.T) {
+	msgs, err := teamsprint.New().PrintMessages(context.Background(), chatContent())
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	data, err := json.Marshal(msgs[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "message",
+		"attachments": [{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"contentUrl": null,
+			"content": {
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type": "AdaptiveCard",
+				"version": "1.4",
+				"msteams": {"width": "Full"},
+				"body": [
+					{"type": "TextBlock", "text": "Daily summary", "size": "ExtraLarge", "weight": "Bolder", "wrap": true},
+					{"type": "TextBlock", "text": "New **alerts** for web-1 & db-2, see [the dashboard](https://example.com/d)", "wrap": true},
+					{"type": "TextBlock", "text": "- first\n- second\n    1. nested", "wrap": true},
+					{"type": "TextBlock", "text": "Host / Risk", "weight": "Bolder", "wrap": true},
+					{"type": "FactSet", "facts": [{"title": "web-1", "value": "high"}]},
+					{"type": "TextBlock", "text": "Host  | Risk | Owner\n------+------+------\nweb-1 | high | ops", "fontType": "Monospace", "wrap": true},
+					{"type": "Container", "style": "emphasis", "separator": true, "items": [
+						{"type": "TextBlock", "text": "Stay safe", "wrap": true}
+					]}
+				]
+			}
+		}]
+	}`, string(data))
+}
+
+func TestTeamsSplit(t *testing.T) {
+	section := plugin.NewSection(0)
+	for range 10 {
+		section.Add(plugin.NewElementFromMarkdown(strings.Repeat("word ", 1000)), nil)
+	}
+	msgs, err := teamsprint.New().PrintMessages(context.Background(), section)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data), teamsprint.MaxMessageSize)
+	}
+}
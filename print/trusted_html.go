@@ -0,0 +1,246 @@
+package print
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/blackstork-io/fabric/plugin"
+	"github.com/blackstork-io/fabric/plugin/ast/astsrc"
+	"github.com/blackstork-io/fabric/plugin/ast/nodes"
+)
+
+// TrustedHTMLTypeURL is the type URL of the custom nodes holding the html rendered in advance
+// (see [PrerenderCustomNodes]). The printers turn them into the trusted html nodes. The nodes
+// of this type returned by the content providers are removed (see [StripTrustedHTML]).
+const TrustedHTMLTypeURL = "blackstork.io/fabric.TrustedHTML"
+
+var (
+	TrustedHTMLBlockKind  = ast.NewNodeKind("TrustedHTMLBlock")
+	TrustedHTMLInlineKind = ast.NewNodeKind("TrustedHTMLInline")
+)
+
+// TrustedHTMLBlock holds the html produced by a node renderer or by the printer itself.
+// Unlike the raw html of the content, it is written to the html output as is.
+type TrustedHTMLBlock struct {
+	ast.BaseBlock
+	Content []byte
+}
+
+var _ ast.Node = &TrustedHTMLBlock{}
+
+// Kind implements ast.Node.
+func (n *TrustedHTMLBlock) Kind() ast.NodeKind {
+	return TrustedHTMLBlockKind
+}
+
+// Dump implements ast.Node.
+func (n *TrustedHTMLBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Content": string(n.Content),
+	}, nil)
+}
+
+// TrustedHTMLInline is the inline version of [TrustedHTMLBlock].
+type TrustedHTMLInline struct {
+	ast.BaseInline
+	Content []byte
+}
+
+var _ ast.Node = &TrustedHTMLInline{}
+
+// Kind implements ast.Node.
+func (n *TrustedHTMLInline) Kind() ast.NodeKind {
+	return TrustedHTMLInlineKind
+}
+
+// Dump implements ast.Node.
+func (n *TrustedHTMLInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{
+		"Content": string(n.Content),
+	}, nil)
+}
+
+// newTrustedHTML returns the trusted html node, inline or block one.
+func newTrustedHTML(content []byte, inline bool) ast.Node {
+	if inline {
+		return &TrustedHTMLInline{Content: content}
+	}
+	return &TrustedHTMLBlock{Content: content}
+}
+
+// StripTrustedHTML removes the custom nodes of [TrustedHTMLTypeURL] type from the content and
+// reports whether there were any. The printers write their html as is, so the nodes must come
+// from [PrerenderCustomNodes] only, not from the content providers.
+func StripTrustedHTML(el plugin.Content) (bool, error) {
+	switch el := el.(type) {
+	case *plugin.ContentSection:
+		stripped := false
+		for _, child := range el.Children {
+			ok, err := StripTrustedHTML(child)
+			if err != nil {
+				return stripped, err
+			}
+			stripped = stripped || ok
+		}
+		return stripped, nil
+	case *plugin.ContentElement:
+		if !el.IsAst() {
+			return false, nil
+		}
+		_, node := el.AsNode()
+		stripped := false
+		_, err := ReplaceNodes(node, func(n ast.Node) (ast.Node, error) {
+			var data *anypb.Any
+			switch n := n.(type) {
+			case *nodes.CustomBlock:
+				data = n.Data
+			case *nodes.CustomInline:
+				data = n.Data
+			default:
+				return n, nil
+			}
+			if data.GetTypeUrl() != TrustedHTMLTypeURL {
+				return n, nil
+			}
+			stripped = true
+			return nil, nil
+		})
+		if stripped {
+			el.InvalidateCache()
+		}
+		return stripped, err
+	}
+	return false, nil
+}
+
+// TrustedHTML keeps the trusted html while the content is printed to markdown and parsed again.
+// The trusted html nodes are replaced with placeholders and the renderer returned by
+// [TrustedHTML.Renderer] writes the html in place of them. The placeholders include a random
+// nonce, so the raw html of the content can't pass for them.
+type TrustedHTML struct {
+	nonce     string
+	fragments [][]byte
+}
+
+// NewTrustedHTML creates the trusted html storage for a single print.
+func NewTrustedHTML() (*TrustedHTML, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return &TrustedHTML{
+		nonce: hex.EncodeToString(nonce[:]),
+	}, nil
+}
+
+// ReplaceWithPlaceholders replaces the trusted html nodes in the content with the placeholders.
+func (t *TrustedHTML) ReplaceWithPlaceholders(el plugin.Content) error {
+	return ReplaceNodesInContent(el, func(src *astsrc.ASTSource, n ast.Node) (ast.Node, error) {
+		switch n := n.(type) {
+		case *TrustedHTMLBlock:
+			block := ast.NewHTMLBlock(ast.HTMLBlockType7)
+			block.Lines().Append(src.AppendString(t.placeholder(n.Content)))
+			// blank lines separate the placeholder from the surrounding blocks
+			block.SetBlankPreviousLines(true)
+			if next := n.NextSibling(); next != nil && next.Type() == ast.TypeBlock {
+				next.SetBlankPreviousLines(true)
+			}
+			return block, nil
+		case *TrustedHTMLInline:
+			raw := ast.NewRawHTML()
+			raw.Segments.Append(src.AppendString(t.placeholder(n.Content)))
+			return raw, nil
+		}
+		return n, nil
+	})
+}
+
+func (t *TrustedHTML) placeholder(content []byte) string {
+	t.fragments = append(t.fragments, content)
+	return fmt.Sprintf(`<fabric-html id="%s-%d"/>`, t.nonce, len(t.fragments)-1)
+}
+
+func (t *TrustedHTML) lookup(raw []byte) ([]byte, bool) {
+	idx, ok := bytes.CutPrefix(bytes.TrimSpace(raw), []byte(`<fabric-html id="`+t.nonce+`-`))
+	if !ok {
+		return nil, false
+	}
+	idx, ok = bytes.CutSuffix(idx, []byte(`"/>`))
+	if !ok {
+		return nil, false
+	}
+	i, err := strconv.Atoi(string(idx))
+	if err != nil || i < 0 || i >= len(t.fragments) {
+		return nil, false
+	}
+	return t.fragments[i], true
+}
+
+// Renderer returns the goldmark renderer writing the trusted html in place of the placeholders.
+// The rest of the raw html is rendered by the default html renderer configured with the options.
+func (t *TrustedHTML) Renderer(opts ...html.Option) util.PrioritizedValue {
+	funcs := htmlRendererFuncs{}
+	html.NewRenderer(opts...).RegisterFuncs(funcs)
+	return util.Prioritized(trustedHTMLRenderer{
+		trusted: t,
+		block:   funcs[ast.KindHTMLBlock],
+		inline:  funcs[ast.KindRawHTML],
+	}, 100)
+}
+
+type trustedHTMLRenderer struct {
+	trusted *TrustedHTML
+	block   renderer.NodeRendererFunc
+	inline  renderer.NodeRendererFunc
+}
+
+func (r trustedHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHTMLBlock, r.renderBlock)
+	reg.Register(ast.KindRawHTML, r.renderInline)
+}
+
+func (r trustedHTMLRenderer) renderBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if n.Lines().Len() == 1 {
+		line := n.Lines().At(0)
+		if content, ok := r.trusted.lookup(line.Value(source)); ok {
+			if entering {
+				_, _ = w.Write(content)
+				if !bytes.HasSuffix(content, []byte("\n")) {
+					_ = w.WriteByte('\n')
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		}
+	}
+	return r.block(w, source, n, entering)
+}
+
+func (r trustedHTMLRenderer) renderInline(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	raw := n.(*ast.RawHTML)
+	if raw.Segments.Len() == 1 {
+		segment := raw.Segments.At(0)
+		if content, ok := r.trusted.lookup(segment.Value(source)); ok {
+			if entering {
+				_, _ = w.Write(content)
+			}
+			return ast.WalkSkipChildren, nil
+		}
+	}
+	return r.inline(w, source, n, entering)
+}
+
+// htmlRendererFuncs collects the functions of the default html renderer.
+type htmlRendererFuncs map[ast.NodeKind]renderer.NodeRendererFunc
+
+func (f htmlRendererFuncs) Register(kind ast.NodeKind, fn renderer.NodeRendererFunc) {
+	f[kind] = fn
+}